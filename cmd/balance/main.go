@@ -8,20 +8,28 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Aggregate time zones are resolved without tzdata in the runtime image.
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
 	"github.com/caarlos0/env/v11"
+	"github.com/iskorotkov/igaming-balance-backend/gen/admin/v1/adminv1connect"
 	"github.com/iskorotkov/igaming-balance-backend/gen/balance/v1/balancev1connect"
+	"github.com/iskorotkov/igaming-balance-backend/internal/aml"
+	"github.com/iskorotkov/igaming-balance-backend/internal/cancellation"
 	"github.com/iskorotkov/igaming-balance-backend/internal/db"
 	"github.com/iskorotkov/igaming-balance-backend/internal/middleware"
+	"github.com/iskorotkov/igaming-balance-backend/internal/rest"
+	"github.com/iskorotkov/igaming-balance-backend/internal/rules"
 	"github.com/iskorotkov/igaming-balance-backend/internal/service"
 	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
 	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 )
@@ -47,7 +55,12 @@ func main() {
 		Level: config.LogLevel,
 	})))
 
-	if err := run(ctx, config); err != nil {
+	if len(os.Args) > 1 && os.Args[1] == "aml-report" {
+		err = amlReport(ctx, config, os.Args[2:])
+	} else {
+		err = run(ctx, config)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
@@ -57,36 +70,94 @@ type Config struct {
 	LogLevel slog.Level `env:"LOG_LEVEL"`
 	Addr     string     `env:"ADDR"`
 	DB       string     `env:"DB"`
+	Rules    string     `env:"RULES"`
+
+	// APIKeys maps API keys to tenant IDs, e.g. "key1:casino1,key2:casino2".
+	APIKeys map[string]string `env:"API_KEYS"`
+	// AdminKeys maps keys of support staff to tenant IDs like APIKeys, only they can call AdminService.
+	AdminKeys map[string]string `env:"ADMIN_KEYS"`
+	// AdminOperators maps admin keys to support staff written to the audit log, e.g. "key3:alice@example.com".
+	AdminOperators map[string]string `env:"ADMIN_OPERATORS"`
+
+	AMLInterval           time.Duration   `env:"AML_INTERVAL"`
+	AMLDelay              time.Duration   `env:"AML_DELAY"`
+	AMLWindow             time.Duration   `env:"AML_WINDOW"`
+	AMLSingleThreshold    decimal.Decimal `env:"AML_SINGLE_THRESHOLD"`
+	AMLAggregateThreshold decimal.Decimal `env:"AML_AGGREGATE_THRESHOLD"`
+	AMLStructuringRatio   decimal.Decimal `env:"AML_STRUCTURING_RATIO"`
+	AMLStructuringCount   int             `env:"AML_STRUCTURING_COUNT"`
+
+	CancellationInterval time.Duration `env:"CANCELLATION_INTERVAL"`
 }
 
 func run(ctx context.Context, c Config) error {
+	for key := range c.AdminKeys {
+		if _, ok := c.APIKeys[key]; ok {
+			return errors.New("admin keys must differ from api keys")
+		}
+		if c.AdminOperators[key] == "" {
+			return errors.New("every admin key must have an operator")
+		}
+	}
+
 	reflector := grpcreflect.NewStaticReflector(
 		balancev1connect.BalanceServiceName,
+		adminv1connect.AdminServiceName,
 	)
 
-	pgxConfig, err := pgxpool.ParseConfig(c.DB)
+	conn, err := connectDB(ctx, c.DB)
 	if err != nil {
-		return fmt.Errorf("parse database config: %w", err)
-	}
-	pgxConfig.AfterConnect = func(ctx context.Context, c *pgx.Conn) error {
-		pgxdecimal.Register(c.TypeMap())
-		return nil
+		return err
 	}
+	defer conn.Close()
 
-	conn, err := pgxpool.NewWithConfig(ctx, pgxConfig)
+	engine, err := rules.Load(c.Rules)
 	if err != nil {
-		return fmt.Errorf("connect to database: %w", err)
+		return fmt.Errorf("load rules: %w", err)
 	}
-	defer conn.Close()
 
 	queries := db.New(conn)
-	storage := storage.NewBalances(conn, queries)
+
+	if c.AMLInterval > 0 {
+		monitor := aml.NewMonitor(storage.NewAML(conn, queries), aml.Config{
+			Thresholds: aml.Thresholds{
+				Single:           c.AMLSingleThreshold,
+				Aggregate:        c.AMLAggregateThreshold,
+				Window:           c.AMLWindow,
+				StructuringRatio: c.AMLStructuringRatio,
+				StructuringCount: c.AMLStructuringCount,
+			},
+			Interval: c.AMLInterval,
+			Delay:    c.AMLDelay,
+		})
+		go monitor.Run(ctx)
+	}
+
+	listener := storage.NewListener(conn)
+	go listener.Run(ctx)
+
+	admin := service.NewAdmin(storage.NewAdmin(conn, queries))
+	storage := storage.NewBalances(conn, queries, engine, listener)
 	service := service.NewBalances(storage)
 
+	if c.CancellationInterval > 0 {
+		worker := cancellation.NewWorker(storage, cancellation.Config{
+			Interval: c.CancellationInterval,
+		})
+		go worker.Run(ctx)
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle(balancev1connect.NewBalanceServiceHandler(service,
-		connect.WithInterceptors(middleware.LogRequests()),
+		connect.WithInterceptors(middleware.LogRequests(), middleware.Authenticate(c.APIKeys), middleware.Validate()),
 	))
+	mux.Handle(adminv1connect.NewAdminServiceHandler(admin,
+		connect.WithInterceptors(middleware.LogRequests(), middleware.AuthenticateAdmin(c.AdminKeys, c.AdminOperators), middleware.Validate()),
+	))
+	restMux := http.NewServeMux()
+	rest.NewHandler(storage, service, middleware.LogRequests(), middleware.Validate()).Register(restMux)
+	mux.Handle("/v1/", middleware.AuthenticateHTTP(c.APIKeys, restMux, rest.WriteError))
+
 	mux.Handle(grpcreflect.NewHandlerV1(reflector))
 	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -98,9 +169,15 @@ func run(ctx context.Context, c Config) error {
 	protocols.SetHTTP2(true)
 	protocols.SetUnencryptedHTTP2(true)
 
+	handler := withoutTimeouts(mux,
+		balancev1connect.BalanceServiceWatchBalanceProcedure,
+		balancev1connect.BalanceServiceRecordTxStreamProcedure,
+		balancev1connect.BalanceServiceExportStatementProcedure,
+	)
+
 	server := &http.Server{
 		Addr:         c.Addr,
-		Handler:      h2c.NewHandler(mux, &http2.Server{}),
+		Handler:      h2c.NewHandler(handler, &http2.Server{}),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		Protocols:    &protocols,
@@ -126,3 +203,38 @@ func run(ctx context.Context, c Config) error {
 
 	return nil
 }
+
+// withoutTimeouts disables the server read and write timeouts for long-lived streams.
+func withoutTimeouts(next http.Handler, procedures ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(procedures, r.URL.Path) {
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(time.Time{}); err != nil {
+				slog.ErrorContext(r.Context(), "failed to disable read timeout", "error", err)
+			}
+			if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+				slog.ErrorContext(r.Context(), "failed to disable write timeout", "error", err)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func connectDB(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	pgxConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse database config: %w", err)
+	}
+	pgxConfig.AfterConnect = func(ctx context.Context, c *pgx.Conn) error {
+		pgxdecimal.Register(c.TypeMap())
+		return nil
+	}
+
+	conn, err := pgxpool.NewWithConfig(ctx, pgxConfig)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+
+	return conn, nil
+}
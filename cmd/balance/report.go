@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/iskorotkov/igaming-balance-backend/internal/aml"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
+)
+
+// amlReport exports AML cases detected within a period and marks open ones as reported.
+func amlReport(ctx context.Context, c Config, args []string) error {
+	flags := flag.NewFlagSet("aml-report", flag.ContinueOnError)
+	from := flags.String("from", "", "period start, inclusive (2006-01-02 or RFC 3339)")
+	to := flags.String("to", "", "period end, exclusive (2006-01-02 or RFC 3339)")
+	format := flags.String("format", string(aml.FormatCSV), "report format (csv or json)")
+	out := flags.String("out", "", "report file (stdout if empty)")
+	tenantID := flags.String("tenant", "", "tenant ID (all tenants if empty)")
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	fromTime, err := parseTime(*from)
+	if err != nil {
+		return fmt.Errorf("parse from: %w", err)
+	}
+
+	toTime, err := parseTime(*to)
+	if err != nil {
+		return fmt.Errorf("parse to: %w", err)
+	}
+
+	f, err := aml.ParseFormat(*format)
+	if err != nil {
+		return fmt.Errorf("parse format: %w", err)
+	}
+
+	file := os.Stdout
+	if *out != "" {
+		file, err = os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("create report file: %w", err)
+		}
+		defer func() {
+			if err := file.Close(); err != nil {
+				slog.ErrorContext(ctx, "failed to close report file", "error", err)
+			}
+		}()
+	}
+
+	conn, err := connectDB(ctx, c.DB)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var tenantFilter *string
+	if *tenantID != "" {
+		tenantFilter = tenantID
+	}
+
+	// Cases are marked as reported only after the report is written and flushed.
+	report := func(cases []domain.AMLCase) error {
+		if err := aml.WriteReport(file, f, cases); err != nil {
+			return err
+		}
+
+		if *out == "" {
+			return nil
+		}
+
+		return file.Sync()
+	}
+
+	if _, err := storage.NewAML(conn, db.New(conn)).Report(ctx, tenantFilter, fromTime, toTime, report); err != nil {
+		return fmt.Errorf("report cases: %w", err)
+	}
+
+	return nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
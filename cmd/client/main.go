@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"math/rand/v2"
 	"net/http"
 	"os"
@@ -52,6 +53,8 @@ type Config struct {
 	LogLevel slog.Level `env:"LOG_LEVEL"`
 	Addr     string     `env:"ADDR"`
 	DB       string     `env:"DB"`
+	Currency string     `env:"CURRENCY"`
+	APIKey   string     `env:"API_KEY"`
 
 	CreateInterval time.Duration `env:"CREATE_INTERVAL"`
 	CreateCount    int           `env:"CREATE_COUNT"`
@@ -64,7 +67,7 @@ type Config struct {
 func run(ctx context.Context, c Config) error {
 	client := balancev1connect.NewBalanceServiceClient(&http.Client{}, c.Addr,
 		connect.WithGRPC(),
-		connect.WithInterceptors(middleware.LogRequests()),
+		connect.WithInterceptors(middleware.WithAPIKey(c.APIKey), middleware.LogRequests()),
 	)
 
 	balanceID, err := uuid.NewV7() // UUID v7 are automatically sorted by timestamp.
@@ -72,9 +75,17 @@ func run(ctx context.Context, c Config) error {
 		return fmt.Errorf("generate balance ID: %w", err)
 	}
 
+	ownerID, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("generate owner ID: %w", err)
+	}
+
 	if _, err := client.OpenBalance(ctx, &connect.Request[balancev1.OpenBalanceRequest]{
 		Msg: &balancev1.OpenBalanceRequest{
-			BalanceId: balanceID.String(),
+			BalanceId:  balanceID.String(),
+			OwnerId:    ownerID.String(),
+			Currency:   c.Currency,
+			WalletType: balancev1.WalletType_WALLET_TYPE_MAIN,
 		},
 	}); err != nil {
 		return fmt.Errorf("open balance: %w", err)
@@ -121,7 +132,7 @@ func createTxs(
 					Source:    balancev1.Source(1 + rand.IntN(3)),
 					State:     balancev1.State(1 + rand.IntN(2)),
 					Amount: &balancev1.Decimal{
-						Value: strconv.FormatFloat(rand.NormFloat64()*c.CreateAmount, 'f', -1, 64),
+						Value: strconv.FormatFloat(0.01+math.Abs(rand.NormFloat64()*c.CreateAmount), 'f', 2, 64),
 					},
 				}
 
@@ -0,0 +1,9 @@
+drop index if exists idx_txs_balance_created_at;
+
+drop table flagged_events;
+
+drop type rule_action;
+
+alter table balances drop column status;
+
+drop type balance_status;
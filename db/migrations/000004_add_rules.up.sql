@@ -0,0 +1,19 @@
+create type balance_status as enum ('Active', 'Frozen');
+
+alter table balances add column status balance_status not null default 'Active';
+
+create type rule_action as enum ('Allow', 'Flag', 'Reject', 'Freeze');
+
+create table flagged_events (
+    created_at timestamptz not null default now(),
+    event_id uuid not null,
+    balance_id uuid not null,
+    tx_id uuid not null,
+    rule text not null,
+    action rule_action not null,
+    primary key (event_id)
+);
+
+create index idx_flagged_events_balance_event_id on flagged_events (balance_id, event_id desc);
+
+create index idx_txs_balance_created_at on txs (balance_id, created_at);
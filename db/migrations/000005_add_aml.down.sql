@@ -0,0 +1,7 @@
+drop table aml_checkpoints;
+
+drop table aml_cases;
+
+drop type aml_case_status;
+
+drop type aml_case_kind;
@@ -0,0 +1,28 @@
+create type aml_case_kind as enum ('Single', 'Aggregate', 'Structuring');
+
+create type aml_case_status as enum ('Open', 'Reported', 'Closed');
+
+create table aml_cases (
+    created_at timestamptz not null default now(),
+    detected_at timestamptz not null,
+    case_id uuid not null,
+    balance_id uuid not null,
+    kind aml_case_kind not null,
+    status aml_case_status not null default 'Open',
+    state tx_state not null,
+    amount numeric not null,
+    tx_ids uuid[] not null,
+    primary key (case_id)
+);
+
+create index idx_aml_cases_detected_at on aml_cases (detected_at);
+
+-- Txs created before scanned_until were already checked by the AML monitor.
+create table aml_checkpoints (
+    monitor text not null,
+    scanned_until timestamptz not null,
+    primary key (monitor)
+);
+
+insert into aml_checkpoints (monitor, scanned_until)
+values ('aml', 'epoch');
@@ -0,0 +1,11 @@
+drop index if exists idx_balances_owner_balance_id;
+
+drop index if exists idx_balances_owner_currency_wallet;
+
+alter table balances
+    drop column wallet_type,
+    drop column currency,
+    drop column external_player_ref,
+    drop column owner_id;
+
+drop type wallet_type;
@@ -0,0 +1,13 @@
+create type wallet_type as enum ('Main', 'Bonus');
+
+alter table balances
+    add column owner_id uuid default null,
+    add column external_player_ref text default null,
+    add column currency text default null check (currency ~ '^[A-Z]{3}$'),
+    add column wallet_type wallet_type not null default 'Main';
+
+-- A player has at most one balance per currency and wallet type.
+create unique index idx_balances_owner_currency_wallet on balances (owner_id, currency, wallet_type) nulls not distinct
+where owner_id is not null;
+
+create index idx_balances_owner_balance_id on balances (owner_id, balance_id);
@@ -0,0 +1,18 @@
+drop index if exists idx_flagged_events_tenant_event_id;
+
+drop index if exists idx_balances_tenant_owner_balance_id;
+
+drop index if exists idx_balances_tenant_owner_currency_wallet;
+
+create unique index idx_balances_owner_currency_wallet on balances (owner_id, currency, wallet_type) nulls not distinct
+where owner_id is not null;
+
+create index idx_balances_owner_balance_id on balances (owner_id, balance_id);
+
+alter table aml_cases drop column tenant_id;
+
+alter table flagged_events drop column tenant_id;
+
+alter table txs drop column tenant_id;
+
+alter table balances drop column tenant_id;
@@ -0,0 +1,24 @@
+-- Existing data belongs to the default tenant, new rows must set the tenant explicitly.
+alter table balances add column tenant_id text not null default 'default';
+alter table balances alter column tenant_id drop default;
+
+alter table txs add column tenant_id text not null default 'default';
+alter table txs alter column tenant_id drop default;
+
+alter table flagged_events add column tenant_id text not null default 'default';
+alter table flagged_events alter column tenant_id drop default;
+
+alter table aml_cases add column tenant_id text not null default 'default';
+alter table aml_cases alter column tenant_id drop default;
+
+drop index if exists idx_balances_owner_currency_wallet;
+
+drop index if exists idx_balances_owner_balance_id;
+
+-- A player has at most one balance per currency and wallet type within a tenant.
+create unique index idx_balances_tenant_owner_currency_wallet on balances (tenant_id, owner_id, currency, wallet_type) nulls not distinct
+where owner_id is not null;
+
+create index idx_balances_tenant_owner_balance_id on balances (tenant_id, owner_id, balance_id);
+
+create index idx_flagged_events_tenant_event_id on flagged_events (tenant_id, event_id desc);
@@ -0,0 +1,5 @@
+drop index if exists idx_txs_balance_cancelled_tx_id;
+
+drop index if exists idx_txs_balance_amount;
+
+drop index if exists idx_txs_balance_source_state_tx_id;
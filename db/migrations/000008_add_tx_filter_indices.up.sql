@@ -0,0 +1,7 @@
+create index idx_txs_balance_source_state_tx_id on txs (balance_id, source, state, tx_id desc);
+
+create index idx_txs_balance_amount on txs (balance_id, amount);
+
+-- Cancelled txs are rare, so a partial index is small.
+create index idx_txs_balance_cancelled_tx_id on txs (balance_id, tx_id desc)
+where deleted_at is not null;
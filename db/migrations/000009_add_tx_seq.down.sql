@@ -0,0 +1,14 @@
+drop index if exists idx_txs_balance_cancelled_seq;
+
+drop index if exists idx_txs_balance_source_state_seq;
+
+create index idx_txs_balance_source_state_tx_id on txs (balance_id, source, state, tx_id desc);
+
+create index idx_txs_balance_cancelled_tx_id on txs (balance_id, tx_id desc)
+where deleted_at is not null;
+
+drop index if exists idx_txs_balance_seq;
+
+alter table txs drop column seq;
+
+alter table balances drop column last_tx_seq;
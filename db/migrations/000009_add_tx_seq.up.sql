@@ -0,0 +1,28 @@
+alter table balances add column last_tx_seq bigint not null default 0;
+
+alter table txs add column seq bigint;
+
+-- Existing txs are numbered in creation order, tx ID breaks ties.
+update txs
+set seq = numbered.seq
+from (
+    select tx_id, row_number() over (partition by balance_id order by created_at, tx_id) as seq
+    from txs
+) as numbered
+where txs.tx_id = numbered.tx_id;
+
+update balances
+set last_tx_seq = coalesce((select max(seq) from txs where txs.balance_id = balances.balance_id), 0);
+
+alter table txs alter column seq set not null;
+
+create unique index idx_txs_balance_seq on txs (balance_id, seq);
+
+drop index if exists idx_txs_balance_source_state_tx_id;
+
+drop index if exists idx_txs_balance_cancelled_tx_id;
+
+create index idx_txs_balance_source_state_seq on txs (balance_id, source, state, seq);
+
+create index idx_txs_balance_cancelled_seq on txs (balance_id, seq)
+where deleted_at is not null;
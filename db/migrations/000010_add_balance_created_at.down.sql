@@ -0,0 +1,5 @@
+drop index if exists idx_balances_tenant_amount;
+
+drop index if exists idx_balances_tenant_created_at;
+
+alter table balances drop column created_at;
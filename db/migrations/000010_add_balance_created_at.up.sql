@@ -0,0 +1,9 @@
+alter table balances add column created_at timestamptz not null default now();
+
+-- Balances were opened before their first tx, so it's the best known approximation.
+update balances
+set created_at = coalesce((select min(created_at) from txs where txs.balance_id = balances.balance_id), created_at);
+
+create index idx_balances_tenant_created_at on balances (tenant_id, created_at, balance_id);
+
+create index idx_balances_tenant_amount on balances (tenant_id, amount, balance_id);
@@ -0,0 +1,3 @@
+drop index if exists idx_txs_tenant_external_ref;
+
+alter table txs drop column external_ref;
@@ -0,0 +1,5 @@
+alter table txs add column external_ref text default null;
+
+-- External reference identifies a tx in operator's systems, so it's unique within a tenant.
+create unique index idx_txs_tenant_external_ref on txs (tenant_id, external_ref)
+where external_ref is not null;
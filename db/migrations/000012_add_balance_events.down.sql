@@ -0,0 +1,5 @@
+drop table balance_events;
+
+alter table balances drop column last_event_seq;
+
+drop type balance_event_kind;
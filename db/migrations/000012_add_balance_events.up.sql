@@ -0,0 +1,16 @@
+create type balance_event_kind as enum ('TxRecorded', 'TxsCancelled', 'StatusChanged');
+
+alter table balances add column last_event_seq bigint not null default 0;
+
+-- Changes of a balance, numbered without gaps under the balance lock, so watchers can resume after any of them.
+create table balance_events (
+    created_at timestamptz not null default now(),
+    tenant_id text not null,
+    balance_id uuid not null,
+    seq bigint not null,
+    kind balance_event_kind not null,
+    amount numeric not null,
+    status balance_status not null,
+    tx_ids uuid[] not null,
+    primary key (balance_id, seq)
+);
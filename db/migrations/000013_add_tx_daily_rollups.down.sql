@@ -0,0 +1,3 @@
+drop index if exists idx_txs_tenant_created_at;
+
+drop table if exists tx_daily_rollups;
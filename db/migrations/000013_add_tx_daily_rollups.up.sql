@@ -0,0 +1,22 @@
+-- Sums of not cancelled txs per UTC day, kept up to date by tx writes, so reports don't scan txs.
+create table tx_daily_rollups (
+    tenant_id text not null,
+    balance_id uuid not null,
+    day date not null,
+    source tx_source not null,
+    state tx_state not null,
+    tx_count bigint not null,
+    amount numeric not null,
+    primary key (tenant_id, balance_id, day, source, state)
+);
+
+create index idx_tx_daily_rollups_tenant_day on tx_daily_rollups (tenant_id, day);
+
+insert into tx_daily_rollups (tenant_id, balance_id, day, source, state, tx_count, amount)
+select tenant_id, balance_id, (created_at at time zone 'UTC')::date, source, state, count(*), sum(amount)
+from txs
+where deleted_at is null
+group by 1, 2, 3, 4, 5;
+
+create index idx_txs_tenant_created_at on txs (tenant_id, created_at)
+where deleted_at is null;
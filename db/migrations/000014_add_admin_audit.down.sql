@@ -0,0 +1,21 @@
+drop table if exists audit_log;
+
+drop type if exists audit_action;
+
+-- Enum values can't be dropped, so adjustments become service txs and the type is recreated without them.
+insert into tx_daily_rollups (tenant_id, balance_id, day, source, state, tx_count, amount)
+select tenant_id, balance_id, day, 'Service', state, tx_count, amount
+from tx_daily_rollups
+where source = 'Adjustment'
+on conflict (tenant_id, balance_id, day, source, state) do update
+set tx_count = tx_daily_rollups.tx_count + excluded.tx_count, amount = tx_daily_rollups.amount + excluded.amount;
+
+delete from tx_daily_rollups where source = 'Adjustment';
+
+update txs set source = 'Service' where source = 'Adjustment';
+
+alter type tx_source rename to tx_source_old;
+create type tx_source as enum ('Game', 'Payment', 'Service');
+alter table txs alter column source type tx_source using source::text::tx_source;
+alter table tx_daily_rollups alter column source type tx_source using source::text::tx_source;
+drop type tx_source_old;
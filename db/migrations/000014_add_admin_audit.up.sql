@@ -0,0 +1,19 @@
+alter type tx_source add value 'Adjustment';
+
+create type audit_action as enum ('AdjustBalance', 'ForceCancelTx', 'InspectBalance');
+
+-- Actions made with admin keys. Entries are only inserted, in the same transaction as the change they describe.
+create table audit_log (
+    created_at timestamptz not null default now(),
+    tenant_id text not null,
+    entry_id uuid primary key,
+    operator text not null,
+    action audit_action not null,
+    balance_id uuid not null,
+    tx_id uuid,
+    amount numeric,
+    reason text not null,
+    ticket_ref text not null
+);
+
+create index idx_audit_log_balance on audit_log (tenant_id, balance_id, created_at desc);
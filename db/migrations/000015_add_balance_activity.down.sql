@@ -0,0 +1,10 @@
+drop index if exists idx_balances_tenant_tx_count;
+
+drop index if exists idx_balances_tenant_last_activity_at;
+
+drop index if exists idx_balances_tenant_updated_at;
+
+alter table balances
+    drop column tx_count,
+    drop column last_activity_at,
+    drop column updated_at;
@@ -0,0 +1,18 @@
+alter table balances
+    add column updated_at timestamptz not null default now(),
+    add column last_activity_at timestamptz,
+    add column tx_count bigint not null default 0;
+
+-- Activity is the last tx recorded or cancelled, tx count excludes cancelled txs.
+update balances
+set tx_count = coalesce((select count(*) from txs where txs.balance_id = balances.balance_id and txs.deleted_at is null), 0),
+    last_activity_at = (select greatest(max(created_at), max(deleted_at)) from txs where txs.balance_id = balances.balance_id);
+
+update balances
+set updated_at = coalesce(last_activity_at, created_at);
+
+create index idx_balances_tenant_updated_at on balances (tenant_id, updated_at, balance_id);
+
+create index idx_balances_tenant_last_activity_at on balances (tenant_id, last_activity_at, balance_id);
+
+create index idx_balances_tenant_tx_count on balances (tenant_id, tx_count, balance_id);
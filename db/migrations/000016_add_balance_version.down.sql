@@ -0,0 +1 @@
+alter table balances drop column version;
@@ -0,0 +1,5 @@
+alter table balances add column version bigint not null default 0;
+
+-- Every change of a balance so far was followed by a balance event, so versions continue from the event seq.
+update balances
+set version = last_event_seq;
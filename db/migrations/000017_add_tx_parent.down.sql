@@ -0,0 +1,3 @@
+drop index if exists idx_txs_balance_parent;
+
+alter table txs drop column parent_tx_id;
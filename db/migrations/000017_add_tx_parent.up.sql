@@ -0,0 +1,6 @@
+-- Wins, refunds and rollbacks point to the tx they relate to, e.g. a bet. Parents are on the same balance.
+alter table txs add column parent_tx_id uuid references txs (tx_id);
+
+-- Most txs have no parent, so a partial index is small.
+create index idx_txs_balance_parent on txs (balance_id, parent_tx_id)
+where parent_tx_id is not null;
@@ -0,0 +1,7 @@
+drop table if exists cancellation_balances;
+
+drop table if exists cancellations;
+
+drop type if exists balance_cancellation_status;
+
+drop type if exists cancellation_status;
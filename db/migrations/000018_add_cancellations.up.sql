@@ -0,0 +1,37 @@
+create type cancellation_status as enum ('Pending', 'Running', 'Done');
+
+create type balance_cancellation_status as enum ('Pending', 'Done', 'Failed');
+
+-- Bulk cancellations of txs matching criteria, processed in the background balance by balance.
+create table cancellations (
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    finished_at timestamptz,
+    tenant_id text not null,
+    cancellation_id uuid primary key,
+    status cancellation_status not null default 'Pending',
+    source tx_source not null,
+    state tx_state,
+    created_from timestamptz not null,
+    created_to timestamptz not null,
+    external_ref_prefix text,
+    balance_ids uuid[] not null
+);
+
+create index idx_cancellations_tenant_created_at on cancellations (tenant_id, created_at);
+
+-- Balances with matching txs when the cancellation was requested, txs are selected again when a balance is processed.
+create table cancellation_balances (
+    updated_at timestamptz not null default now(),
+    cancellation_id uuid not null references cancellations (cancellation_id),
+    balance_id uuid not null,
+    status balance_cancellation_status not null default 'Pending',
+    tx_ids uuid[] not null default '{}',
+    correction numeric not null default 0,
+    error text,
+    primary key (cancellation_id, balance_id)
+);
+
+-- Workers pick pending balances, which are a small part of all processed ones.
+create index idx_cancellation_balances_pending on cancellation_balances (cancellation_id)
+where status = 'Pending';
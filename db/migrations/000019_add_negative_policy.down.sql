@@ -0,0 +1,11 @@
+alter table cancellation_balances
+    drop column if exists debt,
+    drop column if exists skipped_tx_ids;
+
+alter table cancellations drop column if exists negative_policy;
+
+alter table balance_events drop column if exists negative_policy;
+
+alter table balances drop column if exists debt;
+
+drop type if exists negative_policy;
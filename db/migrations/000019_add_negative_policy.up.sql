@@ -0,0 +1,13 @@
+create type negative_policy as enum ('Fail', 'Skip', 'Debt');
+
+-- Part of cancelled deposits the balance couldn't cover, to be recovered from future deposits.
+alter table balances add column debt numeric not null default 0 check (debt >= 0);
+
+-- Policy of the cancellation which caused a TxsCancelled event.
+alter table balance_events add column negative_policy negative_policy;
+
+alter table cancellations add column negative_policy negative_policy not null default 'Fail';
+
+alter table cancellation_balances
+    add column skipped_tx_ids uuid[] not null default '{}',
+    add column debt numeric not null default 0;
@@ -0,0 +1,5 @@
+drop table if exists debt_entries;
+
+alter table balances drop column if exists last_debt_seq;
+
+drop type if exists debt_entry_kind;
@@ -0,0 +1,26 @@
+create type debt_entry_kind as enum ('Incurred', 'Settled');
+
+alter table balances add column last_debt_seq bigint not null default 0;
+
+-- Changes of the debt of a balance, numbered without gaps under the balance lock.
+create table debt_entries (
+    created_at timestamptz not null default now(),
+    tenant_id text not null,
+    balance_id uuid not null,
+    seq bigint not null,
+    kind debt_entry_kind not null,
+    amount numeric not null check (amount > 0),
+    debt numeric not null, -- Debt after the change.
+    tx_ids uuid[] not null,
+    primary key (balance_id, seq)
+);
+
+-- Debt incurred before the ledger existed is carried over as one entry.
+insert into debt_entries (created_at, tenant_id, balance_id, seq, kind, amount, debt, tx_ids)
+select b.updated_at, b.tenant_id, b.balance_id, 1, 'Incurred', b.debt, b.debt, '{}'
+from balances as b
+where b.debt > 0;
+
+update balances
+set last_debt_seq = 1
+where debt > 0;
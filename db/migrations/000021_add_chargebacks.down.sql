@@ -0,0 +1,13 @@
+drop table if exists chargebacks;
+
+-- Enum values can't be dropped, so balances with frozen withdrawals become frozen and the type is recreated without them.
+update balances set status = 'Frozen' where status = 'WithdrawalsFrozen';
+update balance_events set status = 'Frozen' where status = 'WithdrawalsFrozen';
+
+alter type balance_status rename to balance_status_old;
+create type balance_status as enum ('Active', 'Frozen');
+alter table balances alter column status drop default;
+alter table balances alter column status type balance_status using status::text::balance_status;
+alter table balances alter column status set default 'Active';
+alter table balance_events alter column status type balance_status using status::text::balance_status;
+drop type balance_status_old;
@@ -0,0 +1,19 @@
+-- Withdrawals are blocked, deposits are still recorded, e.g. to recover debt after a chargeback.
+alter type balance_status add value 'WithdrawalsFrozen';
+
+-- Payment deposits reversed by card schemes. Only the insert is done, a deposit is charged back at most once.
+create table chargebacks (
+    created_at timestamptz not null default now(),
+    tenant_id text not null,
+    chargeback_id uuid primary key,
+    balance_id uuid not null,
+    tx_id uuid not null,
+    amount numeric not null, -- Amount of the deposit.
+    correction numeric not null, -- Change of the balance amount.
+    debt numeric not null, -- Part of the deposit the balance couldn't cover.
+    reason text
+);
+
+create unique index idx_chargebacks_tenant_tx on chargebacks (tenant_id, tx_id);
+
+create index idx_chargebacks_balance on chargebacks (tenant_id, balance_id, created_at);
@@ -0,0 +1,9 @@
+alter table txs drop column if exists settled_debt;
+
+-- Enum values can't be dropped, so reinstated debt becomes incurred and the type is recreated without them.
+update debt_entries set kind = 'Incurred' where kind = 'Reinstated';
+
+alter type debt_entry_kind rename to debt_entry_kind_old;
+create type debt_entry_kind as enum ('Incurred', 'Settled');
+alter table debt_entries alter column kind type debt_entry_kind using kind::text::debt_entry_kind;
+drop type debt_entry_kind_old;
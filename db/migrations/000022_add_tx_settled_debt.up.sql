@@ -0,0 +1,9 @@
+alter type debt_entry_kind add value 'Reinstated';
+
+-- Part of a deposit which paid debt off instead of adding to the amount, cancellations put it back on the debt.
+alter table txs add column settled_debt numeric not null default 0 check (settled_debt >= 0);
+
+update txs as t
+set settled_debt = d.amount
+from debt_entries as d
+where d.kind = 'Settled' and d.tenant_id = t.tenant_id and d.balance_id = t.balance_id and t.tx_id = any(d.tx_ids);
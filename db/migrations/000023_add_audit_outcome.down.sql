@@ -0,0 +1,10 @@
+alter table audit_log drop column if exists attempt_id;
+
+-- Only outcomes of succeeded changes are kept, like entries written with the change.
+delete from audit_log where outcome <> 'Succeeded';
+
+alter table audit_log
+    drop column if exists error,
+    drop column if exists outcome;
+
+drop type if exists audit_outcome;
@@ -0,0 +1,10 @@
+create type audit_outcome as enum ('Attempted', 'Succeeded', 'Failed');
+
+-- Actions are audited before and after the change outside of its transaction, so failed and interrupted attempts stay in the log.
+-- Entries made before were written with the change, so they succeeded.
+alter table audit_log
+    add column outcome audit_outcome not null default 'Succeeded',
+    add column attempt_id uuid references audit_log (entry_id),
+    add column error text;
+
+alter table audit_log alter column outcome drop default;
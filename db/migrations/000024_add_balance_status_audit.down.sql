@@ -0,0 +1,9 @@
+alter table audit_log drop column if exists status;
+
+-- Enum values can't be dropped, so status changes are removed from the log and the type is recreated without them.
+delete from audit_log where action = 'SetBalanceStatus';
+
+alter type audit_action rename to audit_action_old;
+create type audit_action as enum ('AdjustBalance', 'ForceCancelTx', 'InspectBalance');
+alter table audit_log alter column action type audit_action using action::text::audit_action;
+drop type audit_action_old;
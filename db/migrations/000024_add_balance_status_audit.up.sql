@@ -0,0 +1,4 @@
+alter type audit_action add value 'SetBalanceStatus';
+
+-- Status set by the action, null for other actions.
+alter table audit_log add column status balance_status;
@@ -0,0 +1,24 @@
+-- Fails if tenants have balances or txs with the same IDs.
+drop index if exists idx_flagged_events_tenant_balance_event_id;
+create index idx_flagged_events_balance_event_id on flagged_events (balance_id, event_id desc);
+
+alter table debt_entries drop constraint debt_entries_pkey;
+alter table debt_entries add primary key (balance_id, seq);
+
+alter table balance_events drop constraint balance_events_pkey;
+alter table balance_events add primary key (balance_id, seq);
+
+alter table balances drop constraint balances_pkey;
+alter table balances add constraint balances_balance_id_key unique (balance_id);
+alter table balances add primary key (balance_id);
+
+drop index if exists idx_txs_tenant_balance_seq;
+create unique index idx_txs_balance_seq on txs (balance_id, seq);
+
+alter table txs drop constraint txs_parent_tx_id_fkey;
+
+alter table txs drop constraint txs_pkey;
+alter table txs add constraint txs_tx_id_key unique (tx_id);
+alter table txs add primary key (tx_id);
+
+alter table txs add constraint txs_parent_tx_id_fkey foreign key (parent_tx_id) references txs (tx_id);
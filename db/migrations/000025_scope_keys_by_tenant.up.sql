@@ -0,0 +1,25 @@
+-- Balance and tx IDs are chosen by tenants, so they are unique only within a tenant.
+-- Other IDs, e.g. of events, cases and audit entries, are generated by the service and stay globally unique.
+alter table txs drop constraint txs_parent_tx_id_fkey;
+
+alter table txs drop constraint txs_pkey;
+alter table txs drop constraint txs_tx_id_key;
+alter table txs add primary key (tenant_id, tx_id);
+
+alter table txs add constraint txs_parent_tx_id_fkey foreign key (tenant_id, parent_tx_id) references txs (tenant_id, tx_id);
+
+drop index if exists idx_txs_balance_seq;
+create unique index idx_txs_tenant_balance_seq on txs (tenant_id, balance_id, seq);
+
+alter table balances drop constraint balances_pkey;
+alter table balances drop constraint balances_balance_id_key;
+alter table balances add primary key (tenant_id, balance_id);
+
+alter table balance_events drop constraint balance_events_pkey;
+alter table balance_events add primary key (tenant_id, balance_id, seq);
+
+alter table debt_entries drop constraint debt_entries_pkey;
+alter table debt_entries add primary key (tenant_id, balance_id, seq);
+
+drop index if exists idx_flagged_events_balance_event_id;
+create index idx_flagged_events_tenant_balance_event_id on flagged_events (tenant_id, balance_id, event_id desc);
@@ -0,0 +1,3 @@
+alter table cancellations drop column metadata;
+
+alter table txs drop column metadata;
@@ -0,0 +1,4 @@
+-- Operators tag txs with e.g. provider, game and round, bulk cancellations select txs containing their metadata.
+alter table txs add column metadata jsonb not null default '{}';
+
+alter table cancellations add column metadata jsonb not null default '{}';
@@ -1,44 +1,451 @@
 -- name: LockBalance :execrows
-SELECT pg_advisory_xact_lock(hashtext((@balance_id::uuid)::text)); -- Lock a single balance row.
+-- Lock a single balance row. Tenant is a part of the key, so tenants never wait for each other.
+SELECT pg_advisory_xact_lock(hashtext(@tenant_id::text || ':' || (@balance_id::uuid)::text));
+
+-- name: NextTxSeq :one
+-- Must be called under the balance lock, so txs of a balance are numbered without gaps.
+update balances
+set last_tx_seq = last_tx_seq + 1
+where tenant_id = @tenant_id and balance_id = @balance_id
+returning last_tx_seq;
 
 -- name: InsertTx :execrows
-insert into txs (balance_id, source, state, amount, tx_id)
-values ($1, $2, $3, $4, $5);
+insert into txs (tenant_id, balance_id, source, state, amount, tx_id, seq, external_ref, parent_tx_id, settled_debt, metadata)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 
 -- name: DeleteTxs :execrows
 update txs
 set deleted_at = now()
-where balance_id = $1 and tx_id = any(@tx_ids::uuid[]) and deleted_at is null;
+where tenant_id = @tenant_id and balance_id = @balance_id and tx_id = any(@tx_ids::uuid[]) and deleted_at is null;
 
 -- name: UpdateBalance :execrows
+-- Changes the amount by recorded or cancelled txs, tx count is changed by the number of txs.
+-- Debt grows by what cancellations with the debt policy couldn't take from the amount.
+-- Every change of a balance increments its version.
 update balances
-set amount = amount + $2
-where balance_id = $1;
+set amount = amount + @amount, debt = debt + @debt, tx_count = tx_count + @tx_count::bigint, version = version + 1, updated_at = now(), last_activity_at = now()
+where tenant_id = @tenant_id and balance_id = @balance_id;
 
 -- name: TxsByID :many
 select *
 from txs
-where balance_id = $1 and tx_id = any(@tx_ids::uuid[]);
+where tenant_id = @tenant_id and balance_id = @balance_id and tx_id = any(@tx_ids::uuid[]);
+
+-- name: DescendantTxs :many
+-- Not cancelled txs linked to the txs through parents at any depth.
+with recursive descendants as (
+    select t.tx_id
+    from txs as t
+    where t.tenant_id = @tenant_id and t.balance_id = @balance_id and t.parent_tx_id = any(@tx_ids::uuid[])
+    union
+    select c.tx_id
+    from txs as c
+    join descendants as d on c.parent_tx_id = d.tx_id
+    where c.tenant_id = @tenant_id and c.balance_id = @balance_id
+)
+select txs.*
+from txs
+where txs.tenant_id = @tenant_id and txs.balance_id = @balance_id and txs.deleted_at is null
+    and txs.tx_id in (select descendants.tx_id from descendants)
+order by txs.seq;
 
--- name: RecentTxs :many
+-- name: RelatedTxs :many
+-- Txs sharing the root parent with the tx: the root, all its descendants and the tx itself.
+with recursive ancestors as (
+    select t.tx_id, t.parent_tx_id, 0 as depth
+    from txs as t
+    where t.tenant_id = @tenant_id and t.tx_id = @tx_id
+    union all
+    select p.tx_id, p.parent_tx_id, a.depth + 1
+    from txs as p
+    join ancestors as a on p.tx_id = a.parent_tx_id
+    where p.tenant_id = @tenant_id
+), root as (
+    select a.tx_id
+    from ancestors as a
+    order by a.depth desc
+    limit 1
+), tree as (
+    select r.tx_id
+    from root as r
+    union
+    select c.tx_id
+    from txs as c
+    join tree as p on c.parent_tx_id = p.tx_id
+    where c.tenant_id = @tenant_id
+)
+select txs.*
+from txs
+where txs.tenant_id = @tenant_id and txs.tx_id in (select tree.tx_id from tree)
+order by txs.seq
+limit sqlc.arg('limit');
+
+-- name: TxByID :one
 select *
 from txs
-where balance_id = $1 and (deleted_at is null or @include_deleted::bool)
-order by tx_id desc
-limit $2;
+where tenant_id = @tenant_id and tx_id = @tx_id;
 
--- name: PreviousTxs :many
+-- name: TxByExternalRef :one
 select *
 from txs
-where balance_id = $1 and tx_id < $2 and (deleted_at is null or @include_deleted::bool)
-order by tx_id desc
-limit $3;
+where tenant_id = @tenant_id and external_ref = @external_ref;
 
--- name: OpenBalance :execrows
-insert into balances (balance_id, amount)
-values ($1, 0);
+-- name: TxsNewestFirst :many
+select *
+from txs
+where tenant_id = @tenant_id and balance_id = @balance_id and (sqlc.narg(after_seq)::bigint is null or seq < sqlc.narg(after_seq))
+    and (deleted_at is null or @include_deleted::bool)
+    and (deleted_at is not null or not @cancelled_only::bool)
+    and (sqlc.narg(source)::tx_source is null or source = sqlc.narg(source))
+    and (sqlc.narg(state)::tx_state is null or state = sqlc.narg(state))
+    and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+    and (sqlc.narg(created_to)::timestamptz is null or created_at < sqlc.narg(created_to))
+    and (sqlc.narg(min_amount)::numeric is null or amount >= sqlc.narg(min_amount))
+    and (sqlc.narg(max_amount)::numeric is null or amount <= sqlc.narg(max_amount))
+order by seq desc
+limit sqlc.arg('limit');
+
+-- name: TxsOldestFirst :many
+select *
+from txs
+where tenant_id = @tenant_id and balance_id = @balance_id and (sqlc.narg(after_seq)::bigint is null or seq > sqlc.narg(after_seq))
+    and (deleted_at is null or @include_deleted::bool)
+    and (deleted_at is not null or not @cancelled_only::bool)
+    and (sqlc.narg(source)::tx_source is null or source = sqlc.narg(source))
+    and (sqlc.narg(state)::tx_state is null or state = sqlc.narg(state))
+    and (sqlc.narg(created_from)::timestamptz is null or created_at >= sqlc.narg(created_from))
+    and (sqlc.narg(created_to)::timestamptz is null or created_at < sqlc.narg(created_to))
+    and (sqlc.narg(min_amount)::numeric is null or amount >= sqlc.narg(min_amount))
+    and (sqlc.narg(max_amount)::numeric is null or amount <= sqlc.narg(max_amount))
+order by seq
+limit sqlc.arg('limit');
+
+-- name: OpenBalance :one
+insert into balances (tenant_id, balance_id, amount, owner_id, external_player_ref, currency, wallet_type)
+values ($1, $2, 0, $3, $4, $5, $6)
+returning *;
 
 -- name: Balance :one
-select balance_id, amount
+select *
 from balances
-where balance_id = $1;
+where tenant_id = @tenant_id and balance_id = @balance_id;
+
+-- name: SearchBalances :many
+-- Balances are sorted by the sort key and balance ID. Sort key is negated for descending order, so keyset pagination works the same way for all sorts.
+-- Balances without activity are sorted by last activity as if it was at the epoch.
+select sqlc.embed(b), ((case @sort_by::text
+    when 'Amount' then b.amount
+    when 'TxCount' then b.tx_count
+    when 'UpdatedAt' then extract(epoch from b.updated_at)
+    when 'LastActivityAt' then coalesce(extract(epoch from b.last_activity_at), 0)
+    else extract(epoch from b.created_at)
+end) * (case when @descending::bool then -1 else 1 end))::numeric as sort_key
+from balances as b
+where b.tenant_id = @tenant_id
+    and (sqlc.narg(owner_id)::uuid is null or b.owner_id = sqlc.narg(owner_id))
+    and (sqlc.narg(status)::balance_status is null or b.status = sqlc.narg(status))
+    and (sqlc.narg(min_amount)::numeric is null or b.amount >= sqlc.narg(min_amount))
+    and (sqlc.narg(max_amount)::numeric is null or b.amount <= sqlc.narg(max_amount))
+    and (sqlc.narg(created_from)::timestamptz is null or b.created_at >= sqlc.narg(created_from))
+    and (sqlc.narg(created_to)::timestamptz is null or b.created_at < sqlc.narg(created_to))
+    and (sqlc.narg(updated_from)::timestamptz is null or b.updated_at >= sqlc.narg(updated_from))
+    and (sqlc.narg(updated_to)::timestamptz is null or b.updated_at < sqlc.narg(updated_to))
+    and (sqlc.narg(active_since)::timestamptz is null or b.last_activity_at >= sqlc.narg(active_since))
+    and (sqlc.narg(min_tx_count)::bigint is null or b.tx_count >= sqlc.narg(min_tx_count))
+    and (sqlc.narg(max_tx_count)::bigint is null or b.tx_count <= sqlc.narg(max_tx_count))
+    and (sqlc.narg(after_sort_key)::numeric is null or ((case @sort_by::text
+    when 'Amount' then b.amount
+    when 'TxCount' then b.tx_count
+    when 'UpdatedAt' then extract(epoch from b.updated_at)
+    when 'LastActivityAt' then coalesce(extract(epoch from b.last_activity_at), 0)
+    else extract(epoch from b.created_at)
+end) * (case when @descending::bool then -1 else 1 end), b.balance_id) > (sqlc.narg(after_sort_key), sqlc.narg(after_balance_id)::uuid))
+order by sort_key, b.balance_id
+limit sqlc.arg('limit');
+
+-- name: SetBalanceStatus :execrows
+update balances
+set status = @status, version = version + 1, updated_at = now()
+where tenant_id = @tenant_id and balance_id = @balance_id;
+
+-- name: InsertBalanceEvent :one
+-- Records the current amount and status of the balance, so it must be called after the balance is updated.
+with updated as (
+    update balances
+    set last_event_seq = balances.last_event_seq + 1
+    where balances.tenant_id = @tenant_id and balances.balance_id = @balance_id
+    returning balances.tenant_id, balances.balance_id, balances.last_event_seq, balances.amount, balances.status
+)
+insert into balance_events (tenant_id, balance_id, seq, kind, amount, status, tx_ids, negative_policy)
+select updated.tenant_id, updated.balance_id, updated.last_event_seq, @kind::balance_event_kind, updated.amount, updated.status, @tx_ids::uuid[],
+    sqlc.narg(negative_policy)::negative_policy
+from updated
+returning seq;
+
+-- name: NotifyBalanceEvent :exec
+-- Notifications are delivered on commit.
+select pg_notify('balance_events', @payload::text);
+
+-- name: BalanceEvents :many
+select *
+from balance_events
+where tenant_id = @tenant_id and balance_id = @balance_id and seq > @after_seq
+order by seq
+limit sqlc.arg('limit');
+
+-- name: DeclareStatementCursor :exec
+-- Lists txs and their cancellations with the balance after each of them, rows are read with fetch in batches.
+-- Balance after an entry is the amount of the event which recorded or cancelled the tx, so settled and incurred debt is counted.
+-- Txs recorded before events were added have no events, their balance is summed over the whole history.
+declare statement_cursor no scroll cursor for
+select running.entry_at, running.seq, running.cancellation, running.tx_id, running.external_ref, running.source, running.state,
+    running.amount, running.change, coalesce(running.balance_after, running.summed_balance)::numeric as running_balance
+from (
+    select entries.*, sum(entries.change) over (order by entries.entry_at, entries.seq, entries.cancellation rows unbounded preceding)::numeric as summed_balance
+    from (
+        select t.created_at as entry_at, t.seq, false as cancellation, t.tx_id, t.external_ref, t.source, t.state, t.amount,
+            case t.state when 'Deposit' then t.amount else -t.amount end as change,
+            r.amount as balance_after
+        from txs as t
+        left join (
+            select unnest(e.tx_ids) as tx_id, e.amount
+            from balance_events as e
+            where e.tenant_id = @tenant_id and e.balance_id = @balance_id and e.kind = 'TxRecorded'
+        ) as r on r.tx_id = t.tx_id
+        where t.tenant_id = @tenant_id and t.balance_id = @balance_id
+        union all
+        select t.deleted_at, t.seq, true, t.tx_id, t.external_ref, t.source, t.state, t.amount,
+            case t.state when 'Deposit' then -t.amount else t.amount end,
+            c.amount
+        from txs as t
+        left join (
+            select unnest(e.tx_ids) as tx_id, e.amount
+            from balance_events as e
+            where e.tenant_id = @tenant_id and e.balance_id = @balance_id and e.kind = 'TxsCancelled'
+        ) as c on c.tx_id = t.tx_id
+        where t.tenant_id = @tenant_id and t.balance_id = @balance_id and t.deleted_at is not null
+    ) as entries
+) as running
+where (sqlc.narg(from_time)::timestamptz is null or running.entry_at >= sqlc.narg(from_time))
+    and (sqlc.narg(to_time)::timestamptz is null or running.entry_at < sqlc.narg(to_time))
+order by running.entry_at, running.seq, running.cancellation;
+
+-- name: RollupTxs :exec
+-- Adds not cancelled txs to daily rollups with sign 1 and removes them with sign -1.
+insert into tx_daily_rollups (tenant_id, balance_id, day, source, state, tx_count, amount)
+select t.tenant_id, t.balance_id, (t.created_at at time zone 'UTC')::date, t.source, t.state,
+    count(*) * @sign::int, sum(t.amount) * @sign::int
+from txs as t
+where t.tenant_id = @tenant_id and t.balance_id = @balance_id and t.tx_id = any(@tx_ids::uuid[]) and t.deleted_at is null
+group by t.tenant_id, t.balance_id, (t.created_at at time zone 'UTC')::date, t.source, t.state
+on conflict (tenant_id, balance_id, day, source, state) do update
+set tx_count = tx_daily_rollups.tx_count + excluded.tx_count, amount = tx_daily_rollups.amount + excluded.amount;
+
+-- name: AggregateTxs :many
+-- Sums not cancelled txs per bucket starting at the bucket unit boundary in the time zone.
+select date_trunc(@bucket::text, t.created_at, @time_zone::text)::timestamptz as bucket_start, t.source, t.state,
+    count(*) as tx_count, sum(t.amount)::numeric as amount
+from txs as t
+where t.tenant_id = @tenant_id
+    and (sqlc.narg(balance_id)::uuid is null or t.balance_id = sqlc.narg(balance_id))
+    and t.deleted_at is null
+    and t.created_at >= @created_from::timestamptz and t.created_at < @created_to::timestamptz
+group by 1, t.source, t.state
+order by 1, t.source, t.state;
+
+-- name: AggregateDailyRollups :many
+-- Sums daily rollups per UTC bucket, buckets must not be shorter than a day.
+select (date_trunc(@bucket::text, r.day::timestamp) at time zone 'UTC')::timestamptz as bucket_start, r.source, r.state,
+    sum(r.tx_count)::bigint as tx_count, sum(r.amount)::numeric as amount
+from tx_daily_rollups as r
+where r.tenant_id = @tenant_id
+    and (sqlc.narg(balance_id)::uuid is null or r.balance_id = sqlc.narg(balance_id))
+    and r.day >= @day_from::date and r.day < @day_to::date
+group by 1, r.source, r.state
+having sum(r.tx_count) > 0
+order by 1, r.source, r.state;
+
+-- name: TxStats :many
+-- Cancelled txs were reversed, so rules don't count them.
+select source, state, count(*) as count, coalesce(sum(amount), 0)::numeric as sum
+from txs
+where tenant_id = @tenant_id and balance_id = @balance_id and created_at >= @since::timestamptz and deleted_at is null
+group by source, state;
+
+-- name: InsertFlaggedEvent :execrows
+insert into flagged_events (tenant_id, event_id, balance_id, tx_id, rule, action)
+values ($1, $2, $3, $4, $5, $6);
+
+-- name: RecentFlaggedEvents :many
+select *
+from flagged_events
+where tenant_id = @tenant_id and (sqlc.narg(balance_id)::uuid is null or balance_id = sqlc.narg(balance_id))
+order by event_id desc
+limit sqlc.arg('limit');
+
+-- name: PreviousFlaggedEvents :many
+select *
+from flagged_events
+where tenant_id = @tenant_id and (sqlc.narg(balance_id)::uuid is null or balance_id = sqlc.narg(balance_id)) and event_id < @event_id
+order by event_id desc
+limit sqlc.arg('limit');
+
+-- name: TryLockMonitor :one
+-- Only one replica runs a monitor at a time.
+SELECT pg_try_advisory_xact_lock(hashtext(@monitor::text));
+
+-- name: MonitorCheckpoint :one
+select scanned_until
+from aml_checkpoints
+where monitor = $1;
+
+-- name: SetMonitorCheckpoint :execrows
+update aml_checkpoints
+set scanned_until = $2
+where monitor = $1;
+
+-- name: TxsCreatedBetween :many
+-- Monitors scan txs of all tenants.
+select *
+from txs
+where created_at > @created_after::timestamptz and created_at <= @created_before::timestamptz
+order by tenant_id, balance_id, state, created_at, tx_id;
+
+-- name: InsertAMLCase :execrows
+insert into aml_cases (tenant_id, case_id, detected_at, balance_id, kind, state, amount, tx_ids)
+values ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: AMLCases :many
+select *
+from aml_cases
+where (sqlc.narg(tenant_id)::text is null or tenant_id = sqlc.narg(tenant_id))
+    and detected_at >= @detected_from::timestamptz and detected_at < @detected_to::timestamptz
+order by detected_at, case_id;
+
+-- name: SetAMLCasesStatus :execrows
+update aml_cases
+set status = @status
+where case_id = any(@case_ids::uuid[]) and status = @current_status;
+
+-- name: InsertAuditEntry :execrows
+insert into audit_log (tenant_id, entry_id, operator, action, balance_id, tx_id, amount, reason, ticket_ref, outcome, attempt_id, error, status)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: AuditEntries :many
+select *
+from audit_log
+where tenant_id = @tenant_id and balance_id = @balance_id
+order by created_at desc
+limit sqlc.arg('limit');
+
+-- name: InsertCancellation :one
+insert into cancellations (tenant_id, cancellation_id, source, state, created_from, created_to, external_ref_prefix, balance_ids, negative_policy, metadata)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+returning *;
+
+-- name: InsertCancellationBalances :execrows
+-- Empty balance IDs match all balances of the tenant, empty metadata matches all txs.
+insert into cancellation_balances (cancellation_id, balance_id)
+select distinct c.cancellation_id, t.balance_id
+from cancellations as c
+join txs as t on t.tenant_id = c.tenant_id
+where c.cancellation_id = @cancellation_id and t.deleted_at is null
+    and t.source = c.source and (c.state is null or t.state = c.state)
+    and t.created_at >= c.created_from and t.created_at < c.created_to
+    and (c.external_ref_prefix is null or starts_with(t.external_ref, c.external_ref_prefix))
+    and t.metadata @> c.metadata
+    and (cardinality(c.balance_ids) = 0 or t.balance_id = any(c.balance_ids));
+
+-- name: Cancellation :one
+select *
+from cancellations
+where tenant_id = @tenant_id and cancellation_id = @cancellation_id;
+
+-- name: CancellationProgress :one
+select count(*) as balances_total,
+    count(*) filter (where status = 'Done') as balances_done,
+    count(*) filter (where status = 'Failed') as balances_failed,
+    coalesce(sum(cardinality(tx_ids)), 0)::bigint as txs_cancelled
+from cancellation_balances
+where cancellation_id = @cancellation_id;
+
+-- name: CancellationBalances :many
+select *
+from cancellation_balances
+where cancellation_id = @cancellation_id and (sqlc.narg(after_balance_id)::uuid is null or balance_id > sqlc.narg(after_balance_id))
+order by balance_id
+limit sqlc.arg('limit');
+
+-- name: NextCancellationBalance :one
+-- Replicas process different balances concurrently, older cancellations go first.
+select sqlc.embed(c), cb.balance_id
+from cancellation_balances as cb
+join cancellations as c on c.cancellation_id = cb.cancellation_id
+where cb.status = 'Pending' and c.status <> 'Done'
+order by c.created_at, cb.balance_id
+limit 1
+for update of cb skip locked;
+
+-- name: CriteriaTxs :many
+-- Not cancelled txs of the balance matching criteria of the cancellation.
+select t.*
+from txs as t
+join cancellations as c on c.tenant_id = t.tenant_id
+where c.cancellation_id = @cancellation_id and t.balance_id = @balance_id and t.deleted_at is null
+    and t.source = c.source and (c.state is null or t.state = c.state)
+    and t.created_at >= c.created_from and t.created_at < c.created_to
+    and (c.external_ref_prefix is null or starts_with(t.external_ref, c.external_ref_prefix))
+    and t.metadata @> c.metadata
+order by t.seq;
+
+-- name: SetCancellationBalance :execrows
+update cancellation_balances
+set status = @status, tx_ids = @tx_ids, skipped_tx_ids = @skipped_tx_ids, correction = @correction, debt = @debt, error = @error,
+    updated_at = now()
+where cancellation_id = @cancellation_id and balance_id = @balance_id;
+
+-- name: LockCancellation :execrows
+-- Status is updated after other replicas commit their balances, so the last one sees no pending balances.
+select 1
+from cancellations
+where cancellation_id = @cancellation_id
+for update;
+
+-- name: SetCancellationStatus :execrows
+-- Cancellations are done when no balances are pending.
+update cancellations as c
+set status = case when p.pending then 'Running'::cancellation_status else 'Done'::cancellation_status end,
+    finished_at = case when p.pending then null else now() end,
+    updated_at = now()
+from (
+    select exists (
+        select 1
+        from cancellation_balances as cb
+        where cb.cancellation_id = @cancellation_id and cb.status = 'Pending'
+    ) as pending
+) as p
+where c.cancellation_id = @cancellation_id;
+
+-- name: InsertDebtEntry :execrows
+-- Debt after the change is passed, so one balance update can be split into several entries.
+with updated as (
+    update balances
+    set last_debt_seq = balances.last_debt_seq + 1
+    where balances.tenant_id = @tenant_id and balances.balance_id = @balance_id
+    returning balances.tenant_id, balances.balance_id, balances.last_debt_seq
+)
+insert into debt_entries (tenant_id, balance_id, seq, kind, amount, debt, tx_ids)
+select updated.tenant_id, updated.balance_id, updated.last_debt_seq, @kind::debt_entry_kind, @amount::numeric, @debt::numeric, @tx_ids::uuid[]
+from updated;
+
+-- name: DebtEntries :many
+select *
+from debt_entries
+where tenant_id = @tenant_id and balance_id = @balance_id and seq > @after_seq
+order by seq
+limit sqlc.arg('limit');
+
+-- name: InsertChargeback :one
+insert into chargebacks (tenant_id, chargeback_id, balance_id, tx_id, amount, correction, debt, reason)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
+returning *;
@@ -0,0 +1,811 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: admin/v1/admin.proto
+
+package adminv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	v1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditAction int32
+
+const (
+	AuditAction_AUDIT_ACTION_UNSPECIFIED        AuditAction = 0
+	AuditAction_AUDIT_ACTION_ADJUST_BALANCE     AuditAction = 1
+	AuditAction_AUDIT_ACTION_FORCE_CANCEL_TX    AuditAction = 2
+	AuditAction_AUDIT_ACTION_INSPECT_BALANCE    AuditAction = 3
+	AuditAction_AUDIT_ACTION_SET_BALANCE_STATUS AuditAction = 4
+)
+
+// Enum value maps for AuditAction.
+var (
+	AuditAction_name = map[int32]string{
+		0: "AUDIT_ACTION_UNSPECIFIED",
+		1: "AUDIT_ACTION_ADJUST_BALANCE",
+		2: "AUDIT_ACTION_FORCE_CANCEL_TX",
+		3: "AUDIT_ACTION_INSPECT_BALANCE",
+		4: "AUDIT_ACTION_SET_BALANCE_STATUS",
+	}
+	AuditAction_value = map[string]int32{
+		"AUDIT_ACTION_UNSPECIFIED":        0,
+		"AUDIT_ACTION_ADJUST_BALANCE":     1,
+		"AUDIT_ACTION_FORCE_CANCEL_TX":    2,
+		"AUDIT_ACTION_INSPECT_BALANCE":    3,
+		"AUDIT_ACTION_SET_BALANCE_STATUS": 4,
+	}
+)
+
+func (x AuditAction) Enum() *AuditAction {
+	p := new(AuditAction)
+	*p = x
+	return p
+}
+
+func (x AuditAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (AuditAction) Type() protoreflect.EnumType {
+	return &file_admin_v1_admin_proto_enumTypes[0]
+}
+
+func (x AuditAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditAction.Descriptor instead.
+func (AuditAction) EnumDescriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type AuditOutcome int32
+
+const (
+	AuditOutcome_AUDIT_OUTCOME_UNSPECIFIED AuditOutcome = 0
+	AuditOutcome_AUDIT_OUTCOME_ATTEMPTED   AuditOutcome = 1 // Written before the change, so interrupted actions are audited too.
+	AuditOutcome_AUDIT_OUTCOME_SUCCEEDED   AuditOutcome = 2
+	AuditOutcome_AUDIT_OUTCOME_FAILED      AuditOutcome = 3
+)
+
+// Enum value maps for AuditOutcome.
+var (
+	AuditOutcome_name = map[int32]string{
+		0: "AUDIT_OUTCOME_UNSPECIFIED",
+		1: "AUDIT_OUTCOME_ATTEMPTED",
+		2: "AUDIT_OUTCOME_SUCCEEDED",
+		3: "AUDIT_OUTCOME_FAILED",
+	}
+	AuditOutcome_value = map[string]int32{
+		"AUDIT_OUTCOME_UNSPECIFIED": 0,
+		"AUDIT_OUTCOME_ATTEMPTED":   1,
+		"AUDIT_OUTCOME_SUCCEEDED":   2,
+		"AUDIT_OUTCOME_FAILED":      3,
+	}
+)
+
+func (x AuditOutcome) Enum() *AuditOutcome {
+	p := new(AuditOutcome)
+	*p = x
+	return p
+}
+
+func (x AuditOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_v1_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (AuditOutcome) Type() protoreflect.EnumType {
+	return &file_admin_v1_admin_proto_enumTypes[1]
+}
+
+func (x AuditOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditOutcome.Descriptor instead.
+func (AuditOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+// Why an admin action is requested, stored in the audit log with the action.
+// The operator making the action is resolved from the admin key, see ADMIN_OPERATORS.
+type Audit struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Support ticket which requested the action.
+	TicketRef     string `protobuf:"bytes,3,opt,name=ticket_ref,json=ticketRef,proto3" json:"ticket_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Audit) Reset() {
+	*x = Audit{}
+	mi := &file_admin_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audit) ProtoMessage() {}
+
+func (x *Audit) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audit.ProtoReflect.Descriptor instead.
+func (*Audit) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Audit) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Audit) GetTicketRef() string {
+	if x != nil {
+		return x.TicketRef
+	}
+	return ""
+}
+
+type AdjustBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	TxId          string                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"` // ID of the adjustment tx.
+	State         v1.State               `protobuf:"varint,3,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`
+	Amount        *v1.Decimal            `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Audit         *Audit                 `protobuf:"bytes,5,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustBalanceRequest) Reset() {
+	*x = AdjustBalanceRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustBalanceRequest) ProtoMessage() {}
+
+func (x *AdjustBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AdjustBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AdjustBalanceRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetState() v1.State {
+	if x != nil {
+		return x.State
+	}
+	return v1.State(0)
+}
+
+func (x *AdjustBalanceRequest) GetAmount() *v1.Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *AdjustBalanceRequest) GetAudit() *Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type ForceCancelTxRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	TxId      string                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Audit     *Audit                 `protobuf:"bytes,3,opt,name=audit,proto3" json:"audit,omitempty"`
+	// Also cancels not cancelled descendants of the tx, otherwise a tx with such children can't be cancelled.
+	Cascade       bool `protobuf:"varint,4,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceCancelTxRequest) Reset() {
+	*x = ForceCancelTxRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceCancelTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceCancelTxRequest) ProtoMessage() {}
+
+func (x *ForceCancelTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceCancelTxRequest.ProtoReflect.Descriptor instead.
+func (*ForceCancelTxRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ForceCancelTxRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *ForceCancelTxRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ForceCancelTxRequest) GetAudit() *Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *ForceCancelTxRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+type InspectBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Audit         *Audit                 `protobuf:"bytes,2,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectBalanceRequest) Reset() {
+	*x = InspectBalanceRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectBalanceRequest) ProtoMessage() {}
+
+func (x *InspectBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectBalanceRequest.ProtoReflect.Descriptor instead.
+func (*InspectBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InspectBalanceRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *InspectBalanceRequest) GetAudit() *Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type SetBalanceStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Status        v1.BalanceStatus       `protobuf:"varint,2,opt,name=status,proto3,enum=balance.v1.BalanceStatus" json:"status,omitempty"`
+	Audit         *Audit                 `protobuf:"bytes,3,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBalanceStatusRequest) Reset() {
+	*x = SetBalanceStatusRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBalanceStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBalanceStatusRequest) ProtoMessage() {}
+
+func (x *SetBalanceStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBalanceStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetBalanceStatusRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetBalanceStatusRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *SetBalanceStatusRequest) GetStatus() v1.BalanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return v1.BalanceStatus(0)
+}
+
+func (x *SetBalanceStatusRequest) GetAudit() *Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type AuditEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EntryId        string                 `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Operator       string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Action         AuditAction            `protobuf:"varint,4,opt,name=action,proto3,enum=admin.v1.AuditAction" json:"action,omitempty"`
+	BalanceId      string                 `protobuf:"bytes,5,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	TxId           string                 `protobuf:"bytes,6,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Amount         *v1.Decimal            `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"` // Change of the balance, negative for withdrawals.
+	Reason         string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	TicketRef      string                 `protobuf:"bytes,9,opt,name=ticket_ref,json=ticketRef,proto3" json:"ticket_ref,omitempty"`
+	Outcome        AuditOutcome           `protobuf:"varint,10,opt,name=outcome,proto3,enum=admin.v1.AuditOutcome" json:"outcome,omitempty"`
+	AttemptEntryId string                 `protobuf:"bytes,11,opt,name=attempt_entry_id,json=attemptEntryId,proto3" json:"attempt_entry_id,omitempty"` // Attempt entry of an outcome entry.
+	Error          string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`                                           // Why the action failed.
+	Status         v1.BalanceStatus       `protobuf:"varint,13,opt,name=status,proto3,enum=balance.v1.BalanceStatus" json:"status,omitempty"`          // Status set by the action.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_admin_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAction() AuditAction {
+	if x != nil {
+		return x.Action
+	}
+	return AuditAction_AUDIT_ACTION_UNSPECIFIED
+}
+
+func (x *AuditEntry) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAmount() *v1.Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTicketRef() string {
+	if x != nil {
+		return x.TicketRef
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOutcome() AuditOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return AuditOutcome_AUDIT_OUTCOME_UNSPECIFIED
+}
+
+func (x *AuditEntry) GetAttemptEntryId() string {
+	if x != nil {
+		return x.AttemptEntryId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetStatus() v1.BalanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return v1.BalanceStatus(0)
+}
+
+type InspectBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *v1.BalanceResponse    `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Txs           []*v1.Tx               `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`                                       // Latest txs including cancelled ones, newest first.
+	AuditEntries  []*AuditEntry          `protobuf:"bytes,3,rep,name=audit_entries,json=auditEntries,proto3" json:"audit_entries,omitempty"` // Latest admin actions on the balance, newest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectBalanceResponse) Reset() {
+	*x = InspectBalanceResponse{}
+	mi := &file_admin_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectBalanceResponse) ProtoMessage() {}
+
+func (x *InspectBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectBalanceResponse.ProtoReflect.Descriptor instead.
+func (*InspectBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InspectBalanceResponse) GetBalance() *v1.BalanceResponse {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *InspectBalanceResponse) GetTxs() []*v1.Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *InspectBalanceResponse) GetAuditEntries() []*AuditEntry {
+	if x != nil {
+		return x.AuditEntries
+	}
+	return nil
+}
+
+var File_admin_v1_admin_proto protoreflect.FileDescriptor
+
+const file_admin_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x14admin/v1/admin.proto\x12\badmin.v1\x1a\x18balance/v1/balance.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"f\n" +
+	"\x05Audit\x12\"\n" +
+	"\x06reason\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xe8\aR\x06reason\x12)\n" +
+	"\n" +
+	"ticket_ref\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\tticketRefJ\x04\b\x01\x10\x02R\boperator\"\xe9\x02\n" +
+	"\x14AdjustBalanceRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12\x1d\n" +
+	"\x05tx_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x04txId\x123\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x11.balance.v1.StateB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x05state\x12\xa4\x01\n" +
+	"\x06amount\x18\x04 \x01(\v2\x13.balance.v1.DecimalBw\xbaHt\xba\x01n\n" +
+	"\x0famount.positive\x12\x17amount must be positive\x1aB!this.value.startsWith('-') && !this.value.matches('^0+([.]0+)?$')\xc8\x01\x01R\x06amount\x12-\n" +
+	"\x05audit\x18\x05 \x01(\v2\x0f.admin.v1.AuditB\x06\xbaH\x03\xc8\x01\x01R\x05audit\"\xa7\x01\n" +
+	"\x14ForceCancelTxRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12\x1d\n" +
+	"\x05tx_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x04txId\x12-\n" +
+	"\x05audit\x18\x03 \x01(\v2\x0f.admin.v1.AuditB\x06\xbaH\x03\xc8\x01\x01R\x05audit\x12\x18\n" +
+	"\acascade\x18\x04 \x01(\bR\acascade\"o\n" +
+	"\x15InspectBalanceRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12-\n" +
+	"\x05audit\x18\x02 \x01(\v2\x0f.admin.v1.AuditB\x06\xbaH\x03\xc8\x01\x01R\x05audit\"\xb0\x01\n" +
+	"\x17SetBalanceStatusRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12=\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.balance.v1.BalanceStatusB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x06status\x12-\n" +
+	"\x05audit\x18\x03 \x01(\v2\x0f.admin.v1.AuditB\x06\xbaH\x03\xc8\x01\x01R\x05audit\"\xea\x03\n" +
+	"\n" +
+	"AuditEntry\x129\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12-\n" +
+	"\x06action\x18\x04 \x01(\x0e2\x15.admin.v1.AuditActionR\x06action\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x05 \x01(\tR\tbalanceId\x12\x13\n" +
+	"\x05tx_id\x18\x06 \x01(\tR\x04txId\x12+\n" +
+	"\x06amount\x18\a \x01(\v2\x13.balance.v1.DecimalR\x06amount\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"ticket_ref\x18\t \x01(\tR\tticketRef\x120\n" +
+	"\aoutcome\x18\n" +
+	" \x01(\x0e2\x16.admin.v1.AuditOutcomeR\aoutcome\x12(\n" +
+	"\x10attempt_entry_id\x18\v \x01(\tR\x0eattemptEntryId\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\x121\n" +
+	"\x06status\x18\r \x01(\x0e2\x19.balance.v1.BalanceStatusR\x06status\"\xac\x01\n" +
+	"\x16InspectBalanceResponse\x125\n" +
+	"\abalance\x18\x01 \x01(\v2\x1b.balance.v1.BalanceResponseR\abalance\x12 \n" +
+	"\x03txs\x18\x02 \x03(\v2\x0e.balance.v1.TxR\x03txs\x129\n" +
+	"\raudit_entries\x18\x03 \x03(\v2\x14.admin.v1.AuditEntryR\fauditEntries*\xb5\x01\n" +
+	"\vAuditAction\x12\x1c\n" +
+	"\x18AUDIT_ACTION_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bAUDIT_ACTION_ADJUST_BALANCE\x10\x01\x12 \n" +
+	"\x1cAUDIT_ACTION_FORCE_CANCEL_TX\x10\x02\x12 \n" +
+	"\x1cAUDIT_ACTION_INSPECT_BALANCE\x10\x03\x12#\n" +
+	"\x1fAUDIT_ACTION_SET_BALANCE_STATUS\x10\x04*\x81\x01\n" +
+	"\fAuditOutcome\x12\x1d\n" +
+	"\x19AUDIT_OUTCOME_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17AUDIT_OUTCOME_ATTEMPTED\x10\x01\x12\x1b\n" +
+	"\x17AUDIT_OUTCOME_SUCCEEDED\x10\x02\x12\x18\n" +
+	"\x14AUDIT_OUTCOME_FAILED\x10\x032\xd1\x02\n" +
+	"\fAdminService\x12I\n" +
+	"\rAdjustBalance\x12\x1e.admin.v1.AdjustBalanceRequest\x1a\x16.google.protobuf.Empty\"\x00\x12I\n" +
+	"\rForceCancelTx\x12\x1e.admin.v1.ForceCancelTxRequest\x1a\x16.google.protobuf.Empty\"\x00\x12U\n" +
+	"\x0eInspectBalance\x12\x1f.admin.v1.InspectBalanceRequest\x1a .admin.v1.InspectBalanceResponse\"\x00\x12T\n" +
+	"\x10SetBalanceStatus\x12!.admin.v1.SetBalanceStatusRequest\x1a\x1b.balance.v1.BalanceResponse\"\x00B\x9f\x01\n" +
+	"\fcom.admin.v1B\n" +
+	"AdminProtoP\x01ZBgithub.com/iskorotkov/igaming-balance-backend/gen/admin/v1;adminv1\xa2\x02\x03AXX\xaa\x02\bAdmin.V1\xca\x02\bAdmin\\V1\xe2\x02\x14Admin\\V1\\GPBMetadata\xea\x02\tAdmin::V1b\x06proto3"
+
+var (
+	file_admin_v1_admin_proto_rawDescOnce sync.Once
+	file_admin_v1_admin_proto_rawDescData []byte
+)
+
+func file_admin_v1_admin_proto_rawDescGZIP() []byte {
+	file_admin_v1_admin_proto_rawDescOnce.Do(func() {
+		file_admin_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_admin_v1_admin_proto_rawDesc), len(file_admin_v1_admin_proto_rawDesc)))
+	})
+	return file_admin_v1_admin_proto_rawDescData
+}
+
+var file_admin_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_admin_v1_admin_proto_goTypes = []any{
+	(AuditAction)(0),                // 0: admin.v1.AuditAction
+	(AuditOutcome)(0),               // 1: admin.v1.AuditOutcome
+	(*Audit)(nil),                   // 2: admin.v1.Audit
+	(*AdjustBalanceRequest)(nil),    // 3: admin.v1.AdjustBalanceRequest
+	(*ForceCancelTxRequest)(nil),    // 4: admin.v1.ForceCancelTxRequest
+	(*InspectBalanceRequest)(nil),   // 5: admin.v1.InspectBalanceRequest
+	(*SetBalanceStatusRequest)(nil), // 6: admin.v1.SetBalanceStatusRequest
+	(*AuditEntry)(nil),              // 7: admin.v1.AuditEntry
+	(*InspectBalanceResponse)(nil),  // 8: admin.v1.InspectBalanceResponse
+	(v1.State)(0),                   // 9: balance.v1.State
+	(*v1.Decimal)(nil),              // 10: balance.v1.Decimal
+	(v1.BalanceStatus)(0),           // 11: balance.v1.BalanceStatus
+	(*timestamppb.Timestamp)(nil),   // 12: google.protobuf.Timestamp
+	(*v1.BalanceResponse)(nil),      // 13: balance.v1.BalanceResponse
+	(*v1.Tx)(nil),                   // 14: balance.v1.Tx
+	(*emptypb.Empty)(nil),           // 15: google.protobuf.Empty
+}
+var file_admin_v1_admin_proto_depIdxs = []int32{
+	9,  // 0: admin.v1.AdjustBalanceRequest.state:type_name -> balance.v1.State
+	10, // 1: admin.v1.AdjustBalanceRequest.amount:type_name -> balance.v1.Decimal
+	2,  // 2: admin.v1.AdjustBalanceRequest.audit:type_name -> admin.v1.Audit
+	2,  // 3: admin.v1.ForceCancelTxRequest.audit:type_name -> admin.v1.Audit
+	2,  // 4: admin.v1.InspectBalanceRequest.audit:type_name -> admin.v1.Audit
+	11, // 5: admin.v1.SetBalanceStatusRequest.status:type_name -> balance.v1.BalanceStatus
+	2,  // 6: admin.v1.SetBalanceStatusRequest.audit:type_name -> admin.v1.Audit
+	12, // 7: admin.v1.AuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: admin.v1.AuditEntry.action:type_name -> admin.v1.AuditAction
+	10, // 9: admin.v1.AuditEntry.amount:type_name -> balance.v1.Decimal
+	1,  // 10: admin.v1.AuditEntry.outcome:type_name -> admin.v1.AuditOutcome
+	11, // 11: admin.v1.AuditEntry.status:type_name -> balance.v1.BalanceStatus
+	13, // 12: admin.v1.InspectBalanceResponse.balance:type_name -> balance.v1.BalanceResponse
+	14, // 13: admin.v1.InspectBalanceResponse.txs:type_name -> balance.v1.Tx
+	7,  // 14: admin.v1.InspectBalanceResponse.audit_entries:type_name -> admin.v1.AuditEntry
+	3,  // 15: admin.v1.AdminService.AdjustBalance:input_type -> admin.v1.AdjustBalanceRequest
+	4,  // 16: admin.v1.AdminService.ForceCancelTx:input_type -> admin.v1.ForceCancelTxRequest
+	5,  // 17: admin.v1.AdminService.InspectBalance:input_type -> admin.v1.InspectBalanceRequest
+	6,  // 18: admin.v1.AdminService.SetBalanceStatus:input_type -> admin.v1.SetBalanceStatusRequest
+	15, // 19: admin.v1.AdminService.AdjustBalance:output_type -> google.protobuf.Empty
+	15, // 20: admin.v1.AdminService.ForceCancelTx:output_type -> google.protobuf.Empty
+	8,  // 21: admin.v1.AdminService.InspectBalance:output_type -> admin.v1.InspectBalanceResponse
+	13, // 22: admin.v1.AdminService.SetBalanceStatus:output_type -> balance.v1.BalanceResponse
+	19, // [19:23] is the sub-list for method output_type
+	15, // [15:19] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_admin_v1_admin_proto_init() }
+func file_admin_v1_admin_proto_init() {
+	if File_admin_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_v1_admin_proto_rawDesc), len(file_admin_v1_admin_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_v1_admin_proto_goTypes,
+		DependencyIndexes: file_admin_v1_admin_proto_depIdxs,
+		EnumInfos:         file_admin_v1_admin_proto_enumTypes,
+		MessageInfos:      file_admin_v1_admin_proto_msgTypes,
+	}.Build()
+	File_admin_v1_admin_proto = out.File
+	file_admin_v1_admin_proto_goTypes = nil
+	file_admin_v1_admin_proto_depIdxs = nil
+}
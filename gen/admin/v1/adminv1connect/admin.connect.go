@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: admin/v1/admin.proto
+
+package adminv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/iskorotkov/igaming-balance-backend/gen/admin/v1"
+	v11 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "admin.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceAdjustBalanceProcedure is the fully-qualified name of the AdminService's
+	// AdjustBalance RPC.
+	AdminServiceAdjustBalanceProcedure = "/admin.v1.AdminService/AdjustBalance"
+	// AdminServiceForceCancelTxProcedure is the fully-qualified name of the AdminService's
+	// ForceCancelTx RPC.
+	AdminServiceForceCancelTxProcedure = "/admin.v1.AdminService/ForceCancelTx"
+	// AdminServiceInspectBalanceProcedure is the fully-qualified name of the AdminService's
+	// InspectBalance RPC.
+	AdminServiceInspectBalanceProcedure = "/admin.v1.AdminService/InspectBalance"
+	// AdminServiceSetBalanceStatusProcedure is the fully-qualified name of the AdminService's
+	// SetBalanceStatus RPC.
+	AdminServiceSetBalanceStatusProcedure = "/admin.v1.AdminService/SetBalanceStatus"
+)
+
+// AdminServiceClient is a client for the admin.v1.AdminService service.
+type AdminServiceClient interface {
+	// Records an adjustment tx. Rules don't apply and frozen balances can be adjusted, but balances can't become negative.
+	AdjustBalance(context.Context, *connect.Request[v1.AdjustBalanceRequest]) (*connect.Response[emptypb.Empty], error)
+	// Cancels a tx, including adjustments which CancelTxs of BalanceService refuses to cancel.
+	ForceCancelTx(context.Context, *connect.Request[v1.ForceCancelTxRequest]) (*connect.Response[emptypb.Empty], error)
+	InspectBalance(context.Context, *connect.Request[v1.InspectBalanceRequest]) (*connect.Response[v1.InspectBalanceResponse], error)
+	// Sets the status of a balance, e.g. lifts a freeze set by a rule or a chargeback after a review.
+	// Setting the current status again succeeds without a change.
+	SetBalanceStatus(context.Context, *connect.Request[v1.SetBalanceStatusRequest]) (*connect.Response[v11.BalanceResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the admin.v1.AdminService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := v1.File_admin_v1_admin_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		adjustBalance: connect.NewClient[v1.AdjustBalanceRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceAdjustBalanceProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("AdjustBalance")),
+			connect.WithClientOptions(opts...),
+		),
+		forceCancelTx: connect.NewClient[v1.ForceCancelTxRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceForceCancelTxProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ForceCancelTx")),
+			connect.WithClientOptions(opts...),
+		),
+		inspectBalance: connect.NewClient[v1.InspectBalanceRequest, v1.InspectBalanceResponse](
+			httpClient,
+			baseURL+AdminServiceInspectBalanceProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("InspectBalance")),
+			connect.WithClientOptions(opts...),
+		),
+		setBalanceStatus: connect.NewClient[v1.SetBalanceStatusRequest, v11.BalanceResponse](
+			httpClient,
+			baseURL+AdminServiceSetBalanceStatusProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetBalanceStatus")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	adjustBalance    *connect.Client[v1.AdjustBalanceRequest, emptypb.Empty]
+	forceCancelTx    *connect.Client[v1.ForceCancelTxRequest, emptypb.Empty]
+	inspectBalance   *connect.Client[v1.InspectBalanceRequest, v1.InspectBalanceResponse]
+	setBalanceStatus *connect.Client[v1.SetBalanceStatusRequest, v11.BalanceResponse]
+}
+
+// AdjustBalance calls admin.v1.AdminService.AdjustBalance.
+func (c *adminServiceClient) AdjustBalance(ctx context.Context, req *connect.Request[v1.AdjustBalanceRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.adjustBalance.CallUnary(ctx, req)
+}
+
+// ForceCancelTx calls admin.v1.AdminService.ForceCancelTx.
+func (c *adminServiceClient) ForceCancelTx(ctx context.Context, req *connect.Request[v1.ForceCancelTxRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.forceCancelTx.CallUnary(ctx, req)
+}
+
+// InspectBalance calls admin.v1.AdminService.InspectBalance.
+func (c *adminServiceClient) InspectBalance(ctx context.Context, req *connect.Request[v1.InspectBalanceRequest]) (*connect.Response[v1.InspectBalanceResponse], error) {
+	return c.inspectBalance.CallUnary(ctx, req)
+}
+
+// SetBalanceStatus calls admin.v1.AdminService.SetBalanceStatus.
+func (c *adminServiceClient) SetBalanceStatus(ctx context.Context, req *connect.Request[v1.SetBalanceStatusRequest]) (*connect.Response[v11.BalanceResponse], error) {
+	return c.setBalanceStatus.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the admin.v1.AdminService service.
+type AdminServiceHandler interface {
+	// Records an adjustment tx. Rules don't apply and frozen balances can be adjusted, but balances can't become negative.
+	AdjustBalance(context.Context, *connect.Request[v1.AdjustBalanceRequest]) (*connect.Response[emptypb.Empty], error)
+	// Cancels a tx, including adjustments which CancelTxs of BalanceService refuses to cancel.
+	ForceCancelTx(context.Context, *connect.Request[v1.ForceCancelTxRequest]) (*connect.Response[emptypb.Empty], error)
+	InspectBalance(context.Context, *connect.Request[v1.InspectBalanceRequest]) (*connect.Response[v1.InspectBalanceResponse], error)
+	// Sets the status of a balance, e.g. lifts a freeze set by a rule or a chargeback after a review.
+	// Setting the current status again succeeds without a change.
+	SetBalanceStatus(context.Context, *connect.Request[v1.SetBalanceStatusRequest]) (*connect.Response[v11.BalanceResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := v1.File_admin_v1_admin_proto.Services().ByName("AdminService").Methods()
+	adminServiceAdjustBalanceHandler := connect.NewUnaryHandler(
+		AdminServiceAdjustBalanceProcedure,
+		svc.AdjustBalance,
+		connect.WithSchema(adminServiceMethods.ByName("AdjustBalance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceForceCancelTxHandler := connect.NewUnaryHandler(
+		AdminServiceForceCancelTxProcedure,
+		svc.ForceCancelTx,
+		connect.WithSchema(adminServiceMethods.ByName("ForceCancelTx")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceInspectBalanceHandler := connect.NewUnaryHandler(
+		AdminServiceInspectBalanceProcedure,
+		svc.InspectBalance,
+		connect.WithSchema(adminServiceMethods.ByName("InspectBalance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetBalanceStatusHandler := connect.NewUnaryHandler(
+		AdminServiceSetBalanceStatusProcedure,
+		svc.SetBalanceStatus,
+		connect.WithSchema(adminServiceMethods.ByName("SetBalanceStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/admin.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceAdjustBalanceProcedure:
+			adminServiceAdjustBalanceHandler.ServeHTTP(w, r)
+		case AdminServiceForceCancelTxProcedure:
+			adminServiceForceCancelTxHandler.ServeHTTP(w, r)
+		case AdminServiceInspectBalanceProcedure:
+			adminServiceInspectBalanceHandler.ServeHTTP(w, r)
+		case AdminServiceSetBalanceStatusProcedure:
+			adminServiceSetBalanceStatusHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) AdjustBalance(context.Context, *connect.Request[v1.AdjustBalanceRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.v1.AdminService.AdjustBalance is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ForceCancelTx(context.Context, *connect.Request[v1.ForceCancelTxRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.v1.AdminService.ForceCancelTx is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) InspectBalance(context.Context, *connect.Request[v1.InspectBalanceRequest]) (*connect.Response[v1.InspectBalanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.v1.AdminService.InspectBalance is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetBalanceStatus(context.Context, *connect.Request[v1.SetBalanceStatusRequest]) (*connect.Response[v11.BalanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("admin.v1.AdminService.SetBalanceStatus is not implemented"))
+}
@@ -7,9 +7,9 @@
 package balancev1
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -30,6 +30,7 @@ const (
 	Source_SOURCE_GAME        Source = 1
 	Source_SOURCE_PAYMENT     Source = 2
 	Source_SOURCE_SERVICE     Source = 3
+	Source_SOURCE_ADJUSTMENT  Source = 4 // Manual correction by support, recorded only with AdminService.
 )
 
 // Enum value maps for Source.
@@ -39,12 +40,14 @@ var (
 		1: "SOURCE_GAME",
 		2: "SOURCE_PAYMENT",
 		3: "SOURCE_SERVICE",
+		4: "SOURCE_ADJUSTMENT",
 	}
 	Source_value = map[string]int32{
 		"SOURCE_UNSPECIFIED": 0,
 		"SOURCE_GAME":        1,
 		"SOURCE_PAYMENT":     2,
 		"SOURCE_SERVICE":     3,
+		"SOURCE_ADJUSTMENT":  4,
 	}
 )
 
@@ -124,28 +127,3113 @@ func (State) EnumDescriptor() ([]byte, []int) {
 	return file_balance_v1_balance_proto_rawDescGZIP(), []int{1}
 }
 
+type TxOrder int32
+
+const (
+	TxOrder_TX_ORDER_UNSPECIFIED  TxOrder = 0
+	TxOrder_TX_ORDER_NEWEST_FIRST TxOrder = 1
+	TxOrder_TX_ORDER_OLDEST_FIRST TxOrder = 2
+)
+
+// Enum value maps for TxOrder.
+var (
+	TxOrder_name = map[int32]string{
+		0: "TX_ORDER_UNSPECIFIED",
+		1: "TX_ORDER_NEWEST_FIRST",
+		2: "TX_ORDER_OLDEST_FIRST",
+	}
+	TxOrder_value = map[string]int32{
+		"TX_ORDER_UNSPECIFIED":  0,
+		"TX_ORDER_NEWEST_FIRST": 1,
+		"TX_ORDER_OLDEST_FIRST": 2,
+	}
+)
+
+func (x TxOrder) Enum() *TxOrder {
+	p := new(TxOrder)
+	*p = x
+	return p
+}
+
+func (x TxOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TxOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[2].Descriptor()
+}
+
+func (TxOrder) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[2]
+}
+
+func (x TxOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TxOrder.Descriptor instead.
+func (TxOrder) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{2}
+}
+
+// Balances are opened as active. Every change of the status appends a BALANCE_EVENT_KIND_STATUS_CHANGED event.
+//   - A rule with the freeze action freezes the balance.
+//   - A chargeback leaving debt freezes withdrawals of an active balance. They are unfrozen when deposits pay the debt off.
+//   - Support sets any status with SetBalanceStatus of AdminService, e.g. after reviewing a freeze.
+type BalanceStatus int32
+
+const (
+	BalanceStatus_BALANCE_STATUS_UNSPECIFIED        BalanceStatus = 0
+	BalanceStatus_BALANCE_STATUS_ACTIVE             BalanceStatus = 1
+	BalanceStatus_BALANCE_STATUS_FROZEN             BalanceStatus = 2 // Recording txs fails with ERROR_REASON_BALANCE_FROZEN.
+	BalanceStatus_BALANCE_STATUS_WITHDRAWALS_FROZEN BalanceStatus = 3 // Deposits are recorded, withdrawals fail with ERROR_REASON_BALANCE_FROZEN.
+)
+
+// Enum value maps for BalanceStatus.
+var (
+	BalanceStatus_name = map[int32]string{
+		0: "BALANCE_STATUS_UNSPECIFIED",
+		1: "BALANCE_STATUS_ACTIVE",
+		2: "BALANCE_STATUS_FROZEN",
+		3: "BALANCE_STATUS_WITHDRAWALS_FROZEN",
+	}
+	BalanceStatus_value = map[string]int32{
+		"BALANCE_STATUS_UNSPECIFIED":        0,
+		"BALANCE_STATUS_ACTIVE":             1,
+		"BALANCE_STATUS_FROZEN":             2,
+		"BALANCE_STATUS_WITHDRAWALS_FROZEN": 3,
+	}
+)
+
+func (x BalanceStatus) Enum() *BalanceStatus {
+	p := new(BalanceStatus)
+	*p = x
+	return p
+}
+
+func (x BalanceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[3].Descriptor()
+}
+
+func (BalanceStatus) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[3]
+}
+
+func (x BalanceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceStatus.Descriptor instead.
+func (BalanceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{3}
+}
+
+type WalletType int32
+
+const (
+	WalletType_WALLET_TYPE_UNSPECIFIED WalletType = 0
+	WalletType_WALLET_TYPE_MAIN        WalletType = 1
+	WalletType_WALLET_TYPE_BONUS       WalletType = 2
+)
+
+// Enum value maps for WalletType.
+var (
+	WalletType_name = map[int32]string{
+		0: "WALLET_TYPE_UNSPECIFIED",
+		1: "WALLET_TYPE_MAIN",
+		2: "WALLET_TYPE_BONUS",
+	}
+	WalletType_value = map[string]int32{
+		"WALLET_TYPE_UNSPECIFIED": 0,
+		"WALLET_TYPE_MAIN":        1,
+		"WALLET_TYPE_BONUS":       2,
+	}
+)
+
+func (x WalletType) Enum() *WalletType {
+	p := new(WalletType)
+	*p = x
+	return p
+}
+
+func (x WalletType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WalletType) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[4].Descriptor()
+}
+
+func (WalletType) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[4]
+}
+
+func (x WalletType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WalletType.Descriptor instead.
+func (WalletType) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{4}
+}
+
+type BalanceSortField int32
+
+const (
+	BalanceSortField_BALANCE_SORT_FIELD_UNSPECIFIED      BalanceSortField = 0
+	BalanceSortField_BALANCE_SORT_FIELD_CREATED_AT       BalanceSortField = 1
+	BalanceSortField_BALANCE_SORT_FIELD_AMOUNT           BalanceSortField = 2
+	BalanceSortField_BALANCE_SORT_FIELD_UPDATED_AT       BalanceSortField = 3
+	BalanceSortField_BALANCE_SORT_FIELD_LAST_ACTIVITY_AT BalanceSortField = 4 // Balances without activity go first in ascending order.
+	BalanceSortField_BALANCE_SORT_FIELD_TX_COUNT         BalanceSortField = 5
+)
+
+// Enum value maps for BalanceSortField.
+var (
+	BalanceSortField_name = map[int32]string{
+		0: "BALANCE_SORT_FIELD_UNSPECIFIED",
+		1: "BALANCE_SORT_FIELD_CREATED_AT",
+		2: "BALANCE_SORT_FIELD_AMOUNT",
+		3: "BALANCE_SORT_FIELD_UPDATED_AT",
+		4: "BALANCE_SORT_FIELD_LAST_ACTIVITY_AT",
+		5: "BALANCE_SORT_FIELD_TX_COUNT",
+	}
+	BalanceSortField_value = map[string]int32{
+		"BALANCE_SORT_FIELD_UNSPECIFIED":      0,
+		"BALANCE_SORT_FIELD_CREATED_AT":       1,
+		"BALANCE_SORT_FIELD_AMOUNT":           2,
+		"BALANCE_SORT_FIELD_UPDATED_AT":       3,
+		"BALANCE_SORT_FIELD_LAST_ACTIVITY_AT": 4,
+		"BALANCE_SORT_FIELD_TX_COUNT":         5,
+	}
+)
+
+func (x BalanceSortField) Enum() *BalanceSortField {
+	p := new(BalanceSortField)
+	*p = x
+	return p
+}
+
+func (x BalanceSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[5].Descriptor()
+}
+
+func (BalanceSortField) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[5]
+}
+
+func (x BalanceSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceSortField.Descriptor instead.
+func (BalanceSortField) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{5}
+}
+
+type BalanceEventKind int32
+
+const (
+	BalanceEventKind_BALANCE_EVENT_KIND_UNSPECIFIED    BalanceEventKind = 0
+	BalanceEventKind_BALANCE_EVENT_KIND_SNAPSHOT       BalanceEventKind = 1 // Current state of the balance, sent first when watching from the start.
+	BalanceEventKind_BALANCE_EVENT_KIND_TX_RECORDED    BalanceEventKind = 2
+	BalanceEventKind_BALANCE_EVENT_KIND_TXS_CANCELLED  BalanceEventKind = 3
+	BalanceEventKind_BALANCE_EVENT_KIND_STATUS_CHANGED BalanceEventKind = 4
+)
+
+// Enum value maps for BalanceEventKind.
+var (
+	BalanceEventKind_name = map[int32]string{
+		0: "BALANCE_EVENT_KIND_UNSPECIFIED",
+		1: "BALANCE_EVENT_KIND_SNAPSHOT",
+		2: "BALANCE_EVENT_KIND_TX_RECORDED",
+		3: "BALANCE_EVENT_KIND_TXS_CANCELLED",
+		4: "BALANCE_EVENT_KIND_STATUS_CHANGED",
+	}
+	BalanceEventKind_value = map[string]int32{
+		"BALANCE_EVENT_KIND_UNSPECIFIED":    0,
+		"BALANCE_EVENT_KIND_SNAPSHOT":       1,
+		"BALANCE_EVENT_KIND_TX_RECORDED":    2,
+		"BALANCE_EVENT_KIND_TXS_CANCELLED":  3,
+		"BALANCE_EVENT_KIND_STATUS_CHANGED": 4,
+	}
+)
+
+func (x BalanceEventKind) Enum() *BalanceEventKind {
+	p := new(BalanceEventKind)
+	*p = x
+	return p
+}
+
+func (x BalanceEventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceEventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[6].Descriptor()
+}
+
+func (BalanceEventKind) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[6]
+}
+
+func (x BalanceEventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceEventKind.Descriptor instead.
+func (BalanceEventKind) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{6}
+}
+
+type StatementFormat int32
+
+const (
+	StatementFormat_STATEMENT_FORMAT_UNSPECIFIED StatementFormat = 0
+	StatementFormat_STATEMENT_FORMAT_CSV         StatementFormat = 1
+	StatementFormat_STATEMENT_FORMAT_JSONL       StatementFormat = 2 // JSON Lines.
+)
+
+// Enum value maps for StatementFormat.
+var (
+	StatementFormat_name = map[int32]string{
+		0: "STATEMENT_FORMAT_UNSPECIFIED",
+		1: "STATEMENT_FORMAT_CSV",
+		2: "STATEMENT_FORMAT_JSONL",
+	}
+	StatementFormat_value = map[string]int32{
+		"STATEMENT_FORMAT_UNSPECIFIED": 0,
+		"STATEMENT_FORMAT_CSV":         1,
+		"STATEMENT_FORMAT_JSONL":       2,
+	}
+)
+
+func (x StatementFormat) Enum() *StatementFormat {
+	p := new(StatementFormat)
+	*p = x
+	return p
+}
+
+func (x StatementFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatementFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[7].Descriptor()
+}
+
+func (StatementFormat) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[7]
+}
+
+func (x StatementFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatementFormat.Descriptor instead.
+func (StatementFormat) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{7}
+}
+
+type AggregateBucket int32
+
+const (
+	AggregateBucket_AGGREGATE_BUCKET_UNSPECIFIED AggregateBucket = 0
+	AggregateBucket_AGGREGATE_BUCKET_HOUR        AggregateBucket = 1
+	AggregateBucket_AGGREGATE_BUCKET_DAY         AggregateBucket = 2
+	AggregateBucket_AGGREGATE_BUCKET_MONTH       AggregateBucket = 3
+)
+
+// Enum value maps for AggregateBucket.
+var (
+	AggregateBucket_name = map[int32]string{
+		0: "AGGREGATE_BUCKET_UNSPECIFIED",
+		1: "AGGREGATE_BUCKET_HOUR",
+		2: "AGGREGATE_BUCKET_DAY",
+		3: "AGGREGATE_BUCKET_MONTH",
+	}
+	AggregateBucket_value = map[string]int32{
+		"AGGREGATE_BUCKET_UNSPECIFIED": 0,
+		"AGGREGATE_BUCKET_HOUR":        1,
+		"AGGREGATE_BUCKET_DAY":         2,
+		"AGGREGATE_BUCKET_MONTH":       3,
+	}
+)
+
+func (x AggregateBucket) Enum() *AggregateBucket {
+	p := new(AggregateBucket)
+	*p = x
+	return p
+}
+
+func (x AggregateBucket) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregateBucket) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[8].Descriptor()
+}
+
+func (AggregateBucket) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[8]
+}
+
+func (x AggregateBucket) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregateBucket.Descriptor instead.
+func (AggregateBucket) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{8}
+}
+
+// Reasons of errors, sent as google.rpc.ErrorInfo details with the domain "balance.v1".
+// ErrorInfo reason is the value name without the ERROR_REASON_ prefix, e.g. "INSUFFICIENT_FUNDS".
+type ErrorReason int32
+
+const (
+	ErrorReason_ERROR_REASON_UNSPECIFIED ErrorReason = 0
+	// Request fields are invalid, google.rpc.BadRequest details list them. Code is INVALID_ARGUMENT.
+	ErrorReason_ERROR_REASON_INVALID_ARGUMENT ErrorReason = 1
+	// Metadata has balance_id. Code is NOT_FOUND.
+	ErrorReason_ERROR_REASON_BALANCE_NOT_FOUND ErrorReason = 2
+	// Code is NOT_FOUND.
+	ErrorReason_ERROR_REASON_TX_NOT_FOUND ErrorReason = 3
+	// Metadata has balance_id. Code is ALREADY_EXISTS.
+	ErrorReason_ERROR_REASON_BALANCE_ALREADY_EXISTS ErrorReason = 4
+	// Metadata has tx_id. Code is ALREADY_EXISTS.
+	ErrorReason_ERROR_REASON_TX_ALREADY_EXISTS ErrorReason = 5
+	// The change would make the balance negative, InsufficientFunds details have amounts.
+	// Metadata has balance_id, current and required. Code is FAILED_PRECONDITION.
+	ErrorReason_ERROR_REASON_INSUFFICIENT_FUNDS ErrorReason = 6
+	// Rules rejected the tx. Metadata has balance_id. Code is FAILED_PRECONDITION.
+	ErrorReason_ERROR_REASON_TX_REJECTED ErrorReason = 7
+	// The balance is frozen and doesn't accept txs, or only its withdrawals are frozen. Metadata has balance_id. Code is FAILED_PRECONDITION.
+	ErrorReason_ERROR_REASON_BALANCE_FROZEN ErrorReason = 8
+	// The balance changed since the expected version.
+	// Metadata has balance_id, expected_version and current_version. Code is ABORTED.
+	ErrorReason_ERROR_REASON_VERSION_MISMATCH ErrorReason = 9
+	// Parent tx doesn't exist on the balance. Metadata has balance_id and parent_tx_id. Code is FAILED_PRECONDITION.
+	ErrorReason_ERROR_REASON_PARENT_TX_NOT_FOUND ErrorReason = 10
+	// Txs have not cancelled children and cascade isn't requested. Metadata has balance_id and children,
+	// the number of such children. Code is FAILED_PRECONDITION.
+	ErrorReason_ERROR_REASON_TX_HAS_CHILDREN ErrorReason = 11
+	// Metadata has cancellation_id. Code is NOT_FOUND.
+	ErrorReason_ERROR_REASON_CANCELLATION_NOT_FOUND ErrorReason = 12
+	// Only not cancelled payment deposits can be charged back. Metadata has balance_id and tx_id. Code is FAILED_PRECONDITION.
+	ErrorReason_ERROR_REASON_TX_NOT_CHARGEABLE ErrorReason = 13
+)
+
+// Enum value maps for ErrorReason.
+var (
+	ErrorReason_name = map[int32]string{
+		0:  "ERROR_REASON_UNSPECIFIED",
+		1:  "ERROR_REASON_INVALID_ARGUMENT",
+		2:  "ERROR_REASON_BALANCE_NOT_FOUND",
+		3:  "ERROR_REASON_TX_NOT_FOUND",
+		4:  "ERROR_REASON_BALANCE_ALREADY_EXISTS",
+		5:  "ERROR_REASON_TX_ALREADY_EXISTS",
+		6:  "ERROR_REASON_INSUFFICIENT_FUNDS",
+		7:  "ERROR_REASON_TX_REJECTED",
+		8:  "ERROR_REASON_BALANCE_FROZEN",
+		9:  "ERROR_REASON_VERSION_MISMATCH",
+		10: "ERROR_REASON_PARENT_TX_NOT_FOUND",
+		11: "ERROR_REASON_TX_HAS_CHILDREN",
+		12: "ERROR_REASON_CANCELLATION_NOT_FOUND",
+		13: "ERROR_REASON_TX_NOT_CHARGEABLE",
+	}
+	ErrorReason_value = map[string]int32{
+		"ERROR_REASON_UNSPECIFIED":            0,
+		"ERROR_REASON_INVALID_ARGUMENT":       1,
+		"ERROR_REASON_BALANCE_NOT_FOUND":      2,
+		"ERROR_REASON_TX_NOT_FOUND":           3,
+		"ERROR_REASON_BALANCE_ALREADY_EXISTS": 4,
+		"ERROR_REASON_TX_ALREADY_EXISTS":      5,
+		"ERROR_REASON_INSUFFICIENT_FUNDS":     6,
+		"ERROR_REASON_TX_REJECTED":            7,
+		"ERROR_REASON_BALANCE_FROZEN":         8,
+		"ERROR_REASON_VERSION_MISMATCH":       9,
+		"ERROR_REASON_PARENT_TX_NOT_FOUND":    10,
+		"ERROR_REASON_TX_HAS_CHILDREN":        11,
+		"ERROR_REASON_CANCELLATION_NOT_FOUND": 12,
+		"ERROR_REASON_TX_NOT_CHARGEABLE":      13,
+	}
+)
+
+func (x ErrorReason) Enum() *ErrorReason {
+	p := new(ErrorReason)
+	*p = x
+	return p
+}
+
+func (x ErrorReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[9].Descriptor()
+}
+
+func (ErrorReason) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[9]
+}
+
+func (x ErrorReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorReason.Descriptor instead.
+func (ErrorReason) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{9}
+}
+
+type CancellationStatus int32
+
+const (
+	CancellationStatus_CANCELLATION_STATUS_UNSPECIFIED CancellationStatus = 0
+	CancellationStatus_CANCELLATION_STATUS_PENDING     CancellationStatus = 1
+	CancellationStatus_CANCELLATION_STATUS_RUNNING     CancellationStatus = 2
+	CancellationStatus_CANCELLATION_STATUS_DONE        CancellationStatus = 3 // All balances are processed, some of them may have failed.
+)
+
+// Enum value maps for CancellationStatus.
+var (
+	CancellationStatus_name = map[int32]string{
+		0: "CANCELLATION_STATUS_UNSPECIFIED",
+		1: "CANCELLATION_STATUS_PENDING",
+		2: "CANCELLATION_STATUS_RUNNING",
+		3: "CANCELLATION_STATUS_DONE",
+	}
+	CancellationStatus_value = map[string]int32{
+		"CANCELLATION_STATUS_UNSPECIFIED": 0,
+		"CANCELLATION_STATUS_PENDING":     1,
+		"CANCELLATION_STATUS_RUNNING":     2,
+		"CANCELLATION_STATUS_DONE":        3,
+	}
+)
+
+func (x CancellationStatus) Enum() *CancellationStatus {
+	p := new(CancellationStatus)
+	*p = x
+	return p
+}
+
+func (x CancellationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancellationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[10].Descriptor()
+}
+
+func (CancellationStatus) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[10]
+}
+
+func (x CancellationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancellationStatus.Descriptor instead.
+func (CancellationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{10}
+}
+
+type BalanceCancellationStatus int32
+
+const (
+	BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_UNSPECIFIED BalanceCancellationStatus = 0
+	BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_PENDING     BalanceCancellationStatus = 1
+	BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_DONE        BalanceCancellationStatus = 2
+	BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_FAILED      BalanceCancellationStatus = 3
+)
+
+// Enum value maps for BalanceCancellationStatus.
+var (
+	BalanceCancellationStatus_name = map[int32]string{
+		0: "BALANCE_CANCELLATION_STATUS_UNSPECIFIED",
+		1: "BALANCE_CANCELLATION_STATUS_PENDING",
+		2: "BALANCE_CANCELLATION_STATUS_DONE",
+		3: "BALANCE_CANCELLATION_STATUS_FAILED",
+	}
+	BalanceCancellationStatus_value = map[string]int32{
+		"BALANCE_CANCELLATION_STATUS_UNSPECIFIED": 0,
+		"BALANCE_CANCELLATION_STATUS_PENDING":     1,
+		"BALANCE_CANCELLATION_STATUS_DONE":        2,
+		"BALANCE_CANCELLATION_STATUS_FAILED":      3,
+	}
+)
+
+func (x BalanceCancellationStatus) Enum() *BalanceCancellationStatus {
+	p := new(BalanceCancellationStatus)
+	*p = x
+	return p
+}
+
+func (x BalanceCancellationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceCancellationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[11].Descriptor()
+}
+
+func (BalanceCancellationStatus) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[11]
+}
+
+func (x BalanceCancellationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceCancellationStatus.Descriptor instead.
+func (BalanceCancellationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{11}
+}
+
+// Handles cancellations taking the balance below zero.
+type NegativePolicy int32
+
+const (
+	NegativePolicy_NEGATIVE_POLICY_UNSPECIFIED NegativePolicy = 0 // Same as NEGATIVE_POLICY_FAIL.
+	NegativePolicy_NEGATIVE_POLICY_FAIL        NegativePolicy = 1 // Cancels none of the txs.
+	NegativePolicy_NEGATIVE_POLICY_SKIP        NegativePolicy = 2 // Skips deposits taking the balance below zero with their ancestors, cancels the rest.
+	NegativePolicy_NEGATIVE_POLICY_DEBT        NegativePolicy = 3 // Cancels all txs, the amount below zero becomes debt of the balance.
+)
+
+// Enum value maps for NegativePolicy.
+var (
+	NegativePolicy_name = map[int32]string{
+		0: "NEGATIVE_POLICY_UNSPECIFIED",
+		1: "NEGATIVE_POLICY_FAIL",
+		2: "NEGATIVE_POLICY_SKIP",
+		3: "NEGATIVE_POLICY_DEBT",
+	}
+	NegativePolicy_value = map[string]int32{
+		"NEGATIVE_POLICY_UNSPECIFIED": 0,
+		"NEGATIVE_POLICY_FAIL":        1,
+		"NEGATIVE_POLICY_SKIP":        2,
+		"NEGATIVE_POLICY_DEBT":        3,
+	}
+)
+
+func (x NegativePolicy) Enum() *NegativePolicy {
+	p := new(NegativePolicy)
+	*p = x
+	return p
+}
+
+func (x NegativePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NegativePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[12].Descriptor()
+}
+
+func (NegativePolicy) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[12]
+}
+
+func (x NegativePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NegativePolicy.Descriptor instead.
+func (NegativePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{12}
+}
+
+type DebtEntryKind int32
+
+const (
+	DebtEntryKind_DEBT_ENTRY_KIND_UNSPECIFIED DebtEntryKind = 0
+	DebtEntryKind_DEBT_ENTRY_KIND_INCURRED    DebtEntryKind = 1 // Cancellations took more than the balance had.
+	DebtEntryKind_DEBT_ENTRY_KIND_SETTLED     DebtEntryKind = 2 // A deposit paid the debt off.
+	DebtEntryKind_DEBT_ENTRY_KIND_REINSTATED  DebtEntryKind = 3 // Cancelled deposits had paid the debt off, so it's owed again.
+)
+
+// Enum value maps for DebtEntryKind.
+var (
+	DebtEntryKind_name = map[int32]string{
+		0: "DEBT_ENTRY_KIND_UNSPECIFIED",
+		1: "DEBT_ENTRY_KIND_INCURRED",
+		2: "DEBT_ENTRY_KIND_SETTLED",
+		3: "DEBT_ENTRY_KIND_REINSTATED",
+	}
+	DebtEntryKind_value = map[string]int32{
+		"DEBT_ENTRY_KIND_UNSPECIFIED": 0,
+		"DEBT_ENTRY_KIND_INCURRED":    1,
+		"DEBT_ENTRY_KIND_SETTLED":     2,
+		"DEBT_ENTRY_KIND_REINSTATED":  3,
+	}
+)
+
+func (x DebtEntryKind) Enum() *DebtEntryKind {
+	p := new(DebtEntryKind)
+	*p = x
+	return p
+}
+
+func (x DebtEntryKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DebtEntryKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[13].Descriptor()
+}
+
+func (DebtEntryKind) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[13]
+}
+
+func (x DebtEntryKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DebtEntryKind.Descriptor instead.
+func (DebtEntryKind) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{13}
+}
+
+type RuleAction int32
+
+const (
+	RuleAction_RULE_ACTION_UNSPECIFIED RuleAction = 0
+	RuleAction_RULE_ACTION_ALLOW       RuleAction = 1
+	RuleAction_RULE_ACTION_FLAG        RuleAction = 2
+	RuleAction_RULE_ACTION_REJECT      RuleAction = 3
+	RuleAction_RULE_ACTION_FREEZE      RuleAction = 4
+)
+
+// Enum value maps for RuleAction.
+var (
+	RuleAction_name = map[int32]string{
+		0: "RULE_ACTION_UNSPECIFIED",
+		1: "RULE_ACTION_ALLOW",
+		2: "RULE_ACTION_FLAG",
+		3: "RULE_ACTION_REJECT",
+		4: "RULE_ACTION_FREEZE",
+	}
+	RuleAction_value = map[string]int32{
+		"RULE_ACTION_UNSPECIFIED": 0,
+		"RULE_ACTION_ALLOW":       1,
+		"RULE_ACTION_FLAG":        2,
+		"RULE_ACTION_REJECT":      3,
+		"RULE_ACTION_FREEZE":      4,
+	}
+)
+
+func (x RuleAction) Enum() *RuleAction {
+	p := new(RuleAction)
+	*p = x
+	return p
+}
+
+func (x RuleAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuleAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_balance_v1_balance_proto_enumTypes[14].Descriptor()
+}
+
+func (RuleAction) Type() protoreflect.EnumType {
+	return &file_balance_v1_balance_proto_enumTypes[14]
+}
+
+func (x RuleAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuleAction.Descriptor instead.
+func (RuleAction) EnumDescriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{14}
+}
+
 type Decimal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Up to 8 digits after the point, e.g. "-12.5".
+	Value         string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_balance_v1_balance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decimal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Error detail of ERROR_REASON_INSUFFICIENT_FUNDS.
+type InsufficientFunds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       *Decimal               `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`   // Balance before the change.
+	Required      *Decimal               `protobuf:"bytes,2,opt,name=required,proto3" json:"required,omitempty"` // Amount the change takes from the balance.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InsufficientFunds) Reset() {
+	*x = InsufficientFunds{}
+	mi := &file_balance_v1_balance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsufficientFunds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsufficientFunds) ProtoMessage() {}
+
+func (x *InsufficientFunds) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsufficientFunds.ProtoReflect.Descriptor instead.
+func (*InsufficientFunds) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InsufficientFunds) GetCurrent() *Decimal {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *InsufficientFunds) GetRequired() *Decimal {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+type Tx struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	BalanceId     string                 `protobuf:"bytes,4,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Source        Source                 `protobuf:"varint,5,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`
+	State         State                  `protobuf:"varint,6,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`
+	Amount        *Decimal               `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Seq           int64                  `protobuf:"varint,8,opt,name=seq,proto3" json:"seq,omitempty"` // Position of the tx in the balance history.
+	ExternalRef   string                 `protobuf:"bytes,9,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	ParentTxId    string                 `protobuf:"bytes,10,opt,name=parent_tx_id,json=parentTxId,proto3" json:"parent_tx_id,omitempty"`
+	SettledDebt   *Decimal               `protobuf:"bytes,11,opt,name=settled_debt,json=settledDebt,proto3" json:"settled_debt,omitempty"` // Part of a deposit which paid debt off instead of adding to the amount.
+	Metadata      map[string]string      `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tx) Reset() {
+	*x = Tx{}
+	mi := &file_balance_v1_balance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tx) ProtoMessage() {}
+
+func (x *Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
+func (*Tx) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Tx) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Tx) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Tx) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *Tx) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *Tx) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+func (x *Tx) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Tx) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Tx) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Tx) GetExternalRef() string {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return ""
+}
+
+func (x *Tx) GetParentTxId() string {
+	if x != nil {
+		return x.ParentTxId
+	}
+	return ""
+}
+
+func (x *Tx) GetSettledDebt() *Decimal {
+	if x != nil {
+		return x.SettledDebt
+	}
+	return nil
+}
+
+func (x *Tx) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RecordTxRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Source    Source                 `protobuf:"varint,2,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`
+	State     State                  `protobuf:"varint,3,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`
+	// State defines the direction, so the amount is always positive.
+	Amount      *Decimal `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	TxId        string   `protobuf:"bytes,5,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	ExternalRef string   `protobuf:"bytes,6,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"` // Optional tx ID in operator's systems, unique within a tenant.
+	// Optional, the tx is recorded only if the balance is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	// Runs all checks and rules and returns the projected balance without recording the tx.
+	// Txs which rules would reject or which would freeze the balance aren't errors, rule_action tells what would happen.
+	DryRun bool `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Optional earlier tx of the same balance this tx relates to, e.g. a bet of a win, refund or rollback.
+	ParentTxId string `protobuf:"bytes,9,opt,name=parent_tx_id,json=parentTxId,proto3" json:"parent_tx_id,omitempty"`
+	// Optional tags of the tx, e.g. provider, game and round, bulk cancellations can select txs by them.
+	Metadata      map[string]string `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTxRequest) Reset() {
+	*x = RecordTxRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTxRequest) ProtoMessage() {}
+
+func (x *RecordTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTxRequest.ProtoReflect.Descriptor instead.
+func (*RecordTxRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecordTxRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *RecordTxRequest) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+func (x *RecordTxRequest) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *RecordTxRequest) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *RecordTxRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *RecordTxRequest) GetExternalRef() string {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return ""
+}
+
+func (x *RecordTxRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *RecordTxRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RecordTxRequest) GetParentTxId() string {
+	if x != nil {
+		return x.ParentTxId
+	}
+	return ""
+}
+
+func (x *RecordTxRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RuleHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Action        RuleAction             `protobuf:"varint,2,opt,name=action,proto3,enum=balance.v1.RuleAction" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleHit) Reset() {
+	*x = RuleHit{}
+	mi := &file_balance_v1_balance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleHit) ProtoMessage() {}
+
+func (x *RuleHit) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleHit.ProtoReflect.Descriptor instead.
+func (*RuleHit) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RuleHit) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RuleHit) GetAction() RuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return RuleAction_RULE_ACTION_UNSPECIFIED
+}
+
+type RecordTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                                    // Version of the balance after the tx.
+	Balance       *BalanceResponse       `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`                                                     // Balance after the tx, projected for dry runs.
+	RuleAction    RuleAction             `protobuf:"varint,3,opt,name=rule_action,json=ruleAction,proto3,enum=balance.v1.RuleAction" json:"rule_action,omitempty"` // Strongest action of matched rules.
+	RuleHits      []*RuleHit             `protobuf:"bytes,4,rep,name=rule_hits,json=ruleHits,proto3" json:"rule_hits,omitempty"`
+	Tx            *Tx                    `protobuf:"bytes,5,opt,name=tx,proto3" json:"tx,omitempty"` // Recorded tx with server fields, unset if rules rejected it or froze the balance.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTxResponse) Reset() {
+	*x = RecordTxResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTxResponse) ProtoMessage() {}
+
+func (x *RecordTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTxResponse.ProtoReflect.Descriptor instead.
+func (*RecordTxResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecordTxResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RecordTxResponse) GetBalance() *BalanceResponse {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *RecordTxResponse) GetRuleAction() RuleAction {
+	if x != nil {
+		return x.RuleAction
+	}
+	return RuleAction_RULE_ACTION_UNSPECIFIED
+}
+
+func (x *RecordTxResponse) GetRuleHits() []*RuleHit {
+	if x != nil {
+		return x.RuleHits
+	}
+	return nil
+}
+
+func (x *RecordTxResponse) GetTx() *Tx {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type RecordTxResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Empty if the tx is recorded, error code otherwise, e.g. "not_found" or "already_exists".
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`    // Error reason like in ErrorInfo details, e.g. "INSUFFICIENT_FUNDS".
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"` // Version of the balance after the tx, zero if it failed.
+	Balance       *BalanceResponse       `protobuf:"bytes,6,opt,name=balance,proto3" json:"balance,omitempty"`  // Balance after the tx, projected for dry runs, unset if it failed.
+	RuleAction    RuleAction             `protobuf:"varint,7,opt,name=rule_action,json=ruleAction,proto3,enum=balance.v1.RuleAction" json:"rule_action,omitempty"`
+	RuleHits      []*RuleHit             `protobuf:"bytes,8,rep,name=rule_hits,json=ruleHits,proto3" json:"rule_hits,omitempty"`
+	Tx            *Tx                    `protobuf:"bytes,9,opt,name=tx,proto3" json:"tx,omitempty"` // Recorded tx with server fields, unset if it failed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordTxResult) Reset() {
+	*x = RecordTxResult{}
+	mi := &file_balance_v1_balance_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordTxResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordTxResult) ProtoMessage() {}
+
+func (x *RecordTxResult) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordTxResult.ProtoReflect.Descriptor instead.
+func (*RecordTxResult) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecordTxResult) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *RecordTxResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RecordTxResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RecordTxResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RecordTxResult) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RecordTxResult) GetBalance() *BalanceResponse {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *RecordTxResult) GetRuleAction() RuleAction {
+	if x != nil {
+		return x.RuleAction
+	}
+	return RuleAction_RULE_ACTION_UNSPECIFIED
+}
+
+func (x *RecordTxResult) GetRuleHits() []*RuleHit {
+	if x != nil {
+		return x.RuleHits
+	}
+	return nil
+}
+
+func (x *RecordTxResult) GetTx() *Tx {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type GetTxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	ExternalRef   string                 `protobuf:"bytes,2,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTxRequest) Reset() {
+	*x = GetTxRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxRequest) ProtoMessage() {}
+
+func (x *GetTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxRequest.ProtoReflect.Descriptor instead.
+func (*GetTxRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTxRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *GetTxRequest) GetExternalRef() string {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return ""
+}
+
+type CancelTxsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	TxIds     []string               `protobuf:"bytes,2,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
+	// Optional, the txs are cancelled only if the balance is still at this version.
+	ExpectedVersion *int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	DryRun          bool   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Runs all checks and returns the projected balance without cancelling the txs.
+	// Also cancels not cancelled descendants of the txs, otherwise txs with such children can't be cancelled.
+	Cascade        bool           `protobuf:"varint,5,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	NegativePolicy NegativePolicy `protobuf:"varint,6,opt,name=negative_policy,json=negativePolicy,proto3,enum=balance.v1.NegativePolicy" json:"negative_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelTxsRequest) Reset() {
+	*x = CancelTxsRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTxsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTxsRequest) ProtoMessage() {}
+
+func (x *CancelTxsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTxsRequest.ProtoReflect.Descriptor instead.
+func (*CancelTxsRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelTxsRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *CancelTxsRequest) GetTxIds() []string {
+	if x != nil {
+		return x.TxIds
+	}
+	return nil
+}
+
+func (x *CancelTxsRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *CancelTxsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CancelTxsRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *CancelTxsRequest) GetNegativePolicy() NegativePolicy {
+	if x != nil {
+		return x.NegativePolicy
+	}
+	return NegativePolicy_NEGATIVE_POLICY_UNSPECIFIED
+}
+
+type CancelTxsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Version      int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                                // Version of the balance after the cancellation.
+	Balance      *BalanceResponse       `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`                                 // Balance after the cancellation, projected for dry runs.
+	Txs          []*Tx                  `protobuf:"bytes,3,rep,name=txs,proto3" json:"txs,omitempty"`                                         // Cancelled txs, txs cancelled before are skipped.
+	Correction   *Decimal               `protobuf:"bytes,4,opt,name=correction,proto3" json:"correction,omitempty"`                           // Change of the balance amount reverting the txs, negative if deposits were cancelled.
+	SkippedTxIds []string               `protobuf:"bytes,5,rep,name=skipped_tx_ids,json=skippedTxIds,proto3" json:"skipped_tx_ids,omitempty"` // Txs left as is by NEGATIVE_POLICY_SKIP.
+	// Debt added: the part NEGATIVE_POLICY_DEBT couldn't take from the balance and debt paid off by cancelled deposits.
+	Debt          *Decimal `protobuf:"bytes,6,opt,name=debt,proto3" json:"debt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTxsResponse) Reset() {
+	*x = CancelTxsResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTxsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTxsResponse) ProtoMessage() {}
+
+func (x *CancelTxsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTxsResponse.ProtoReflect.Descriptor instead.
+func (*CancelTxsResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelTxsResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CancelTxsResponse) GetBalance() *BalanceResponse {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *CancelTxsResponse) GetTxs() []*Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *CancelTxsResponse) GetCorrection() *Decimal {
+	if x != nil {
+		return x.Correction
+	}
+	return nil
+}
+
+func (x *CancelTxsResponse) GetSkippedTxIds() []string {
+	if x != nil {
+		return x.SkippedTxIds
+	}
+	return nil
+}
+
+func (x *CancelTxsResponse) GetDebt() *Decimal {
+	if x != nil {
+		return x.Debt
+	}
+	return nil
+}
+
+// Selects not cancelled txs of the tenant.
+type CancellationCriteria struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Source      Source                 `protobuf:"varint,1,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`
+	State       State                  `protobuf:"varint,2,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`         // Optional, both states if unspecified.
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // Inclusive.
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // Exclusive.
+	// Optional prefix of external refs, e.g. "provider-x:" if operators encode providers in them.
+	ExternalRefPrefix string `protobuf:"bytes,5,opt,name=external_ref_prefix,json=externalRefPrefix,proto3" json:"external_ref_prefix,omitempty"`
+	// Optional, all balances of the tenant if empty.
+	BalanceIds []string `protobuf:"bytes,6,rep,name=balance_ids,json=balanceIds,proto3" json:"balance_ids,omitempty"`
+	// Optional, selects txs having all of these metadata pairs, e.g. {"provider": "x"}.
+	Metadata      map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancellationCriteria) Reset() {
+	*x = CancellationCriteria{}
+	mi := &file_balance_v1_balance_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancellationCriteria) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationCriteria) ProtoMessage() {}
+
+func (x *CancellationCriteria) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationCriteria.ProtoReflect.Descriptor instead.
+func (*CancellationCriteria) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancellationCriteria) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+func (x *CancellationCriteria) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *CancellationCriteria) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *CancellationCriteria) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *CancellationCriteria) GetExternalRefPrefix() string {
+	if x != nil {
+		return x.ExternalRefPrefix
+	}
+	return ""
+}
+
+func (x *CancellationCriteria) GetBalanceIds() []string {
+	if x != nil {
+		return x.BalanceIds
+	}
+	return nil
+}
+
+func (x *CancellationCriteria) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type CancelTxsByCriteriaRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Criteria       *CancellationCriteria  `protobuf:"bytes,1,opt,name=criteria,proto3" json:"criteria,omitempty"`
+	NegativePolicy NegativePolicy         `protobuf:"varint,2,opt,name=negative_policy,json=negativePolicy,proto3,enum=balance.v1.NegativePolicy" json:"negative_policy,omitempty"` // Applied to each balance.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelTxsByCriteriaRequest) Reset() {
+	*x = CancelTxsByCriteriaRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTxsByCriteriaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTxsByCriteriaRequest) ProtoMessage() {}
+
+func (x *CancelTxsByCriteriaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTxsByCriteriaRequest.ProtoReflect.Descriptor instead.
+func (*CancelTxsByCriteriaRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelTxsByCriteriaRequest) GetCriteria() *CancellationCriteria {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *CancelTxsByCriteriaRequest) GetNegativePolicy() NegativePolicy {
+	if x != nil {
+		return x.NegativePolicy
+	}
+	return NegativePolicy_NEGATIVE_POLICY_UNSPECIFIED
+}
+
+type Cancellation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CancellationId string                 `protobuf:"bytes,1,opt,name=cancellation_id,json=cancellationId,proto3" json:"cancellation_id,omitempty"`
+	Status         CancellationStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=balance.v1.CancellationStatus" json:"status,omitempty"`
+	Criteria       *CancellationCriteria  `protobuf:"bytes,3,opt,name=criteria,proto3" json:"criteria,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FinishedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`           // Unset until done.
+	BalancesTotal  int64                  `protobuf:"varint,7,opt,name=balances_total,json=balancesTotal,proto3" json:"balances_total,omitempty"` // Balances with matching txs when the cancellation was requested.
+	BalancesDone   int64                  `protobuf:"varint,8,opt,name=balances_done,json=balancesDone,proto3" json:"balances_done,omitempty"`
+	BalancesFailed int64                  `protobuf:"varint,9,opt,name=balances_failed,json=balancesFailed,proto3" json:"balances_failed,omitempty"`
+	TxsCancelled   int64                  `protobuf:"varint,10,opt,name=txs_cancelled,json=txsCancelled,proto3" json:"txs_cancelled,omitempty"` // Includes not matching descendants of matching txs.
+	NegativePolicy NegativePolicy         `protobuf:"varint,11,opt,name=negative_policy,json=negativePolicy,proto3,enum=balance.v1.NegativePolicy" json:"negative_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Cancellation) Reset() {
+	*x = Cancellation{}
+	mi := &file_balance_v1_balance_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cancellation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cancellation) ProtoMessage() {}
+
+func (x *Cancellation) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cancellation.ProtoReflect.Descriptor instead.
+func (*Cancellation) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Cancellation) GetCancellationId() string {
+	if x != nil {
+		return x.CancellationId
+	}
+	return ""
+}
+
+func (x *Cancellation) GetStatus() CancellationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CancellationStatus_CANCELLATION_STATUS_UNSPECIFIED
+}
+
+func (x *Cancellation) GetCriteria() *CancellationCriteria {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *Cancellation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cancellation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Cancellation) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *Cancellation) GetBalancesTotal() int64 {
+	if x != nil {
+		return x.BalancesTotal
+	}
+	return 0
+}
+
+func (x *Cancellation) GetBalancesDone() int64 {
+	if x != nil {
+		return x.BalancesDone
+	}
+	return 0
+}
+
+func (x *Cancellation) GetBalancesFailed() int64 {
+	if x != nil {
+		return x.BalancesFailed
+	}
+	return 0
+}
+
+func (x *Cancellation) GetTxsCancelled() int64 {
+	if x != nil {
+		return x.TxsCancelled
+	}
+	return 0
+}
+
+func (x *Cancellation) GetNegativePolicy() NegativePolicy {
+	if x != nil {
+		return x.NegativePolicy
+	}
+	return NegativePolicy_NEGATIVE_POLICY_UNSPECIFIED
+}
+
+type CancelTxsByCriteriaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancellation  *Cancellation          `protobuf:"bytes,1,opt,name=cancellation,proto3" json:"cancellation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTxsByCriteriaResponse) Reset() {
+	*x = CancelTxsByCriteriaResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTxsByCriteriaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTxsByCriteriaResponse) ProtoMessage() {}
+
+func (x *CancelTxsByCriteriaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTxsByCriteriaResponse.ProtoReflect.Descriptor instead.
+func (*CancelTxsByCriteriaResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelTxsByCriteriaResponse) GetCancellation() *Cancellation {
+	if x != nil {
+		return x.Cancellation
+	}
+	return nil
+}
+
+type BalanceCancellation struct {
+	state        protoimpl.MessageState    `protogen:"open.v1"`
+	BalanceId    string                    `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Status       BalanceCancellationStatus `protobuf:"varint,2,opt,name=status,proto3,enum=balance.v1.BalanceCancellationStatus" json:"status,omitempty"`
+	TxIds        []string                  `protobuf:"bytes,3,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"` // Cancelled txs.
+	Correction   *Decimal                  `protobuf:"bytes,4,opt,name=correction,proto3" json:"correction,omitempty"`    // Change of the balance amount reverting the txs.
+	Error        string                    `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`              // Reason the balance failed, e.g. insufficient funds.
+	UpdatedAt    *timestamppb.Timestamp    `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SkippedTxIds []string                  `protobuf:"bytes,7,rep,name=skipped_tx_ids,json=skippedTxIds,proto3" json:"skipped_tx_ids,omitempty"` // Txs left as is by NEGATIVE_POLICY_SKIP.
+	// Debt added: the part NEGATIVE_POLICY_DEBT couldn't take from the balance and debt paid off by cancelled deposits.
+	Debt          *Decimal `protobuf:"bytes,8,opt,name=debt,proto3" json:"debt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceCancellation) Reset() {
+	*x = BalanceCancellation{}
+	mi := &file_balance_v1_balance_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceCancellation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceCancellation) ProtoMessage() {}
+
+func (x *BalanceCancellation) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceCancellation.ProtoReflect.Descriptor instead.
+func (*BalanceCancellation) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BalanceCancellation) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *BalanceCancellation) GetStatus() BalanceCancellationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_UNSPECIFIED
+}
+
+func (x *BalanceCancellation) GetTxIds() []string {
+	if x != nil {
+		return x.TxIds
+	}
+	return nil
+}
+
+func (x *BalanceCancellation) GetCorrection() *Decimal {
+	if x != nil {
+		return x.Correction
+	}
+	return nil
+}
+
+func (x *BalanceCancellation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BalanceCancellation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *BalanceCancellation) GetSkippedTxIds() []string {
+	if x != nil {
+		return x.SkippedTxIds
+	}
+	return nil
+}
+
+func (x *BalanceCancellation) GetDebt() *Decimal {
+	if x != nil {
+		return x.Debt
+	}
+	return nil
+}
+
+type GetCancellationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CancellationId string                 `protobuf:"bytes,1,opt,name=cancellation_id,json=cancellationId,proto3" json:"cancellation_id,omitempty"`
+	PageSize       int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Opaque, valid only with the same filter.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetCancellationRequest) Reset() {
+	*x = GetCancellationRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCancellationRequest) ProtoMessage() {}
+
+func (x *GetCancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCancellationRequest.ProtoReflect.Descriptor instead.
+func (*GetCancellationRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetCancellationRequest) GetCancellationId() string {
+	if x != nil {
+		return x.CancellationId
+	}
+	return ""
+}
+
+func (x *GetCancellationRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetCancellationRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetCancellationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancellation  *Cancellation          `protobuf:"bytes,1,opt,name=cancellation,proto3" json:"cancellation,omitempty"`
+	Balances      []*BalanceCancellation `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"` // Outcomes per balance ordered by balance ID.
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCancellationResponse) Reset() {
+	*x = GetCancellationResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCancellationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCancellationResponse) ProtoMessage() {}
+
+func (x *GetCancellationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCancellationResponse.ProtoReflect.Descriptor instead.
+func (*GetCancellationResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetCancellationResponse) GetCancellation() *Cancellation {
+	if x != nil {
+		return x.Cancellation
+	}
+	return nil
+}
+
+func (x *GetCancellationResponse) GetBalances() []*BalanceCancellation {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *GetCancellationResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListRelatedTxsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelatedTxsRequest) Reset() {
+	*x = ListRelatedTxsRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelatedTxsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelatedTxsRequest) ProtoMessage() {}
+
+func (x *ListRelatedTxsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelatedTxsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelatedTxsRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListRelatedTxsRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+type ListRelatedTxsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txs           []*Tx                  `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"` // Root parent of the tx and all its descendants, including cancelled ones, in balance history order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelatedTxsResponse) Reset() {
+	*x = ListRelatedTxsResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelatedTxsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelatedTxsResponse) ProtoMessage() {}
+
+func (x *ListRelatedTxsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelatedTxsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelatedTxsResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRelatedTxsResponse) GetTxs() []*Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+type ListTxRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId      string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	PageSize       int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`               // Opaque, valid only with the same filter.
+	Source         Source                 `protobuf:"varint,5,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`              // Optional.
+	State          State                  `protobuf:"varint,6,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`                 // Optional.
+	CreatedFrom    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`         // Optional, inclusive.
+	CreatedTo      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`               // Optional, exclusive.
+	MinAmount      *Decimal               `protobuf:"bytes,9,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`               // Optional, inclusive.
+	MaxAmount      *Decimal               `protobuf:"bytes,10,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`              // Optional, inclusive.
+	CancelledOnly  bool                   `protobuf:"varint,11,opt,name=cancelled_only,json=cancelledOnly,proto3" json:"cancelled_only,omitempty"` // Lists only cancelled txs, implies include_deleted.
+	Order          TxOrder                `protobuf:"varint,12,opt,name=order,proto3,enum=balance.v1.TxOrder" json:"order,omitempty"`              // Newest first if unspecified.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListTxRequest) Reset() {
+	*x = ListTxRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTxRequest) ProtoMessage() {}
+
+func (x *ListTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTxRequest.ProtoReflect.Descriptor instead.
+func (*ListTxRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTxRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *ListTxRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListTxRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTxRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTxRequest) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+func (x *ListTxRequest) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *ListTxRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListTxRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListTxRequest) GetMinAmount() *Decimal {
+	if x != nil {
+		return x.MinAmount
+	}
+	return nil
+}
+
+func (x *ListTxRequest) GetMaxAmount() *Decimal {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return nil
+}
+
+func (x *ListTxRequest) GetCancelledOnly() bool {
+	if x != nil {
+		return x.CancelledOnly
+	}
+	return false
+}
+
+func (x *ListTxRequest) GetOrder() TxOrder {
+	if x != nil {
+		return x.Order
+	}
+	return TxOrder_TX_ORDER_UNSPECIFIED
+}
+
+type ListTxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txs           []*Tx                  `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTxResponse) Reset() {
+	*x = ListTxResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTxResponse) ProtoMessage() {}
+
+func (x *ListTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTxResponse.ProtoReflect.Descriptor instead.
+func (*ListTxResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTxResponse) GetTxs() []*Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *ListTxResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type OpenBalanceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	// Optional player ID, a player has one balance per currency and wallet type.
+	OwnerId           string `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	ExternalPlayerRef string `protobuf:"bytes,3,opt,name=external_player_ref,json=externalPlayerRef,proto3" json:"external_player_ref,omitempty"` // Optional player ID in operator's systems.
+	// Optional ISO 4217 code.
+	Currency      string     `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	WalletType    WalletType `protobuf:"varint,5,opt,name=wallet_type,json=walletType,proto3,enum=balance.v1.WalletType" json:"wallet_type,omitempty"` // Main wallet if unspecified.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenBalanceRequest) Reset() {
+	*x = OpenBalanceRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenBalanceRequest) ProtoMessage() {}
+
+func (x *OpenBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenBalanceRequest.ProtoReflect.Descriptor instead.
+func (*OpenBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OpenBalanceRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *OpenBalanceRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *OpenBalanceRequest) GetExternalPlayerRef() string {
+	if x != nil {
+		return x.ExternalPlayerRef
+	}
+	return ""
+}
+
+func (x *OpenBalanceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OpenBalanceRequest) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+type BalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceRequest) Reset() {
+	*x = BalanceRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceRequest) ProtoMessage() {}
+
+func (x *BalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceRequest.ProtoReflect.Descriptor instead.
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BalanceRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+type BalanceResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId         string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Amount            *Decimal               `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status            BalanceStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=balance.v1.BalanceStatus" json:"status,omitempty"`
+	OwnerId           string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	ExternalPlayerRef string                 `protobuf:"bytes,5,opt,name=external_player_ref,json=externalPlayerRef,proto3" json:"external_player_ref,omitempty"`
+	Currency          string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	WalletType        WalletType             `protobuf:"varint,7,opt,name=wallet_type,json=walletType,proto3,enum=balance.v1.WalletType" json:"wallet_type,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                   // Last change of the amount or status.
+	LastActivityAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"` // Last tx recorded or cancelled, unset if there were none.
+	TxCount           int64                  `protobuf:"varint,11,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`                       // Not cancelled txs.
+	Version           int64                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`                                      // Incremented with every change of the balance, see expected_version of writes.
+	Debt              *Decimal               `protobuf:"bytes,13,opt,name=debt,proto3" json:"debt,omitempty"`                                             // Owed by the player, deposits pay it off before adding to the amount.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BalanceResponse) Reset() {
+	*x = BalanceResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceResponse) ProtoMessage() {}
+
+func (x *BalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceResponse.ProtoReflect.Descriptor instead.
+func (*BalanceResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BalanceResponse) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *BalanceResponse) GetStatus() BalanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BalanceStatus_BALANCE_STATUS_UNSPECIFIED
+}
+
+func (x *BalanceResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetExternalPlayerRef() string {
+	if x != nil {
+		return x.ExternalPlayerRef
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetWalletType() WalletType {
+	if x != nil {
+		return x.WalletType
+	}
+	return WalletType_WALLET_TYPE_UNSPECIFIED
+}
+
+func (x *BalanceResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BalanceResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *BalanceResponse) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+func (x *BalanceResponse) GetTxCount() int64 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *BalanceResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BalanceResponse) GetDebt() *Decimal {
+	if x != nil {
+		return x.Debt
+	}
+	return nil
+}
+
+type ListBalancesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional.
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                           // Opaque, valid only with the same filter and sorting.
+	Status        BalanceStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=balance.v1.BalanceStatus" json:"status,omitempty"`                   // Optional.
+	MinAmount     *Decimal               `protobuf:"bytes,5,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`                           // Optional, inclusive.
+	MaxAmount     *Decimal               `protobuf:"bytes,6,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`                           // Optional, inclusive.
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`                     // Optional, inclusive.
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`                           // Optional, exclusive.
+	ActiveSince   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=active_since,json=activeSince,proto3" json:"active_since,omitempty"`                     // Optional, lists only balances with txs recorded or cancelled since.
+	SortBy        BalanceSortField       `protobuf:"varint,10,opt,name=sort_by,json=sortBy,proto3,enum=balance.v1.BalanceSortField" json:"sort_by,omitempty"` // Creation time if unspecified.
+	Descending    bool                   `protobuf:"varint,11,opt,name=descending,proto3" json:"descending,omitempty"`
+	UpdatedFrom   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`       // Optional, inclusive.
+	UpdatedTo     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`             // Optional, exclusive.
+	MinTxCount    *int64                 `protobuf:"varint,14,opt,name=min_tx_count,json=minTxCount,proto3,oneof" json:"min_tx_count,omitempty"` // Optional, inclusive.
+	MaxTxCount    *int64                 `protobuf:"varint,15,opt,name=max_tx_count,json=maxTxCount,proto3,oneof" json:"max_tx_count,omitempty"` // Optional, inclusive.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBalancesRequest) Reset() {
+	*x = ListBalancesRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBalancesRequest) ProtoMessage() {}
+
+func (x *ListBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBalancesRequest.ProtoReflect.Descriptor instead.
+func (*ListBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListBalancesRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ListBalancesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBalancesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBalancesRequest) GetStatus() BalanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BalanceStatus_BALANCE_STATUS_UNSPECIFIED
+}
+
+func (x *ListBalancesRequest) GetMinAmount() *Decimal {
+	if x != nil {
+		return x.MinAmount
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetMaxAmount() *Decimal {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetActiveSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveSince
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetSortBy() BalanceSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return BalanceSortField_BALANCE_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListBalancesRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListBalancesRequest) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+func (x *ListBalancesRequest) GetMinTxCount() int64 {
+	if x != nil && x.MinTxCount != nil {
+		return *x.MinTxCount
+	}
+	return 0
+}
+
+func (x *ListBalancesRequest) GetMaxTxCount() int64 {
+	if x != nil && x.MaxTxCount != nil {
+		return *x.MaxTxCount
+	}
+	return 0
+}
+
+type ListBalancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*BalanceResponse     `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBalancesResponse) Reset() {
+	*x = ListBalancesResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBalancesResponse) ProtoMessage() {}
+
+func (x *ListBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBalancesResponse.ProtoReflect.Descriptor instead.
+func (*ListBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListBalancesResponse) GetBalances() []*BalanceResponse {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *ListBalancesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchBalanceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	// Optional, resumes after the last received event instead of starting with a snapshot.
+	AfterSeq      int64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBalanceRequest) Reset() {
+	*x = WatchBalanceRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBalanceRequest) ProtoMessage() {}
+
+func (x *WatchBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBalanceRequest.ProtoReflect.Descriptor instead.
+func (*WatchBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchBalanceRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *WatchBalanceRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+type BalanceEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Seq            int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // Increases by one with every change of the balance.
+	Kind           BalanceEventKind       `protobuf:"varint,2,opt,name=kind,proto3,enum=balance.v1.BalanceEventKind" json:"kind,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Amount         *Decimal               `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`                                                                       // Amount after the change.
+	Status         BalanceStatus          `protobuf:"varint,5,opt,name=status,proto3,enum=balance.v1.BalanceStatus" json:"status,omitempty"`                                        // Status after the change.
+	Txs            []*Tx                  `protobuf:"bytes,6,rep,name=txs,proto3" json:"txs,omitempty"`                                                                             // Txs which caused the change.
+	NegativePolicy NegativePolicy         `protobuf:"varint,7,opt,name=negative_policy,json=negativePolicy,proto3,enum=balance.v1.NegativePolicy" json:"negative_policy,omitempty"` // Set for cancellations only.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BalanceEvent) Reset() {
+	*x = BalanceEvent{}
+	mi := &file_balance_v1_balance_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceEvent) ProtoMessage() {}
+
+func (x *BalanceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceEvent.ProtoReflect.Descriptor instead.
+func (*BalanceEvent) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BalanceEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *BalanceEvent) GetKind() BalanceEventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return BalanceEventKind_BALANCE_EVENT_KIND_UNSPECIFIED
+}
+
+func (x *BalanceEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BalanceEvent) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *BalanceEvent) GetStatus() BalanceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BalanceStatus_BALANCE_STATUS_UNSPECIFIED
+}
+
+func (x *BalanceEvent) GetTxs() []*Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *BalanceEvent) GetNegativePolicy() NegativePolicy {
+	if x != nil {
+		return x.NegativePolicy
+	}
+	return NegativePolicy_NEGATIVE_POLICY_UNSPECIFIED
+}
+
+type ExportStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`                                      // Optional, inclusive.
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`                                          // Optional, exclusive.
+	Format        StatementFormat        `protobuf:"varint,4,opt,name=format,proto3,enum=balance.v1.StatementFormat" json:"format,omitempty"` // CSV if unspecified.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStatementRequest) Reset() {
+	*x = ExportStatementRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStatementRequest) ProtoMessage() {}
+
+func (x *ExportStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStatementRequest.ProtoReflect.Descriptor instead.
+func (*ExportStatementRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportStatementRequest) GetBalanceId() string {
+	if x != nil {
+		return x.BalanceId
+	}
+	return ""
+}
+
+func (x *ExportStatementRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportStatementRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportStatementRequest) GetFormat() StatementFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StatementFormat_STATEMENT_FORMAT_UNSPECIFIED
+}
+
+type StatementChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // Part of the statement file, chunks must be concatenated in order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatementChunk) Reset() {
+	*x = StatementChunk{}
+	mi := &file_balance_v1_balance_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementChunk) ProtoMessage() {}
+
+func (x *StatementChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementChunk.ProtoReflect.Descriptor instead.
+func (*StatementChunk) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StatementChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AggregateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional, aggregates all balances of the tenant if empty.
+	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`                                      // Inclusive.
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`                                          // Exclusive.
+	Bucket        AggregateBucket        `protobuf:"varint,4,opt,name=bucket,proto3,enum=balance.v1.AggregateBucket" json:"bucket,omitempty"` // Day if unspecified.
+	TimeZone      string                 `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`              // Optional IANA time zone of bucket boundaries, e.g. "Europe/Malta", UTC if empty.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Decimal) Reset() {
-	*x = Decimal{}
-	mi := &file_balance_v1_balance_proto_msgTypes[0]
+func (x *AggregateRequest) Reset() {
+	*x = AggregateRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Decimal) String() string {
+func (x *AggregateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Decimal) ProtoMessage() {}
+func (*AggregateRequest) ProtoMessage() {}
 
-func (x *Decimal) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[0]
+func (x *AggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -156,46 +3244,72 @@ func (x *Decimal) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
-func (*Decimal) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use AggregateRequest.ProtoReflect.Descriptor instead.
+func (*AggregateRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *Decimal) GetValue() string {
+func (x *AggregateRequest) GetBalanceId() string {
 	if x != nil {
-		return x.Value
+		return x.BalanceId
 	}
 	return ""
 }
 
-type Tx struct {
+func (x *AggregateRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *AggregateRequest) GetBucket() AggregateBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return AggregateBucket_AGGREGATE_BUCKET_UNSPECIFIED
+}
+
+func (x *AggregateRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type AggregateRow struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
-	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	BalanceId     string                 `protobuf:"bytes,4,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
-	Source        Source                 `protobuf:"varint,5,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`
-	State         State                  `protobuf:"varint,6,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`
-	Amount        *Decimal               `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	BucketStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=bucket_start,json=bucketStart,proto3" json:"bucket_start,omitempty"`
+	Source        Source                 `protobuf:"varint,2,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`
+	State         State                  `protobuf:"varint,3,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Amount        *Decimal               `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Tx) Reset() {
-	*x = Tx{}
-	mi := &file_balance_v1_balance_proto_msgTypes[1]
+func (x *AggregateRow) Reset() {
+	*x = AggregateRow{}
+	mi := &file_balance_v1_balance_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Tx) String() string {
+func (x *AggregateRow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tx) ProtoMessage() {}
+func (*AggregateRow) ProtoMessage() {}
 
-func (x *Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[1]
+func (x *AggregateRow) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,86 +3320,283 @@ func (x *Tx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
-func (*Tx) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use AggregateRow.ProtoReflect.Descriptor instead.
+func (*AggregateRow) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *Tx) GetCreatedAt() *timestamppb.Timestamp {
+func (x *AggregateRow) GetBucketStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.BucketStart
 	}
 	return nil
 }
 
-func (x *Tx) GetDeletedAt() *timestamppb.Timestamp {
+func (x *AggregateRow) GetSource() Source {
 	if x != nil {
-		return x.DeletedAt
+		return x.Source
+	}
+	return Source_SOURCE_UNSPECIFIED
+}
+
+func (x *AggregateRow) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *AggregateRow) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AggregateRow) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
 	}
 	return nil
 }
 
-func (x *Tx) GetTxId() string {
+type Revenue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=bucket_start,json=bucketStart,proto3" json:"bucket_start,omitempty"`
+	Ggr           *Decimal               `protobuf:"bytes,2,opt,name=ggr,proto3" json:"ggr,omitempty"`           // Gross gaming revenue, game withdrawals minus game deposits.
+	Turnover      *Decimal               `protobuf:"bytes,3,opt,name=turnover,proto3" json:"turnover,omitempty"` // Game withdrawals.
+	Deposits      *Decimal               `protobuf:"bytes,4,opt,name=deposits,proto3" json:"deposits,omitempty"` // Payment deposits.
+	Payouts       *Decimal               `protobuf:"bytes,5,opt,name=payouts,proto3" json:"payouts,omitempty"`   // Payment withdrawals.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revenue) Reset() {
+	*x = Revenue{}
+	mi := &file_balance_v1_balance_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revenue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revenue) ProtoMessage() {}
+
+func (x *Revenue) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[32]
 	if x != nil {
-		return x.TxId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revenue.ProtoReflect.Descriptor instead.
+func (*Revenue) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Revenue) GetBucketStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BucketStart
+	}
+	return nil
+}
+
+func (x *Revenue) GetGgr() *Decimal {
+	if x != nil {
+		return x.Ggr
+	}
+	return nil
+}
+
+func (x *Revenue) GetTurnover() *Decimal {
+	if x != nil {
+		return x.Turnover
+	}
+	return nil
+}
+
+func (x *Revenue) GetDeposits() *Decimal {
+	if x != nil {
+		return x.Deposits
+	}
+	return nil
+}
+
+func (x *Revenue) GetPayouts() *Decimal {
+	if x != nil {
+		return x.Payouts
+	}
+	return nil
+}
+
+type AggregateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*AggregateRow        `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`         // Sorted by bucket, source and state.
+	Revenues      []*Revenue             `protobuf:"bytes,2,rep,name=revenues,proto3" json:"revenues,omitempty"` // Sorted by bucket.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateResponse) Reset() {
+	*x = AggregateResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateResponse) ProtoMessage() {}
+
+func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateResponse.ProtoReflect.Descriptor instead.
+func (*AggregateResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AggregateResponse) GetRows() []*AggregateRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *AggregateResponse) GetRevenues() []*Revenue {
+	if x != nil {
+		return x.Revenues
+	}
+	return nil
+}
+
+type FlaggedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	BalanceId     string                 `protobuf:"bytes,3,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	TxId          string                 `protobuf:"bytes,4,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Rule          string                 `protobuf:"bytes,5,opt,name=rule,proto3" json:"rule,omitempty"`
+	Action        RuleAction             `protobuf:"varint,6,opt,name=action,proto3,enum=balance.v1.RuleAction" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlaggedEvent) Reset() {
+	*x = FlaggedEvent{}
+	mi := &file_balance_v1_balance_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlaggedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlaggedEvent) ProtoMessage() {}
+
+func (x *FlaggedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlaggedEvent.ProtoReflect.Descriptor instead.
+func (*FlaggedEvent) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FlaggedEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FlaggedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
 	}
 	return ""
 }
 
-func (x *Tx) GetBalanceId() string {
+func (x *FlaggedEvent) GetBalanceId() string {
 	if x != nil {
 		return x.BalanceId
 	}
 	return ""
 }
 
-func (x *Tx) GetSource() Source {
+func (x *FlaggedEvent) GetTxId() string {
 	if x != nil {
-		return x.Source
+		return x.TxId
 	}
-	return Source_SOURCE_UNSPECIFIED
+	return ""
 }
 
-func (x *Tx) GetState() State {
+func (x *FlaggedEvent) GetRule() string {
 	if x != nil {
-		return x.State
+		return x.Rule
 	}
-	return State_STATE_UNSPECIFIED
+	return ""
 }
 
-func (x *Tx) GetAmount() *Decimal {
+func (x *FlaggedEvent) GetAction() RuleAction {
 	if x != nil {
-		return x.Amount
+		return x.Action
 	}
-	return nil
+	return RuleAction_RULE_ACTION_UNSPECIFIED
 }
 
-type RecordTxRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
-	Source        Source                 `protobuf:"varint,2,opt,name=source,proto3,enum=balance.v1.Source" json:"source,omitempty"`
-	State         State                  `protobuf:"varint,3,opt,name=state,proto3,enum=balance.v1.State" json:"state,omitempty"`
-	Amount        *Decimal               `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	TxId          string                 `protobuf:"bytes,5,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+type ListFlaggedEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional, lists events of all balances if empty.
+	BalanceId     string `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Opaque, valid only with the same filter.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordTxRequest) Reset() {
-	*x = RecordTxRequest{}
-	mi := &file_balance_v1_balance_proto_msgTypes[2]
+func (x *ListFlaggedEventsRequest) Reset() {
+	*x = ListFlaggedEventsRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordTxRequest) String() string {
+func (x *ListFlaggedEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordTxRequest) ProtoMessage() {}
+func (*ListFlaggedEventsRequest) ProtoMessage() {}
 
-func (x *RecordTxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[2]
+func (x *ListFlaggedEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,69 +3607,108 @@ func (x *RecordTxRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordTxRequest.ProtoReflect.Descriptor instead.
-func (*RecordTxRequest) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListFlaggedEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListFlaggedEventsRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *RecordTxRequest) GetBalanceId() string {
+func (x *ListFlaggedEventsRequest) GetBalanceId() string {
 	if x != nil {
 		return x.BalanceId
 	}
 	return ""
 }
 
-func (x *RecordTxRequest) GetSource() Source {
-	if x != nil {
-		return x.Source
-	}
-	return Source_SOURCE_UNSPECIFIED
+func (x *ListFlaggedEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFlaggedEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListFlaggedEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*FlaggedEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFlaggedEventsResponse) Reset() {
+	*x = ListFlaggedEventsResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFlaggedEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *RecordTxRequest) GetState() State {
+func (*ListFlaggedEventsResponse) ProtoMessage() {}
+
+func (x *ListFlaggedEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[36]
 	if x != nil {
-		return x.State
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return State_STATE_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *RecordTxRequest) GetAmount() *Decimal {
+// Deprecated: Use ListFlaggedEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListFlaggedEventsResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListFlaggedEventsResponse) GetEvents() []*FlaggedEvent {
 	if x != nil {
-		return x.Amount
+		return x.Events
 	}
 	return nil
 }
 
-func (x *RecordTxRequest) GetTxId() string {
+func (x *ListFlaggedEventsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.TxId
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type CancelTxsRequest struct {
+type ChargebackRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
-	TxIds         []string               `protobuf:"bytes,2,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
+	TxId          string                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"` // Payment deposit charged back.
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`         // Optional, e.g. the reason code of the card scheme.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelTxsRequest) Reset() {
-	*x = CancelTxsRequest{}
-	mi := &file_balance_v1_balance_proto_msgTypes[3]
+func (x *ChargebackRequest) Reset() {
+	*x = ChargebackRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelTxsRequest) String() string {
+func (x *ChargebackRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelTxsRequest) ProtoMessage() {}
+func (*ChargebackRequest) ProtoMessage() {}
 
-func (x *CancelTxsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[3]
+func (x *ChargebackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,50 +3719,61 @@ func (x *CancelTxsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelTxsRequest.ProtoReflect.Descriptor instead.
-func (*CancelTxsRequest) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ChargebackRequest.ProtoReflect.Descriptor instead.
+func (*ChargebackRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *CancelTxsRequest) GetBalanceId() string {
+func (x *ChargebackRequest) GetBalanceId() string {
 	if x != nil {
 		return x.BalanceId
 	}
 	return ""
 }
 
-func (x *CancelTxsRequest) GetTxIds() []string {
+func (x *ChargebackRequest) GetTxId() string {
 	if x != nil {
-		return x.TxIds
+		return x.TxId
 	}
-	return nil
+	return ""
 }
 
-type ListTxRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	BalanceId      string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
-	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
-	PageSize       int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken      string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ChargebackRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *ListTxRequest) Reset() {
-	*x = ListTxRequest{}
-	mi := &file_balance_v1_balance_proto_msgTypes[4]
+type Chargeback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChargebackId  string                 `protobuf:"bytes,1,opt,name=chargeback_id,json=chargebackId,proto3" json:"chargeback_id,omitempty"`
+	BalanceId     string                 `protobuf:"bytes,2,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Amount        *Decimal               `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`         // Amount of the deposit.
+	Correction    *Decimal               `protobuf:"bytes,5,opt,name=correction,proto3" json:"correction,omitempty"` // Change of the balance amount.
+	Debt          *Decimal               `protobuf:"bytes,6,opt,name=debt,proto3" json:"debt,omitempty"`             // Debt added: the part the balance couldn't cover and debt the deposit paid off.
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chargeback) Reset() {
+	*x = Chargeback{}
+	mi := &file_balance_v1_balance_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTxRequest) String() string {
+func (x *Chargeback) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTxRequest) ProtoMessage() {}
+func (*Chargeback) ProtoMessage() {}
 
-func (x *ListTxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[4]
+func (x *Chargeback) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,62 +3784,91 @@ func (x *ListTxRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTxRequest.ProtoReflect.Descriptor instead.
-func (*ListTxRequest) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Chargeback.ProtoReflect.Descriptor instead.
+func (*Chargeback) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ListTxRequest) GetBalanceId() string {
+func (x *Chargeback) GetChargebackId() string {
+	if x != nil {
+		return x.ChargebackId
+	}
+	return ""
+}
+
+func (x *Chargeback) GetBalanceId() string {
 	if x != nil {
 		return x.BalanceId
 	}
 	return ""
 }
 
-func (x *ListTxRequest) GetIncludeDeleted() bool {
+func (x *Chargeback) GetTxId() string {
 	if x != nil {
-		return x.IncludeDeleted
+		return x.TxId
 	}
-	return false
+	return ""
 }
 
-func (x *ListTxRequest) GetPageSize() int32 {
+func (x *Chargeback) GetAmount() *Decimal {
 	if x != nil {
-		return x.PageSize
+		return x.Amount
 	}
-	return 0
+	return nil
 }
 
-func (x *ListTxRequest) GetPageToken() string {
+func (x *Chargeback) GetCorrection() *Decimal {
 	if x != nil {
-		return x.PageToken
+		return x.Correction
+	}
+	return nil
+}
+
+func (x *Chargeback) GetDebt() *Decimal {
+	if x != nil {
+		return x.Debt
+	}
+	return nil
+}
+
+func (x *Chargeback) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-type ListTxResponse struct {
+func (x *Chargeback) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ChargebackResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Txs           []*Tx                  `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Chargeback    *Chargeback            `protobuf:"bytes,1,opt,name=chargeback,proto3" json:"chargeback,omitempty"`
+	Tx            *Tx                    `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`           // Charged back deposit with its cancellation time.
+	Balance       *BalanceResponse       `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"` // Balance after the chargeback.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTxResponse) Reset() {
-	*x = ListTxResponse{}
-	mi := &file_balance_v1_balance_proto_msgTypes[5]
+func (x *ChargebackResponse) Reset() {
+	*x = ChargebackResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTxResponse) String() string {
+func (x *ChargebackResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTxResponse) ProtoMessage() {}
+func (*ChargebackResponse) ProtoMessage() {}
 
-func (x *ListTxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[5]
+func (x *ChargebackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,47 +3879,60 @@ func (x *ListTxResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTxResponse.ProtoReflect.Descriptor instead.
-func (*ListTxResponse) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ChargebackResponse.ProtoReflect.Descriptor instead.
+func (*ChargebackResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ListTxResponse) GetTxs() []*Tx {
+func (x *ChargebackResponse) GetChargeback() *Chargeback {
 	if x != nil {
-		return x.Txs
+		return x.Chargeback
 	}
 	return nil
 }
 
-func (x *ListTxResponse) GetNextPageToken() string {
+func (x *ChargebackResponse) GetTx() *Tx {
 	if x != nil {
-		return x.NextPageToken
+		return x.Tx
 	}
-	return ""
+	return nil
 }
 
-type OpenBalanceRequest struct {
+func (x *ChargebackResponse) GetBalance() *BalanceResponse {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+// Change of the debt of a balance.
+type DebtEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // Increases by one with every change of the debt.
+	Kind          DebtEntryKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=balance.v1.DebtEntryKind" json:"kind,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Amount        *Decimal               `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`            // Always positive, the kind tells the direction.
+	Debt          *Decimal               `protobuf:"bytes,5,opt,name=debt,proto3" json:"debt,omitempty"`                // Debt after the change.
+	TxIds         []string               `protobuf:"bytes,6,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"` // Cancelled txs or the settling deposit.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OpenBalanceRequest) Reset() {
-	*x = OpenBalanceRequest{}
-	mi := &file_balance_v1_balance_proto_msgTypes[6]
+func (x *DebtEntry) Reset() {
+	*x = DebtEntry{}
+	mi := &file_balance_v1_balance_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenBalanceRequest) String() string {
+func (x *DebtEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenBalanceRequest) ProtoMessage() {}
+func (*DebtEntry) ProtoMessage() {}
 
-func (x *OpenBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[6]
+func (x *DebtEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,40 +3943,77 @@ func (x *OpenBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenBalanceRequest.ProtoReflect.Descriptor instead.
-func (*OpenBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use DebtEntry.ProtoReflect.Descriptor instead.
+func (*DebtEntry) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *OpenBalanceRequest) GetBalanceId() string {
+func (x *DebtEntry) GetSeq() int64 {
 	if x != nil {
-		return x.BalanceId
+		return x.Seq
 	}
-	return ""
+	return 0
 }
 
-type BalanceRequest struct {
+func (x *DebtEntry) GetKind() DebtEntryKind {
+	if x != nil {
+		return x.Kind
+	}
+	return DebtEntryKind_DEBT_ENTRY_KIND_UNSPECIFIED
+}
+
+func (x *DebtEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DebtEntry) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *DebtEntry) GetDebt() *Decimal {
+	if x != nil {
+		return x.Debt
+	}
+	return nil
+}
+
+func (x *DebtEntry) GetTxIds() []string {
+	if x != nil {
+		return x.TxIds
+	}
+	return nil
+}
+
+type ListDebtEntriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Opaque, valid only for the same balance.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BalanceRequest) Reset() {
-	*x = BalanceRequest{}
-	mi := &file_balance_v1_balance_proto_msgTypes[7]
+func (x *ListDebtEntriesRequest) Reset() {
+	*x = ListDebtEntriesRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BalanceRequest) String() string {
+func (x *ListDebtEntriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BalanceRequest) ProtoMessage() {}
+func (*ListDebtEntriesRequest) ProtoMessage() {}
 
-func (x *BalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[7]
+func (x *ListDebtEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,41 +4024,55 @@ func (x *BalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BalanceRequest.ProtoReflect.Descriptor instead.
-func (*BalanceRequest) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListDebtEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListDebtEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *BalanceRequest) GetBalanceId() string {
+func (x *ListDebtEntriesRequest) GetBalanceId() string {
 	if x != nil {
 		return x.BalanceId
 	}
 	return ""
 }
 
-type BalanceResponse struct {
+func (x *ListDebtEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDebtEntriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDebtEntriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BalanceId     string                 `protobuf:"bytes,1,opt,name=balance_id,json=balanceId,proto3" json:"balance_id,omitempty"`
-	Amount        *Decimal               `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Entries       []*DebtEntry           `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Oldest first.
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BalanceResponse) Reset() {
-	*x = BalanceResponse{}
-	mi := &file_balance_v1_balance_proto_msgTypes[8]
+func (x *ListDebtEntriesResponse) Reset() {
+	*x = ListDebtEntriesResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BalanceResponse) String() string {
+func (x *ListDebtEntriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BalanceResponse) ProtoMessage() {}
+func (*ListDebtEntriesResponse) ProtoMessage() {}
 
-func (x *BalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_balance_v1_balance_proto_msgTypes[8]
+func (x *ListDebtEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,23 +4083,23 @@ func (x *BalanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BalanceResponse.ProtoReflect.Descriptor instead.
-func (*BalanceResponse) Descriptor() ([]byte, []int) {
-	return file_balance_v1_balance_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListDebtEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListDebtEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *BalanceResponse) GetBalanceId() string {
+func (x *ListDebtEntriesResponse) GetEntries() []*DebtEntry {
 	if x != nil {
-		return x.BalanceId
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-func (x *BalanceResponse) GetAmount() *Decimal {
+func (x *ListDebtEntriesResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Amount
+		return x.NextPageToken
 	}
-	return nil
+	return ""
 }
 
 var File_balance_v1_balance_proto protoreflect.FileDescriptor
@@ -653,9 +4107,12 @@ var File_balance_v1_balance_proto protoreflect.FileDescriptor
 const file_balance_v1_balance_proto_rawDesc = "" +
 	"\n" +
 	"\x18balance/v1/balance.proto\x12\n" +
-	"balance.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x1f\n" +
-	"\aDecimal\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value\"\xb0\x02\n" +
+	"balance.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"G\n" +
+	"\aDecimal\x12<\n" +
+	"\x05value\x18\x01 \x01(\tB&\xbaH#r!2\x1f^-?[0-9]{1,20}([.][0-9]{1,8})?$R\x05value\"s\n" +
+	"\x11InsufficientFunds\x12-\n" +
+	"\acurrent\x18\x01 \x01(\v2\x13.balance.v1.DecimalR\acurrent\x12/\n" +
+	"\brequired\x18\x02 \x01(\v2\x13.balance.v1.DecimalR\brequired\"\xb6\x04\n" +
 	"\x02Tx\x129\n" +
 	"\n" +
 	"created_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
@@ -666,53 +4123,442 @@ const file_balance_v1_balance_proto_rawDesc = "" +
 	"balance_id\x18\x04 \x01(\tR\tbalanceId\x12*\n" +
 	"\x06source\x18\x05 \x01(\x0e2\x12.balance.v1.SourceR\x06source\x12'\n" +
 	"\x05state\x18\x06 \x01(\x0e2\x11.balance.v1.StateR\x05state\x12+\n" +
-	"\x06amount\x18\a \x01(\v2\x13.balance.v1.DecimalR\x06amount\"\xc7\x01\n" +
-	"\x0fRecordTxRequest\x12\x1d\n" +
+	"\x06amount\x18\a \x01(\v2\x13.balance.v1.DecimalR\x06amount\x12\x10\n" +
+	"\x03seq\x18\b \x01(\x03R\x03seq\x12!\n" +
+	"\fexternal_ref\x18\t \x01(\tR\vexternalRef\x12 \n" +
+	"\fparent_tx_id\x18\n" +
+	" \x01(\tR\n" +
+	"parentTxId\x126\n" +
+	"\fsettled_debt\x18\v \x01(\v2\x13.balance.v1.DecimalR\vsettledDebt\x128\n" +
+	"\bmetadata\x18\f \x03(\v2\x1c.balance.v1.Tx.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcf\x05\n" +
+	"\x0fRecordTxRequest\x12'\n" +
 	"\n" +
-	"balance_id\x18\x01 \x01(\tR\tbalanceId\x12*\n" +
-	"\x06source\x18\x02 \x01(\x0e2\x12.balance.v1.SourceR\x06source\x12'\n" +
-	"\x05state\x18\x03 \x01(\x0e2\x11.balance.v1.StateR\x05state\x12+\n" +
-	"\x06amount\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\x06amount\x12\x13\n" +
-	"\x05tx_id\x18\x05 \x01(\tR\x04txId\"H\n" +
-	"\x10CancelTxsRequest\x12\x1d\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x128\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x12.balance.v1.SourceB\f\xbaH\t\x82\x01\x06\x10\x01 \x00 \x04R\x06source\x123\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x11.balance.v1.StateB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x05state\x12\xa4\x01\n" +
+	"\x06amount\x18\x04 \x01(\v2\x13.balance.v1.DecimalBw\xbaHt\xba\x01n\n" +
+	"\x0famount.positive\x12\x17amount must be positive\x1aB!this.value.startsWith('-') && !this.value.matches('^0+([.]0+)?$')\xc8\x01\x01R\x06amount\x12\x1d\n" +
+	"\x05tx_id\x18\x05 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x04txId\x12+\n" +
+	"\fexternal_ref\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\vexternalRef\x127\n" +
+	"\x10expected_version\x18\a \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\x0fexpectedVersion\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\b \x01(\bR\x06dryRun\x12-\n" +
+	"\fparent_tx_id\x18\t \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\n" +
+	"parentTxId\x12^\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2).balance.v1.RecordTxRequest.MetadataEntryB\x17\xbaH\x14\x9a\x01\x11\x10 \"\x06r\x04\x10\x01\x18@*\x05r\x03\x18\xff\x01R\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x13\n" +
+	"\x11_expected_version\"M\n" +
+	"\aRuleHit\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12.\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x16.balance.v1.RuleActionR\x06action\"\xee\x01\n" +
+	"\x10RecordTxResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x125\n" +
+	"\abalance\x18\x02 \x01(\v2\x1b.balance.v1.BalanceResponseR\abalance\x127\n" +
+	"\vrule_action\x18\x03 \x01(\x0e2\x16.balance.v1.RuleActionR\n" +
+	"ruleAction\x120\n" +
+	"\trule_hits\x18\x04 \x03(\v2\x13.balance.v1.RuleHitR\bruleHits\x12\x1e\n" +
+	"\x02tx\x18\x05 \x01(\v2\x0e.balance.v1.TxR\x02tx\"\xc7\x02\n" +
+	"\x0eRecordTxResult\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x125\n" +
+	"\abalance\x18\x06 \x01(\v2\x1b.balance.v1.BalanceResponseR\abalance\x127\n" +
+	"\vrule_action\x18\a \x01(\x0e2\x16.balance.v1.RuleActionR\n" +
+	"ruleAction\x120\n" +
+	"\trule_hits\x18\b \x03(\v2\x13.balance.v1.RuleHitR\bruleHits\x12\x1e\n" +
+	"\x02tx\x18\t \x01(\v2\x0e.balance.v1.TxR\x02tx\"x\n" +
+	"\fGetTxRequest\x12\x1d\n" +
+	"\x05tx_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x04txId\x12+\n" +
+	"\fexternal_ref\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\vexternalRef:\x1c\xbaH\x19\"\x17\n" +
+	"\x05tx_id\n" +
+	"\fexternal_ref\x10\x01\"\xb7\x02\n" +
+	"\x10CancelTxsRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12*\n" +
+	"\x06tx_ids\x18\x02 \x03(\tB\x13\xbaH\x10\x92\x01\r\b\x01\x10d\x18\x01\"\x05r\x03\xb0\x01\x01R\x05txIds\x127\n" +
+	"\x10expected_version\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\x0fexpectedVersion\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\acascade\x18\x05 \x01(\bR\acascade\x12M\n" +
+	"\x0fnegative_policy\x18\x06 \x01(\x0e2\x1a.balance.v1.NegativePolicyB\b\xbaH\x05\x82\x01\x02\x10\x01R\x0enegativePolicyB\x13\n" +
+	"\x11_expected_version\"\x8a\x02\n" +
+	"\x11CancelTxsResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x125\n" +
+	"\abalance\x18\x02 \x01(\v2\x1b.balance.v1.BalanceResponseR\abalance\x12 \n" +
+	"\x03txs\x18\x03 \x03(\v2\x0e.balance.v1.TxR\x03txs\x123\n" +
+	"\n" +
+	"correction\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\n" +
+	"correction\x12$\n" +
+	"\x0eskipped_tx_ids\x18\x05 \x03(\tR\fskippedTxIds\x12'\n" +
+	"\x04debt\x18\x06 \x01(\v2\x13.balance.v1.DecimalR\x04debt\"\x9e\x04\n" +
+	"\x14CancellationCriteria\x128\n" +
+	"\x06source\x18\x01 \x01(\x0e2\x12.balance.v1.SourceB\f\xbaH\t\x82\x01\x06\x10\x01 \x00 \x04R\x06source\x121\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x11.balance.v1.StateB\b\xbaH\x05\x82\x01\x02\x10\x01R\x05state\x12E\n" +
+	"\fcreated_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\vcreatedFrom\x12A\n" +
+	"\n" +
+	"created_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\tcreatedTo\x128\n" +
+	"\x13external_ref_prefix\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x11externalRefPrefix\x123\n" +
+	"\vbalance_ids\x18\x06 \x03(\tB\x12\xbaH\x0f\x92\x01\f\x10\xe8\a\x18\x01\"\x05r\x03\xb0\x01\x01R\n" +
+	"balanceIds\x12c\n" +
+	"\bmetadata\x18\a \x03(\v2..balance.v1.CancellationCriteria.MetadataEntryB\x17\xbaH\x14\x9a\x01\x11\x10 \"\x06r\x04\x10\x01\x18@*\x05r\x03\x18\xff\x01R\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb1\x01\n" +
+	"\x1aCancelTxsByCriteriaRequest\x12D\n" +
+	"\bcriteria\x18\x01 \x01(\v2 .balance.v1.CancellationCriteriaB\x06\xbaH\x03\xc8\x01\x01R\bcriteria\x12M\n" +
+	"\x0fnegative_policy\x18\x02 \x01(\x0e2\x1a.balance.v1.NegativePolicyB\b\xbaH\x05\x82\x01\x02\x10\x01R\x0enegativePolicy\"\xbf\x04\n" +
+	"\fCancellation\x12'\n" +
+	"\x0fcancellation_id\x18\x01 \x01(\tR\x0ecancellationId\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.balance.v1.CancellationStatusR\x06status\x12<\n" +
+	"\bcriteria\x18\x03 \x01(\v2 .balance.v1.CancellationCriteriaR\bcriteria\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vfinished_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12%\n" +
+	"\x0ebalances_total\x18\a \x01(\x03R\rbalancesTotal\x12#\n" +
+	"\rbalances_done\x18\b \x01(\x03R\fbalancesDone\x12'\n" +
+	"\x0fbalances_failed\x18\t \x01(\x03R\x0ebalancesFailed\x12#\n" +
+	"\rtxs_cancelled\x18\n" +
+	" \x01(\x03R\ftxsCancelled\x12C\n" +
+	"\x0fnegative_policy\x18\v \x01(\x0e2\x1a.balance.v1.NegativePolicyR\x0enegativePolicy\"[\n" +
+	"\x1bCancelTxsByCriteriaResponse\x12<\n" +
+	"\fcancellation\x18\x01 \x01(\v2\x18.balance.v1.CancellationR\fcancellation\"\xdf\x02\n" +
+	"\x13BalanceCancellation\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tR\tbalanceId\x12=\n" +
+	"\x06status\x18\x02 \x01(\x0e2%.balance.v1.BalanceCancellationStatusR\x06status\x12\x15\n" +
+	"\x06tx_ids\x18\x03 \x03(\tR\x05txIds\x123\n" +
+	"\n" +
+	"correction\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\n" +
+	"correction\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\x0eskipped_tx_ids\x18\a \x03(\tR\fskippedTxIds\x12'\n" +
+	"\x04debt\x18\b \x01(\v2\x13.balance.v1.DecimalR\x04debt\"\x93\x01\n" +
+	"\x16GetCancellationRequest\x121\n" +
+	"\x0fcancellation_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x0ecancellationId\x12'\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xbc\x01\n" +
+	"\x17GetCancellationResponse\x12<\n" +
+	"\fcancellation\x18\x01 \x01(\v2\x18.balance.v1.CancellationR\fcancellation\x12;\n" +
+	"\bbalances\x18\x02 \x03(\v2\x1f.balance.v1.BalanceCancellationR\bbalances\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"6\n" +
+	"\x15ListRelatedTxsRequest\x12\x1d\n" +
+	"\x05tx_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x04txId\":\n" +
+	"\x16ListRelatedTxsResponse\x12 \n" +
+	"\x03txs\x18\x01 \x03(\v2\x0e.balance.v1.TxR\x03txs\"\xd0\x04\n" +
+	"\rListTxRequest\x12'\n" +
 	"\n" +
-	"balance_id\x18\x01 \x01(\tR\tbalanceId\x12\x15\n" +
-	"\x06tx_ids\x18\x02 \x03(\tR\x05txIds\"\x93\x01\n" +
-	"\rListTxRequest\x12\x1d\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\x12'\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"balance_id\x18\x01 \x01(\tR\tbalanceId\x12'\n" +
-	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x124\n" +
+	"\x06source\x18\x05 \x01(\x0e2\x12.balance.v1.SourceB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06source\x121\n" +
+	"\x05state\x18\x06 \x01(\x0e2\x11.balance.v1.StateB\b\xbaH\x05\x82\x01\x02\x10\x01R\x05state\x12=\n" +
+	"\fcreated_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
 	"\n" +
-	"page_token\x18\x04 \x01(\tR\tpageToken\"Z\n" +
+	"created_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x122\n" +
+	"\n" +
+	"min_amount\x18\t \x01(\v2\x13.balance.v1.DecimalR\tminAmount\x122\n" +
+	"\n" +
+	"max_amount\x18\n" +
+	" \x01(\v2\x13.balance.v1.DecimalR\tmaxAmount\x12%\n" +
+	"\x0ecancelled_only\x18\v \x01(\bR\rcancelledOnly\x123\n" +
+	"\x05order\x18\f \x01(\x0e2\x13.balance.v1.TxOrderB\b\xbaH\x05\x82\x01\x02\x10\x01R\x05order\"Z\n" +
 	"\x0eListTxResponse\x12 \n" +
 	"\x03txs\x18\x01 \x03(\v2\x0e.balance.v1.TxR\x03txs\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"3\n" +
-	"\x12OpenBalanceRequest\x12\x1d\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x94\x02\n" +
+	"\x12OpenBalanceRequest\x12'\n" +
 	"\n" +
-	"balance_id\x18\x01 \x01(\tR\tbalanceId\"/\n" +
-	"\x0eBalanceRequest\x12\x1d\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12&\n" +
+	"\bowner_id\x18\x02 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\aownerId\x128\n" +
+	"\x13external_player_ref\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x11externalPlayerRef\x120\n" +
+	"\bcurrency\x18\x04 \x01(\tB\x14\xbaH\x11\xd8\x01\x01r\f2\n" +
+	"^[A-Z]{3}$R\bcurrency\x12A\n" +
+	"\vwallet_type\x18\x05 \x01(\x0e2\x16.balance.v1.WalletTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"walletType\"9\n" +
+	"\x0eBalanceRequest\x12'\n" +
 	"\n" +
-	"balance_id\x18\x01 \x01(\tR\tbalanceId\"]\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\"\xca\x04\n" +
 	"\x0fBalanceResponse\x12\x1d\n" +
 	"\n" +
 	"balance_id\x18\x01 \x01(\tR\tbalanceId\x12+\n" +
-	"\x06amount\x18\x02 \x01(\v2\x13.balance.v1.DecimalR\x06amount*Y\n" +
+	"\x06amount\x18\x02 \x01(\v2\x13.balance.v1.DecimalR\x06amount\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.balance.v1.BalanceStatusR\x06status\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12.\n" +
+	"\x13external_player_ref\x18\x05 \x01(\tR\x11externalPlayerRef\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x127\n" +
+	"\vwallet_type\x18\a \x01(\x0e2\x16.balance.v1.WalletTypeR\n" +
+	"walletType\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12D\n" +
+	"\x10last_activity_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\x12\x19\n" +
+	"\btx_count\x18\v \x01(\x03R\atxCount\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x12'\n" +
+	"\x04debt\x18\r \x01(\v2\x13.balance.v1.DecimalR\x04debt\"\xc0\x06\n" +
+	"\x13ListBalancesRequest\x12&\n" +
+	"\bowner_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\aownerId\x12'\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12;\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x19.balance.v1.BalanceStatusB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06status\x122\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\v2\x13.balance.v1.DecimalR\tminAmount\x122\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\v2\x13.balance.v1.DecimalR\tmaxAmount\x12=\n" +
+	"\fcreated_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\factive_since\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vactiveSince\x12?\n" +
+	"\asort_by\x18\n" +
+	" \x01(\x0e2\x1c.balance.v1.BalanceSortFieldB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\v \x01(\bR\n" +
+	"descending\x12=\n" +
+	"\fupdated_from\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedTo\x12.\n" +
+	"\fmin_tx_count\x18\x0e \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\n" +
+	"minTxCount\x88\x01\x01\x12.\n" +
+	"\fmax_tx_count\x18\x0f \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x01R\n" +
+	"maxTxCount\x88\x01\x01B\x0f\n" +
+	"\r_min_tx_countB\x0f\n" +
+	"\r_max_tx_count\"w\n" +
+	"\x14ListBalancesResponse\x127\n" +
+	"\bbalances\x18\x01 \x03(\v2\x1b.balance.v1.BalanceResponseR\bbalances\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"d\n" +
+	"\x13WatchBalanceRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12$\n" +
+	"\tafter_seq\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bafterSeq\"\xd4\x02\n" +
+	"\fBalanceEvent\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x120\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1c.balance.v1.BalanceEventKindR\x04kind\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12+\n" +
+	"\x06amount\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\x06amount\x121\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x19.balance.v1.BalanceStatusR\x06status\x12 \n" +
+	"\x03txs\x18\x06 \x03(\v2\x0e.balance.v1.TxR\x03txs\x12C\n" +
+	"\x0fnegative_policy\x18\a \x01(\x0e2\x1a.balance.v1.NegativePolicyR\x0enegativePolicy\"\xdc\x01\n" +
+	"\x16ExportStatementRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12=\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x1b.balance.v1.StatementFormatB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06format\"$\n" +
+	"\x0eStatementChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x86\x02\n" +
+	"\x10AggregateRequest\x12*\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\tbalanceId\x126\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\x04from\x122\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\x02to\x12=\n" +
+	"\x06bucket\x18\x04 \x01(\x0e2\x1b.balance.v1.AggregateBucketB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06bucket\x12\x1b\n" +
+	"\ttime_zone\x18\x05 \x01(\tR\btimeZone\"\xe5\x01\n" +
+	"\fAggregateRow\x12=\n" +
+	"\fbucket_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vbucketStart\x12*\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x12.balance.v1.SourceR\x06source\x12'\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x11.balance.v1.StateR\x05state\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12+\n" +
+	"\x06amount\x18\x05 \x01(\v2\x13.balance.v1.DecimalR\x06amount\"\x80\x02\n" +
+	"\aRevenue\x12=\n" +
+	"\fbucket_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vbucketStart\x12%\n" +
+	"\x03ggr\x18\x02 \x01(\v2\x13.balance.v1.DecimalR\x03ggr\x12/\n" +
+	"\bturnover\x18\x03 \x01(\v2\x13.balance.v1.DecimalR\bturnover\x12/\n" +
+	"\bdeposits\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\bdeposits\x12-\n" +
+	"\apayouts\x18\x05 \x01(\v2\x13.balance.v1.DecimalR\apayouts\"r\n" +
+	"\x11AggregateResponse\x12,\n" +
+	"\x04rows\x18\x01 \x03(\v2\x18.balance.v1.AggregateRowR\x04rows\x12/\n" +
+	"\brevenues\x18\x02 \x03(\v2\x13.balance.v1.RevenueR\brevenues\"\xdc\x01\n" +
+	"\fFlaggedEvent\x129\n" +
+	"\n" +
+	"created_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x03 \x01(\tR\tbalanceId\x12\x13\n" +
+	"\x05tx_id\x18\x04 \x01(\tR\x04txId\x12\x12\n" +
+	"\x04rule\x18\x05 \x01(\tR\x04rule\x12.\n" +
+	"\x06action\x18\x06 \x01(\x0e2\x16.balance.v1.RuleActionR\x06action\"\x8e\x01\n" +
+	"\x18ListFlaggedEventsRequest\x12*\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\tbalanceId\x12'\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"u\n" +
+	"\x19ListFlaggedEventsResponse\x120\n" +
+	"\x06events\x18\x01 \x03(\v2\x18.balance.v1.FlaggedEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"}\n" +
+	"\x11ChargebackRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12\x1d\n" +
+	"\x05tx_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x04txId\x12 \n" +
+	"\x06reason\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x06reason\"\xc3\x02\n" +
+	"\n" +
+	"Chargeback\x12#\n" +
+	"\rchargeback_id\x18\x01 \x01(\tR\fchargebackId\x12\x1d\n" +
+	"\n" +
+	"balance_id\x18\x02 \x01(\tR\tbalanceId\x12\x13\n" +
+	"\x05tx_id\x18\x03 \x01(\tR\x04txId\x12+\n" +
+	"\x06amount\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\x06amount\x123\n" +
+	"\n" +
+	"correction\x18\x05 \x01(\v2\x13.balance.v1.DecimalR\n" +
+	"correction\x12'\n" +
+	"\x04debt\x18\x06 \x01(\v2\x13.balance.v1.DecimalR\x04debt\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa3\x01\n" +
+	"\x12ChargebackResponse\x126\n" +
+	"\n" +
+	"chargeback\x18\x01 \x01(\v2\x16.balance.v1.ChargebackR\n" +
+	"chargeback\x12\x1e\n" +
+	"\x02tx\x18\x02 \x01(\v2\x0e.balance.v1.TxR\x02tx\x125\n" +
+	"\abalance\x18\x03 \x01(\v2\x1b.balance.v1.BalanceResponseR\abalance\"\xf4\x01\n" +
+	"\tDebtEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12-\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x19.balance.v1.DebtEntryKindR\x04kind\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12+\n" +
+	"\x06amount\x18\x04 \x01(\v2\x13.balance.v1.DecimalR\x06amount\x12'\n" +
+	"\x04debt\x18\x05 \x01(\v2\x13.balance.v1.DecimalR\x04debt\x12\x15\n" +
+	"\x06tx_ids\x18\x06 \x03(\tR\x05txIds\"\x89\x01\n" +
+	"\x16ListDebtEntriesRequest\x12'\n" +
+	"\n" +
+	"balance_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tbalanceId\x12'\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"r\n" +
+	"\x17ListDebtEntriesResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.balance.v1.DebtEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*p\n" +
 	"\x06Source\x12\x16\n" +
 	"\x12SOURCE_UNSPECIFIED\x10\x00\x12\x0f\n" +
 	"\vSOURCE_GAME\x10\x01\x12\x12\n" +
 	"\x0eSOURCE_PAYMENT\x10\x02\x12\x12\n" +
-	"\x0eSOURCE_SERVICE\x10\x03*E\n" +
+	"\x0eSOURCE_SERVICE\x10\x03\x12\x15\n" +
+	"\x11SOURCE_ADJUSTMENT\x10\x04*E\n" +
 	"\x05State\x12\x15\n" +
 	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rSTATE_DEPOSIT\x10\x01\x12\x12\n" +
-	"\x0eSTATE_WITHDRAW\x10\x022\xea\x02\n" +
-	"\x0eBalanceService\x12A\n" +
-	"\bRecordTx\x12\x1b.balance.v1.RecordTxRequest\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
-	"\tCancelTxs\x12\x1c.balance.v1.CancelTxsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12A\n" +
-	"\x06ListTx\x12\x19.balance.v1.ListTxRequest\x1a\x1a.balance.v1.ListTxResponse\"\x00\x12G\n" +
-	"\vOpenBalance\x12\x1e.balance.v1.OpenBalanceRequest\x1a\x16.google.protobuf.Empty\"\x00\x12D\n" +
-	"\aBalance\x12\x1a.balance.v1.BalanceRequest\x1a\x1b.balance.v1.BalanceResponse\"\x00B\xaf\x01\n" +
+	"\x0eSTATE_WITHDRAW\x10\x02*Y\n" +
+	"\aTxOrder\x12\x18\n" +
+	"\x14TX_ORDER_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15TX_ORDER_NEWEST_FIRST\x10\x01\x12\x19\n" +
+	"\x15TX_ORDER_OLDEST_FIRST\x10\x02*\x8c\x01\n" +
+	"\rBalanceStatus\x12\x1e\n" +
+	"\x1aBALANCE_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BALANCE_STATUS_ACTIVE\x10\x01\x12\x19\n" +
+	"\x15BALANCE_STATUS_FROZEN\x10\x02\x12%\n" +
+	"!BALANCE_STATUS_WITHDRAWALS_FROZEN\x10\x03*V\n" +
+	"\n" +
+	"WalletType\x12\x1b\n" +
+	"\x17WALLET_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10WALLET_TYPE_MAIN\x10\x01\x12\x15\n" +
+	"\x11WALLET_TYPE_BONUS\x10\x02*\xe5\x01\n" +
+	"\x10BalanceSortField\x12\"\n" +
+	"\x1eBALANCE_SORT_FIELD_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dBALANCE_SORT_FIELD_CREATED_AT\x10\x01\x12\x1d\n" +
+	"\x19BALANCE_SORT_FIELD_AMOUNT\x10\x02\x12!\n" +
+	"\x1dBALANCE_SORT_FIELD_UPDATED_AT\x10\x03\x12'\n" +
+	"#BALANCE_SORT_FIELD_LAST_ACTIVITY_AT\x10\x04\x12\x1f\n" +
+	"\x1bBALANCE_SORT_FIELD_TX_COUNT\x10\x05*\xc8\x01\n" +
+	"\x10BalanceEventKind\x12\"\n" +
+	"\x1eBALANCE_EVENT_KIND_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bBALANCE_EVENT_KIND_SNAPSHOT\x10\x01\x12\"\n" +
+	"\x1eBALANCE_EVENT_KIND_TX_RECORDED\x10\x02\x12$\n" +
+	" BALANCE_EVENT_KIND_TXS_CANCELLED\x10\x03\x12%\n" +
+	"!BALANCE_EVENT_KIND_STATUS_CHANGED\x10\x04*i\n" +
+	"\x0fStatementFormat\x12 \n" +
+	"\x1cSTATEMENT_FORMAT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14STATEMENT_FORMAT_CSV\x10\x01\x12\x1a\n" +
+	"\x16STATEMENT_FORMAT_JSONL\x10\x02*\x84\x01\n" +
+	"\x0fAggregateBucket\x12 \n" +
+	"\x1cAGGREGATE_BUCKET_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15AGGREGATE_BUCKET_HOUR\x10\x01\x12\x18\n" +
+	"\x14AGGREGATE_BUCKET_DAY\x10\x02\x12\x1a\n" +
+	"\x16AGGREGATE_BUCKET_MONTH\x10\x03*\xfa\x03\n" +
+	"\vErrorReason\x12\x1c\n" +
+	"\x18ERROR_REASON_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dERROR_REASON_INVALID_ARGUMENT\x10\x01\x12\"\n" +
+	"\x1eERROR_REASON_BALANCE_NOT_FOUND\x10\x02\x12\x1d\n" +
+	"\x19ERROR_REASON_TX_NOT_FOUND\x10\x03\x12'\n" +
+	"#ERROR_REASON_BALANCE_ALREADY_EXISTS\x10\x04\x12\"\n" +
+	"\x1eERROR_REASON_TX_ALREADY_EXISTS\x10\x05\x12#\n" +
+	"\x1fERROR_REASON_INSUFFICIENT_FUNDS\x10\x06\x12\x1c\n" +
+	"\x18ERROR_REASON_TX_REJECTED\x10\a\x12\x1f\n" +
+	"\x1bERROR_REASON_BALANCE_FROZEN\x10\b\x12!\n" +
+	"\x1dERROR_REASON_VERSION_MISMATCH\x10\t\x12$\n" +
+	" ERROR_REASON_PARENT_TX_NOT_FOUND\x10\n" +
+	"\x12 \n" +
+	"\x1cERROR_REASON_TX_HAS_CHILDREN\x10\v\x12'\n" +
+	"#ERROR_REASON_CANCELLATION_NOT_FOUND\x10\f\x12\"\n" +
+	"\x1eERROR_REASON_TX_NOT_CHARGEABLE\x10\r*\x99\x01\n" +
+	"\x12CancellationStatus\x12#\n" +
+	"\x1fCANCELLATION_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCANCELLATION_STATUS_PENDING\x10\x01\x12\x1f\n" +
+	"\x1bCANCELLATION_STATUS_RUNNING\x10\x02\x12\x1c\n" +
+	"\x18CANCELLATION_STATUS_DONE\x10\x03*\xbf\x01\n" +
+	"\x19BalanceCancellationStatus\x12+\n" +
+	"'BALANCE_CANCELLATION_STATUS_UNSPECIFIED\x10\x00\x12'\n" +
+	"#BALANCE_CANCELLATION_STATUS_PENDING\x10\x01\x12$\n" +
+	" BALANCE_CANCELLATION_STATUS_DONE\x10\x02\x12&\n" +
+	"\"BALANCE_CANCELLATION_STATUS_FAILED\x10\x03*\x7f\n" +
+	"\x0eNegativePolicy\x12\x1f\n" +
+	"\x1bNEGATIVE_POLICY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14NEGATIVE_POLICY_FAIL\x10\x01\x12\x18\n" +
+	"\x14NEGATIVE_POLICY_SKIP\x10\x02\x12\x18\n" +
+	"\x14NEGATIVE_POLICY_DEBT\x10\x03*\x8b\x01\n" +
+	"\rDebtEntryKind\x12\x1f\n" +
+	"\x1bDEBT_ENTRY_KIND_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18DEBT_ENTRY_KIND_INCURRED\x10\x01\x12\x1b\n" +
+	"\x17DEBT_ENTRY_KIND_SETTLED\x10\x02\x12\x1e\n" +
+	"\x1aDEBT_ENTRY_KIND_REINSTATED\x10\x03*\x86\x01\n" +
+	"\n" +
+	"RuleAction\x12\x1b\n" +
+	"\x17RULE_ACTION_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11RULE_ACTION_ALLOW\x10\x01\x12\x14\n" +
+	"\x10RULE_ACTION_FLAG\x10\x02\x12\x16\n" +
+	"\x12RULE_ACTION_REJECT\x10\x03\x12\x16\n" +
+	"\x12RULE_ACTION_FREEZE\x10\x042\xfd\n" +
+	"\n" +
+	"\x0eBalanceService\x12G\n" +
+	"\bRecordTx\x12\x1b.balance.v1.RecordTxRequest\x1a\x1c.balance.v1.RecordTxResponse\"\x00\x12O\n" +
+	"\x0eRecordTxStream\x12\x1b.balance.v1.RecordTxRequest\x1a\x1a.balance.v1.RecordTxResult\"\x00(\x010\x01\x12J\n" +
+	"\tCancelTxs\x12\x1c.balance.v1.CancelTxsRequest\x1a\x1d.balance.v1.CancelTxsResponse\"\x00\x12A\n" +
+	"\x06ListTx\x12\x19.balance.v1.ListTxRequest\x1a\x1a.balance.v1.ListTxResponse\"\x00\x123\n" +
+	"\x05GetTx\x12\x18.balance.v1.GetTxRequest\x1a\x0e.balance.v1.Tx\"\x00\x12Y\n" +
+	"\x0eListRelatedTxs\x12!.balance.v1.ListRelatedTxsRequest\x1a\".balance.v1.ListRelatedTxsResponse\"\x00\x12h\n" +
+	"\x13CancelTxsByCriteria\x12&.balance.v1.CancelTxsByCriteriaRequest\x1a'.balance.v1.CancelTxsByCriteriaResponse\"\x00\x12\\\n" +
+	"\x0fGetCancellation\x12\".balance.v1.GetCancellationRequest\x1a#.balance.v1.GetCancellationResponse\"\x00\x12L\n" +
+	"\vOpenBalance\x12\x1e.balance.v1.OpenBalanceRequest\x1a\x1b.balance.v1.BalanceResponse\"\x00\x12D\n" +
+	"\aBalance\x12\x1a.balance.v1.BalanceRequest\x1a\x1b.balance.v1.BalanceResponse\"\x00\x12S\n" +
+	"\fListBalances\x12\x1f.balance.v1.ListBalancesRequest\x1a .balance.v1.ListBalancesResponse\"\x00\x12M\n" +
+	"\fWatchBalance\x12\x1f.balance.v1.WatchBalanceRequest\x1a\x18.balance.v1.BalanceEvent\"\x000\x01\x12U\n" +
+	"\x0fExportStatement\x12\".balance.v1.ExportStatementRequest\x1a\x1a.balance.v1.StatementChunk\"\x000\x01\x12J\n" +
+	"\tAggregate\x12\x1c.balance.v1.AggregateRequest\x1a\x1d.balance.v1.AggregateResponse\"\x00\x12b\n" +
+	"\x11ListFlaggedEvents\x12$.balance.v1.ListFlaggedEventsRequest\x1a%.balance.v1.ListFlaggedEventsResponse\"\x00\x12\\\n" +
+	"\x0fListDebtEntries\x12\".balance.v1.ListDebtEntriesRequest\x1a#.balance.v1.ListDebtEntriesResponse\"\x00\x12M\n" +
+	"\n" +
+	"Chargeback\x12\x1d.balance.v1.ChargebackRequest\x1a\x1e.balance.v1.ChargebackResponse\"\x00B\xaf\x01\n" +
 	"\x0ecom.balance.v1B\fBalanceProtoP\x01ZFgithub.com/iskorotkov/igaming-balance-backend/gen/balance/v1;balancev1\xa2\x02\x03BXX\xaa\x02\n" +
 	"Balance.V1\xca\x02\n" +
 	"Balance\\V1\xe2\x02\x16Balance\\V1\\GPBMetadata\xea\x02\vBalance::V1b\x06proto3"
@@ -729,49 +4575,224 @@ func file_balance_v1_balance_proto_rawDescGZIP() []byte {
 	return file_balance_v1_balance_proto_rawDescData
 }
 
-var file_balance_v1_balance_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_balance_v1_balance_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_balance_v1_balance_proto_enumTypes = make([]protoimpl.EnumInfo, 15)
+var file_balance_v1_balance_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_balance_v1_balance_proto_goTypes = []any{
-	(Source)(0),                   // 0: balance.v1.Source
-	(State)(0),                    // 1: balance.v1.State
-	(*Decimal)(nil),               // 2: balance.v1.Decimal
-	(*Tx)(nil),                    // 3: balance.v1.Tx
-	(*RecordTxRequest)(nil),       // 4: balance.v1.RecordTxRequest
-	(*CancelTxsRequest)(nil),      // 5: balance.v1.CancelTxsRequest
-	(*ListTxRequest)(nil),         // 6: balance.v1.ListTxRequest
-	(*ListTxResponse)(nil),        // 7: balance.v1.ListTxResponse
-	(*OpenBalanceRequest)(nil),    // 8: balance.v1.OpenBalanceRequest
-	(*BalanceRequest)(nil),        // 9: balance.v1.BalanceRequest
-	(*BalanceResponse)(nil),       // 10: balance.v1.BalanceResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+	(Source)(0),                         // 0: balance.v1.Source
+	(State)(0),                          // 1: balance.v1.State
+	(TxOrder)(0),                        // 2: balance.v1.TxOrder
+	(BalanceStatus)(0),                  // 3: balance.v1.BalanceStatus
+	(WalletType)(0),                     // 4: balance.v1.WalletType
+	(BalanceSortField)(0),               // 5: balance.v1.BalanceSortField
+	(BalanceEventKind)(0),               // 6: balance.v1.BalanceEventKind
+	(StatementFormat)(0),                // 7: balance.v1.StatementFormat
+	(AggregateBucket)(0),                // 8: balance.v1.AggregateBucket
+	(ErrorReason)(0),                    // 9: balance.v1.ErrorReason
+	(CancellationStatus)(0),             // 10: balance.v1.CancellationStatus
+	(BalanceCancellationStatus)(0),      // 11: balance.v1.BalanceCancellationStatus
+	(NegativePolicy)(0),                 // 12: balance.v1.NegativePolicy
+	(DebtEntryKind)(0),                  // 13: balance.v1.DebtEntryKind
+	(RuleAction)(0),                     // 14: balance.v1.RuleAction
+	(*Decimal)(nil),                     // 15: balance.v1.Decimal
+	(*InsufficientFunds)(nil),           // 16: balance.v1.InsufficientFunds
+	(*Tx)(nil),                          // 17: balance.v1.Tx
+	(*RecordTxRequest)(nil),             // 18: balance.v1.RecordTxRequest
+	(*RuleHit)(nil),                     // 19: balance.v1.RuleHit
+	(*RecordTxResponse)(nil),            // 20: balance.v1.RecordTxResponse
+	(*RecordTxResult)(nil),              // 21: balance.v1.RecordTxResult
+	(*GetTxRequest)(nil),                // 22: balance.v1.GetTxRequest
+	(*CancelTxsRequest)(nil),            // 23: balance.v1.CancelTxsRequest
+	(*CancelTxsResponse)(nil),           // 24: balance.v1.CancelTxsResponse
+	(*CancellationCriteria)(nil),        // 25: balance.v1.CancellationCriteria
+	(*CancelTxsByCriteriaRequest)(nil),  // 26: balance.v1.CancelTxsByCriteriaRequest
+	(*Cancellation)(nil),                // 27: balance.v1.Cancellation
+	(*CancelTxsByCriteriaResponse)(nil), // 28: balance.v1.CancelTxsByCriteriaResponse
+	(*BalanceCancellation)(nil),         // 29: balance.v1.BalanceCancellation
+	(*GetCancellationRequest)(nil),      // 30: balance.v1.GetCancellationRequest
+	(*GetCancellationResponse)(nil),     // 31: balance.v1.GetCancellationResponse
+	(*ListRelatedTxsRequest)(nil),       // 32: balance.v1.ListRelatedTxsRequest
+	(*ListRelatedTxsResponse)(nil),      // 33: balance.v1.ListRelatedTxsResponse
+	(*ListTxRequest)(nil),               // 34: balance.v1.ListTxRequest
+	(*ListTxResponse)(nil),              // 35: balance.v1.ListTxResponse
+	(*OpenBalanceRequest)(nil),          // 36: balance.v1.OpenBalanceRequest
+	(*BalanceRequest)(nil),              // 37: balance.v1.BalanceRequest
+	(*BalanceResponse)(nil),             // 38: balance.v1.BalanceResponse
+	(*ListBalancesRequest)(nil),         // 39: balance.v1.ListBalancesRequest
+	(*ListBalancesResponse)(nil),        // 40: balance.v1.ListBalancesResponse
+	(*WatchBalanceRequest)(nil),         // 41: balance.v1.WatchBalanceRequest
+	(*BalanceEvent)(nil),                // 42: balance.v1.BalanceEvent
+	(*ExportStatementRequest)(nil),      // 43: balance.v1.ExportStatementRequest
+	(*StatementChunk)(nil),              // 44: balance.v1.StatementChunk
+	(*AggregateRequest)(nil),            // 45: balance.v1.AggregateRequest
+	(*AggregateRow)(nil),                // 46: balance.v1.AggregateRow
+	(*Revenue)(nil),                     // 47: balance.v1.Revenue
+	(*AggregateResponse)(nil),           // 48: balance.v1.AggregateResponse
+	(*FlaggedEvent)(nil),                // 49: balance.v1.FlaggedEvent
+	(*ListFlaggedEventsRequest)(nil),    // 50: balance.v1.ListFlaggedEventsRequest
+	(*ListFlaggedEventsResponse)(nil),   // 51: balance.v1.ListFlaggedEventsResponse
+	(*ChargebackRequest)(nil),           // 52: balance.v1.ChargebackRequest
+	(*Chargeback)(nil),                  // 53: balance.v1.Chargeback
+	(*ChargebackResponse)(nil),          // 54: balance.v1.ChargebackResponse
+	(*DebtEntry)(nil),                   // 55: balance.v1.DebtEntry
+	(*ListDebtEntriesRequest)(nil),      // 56: balance.v1.ListDebtEntriesRequest
+	(*ListDebtEntriesResponse)(nil),     // 57: balance.v1.ListDebtEntriesResponse
+	nil,                                 // 58: balance.v1.Tx.MetadataEntry
+	nil,                                 // 59: balance.v1.RecordTxRequest.MetadataEntry
+	nil,                                 // 60: balance.v1.CancellationCriteria.MetadataEntry
+	(*timestamppb.Timestamp)(nil),       // 61: google.protobuf.Timestamp
 }
 var file_balance_v1_balance_proto_depIdxs = []int32{
-	11, // 0: balance.v1.Tx.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: balance.v1.Tx.deleted_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: balance.v1.Tx.source:type_name -> balance.v1.Source
-	1,  // 3: balance.v1.Tx.state:type_name -> balance.v1.State
-	2,  // 4: balance.v1.Tx.amount:type_name -> balance.v1.Decimal
-	0,  // 5: balance.v1.RecordTxRequest.source:type_name -> balance.v1.Source
-	1,  // 6: balance.v1.RecordTxRequest.state:type_name -> balance.v1.State
-	2,  // 7: balance.v1.RecordTxRequest.amount:type_name -> balance.v1.Decimal
-	3,  // 8: balance.v1.ListTxResponse.txs:type_name -> balance.v1.Tx
-	2,  // 9: balance.v1.BalanceResponse.amount:type_name -> balance.v1.Decimal
-	4,  // 10: balance.v1.BalanceService.RecordTx:input_type -> balance.v1.RecordTxRequest
-	5,  // 11: balance.v1.BalanceService.CancelTxs:input_type -> balance.v1.CancelTxsRequest
-	6,  // 12: balance.v1.BalanceService.ListTx:input_type -> balance.v1.ListTxRequest
-	8,  // 13: balance.v1.BalanceService.OpenBalance:input_type -> balance.v1.OpenBalanceRequest
-	9,  // 14: balance.v1.BalanceService.Balance:input_type -> balance.v1.BalanceRequest
-	12, // 15: balance.v1.BalanceService.RecordTx:output_type -> google.protobuf.Empty
-	12, // 16: balance.v1.BalanceService.CancelTxs:output_type -> google.protobuf.Empty
-	7,  // 17: balance.v1.BalanceService.ListTx:output_type -> balance.v1.ListTxResponse
-	12, // 18: balance.v1.BalanceService.OpenBalance:output_type -> google.protobuf.Empty
-	10, // 19: balance.v1.BalanceService.Balance:output_type -> balance.v1.BalanceResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	15,  // 0: balance.v1.InsufficientFunds.current:type_name -> balance.v1.Decimal
+	15,  // 1: balance.v1.InsufficientFunds.required:type_name -> balance.v1.Decimal
+	61,  // 2: balance.v1.Tx.created_at:type_name -> google.protobuf.Timestamp
+	61,  // 3: balance.v1.Tx.deleted_at:type_name -> google.protobuf.Timestamp
+	0,   // 4: balance.v1.Tx.source:type_name -> balance.v1.Source
+	1,   // 5: balance.v1.Tx.state:type_name -> balance.v1.State
+	15,  // 6: balance.v1.Tx.amount:type_name -> balance.v1.Decimal
+	15,  // 7: balance.v1.Tx.settled_debt:type_name -> balance.v1.Decimal
+	58,  // 8: balance.v1.Tx.metadata:type_name -> balance.v1.Tx.MetadataEntry
+	0,   // 9: balance.v1.RecordTxRequest.source:type_name -> balance.v1.Source
+	1,   // 10: balance.v1.RecordTxRequest.state:type_name -> balance.v1.State
+	15,  // 11: balance.v1.RecordTxRequest.amount:type_name -> balance.v1.Decimal
+	59,  // 12: balance.v1.RecordTxRequest.metadata:type_name -> balance.v1.RecordTxRequest.MetadataEntry
+	14,  // 13: balance.v1.RuleHit.action:type_name -> balance.v1.RuleAction
+	38,  // 14: balance.v1.RecordTxResponse.balance:type_name -> balance.v1.BalanceResponse
+	14,  // 15: balance.v1.RecordTxResponse.rule_action:type_name -> balance.v1.RuleAction
+	19,  // 16: balance.v1.RecordTxResponse.rule_hits:type_name -> balance.v1.RuleHit
+	17,  // 17: balance.v1.RecordTxResponse.tx:type_name -> balance.v1.Tx
+	38,  // 18: balance.v1.RecordTxResult.balance:type_name -> balance.v1.BalanceResponse
+	14,  // 19: balance.v1.RecordTxResult.rule_action:type_name -> balance.v1.RuleAction
+	19,  // 20: balance.v1.RecordTxResult.rule_hits:type_name -> balance.v1.RuleHit
+	17,  // 21: balance.v1.RecordTxResult.tx:type_name -> balance.v1.Tx
+	12,  // 22: balance.v1.CancelTxsRequest.negative_policy:type_name -> balance.v1.NegativePolicy
+	38,  // 23: balance.v1.CancelTxsResponse.balance:type_name -> balance.v1.BalanceResponse
+	17,  // 24: balance.v1.CancelTxsResponse.txs:type_name -> balance.v1.Tx
+	15,  // 25: balance.v1.CancelTxsResponse.correction:type_name -> balance.v1.Decimal
+	15,  // 26: balance.v1.CancelTxsResponse.debt:type_name -> balance.v1.Decimal
+	0,   // 27: balance.v1.CancellationCriteria.source:type_name -> balance.v1.Source
+	1,   // 28: balance.v1.CancellationCriteria.state:type_name -> balance.v1.State
+	61,  // 29: balance.v1.CancellationCriteria.created_from:type_name -> google.protobuf.Timestamp
+	61,  // 30: balance.v1.CancellationCriteria.created_to:type_name -> google.protobuf.Timestamp
+	60,  // 31: balance.v1.CancellationCriteria.metadata:type_name -> balance.v1.CancellationCriteria.MetadataEntry
+	25,  // 32: balance.v1.CancelTxsByCriteriaRequest.criteria:type_name -> balance.v1.CancellationCriteria
+	12,  // 33: balance.v1.CancelTxsByCriteriaRequest.negative_policy:type_name -> balance.v1.NegativePolicy
+	10,  // 34: balance.v1.Cancellation.status:type_name -> balance.v1.CancellationStatus
+	25,  // 35: balance.v1.Cancellation.criteria:type_name -> balance.v1.CancellationCriteria
+	61,  // 36: balance.v1.Cancellation.created_at:type_name -> google.protobuf.Timestamp
+	61,  // 37: balance.v1.Cancellation.updated_at:type_name -> google.protobuf.Timestamp
+	61,  // 38: balance.v1.Cancellation.finished_at:type_name -> google.protobuf.Timestamp
+	12,  // 39: balance.v1.Cancellation.negative_policy:type_name -> balance.v1.NegativePolicy
+	27,  // 40: balance.v1.CancelTxsByCriteriaResponse.cancellation:type_name -> balance.v1.Cancellation
+	11,  // 41: balance.v1.BalanceCancellation.status:type_name -> balance.v1.BalanceCancellationStatus
+	15,  // 42: balance.v1.BalanceCancellation.correction:type_name -> balance.v1.Decimal
+	61,  // 43: balance.v1.BalanceCancellation.updated_at:type_name -> google.protobuf.Timestamp
+	15,  // 44: balance.v1.BalanceCancellation.debt:type_name -> balance.v1.Decimal
+	27,  // 45: balance.v1.GetCancellationResponse.cancellation:type_name -> balance.v1.Cancellation
+	29,  // 46: balance.v1.GetCancellationResponse.balances:type_name -> balance.v1.BalanceCancellation
+	17,  // 47: balance.v1.ListRelatedTxsResponse.txs:type_name -> balance.v1.Tx
+	0,   // 48: balance.v1.ListTxRequest.source:type_name -> balance.v1.Source
+	1,   // 49: balance.v1.ListTxRequest.state:type_name -> balance.v1.State
+	61,  // 50: balance.v1.ListTxRequest.created_from:type_name -> google.protobuf.Timestamp
+	61,  // 51: balance.v1.ListTxRequest.created_to:type_name -> google.protobuf.Timestamp
+	15,  // 52: balance.v1.ListTxRequest.min_amount:type_name -> balance.v1.Decimal
+	15,  // 53: balance.v1.ListTxRequest.max_amount:type_name -> balance.v1.Decimal
+	2,   // 54: balance.v1.ListTxRequest.order:type_name -> balance.v1.TxOrder
+	17,  // 55: balance.v1.ListTxResponse.txs:type_name -> balance.v1.Tx
+	4,   // 56: balance.v1.OpenBalanceRequest.wallet_type:type_name -> balance.v1.WalletType
+	15,  // 57: balance.v1.BalanceResponse.amount:type_name -> balance.v1.Decimal
+	3,   // 58: balance.v1.BalanceResponse.status:type_name -> balance.v1.BalanceStatus
+	4,   // 59: balance.v1.BalanceResponse.wallet_type:type_name -> balance.v1.WalletType
+	61,  // 60: balance.v1.BalanceResponse.created_at:type_name -> google.protobuf.Timestamp
+	61,  // 61: balance.v1.BalanceResponse.updated_at:type_name -> google.protobuf.Timestamp
+	61,  // 62: balance.v1.BalanceResponse.last_activity_at:type_name -> google.protobuf.Timestamp
+	15,  // 63: balance.v1.BalanceResponse.debt:type_name -> balance.v1.Decimal
+	3,   // 64: balance.v1.ListBalancesRequest.status:type_name -> balance.v1.BalanceStatus
+	15,  // 65: balance.v1.ListBalancesRequest.min_amount:type_name -> balance.v1.Decimal
+	15,  // 66: balance.v1.ListBalancesRequest.max_amount:type_name -> balance.v1.Decimal
+	61,  // 67: balance.v1.ListBalancesRequest.created_from:type_name -> google.protobuf.Timestamp
+	61,  // 68: balance.v1.ListBalancesRequest.created_to:type_name -> google.protobuf.Timestamp
+	61,  // 69: balance.v1.ListBalancesRequest.active_since:type_name -> google.protobuf.Timestamp
+	5,   // 70: balance.v1.ListBalancesRequest.sort_by:type_name -> balance.v1.BalanceSortField
+	61,  // 71: balance.v1.ListBalancesRequest.updated_from:type_name -> google.protobuf.Timestamp
+	61,  // 72: balance.v1.ListBalancesRequest.updated_to:type_name -> google.protobuf.Timestamp
+	38,  // 73: balance.v1.ListBalancesResponse.balances:type_name -> balance.v1.BalanceResponse
+	6,   // 74: balance.v1.BalanceEvent.kind:type_name -> balance.v1.BalanceEventKind
+	61,  // 75: balance.v1.BalanceEvent.created_at:type_name -> google.protobuf.Timestamp
+	15,  // 76: balance.v1.BalanceEvent.amount:type_name -> balance.v1.Decimal
+	3,   // 77: balance.v1.BalanceEvent.status:type_name -> balance.v1.BalanceStatus
+	17,  // 78: balance.v1.BalanceEvent.txs:type_name -> balance.v1.Tx
+	12,  // 79: balance.v1.BalanceEvent.negative_policy:type_name -> balance.v1.NegativePolicy
+	61,  // 80: balance.v1.ExportStatementRequest.from:type_name -> google.protobuf.Timestamp
+	61,  // 81: balance.v1.ExportStatementRequest.to:type_name -> google.protobuf.Timestamp
+	7,   // 82: balance.v1.ExportStatementRequest.format:type_name -> balance.v1.StatementFormat
+	61,  // 83: balance.v1.AggregateRequest.from:type_name -> google.protobuf.Timestamp
+	61,  // 84: balance.v1.AggregateRequest.to:type_name -> google.protobuf.Timestamp
+	8,   // 85: balance.v1.AggregateRequest.bucket:type_name -> balance.v1.AggregateBucket
+	61,  // 86: balance.v1.AggregateRow.bucket_start:type_name -> google.protobuf.Timestamp
+	0,   // 87: balance.v1.AggregateRow.source:type_name -> balance.v1.Source
+	1,   // 88: balance.v1.AggregateRow.state:type_name -> balance.v1.State
+	15,  // 89: balance.v1.AggregateRow.amount:type_name -> balance.v1.Decimal
+	61,  // 90: balance.v1.Revenue.bucket_start:type_name -> google.protobuf.Timestamp
+	15,  // 91: balance.v1.Revenue.ggr:type_name -> balance.v1.Decimal
+	15,  // 92: balance.v1.Revenue.turnover:type_name -> balance.v1.Decimal
+	15,  // 93: balance.v1.Revenue.deposits:type_name -> balance.v1.Decimal
+	15,  // 94: balance.v1.Revenue.payouts:type_name -> balance.v1.Decimal
+	46,  // 95: balance.v1.AggregateResponse.rows:type_name -> balance.v1.AggregateRow
+	47,  // 96: balance.v1.AggregateResponse.revenues:type_name -> balance.v1.Revenue
+	61,  // 97: balance.v1.FlaggedEvent.created_at:type_name -> google.protobuf.Timestamp
+	14,  // 98: balance.v1.FlaggedEvent.action:type_name -> balance.v1.RuleAction
+	49,  // 99: balance.v1.ListFlaggedEventsResponse.events:type_name -> balance.v1.FlaggedEvent
+	15,  // 100: balance.v1.Chargeback.amount:type_name -> balance.v1.Decimal
+	15,  // 101: balance.v1.Chargeback.correction:type_name -> balance.v1.Decimal
+	15,  // 102: balance.v1.Chargeback.debt:type_name -> balance.v1.Decimal
+	61,  // 103: balance.v1.Chargeback.created_at:type_name -> google.protobuf.Timestamp
+	53,  // 104: balance.v1.ChargebackResponse.chargeback:type_name -> balance.v1.Chargeback
+	17,  // 105: balance.v1.ChargebackResponse.tx:type_name -> balance.v1.Tx
+	38,  // 106: balance.v1.ChargebackResponse.balance:type_name -> balance.v1.BalanceResponse
+	13,  // 107: balance.v1.DebtEntry.kind:type_name -> balance.v1.DebtEntryKind
+	61,  // 108: balance.v1.DebtEntry.created_at:type_name -> google.protobuf.Timestamp
+	15,  // 109: balance.v1.DebtEntry.amount:type_name -> balance.v1.Decimal
+	15,  // 110: balance.v1.DebtEntry.debt:type_name -> balance.v1.Decimal
+	55,  // 111: balance.v1.ListDebtEntriesResponse.entries:type_name -> balance.v1.DebtEntry
+	18,  // 112: balance.v1.BalanceService.RecordTx:input_type -> balance.v1.RecordTxRequest
+	18,  // 113: balance.v1.BalanceService.RecordTxStream:input_type -> balance.v1.RecordTxRequest
+	23,  // 114: balance.v1.BalanceService.CancelTxs:input_type -> balance.v1.CancelTxsRequest
+	34,  // 115: balance.v1.BalanceService.ListTx:input_type -> balance.v1.ListTxRequest
+	22,  // 116: balance.v1.BalanceService.GetTx:input_type -> balance.v1.GetTxRequest
+	32,  // 117: balance.v1.BalanceService.ListRelatedTxs:input_type -> balance.v1.ListRelatedTxsRequest
+	26,  // 118: balance.v1.BalanceService.CancelTxsByCriteria:input_type -> balance.v1.CancelTxsByCriteriaRequest
+	30,  // 119: balance.v1.BalanceService.GetCancellation:input_type -> balance.v1.GetCancellationRequest
+	36,  // 120: balance.v1.BalanceService.OpenBalance:input_type -> balance.v1.OpenBalanceRequest
+	37,  // 121: balance.v1.BalanceService.Balance:input_type -> balance.v1.BalanceRequest
+	39,  // 122: balance.v1.BalanceService.ListBalances:input_type -> balance.v1.ListBalancesRequest
+	41,  // 123: balance.v1.BalanceService.WatchBalance:input_type -> balance.v1.WatchBalanceRequest
+	43,  // 124: balance.v1.BalanceService.ExportStatement:input_type -> balance.v1.ExportStatementRequest
+	45,  // 125: balance.v1.BalanceService.Aggregate:input_type -> balance.v1.AggregateRequest
+	50,  // 126: balance.v1.BalanceService.ListFlaggedEvents:input_type -> balance.v1.ListFlaggedEventsRequest
+	56,  // 127: balance.v1.BalanceService.ListDebtEntries:input_type -> balance.v1.ListDebtEntriesRequest
+	52,  // 128: balance.v1.BalanceService.Chargeback:input_type -> balance.v1.ChargebackRequest
+	20,  // 129: balance.v1.BalanceService.RecordTx:output_type -> balance.v1.RecordTxResponse
+	21,  // 130: balance.v1.BalanceService.RecordTxStream:output_type -> balance.v1.RecordTxResult
+	24,  // 131: balance.v1.BalanceService.CancelTxs:output_type -> balance.v1.CancelTxsResponse
+	35,  // 132: balance.v1.BalanceService.ListTx:output_type -> balance.v1.ListTxResponse
+	17,  // 133: balance.v1.BalanceService.GetTx:output_type -> balance.v1.Tx
+	33,  // 134: balance.v1.BalanceService.ListRelatedTxs:output_type -> balance.v1.ListRelatedTxsResponse
+	28,  // 135: balance.v1.BalanceService.CancelTxsByCriteria:output_type -> balance.v1.CancelTxsByCriteriaResponse
+	31,  // 136: balance.v1.BalanceService.GetCancellation:output_type -> balance.v1.GetCancellationResponse
+	38,  // 137: balance.v1.BalanceService.OpenBalance:output_type -> balance.v1.BalanceResponse
+	38,  // 138: balance.v1.BalanceService.Balance:output_type -> balance.v1.BalanceResponse
+	40,  // 139: balance.v1.BalanceService.ListBalances:output_type -> balance.v1.ListBalancesResponse
+	42,  // 140: balance.v1.BalanceService.WatchBalance:output_type -> balance.v1.BalanceEvent
+	44,  // 141: balance.v1.BalanceService.ExportStatement:output_type -> balance.v1.StatementChunk
+	48,  // 142: balance.v1.BalanceService.Aggregate:output_type -> balance.v1.AggregateResponse
+	51,  // 143: balance.v1.BalanceService.ListFlaggedEvents:output_type -> balance.v1.ListFlaggedEventsResponse
+	57,  // 144: balance.v1.BalanceService.ListDebtEntries:output_type -> balance.v1.ListDebtEntriesResponse
+	54,  // 145: balance.v1.BalanceService.Chargeback:output_type -> balance.v1.ChargebackResponse
+	129, // [129:146] is the sub-list for method output_type
+	112, // [112:129] is the sub-list for method input_type
+	112, // [112:112] is the sub-list for extension type_name
+	112, // [112:112] is the sub-list for extension extendee
+	0,   // [0:112] is the sub-list for field type_name
 }
 
 func init() { file_balance_v1_balance_proto_init() }
@@ -779,13 +4800,16 @@ func file_balance_v1_balance_proto_init() {
 	if File_balance_v1_balance_proto != nil {
 		return
 	}
+	file_balance_v1_balance_proto_msgTypes[3].OneofWrappers = []any{}
+	file_balance_v1_balance_proto_msgTypes[8].OneofWrappers = []any{}
+	file_balance_v1_balance_proto_msgTypes[24].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_balance_v1_balance_proto_rawDesc), len(file_balance_v1_balance_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   9,
+			NumEnums:      15,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -9,7 +9,6 @@ import (
 	context "context"
 	errors "errors"
 	v1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	http "net/http"
 	strings "strings"
 )
@@ -36,25 +35,82 @@ const (
 const (
 	// BalanceServiceRecordTxProcedure is the fully-qualified name of the BalanceService's RecordTx RPC.
 	BalanceServiceRecordTxProcedure = "/balance.v1.BalanceService/RecordTx"
+	// BalanceServiceRecordTxStreamProcedure is the fully-qualified name of the BalanceService's
+	// RecordTxStream RPC.
+	BalanceServiceRecordTxStreamProcedure = "/balance.v1.BalanceService/RecordTxStream"
 	// BalanceServiceCancelTxsProcedure is the fully-qualified name of the BalanceService's CancelTxs
 	// RPC.
 	BalanceServiceCancelTxsProcedure = "/balance.v1.BalanceService/CancelTxs"
 	// BalanceServiceListTxProcedure is the fully-qualified name of the BalanceService's ListTx RPC.
 	BalanceServiceListTxProcedure = "/balance.v1.BalanceService/ListTx"
+	// BalanceServiceGetTxProcedure is the fully-qualified name of the BalanceService's GetTx RPC.
+	BalanceServiceGetTxProcedure = "/balance.v1.BalanceService/GetTx"
+	// BalanceServiceListRelatedTxsProcedure is the fully-qualified name of the BalanceService's
+	// ListRelatedTxs RPC.
+	BalanceServiceListRelatedTxsProcedure = "/balance.v1.BalanceService/ListRelatedTxs"
+	// BalanceServiceCancelTxsByCriteriaProcedure is the fully-qualified name of the BalanceService's
+	// CancelTxsByCriteria RPC.
+	BalanceServiceCancelTxsByCriteriaProcedure = "/balance.v1.BalanceService/CancelTxsByCriteria"
+	// BalanceServiceGetCancellationProcedure is the fully-qualified name of the BalanceService's
+	// GetCancellation RPC.
+	BalanceServiceGetCancellationProcedure = "/balance.v1.BalanceService/GetCancellation"
 	// BalanceServiceOpenBalanceProcedure is the fully-qualified name of the BalanceService's
 	// OpenBalance RPC.
 	BalanceServiceOpenBalanceProcedure = "/balance.v1.BalanceService/OpenBalance"
 	// BalanceServiceBalanceProcedure is the fully-qualified name of the BalanceService's Balance RPC.
 	BalanceServiceBalanceProcedure = "/balance.v1.BalanceService/Balance"
+	// BalanceServiceListBalancesProcedure is the fully-qualified name of the BalanceService's
+	// ListBalances RPC.
+	BalanceServiceListBalancesProcedure = "/balance.v1.BalanceService/ListBalances"
+	// BalanceServiceWatchBalanceProcedure is the fully-qualified name of the BalanceService's
+	// WatchBalance RPC.
+	BalanceServiceWatchBalanceProcedure = "/balance.v1.BalanceService/WatchBalance"
+	// BalanceServiceExportStatementProcedure is the fully-qualified name of the BalanceService's
+	// ExportStatement RPC.
+	BalanceServiceExportStatementProcedure = "/balance.v1.BalanceService/ExportStatement"
+	// BalanceServiceAggregateProcedure is the fully-qualified name of the BalanceService's Aggregate
+	// RPC.
+	BalanceServiceAggregateProcedure = "/balance.v1.BalanceService/Aggregate"
+	// BalanceServiceListFlaggedEventsProcedure is the fully-qualified name of the BalanceService's
+	// ListFlaggedEvents RPC.
+	BalanceServiceListFlaggedEventsProcedure = "/balance.v1.BalanceService/ListFlaggedEvents"
+	// BalanceServiceListDebtEntriesProcedure is the fully-qualified name of the BalanceService's
+	// ListDebtEntries RPC.
+	BalanceServiceListDebtEntriesProcedure = "/balance.v1.BalanceService/ListDebtEntries"
+	// BalanceServiceChargebackProcedure is the fully-qualified name of the BalanceService's Chargeback
+	// RPC.
+	BalanceServiceChargebackProcedure = "/balance.v1.BalanceService/Chargeback"
 )
 
 // BalanceServiceClient is a client for the balance.v1.BalanceService service.
 type BalanceServiceClient interface {
-	RecordTx(context.Context, *connect.Request[v1.RecordTxRequest]) (*connect.Response[emptypb.Empty], error)
-	CancelTxs(context.Context, *connect.Request[v1.CancelTxsRequest]) (*connect.Response[emptypb.Empty], error)
+	RecordTx(context.Context, *connect.Request[v1.RecordTxRequest]) (*connect.Response[v1.RecordTxResponse], error)
+	// Records streamed txs in order per balance and acks every tx by ID. Txs of different balances are recorded concurrently.
+	RecordTxStream(context.Context) *connect.BidiStreamForClient[v1.RecordTxRequest, v1.RecordTxResult]
+	CancelTxs(context.Context, *connect.Request[v1.CancelTxsRequest]) (*connect.Response[v1.CancelTxsResponse], error)
 	ListTx(context.Context, *connect.Request[v1.ListTxRequest]) (*connect.Response[v1.ListTxResponse], error)
-	OpenBalance(context.Context, *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[emptypb.Empty], error)
+	GetTx(context.Context, *connect.Request[v1.GetTxRequest]) (*connect.Response[v1.Tx], error)
+	// Lists txs linked to the tx through parents.
+	ListRelatedTxs(context.Context, *connect.Request[v1.ListRelatedTxsRequest]) (*connect.Response[v1.ListRelatedTxsResponse], error)
+	// Starts cancelling matching txs of many balances in the background and returns at once.
+	// Balances are processed one by one, each of them is locked while its txs are cancelled.
+	CancelTxsByCriteria(context.Context, *connect.Request[v1.CancelTxsByCriteriaRequest]) (*connect.Response[v1.CancelTxsByCriteriaResponse], error)
+	// Returns progress of a bulk cancellation and outcomes per balance.
+	GetCancellation(context.Context, *connect.Request[v1.GetCancellationRequest]) (*connect.Response[v1.GetCancellationResponse], error)
+	OpenBalance(context.Context, *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[v1.BalanceResponse], error)
 	Balance(context.Context, *connect.Request[v1.BalanceRequest]) (*connect.Response[v1.BalanceResponse], error)
+	ListBalances(context.Context, *connect.Request[v1.ListBalancesRequest]) (*connect.Response[v1.ListBalancesResponse], error)
+	WatchBalance(context.Context, *connect.Request[v1.WatchBalanceRequest]) (*connect.ServerStreamForClient[v1.BalanceEvent], error)
+	// Streams txs and cancellations of the balance with the balance after each of them.
+	ExportStatement(context.Context, *connect.Request[v1.ExportStatementRequest]) (*connect.ServerStreamForClient[v1.StatementChunk], error)
+	// Sums not cancelled txs per bucket, source and state.
+	Aggregate(context.Context, *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error)
+	ListFlaggedEvents(context.Context, *connect.Request[v1.ListFlaggedEventsRequest]) (*connect.Response[v1.ListFlaggedEventsResponse], error)
+	// Lists how the debt of the balance was incurred and settled.
+	ListDebtEntries(context.Context, *connect.Request[v1.ListDebtEntriesRequest]) (*connect.Response[v1.ListDebtEntriesResponse], error)
+	// Reverses a payment deposit charged back by a card scheme, even if the player already spent it.
+	// What the balance can't cover becomes debt, and withdrawals of the balance are frozen until the debt is paid off.
+	Chargeback(context.Context, *connect.Request[v1.ChargebackRequest]) (*connect.Response[v1.ChargebackResponse], error)
 }
 
 // NewBalanceServiceClient constructs a client for the balance.v1.BalanceService service. By
@@ -68,13 +124,19 @@ func NewBalanceServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 	baseURL = strings.TrimRight(baseURL, "/")
 	balanceServiceMethods := v1.File_balance_v1_balance_proto.Services().ByName("BalanceService").Methods()
 	return &balanceServiceClient{
-		recordTx: connect.NewClient[v1.RecordTxRequest, emptypb.Empty](
+		recordTx: connect.NewClient[v1.RecordTxRequest, v1.RecordTxResponse](
 			httpClient,
 			baseURL+BalanceServiceRecordTxProcedure,
 			connect.WithSchema(balanceServiceMethods.ByName("RecordTx")),
 			connect.WithClientOptions(opts...),
 		),
-		cancelTxs: connect.NewClient[v1.CancelTxsRequest, emptypb.Empty](
+		recordTxStream: connect.NewClient[v1.RecordTxRequest, v1.RecordTxResult](
+			httpClient,
+			baseURL+BalanceServiceRecordTxStreamProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("RecordTxStream")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelTxs: connect.NewClient[v1.CancelTxsRequest, v1.CancelTxsResponse](
 			httpClient,
 			baseURL+BalanceServiceCancelTxsProcedure,
 			connect.WithSchema(balanceServiceMethods.ByName("CancelTxs")),
@@ -86,7 +148,31 @@ func NewBalanceServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(balanceServiceMethods.ByName("ListTx")),
 			connect.WithClientOptions(opts...),
 		),
-		openBalance: connect.NewClient[v1.OpenBalanceRequest, emptypb.Empty](
+		getTx: connect.NewClient[v1.GetTxRequest, v1.Tx](
+			httpClient,
+			baseURL+BalanceServiceGetTxProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("GetTx")),
+			connect.WithClientOptions(opts...),
+		),
+		listRelatedTxs: connect.NewClient[v1.ListRelatedTxsRequest, v1.ListRelatedTxsResponse](
+			httpClient,
+			baseURL+BalanceServiceListRelatedTxsProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("ListRelatedTxs")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelTxsByCriteria: connect.NewClient[v1.CancelTxsByCriteriaRequest, v1.CancelTxsByCriteriaResponse](
+			httpClient,
+			baseURL+BalanceServiceCancelTxsByCriteriaProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("CancelTxsByCriteria")),
+			connect.WithClientOptions(opts...),
+		),
+		getCancellation: connect.NewClient[v1.GetCancellationRequest, v1.GetCancellationResponse](
+			httpClient,
+			baseURL+BalanceServiceGetCancellationProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("GetCancellation")),
+			connect.WithClientOptions(opts...),
+		),
+		openBalance: connect.NewClient[v1.OpenBalanceRequest, v1.BalanceResponse](
 			httpClient,
 			baseURL+BalanceServiceOpenBalanceProcedure,
 			connect.WithSchema(balanceServiceMethods.ByName("OpenBalance")),
@@ -98,25 +184,84 @@ func NewBalanceServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(balanceServiceMethods.ByName("Balance")),
 			connect.WithClientOptions(opts...),
 		),
+		listBalances: connect.NewClient[v1.ListBalancesRequest, v1.ListBalancesResponse](
+			httpClient,
+			baseURL+BalanceServiceListBalancesProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("ListBalances")),
+			connect.WithClientOptions(opts...),
+		),
+		watchBalance: connect.NewClient[v1.WatchBalanceRequest, v1.BalanceEvent](
+			httpClient,
+			baseURL+BalanceServiceWatchBalanceProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("WatchBalance")),
+			connect.WithClientOptions(opts...),
+		),
+		exportStatement: connect.NewClient[v1.ExportStatementRequest, v1.StatementChunk](
+			httpClient,
+			baseURL+BalanceServiceExportStatementProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("ExportStatement")),
+			connect.WithClientOptions(opts...),
+		),
+		aggregate: connect.NewClient[v1.AggregateRequest, v1.AggregateResponse](
+			httpClient,
+			baseURL+BalanceServiceAggregateProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("Aggregate")),
+			connect.WithClientOptions(opts...),
+		),
+		listFlaggedEvents: connect.NewClient[v1.ListFlaggedEventsRequest, v1.ListFlaggedEventsResponse](
+			httpClient,
+			baseURL+BalanceServiceListFlaggedEventsProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("ListFlaggedEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		listDebtEntries: connect.NewClient[v1.ListDebtEntriesRequest, v1.ListDebtEntriesResponse](
+			httpClient,
+			baseURL+BalanceServiceListDebtEntriesProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("ListDebtEntries")),
+			connect.WithClientOptions(opts...),
+		),
+		chargeback: connect.NewClient[v1.ChargebackRequest, v1.ChargebackResponse](
+			httpClient,
+			baseURL+BalanceServiceChargebackProcedure,
+			connect.WithSchema(balanceServiceMethods.ByName("Chargeback")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // balanceServiceClient implements BalanceServiceClient.
 type balanceServiceClient struct {
-	recordTx    *connect.Client[v1.RecordTxRequest, emptypb.Empty]
-	cancelTxs   *connect.Client[v1.CancelTxsRequest, emptypb.Empty]
-	listTx      *connect.Client[v1.ListTxRequest, v1.ListTxResponse]
-	openBalance *connect.Client[v1.OpenBalanceRequest, emptypb.Empty]
-	balance     *connect.Client[v1.BalanceRequest, v1.BalanceResponse]
+	recordTx            *connect.Client[v1.RecordTxRequest, v1.RecordTxResponse]
+	recordTxStream      *connect.Client[v1.RecordTxRequest, v1.RecordTxResult]
+	cancelTxs           *connect.Client[v1.CancelTxsRequest, v1.CancelTxsResponse]
+	listTx              *connect.Client[v1.ListTxRequest, v1.ListTxResponse]
+	getTx               *connect.Client[v1.GetTxRequest, v1.Tx]
+	listRelatedTxs      *connect.Client[v1.ListRelatedTxsRequest, v1.ListRelatedTxsResponse]
+	cancelTxsByCriteria *connect.Client[v1.CancelTxsByCriteriaRequest, v1.CancelTxsByCriteriaResponse]
+	getCancellation     *connect.Client[v1.GetCancellationRequest, v1.GetCancellationResponse]
+	openBalance         *connect.Client[v1.OpenBalanceRequest, v1.BalanceResponse]
+	balance             *connect.Client[v1.BalanceRequest, v1.BalanceResponse]
+	listBalances        *connect.Client[v1.ListBalancesRequest, v1.ListBalancesResponse]
+	watchBalance        *connect.Client[v1.WatchBalanceRequest, v1.BalanceEvent]
+	exportStatement     *connect.Client[v1.ExportStatementRequest, v1.StatementChunk]
+	aggregate           *connect.Client[v1.AggregateRequest, v1.AggregateResponse]
+	listFlaggedEvents   *connect.Client[v1.ListFlaggedEventsRequest, v1.ListFlaggedEventsResponse]
+	listDebtEntries     *connect.Client[v1.ListDebtEntriesRequest, v1.ListDebtEntriesResponse]
+	chargeback          *connect.Client[v1.ChargebackRequest, v1.ChargebackResponse]
 }
 
 // RecordTx calls balance.v1.BalanceService.RecordTx.
-func (c *balanceServiceClient) RecordTx(ctx context.Context, req *connect.Request[v1.RecordTxRequest]) (*connect.Response[emptypb.Empty], error) {
+func (c *balanceServiceClient) RecordTx(ctx context.Context, req *connect.Request[v1.RecordTxRequest]) (*connect.Response[v1.RecordTxResponse], error) {
 	return c.recordTx.CallUnary(ctx, req)
 }
 
+// RecordTxStream calls balance.v1.BalanceService.RecordTxStream.
+func (c *balanceServiceClient) RecordTxStream(ctx context.Context) *connect.BidiStreamForClient[v1.RecordTxRequest, v1.RecordTxResult] {
+	return c.recordTxStream.CallBidiStream(ctx)
+}
+
 // CancelTxs calls balance.v1.BalanceService.CancelTxs.
-func (c *balanceServiceClient) CancelTxs(ctx context.Context, req *connect.Request[v1.CancelTxsRequest]) (*connect.Response[emptypb.Empty], error) {
+func (c *balanceServiceClient) CancelTxs(ctx context.Context, req *connect.Request[v1.CancelTxsRequest]) (*connect.Response[v1.CancelTxsResponse], error) {
 	return c.cancelTxs.CallUnary(ctx, req)
 }
 
@@ -125,8 +270,28 @@ func (c *balanceServiceClient) ListTx(ctx context.Context, req *connect.Request[
 	return c.listTx.CallUnary(ctx, req)
 }
 
+// GetTx calls balance.v1.BalanceService.GetTx.
+func (c *balanceServiceClient) GetTx(ctx context.Context, req *connect.Request[v1.GetTxRequest]) (*connect.Response[v1.Tx], error) {
+	return c.getTx.CallUnary(ctx, req)
+}
+
+// ListRelatedTxs calls balance.v1.BalanceService.ListRelatedTxs.
+func (c *balanceServiceClient) ListRelatedTxs(ctx context.Context, req *connect.Request[v1.ListRelatedTxsRequest]) (*connect.Response[v1.ListRelatedTxsResponse], error) {
+	return c.listRelatedTxs.CallUnary(ctx, req)
+}
+
+// CancelTxsByCriteria calls balance.v1.BalanceService.CancelTxsByCriteria.
+func (c *balanceServiceClient) CancelTxsByCriteria(ctx context.Context, req *connect.Request[v1.CancelTxsByCriteriaRequest]) (*connect.Response[v1.CancelTxsByCriteriaResponse], error) {
+	return c.cancelTxsByCriteria.CallUnary(ctx, req)
+}
+
+// GetCancellation calls balance.v1.BalanceService.GetCancellation.
+func (c *balanceServiceClient) GetCancellation(ctx context.Context, req *connect.Request[v1.GetCancellationRequest]) (*connect.Response[v1.GetCancellationResponse], error) {
+	return c.getCancellation.CallUnary(ctx, req)
+}
+
 // OpenBalance calls balance.v1.BalanceService.OpenBalance.
-func (c *balanceServiceClient) OpenBalance(ctx context.Context, req *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[emptypb.Empty], error) {
+func (c *balanceServiceClient) OpenBalance(ctx context.Context, req *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[v1.BalanceResponse], error) {
 	return c.openBalance.CallUnary(ctx, req)
 }
 
@@ -135,13 +300,70 @@ func (c *balanceServiceClient) Balance(ctx context.Context, req *connect.Request
 	return c.balance.CallUnary(ctx, req)
 }
 
+// ListBalances calls balance.v1.BalanceService.ListBalances.
+func (c *balanceServiceClient) ListBalances(ctx context.Context, req *connect.Request[v1.ListBalancesRequest]) (*connect.Response[v1.ListBalancesResponse], error) {
+	return c.listBalances.CallUnary(ctx, req)
+}
+
+// WatchBalance calls balance.v1.BalanceService.WatchBalance.
+func (c *balanceServiceClient) WatchBalance(ctx context.Context, req *connect.Request[v1.WatchBalanceRequest]) (*connect.ServerStreamForClient[v1.BalanceEvent], error) {
+	return c.watchBalance.CallServerStream(ctx, req)
+}
+
+// ExportStatement calls balance.v1.BalanceService.ExportStatement.
+func (c *balanceServiceClient) ExportStatement(ctx context.Context, req *connect.Request[v1.ExportStatementRequest]) (*connect.ServerStreamForClient[v1.StatementChunk], error) {
+	return c.exportStatement.CallServerStream(ctx, req)
+}
+
+// Aggregate calls balance.v1.BalanceService.Aggregate.
+func (c *balanceServiceClient) Aggregate(ctx context.Context, req *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error) {
+	return c.aggregate.CallUnary(ctx, req)
+}
+
+// ListFlaggedEvents calls balance.v1.BalanceService.ListFlaggedEvents.
+func (c *balanceServiceClient) ListFlaggedEvents(ctx context.Context, req *connect.Request[v1.ListFlaggedEventsRequest]) (*connect.Response[v1.ListFlaggedEventsResponse], error) {
+	return c.listFlaggedEvents.CallUnary(ctx, req)
+}
+
+// ListDebtEntries calls balance.v1.BalanceService.ListDebtEntries.
+func (c *balanceServiceClient) ListDebtEntries(ctx context.Context, req *connect.Request[v1.ListDebtEntriesRequest]) (*connect.Response[v1.ListDebtEntriesResponse], error) {
+	return c.listDebtEntries.CallUnary(ctx, req)
+}
+
+// Chargeback calls balance.v1.BalanceService.Chargeback.
+func (c *balanceServiceClient) Chargeback(ctx context.Context, req *connect.Request[v1.ChargebackRequest]) (*connect.Response[v1.ChargebackResponse], error) {
+	return c.chargeback.CallUnary(ctx, req)
+}
+
 // BalanceServiceHandler is an implementation of the balance.v1.BalanceService service.
 type BalanceServiceHandler interface {
-	RecordTx(context.Context, *connect.Request[v1.RecordTxRequest]) (*connect.Response[emptypb.Empty], error)
-	CancelTxs(context.Context, *connect.Request[v1.CancelTxsRequest]) (*connect.Response[emptypb.Empty], error)
+	RecordTx(context.Context, *connect.Request[v1.RecordTxRequest]) (*connect.Response[v1.RecordTxResponse], error)
+	// Records streamed txs in order per balance and acks every tx by ID. Txs of different balances are recorded concurrently.
+	RecordTxStream(context.Context, *connect.BidiStream[v1.RecordTxRequest, v1.RecordTxResult]) error
+	CancelTxs(context.Context, *connect.Request[v1.CancelTxsRequest]) (*connect.Response[v1.CancelTxsResponse], error)
 	ListTx(context.Context, *connect.Request[v1.ListTxRequest]) (*connect.Response[v1.ListTxResponse], error)
-	OpenBalance(context.Context, *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[emptypb.Empty], error)
+	GetTx(context.Context, *connect.Request[v1.GetTxRequest]) (*connect.Response[v1.Tx], error)
+	// Lists txs linked to the tx through parents.
+	ListRelatedTxs(context.Context, *connect.Request[v1.ListRelatedTxsRequest]) (*connect.Response[v1.ListRelatedTxsResponse], error)
+	// Starts cancelling matching txs of many balances in the background and returns at once.
+	// Balances are processed one by one, each of them is locked while its txs are cancelled.
+	CancelTxsByCriteria(context.Context, *connect.Request[v1.CancelTxsByCriteriaRequest]) (*connect.Response[v1.CancelTxsByCriteriaResponse], error)
+	// Returns progress of a bulk cancellation and outcomes per balance.
+	GetCancellation(context.Context, *connect.Request[v1.GetCancellationRequest]) (*connect.Response[v1.GetCancellationResponse], error)
+	OpenBalance(context.Context, *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[v1.BalanceResponse], error)
 	Balance(context.Context, *connect.Request[v1.BalanceRequest]) (*connect.Response[v1.BalanceResponse], error)
+	ListBalances(context.Context, *connect.Request[v1.ListBalancesRequest]) (*connect.Response[v1.ListBalancesResponse], error)
+	WatchBalance(context.Context, *connect.Request[v1.WatchBalanceRequest], *connect.ServerStream[v1.BalanceEvent]) error
+	// Streams txs and cancellations of the balance with the balance after each of them.
+	ExportStatement(context.Context, *connect.Request[v1.ExportStatementRequest], *connect.ServerStream[v1.StatementChunk]) error
+	// Sums not cancelled txs per bucket, source and state.
+	Aggregate(context.Context, *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error)
+	ListFlaggedEvents(context.Context, *connect.Request[v1.ListFlaggedEventsRequest]) (*connect.Response[v1.ListFlaggedEventsResponse], error)
+	// Lists how the debt of the balance was incurred and settled.
+	ListDebtEntries(context.Context, *connect.Request[v1.ListDebtEntriesRequest]) (*connect.Response[v1.ListDebtEntriesResponse], error)
+	// Reverses a payment deposit charged back by a card scheme, even if the player already spent it.
+	// What the balance can't cover becomes debt, and withdrawals of the balance are frozen until the debt is paid off.
+	Chargeback(context.Context, *connect.Request[v1.ChargebackRequest]) (*connect.Response[v1.ChargebackResponse], error)
 }
 
 // NewBalanceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -157,6 +379,12 @@ func NewBalanceServiceHandler(svc BalanceServiceHandler, opts ...connect.Handler
 		connect.WithSchema(balanceServiceMethods.ByName("RecordTx")),
 		connect.WithHandlerOptions(opts...),
 	)
+	balanceServiceRecordTxStreamHandler := connect.NewBidiStreamHandler(
+		BalanceServiceRecordTxStreamProcedure,
+		svc.RecordTxStream,
+		connect.WithSchema(balanceServiceMethods.ByName("RecordTxStream")),
+		connect.WithHandlerOptions(opts...),
+	)
 	balanceServiceCancelTxsHandler := connect.NewUnaryHandler(
 		BalanceServiceCancelTxsProcedure,
 		svc.CancelTxs,
@@ -169,6 +397,30 @@ func NewBalanceServiceHandler(svc BalanceServiceHandler, opts ...connect.Handler
 		connect.WithSchema(balanceServiceMethods.ByName("ListTx")),
 		connect.WithHandlerOptions(opts...),
 	)
+	balanceServiceGetTxHandler := connect.NewUnaryHandler(
+		BalanceServiceGetTxProcedure,
+		svc.GetTx,
+		connect.WithSchema(balanceServiceMethods.ByName("GetTx")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceListRelatedTxsHandler := connect.NewUnaryHandler(
+		BalanceServiceListRelatedTxsProcedure,
+		svc.ListRelatedTxs,
+		connect.WithSchema(balanceServiceMethods.ByName("ListRelatedTxs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceCancelTxsByCriteriaHandler := connect.NewUnaryHandler(
+		BalanceServiceCancelTxsByCriteriaProcedure,
+		svc.CancelTxsByCriteria,
+		connect.WithSchema(balanceServiceMethods.ByName("CancelTxsByCriteria")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceGetCancellationHandler := connect.NewUnaryHandler(
+		BalanceServiceGetCancellationProcedure,
+		svc.GetCancellation,
+		connect.WithSchema(balanceServiceMethods.ByName("GetCancellation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	balanceServiceOpenBalanceHandler := connect.NewUnaryHandler(
 		BalanceServiceOpenBalanceProcedure,
 		svc.OpenBalance,
@@ -181,18 +433,84 @@ func NewBalanceServiceHandler(svc BalanceServiceHandler, opts ...connect.Handler
 		connect.WithSchema(balanceServiceMethods.ByName("Balance")),
 		connect.WithHandlerOptions(opts...),
 	)
+	balanceServiceListBalancesHandler := connect.NewUnaryHandler(
+		BalanceServiceListBalancesProcedure,
+		svc.ListBalances,
+		connect.WithSchema(balanceServiceMethods.ByName("ListBalances")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceWatchBalanceHandler := connect.NewServerStreamHandler(
+		BalanceServiceWatchBalanceProcedure,
+		svc.WatchBalance,
+		connect.WithSchema(balanceServiceMethods.ByName("WatchBalance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceExportStatementHandler := connect.NewServerStreamHandler(
+		BalanceServiceExportStatementProcedure,
+		svc.ExportStatement,
+		connect.WithSchema(balanceServiceMethods.ByName("ExportStatement")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceAggregateHandler := connect.NewUnaryHandler(
+		BalanceServiceAggregateProcedure,
+		svc.Aggregate,
+		connect.WithSchema(balanceServiceMethods.ByName("Aggregate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceListFlaggedEventsHandler := connect.NewUnaryHandler(
+		BalanceServiceListFlaggedEventsProcedure,
+		svc.ListFlaggedEvents,
+		connect.WithSchema(balanceServiceMethods.ByName("ListFlaggedEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceListDebtEntriesHandler := connect.NewUnaryHandler(
+		BalanceServiceListDebtEntriesProcedure,
+		svc.ListDebtEntries,
+		connect.WithSchema(balanceServiceMethods.ByName("ListDebtEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	balanceServiceChargebackHandler := connect.NewUnaryHandler(
+		BalanceServiceChargebackProcedure,
+		svc.Chargeback,
+		connect.WithSchema(balanceServiceMethods.ByName("Chargeback")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/balance.v1.BalanceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BalanceServiceRecordTxProcedure:
 			balanceServiceRecordTxHandler.ServeHTTP(w, r)
+		case BalanceServiceRecordTxStreamProcedure:
+			balanceServiceRecordTxStreamHandler.ServeHTTP(w, r)
 		case BalanceServiceCancelTxsProcedure:
 			balanceServiceCancelTxsHandler.ServeHTTP(w, r)
 		case BalanceServiceListTxProcedure:
 			balanceServiceListTxHandler.ServeHTTP(w, r)
+		case BalanceServiceGetTxProcedure:
+			balanceServiceGetTxHandler.ServeHTTP(w, r)
+		case BalanceServiceListRelatedTxsProcedure:
+			balanceServiceListRelatedTxsHandler.ServeHTTP(w, r)
+		case BalanceServiceCancelTxsByCriteriaProcedure:
+			balanceServiceCancelTxsByCriteriaHandler.ServeHTTP(w, r)
+		case BalanceServiceGetCancellationProcedure:
+			balanceServiceGetCancellationHandler.ServeHTTP(w, r)
 		case BalanceServiceOpenBalanceProcedure:
 			balanceServiceOpenBalanceHandler.ServeHTTP(w, r)
 		case BalanceServiceBalanceProcedure:
 			balanceServiceBalanceHandler.ServeHTTP(w, r)
+		case BalanceServiceListBalancesProcedure:
+			balanceServiceListBalancesHandler.ServeHTTP(w, r)
+		case BalanceServiceWatchBalanceProcedure:
+			balanceServiceWatchBalanceHandler.ServeHTTP(w, r)
+		case BalanceServiceExportStatementProcedure:
+			balanceServiceExportStatementHandler.ServeHTTP(w, r)
+		case BalanceServiceAggregateProcedure:
+			balanceServiceAggregateHandler.ServeHTTP(w, r)
+		case BalanceServiceListFlaggedEventsProcedure:
+			balanceServiceListFlaggedEventsHandler.ServeHTTP(w, r)
+		case BalanceServiceListDebtEntriesProcedure:
+			balanceServiceListDebtEntriesHandler.ServeHTTP(w, r)
+		case BalanceServiceChargebackProcedure:
+			balanceServiceChargebackHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -202,11 +520,15 @@ func NewBalanceServiceHandler(svc BalanceServiceHandler, opts ...connect.Handler
 // UnimplementedBalanceServiceHandler returns CodeUnimplemented from all methods.
 type UnimplementedBalanceServiceHandler struct{}
 
-func (UnimplementedBalanceServiceHandler) RecordTx(context.Context, *connect.Request[v1.RecordTxRequest]) (*connect.Response[emptypb.Empty], error) {
+func (UnimplementedBalanceServiceHandler) RecordTx(context.Context, *connect.Request[v1.RecordTxRequest]) (*connect.Response[v1.RecordTxResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.RecordTx is not implemented"))
 }
 
-func (UnimplementedBalanceServiceHandler) CancelTxs(context.Context, *connect.Request[v1.CancelTxsRequest]) (*connect.Response[emptypb.Empty], error) {
+func (UnimplementedBalanceServiceHandler) RecordTxStream(context.Context, *connect.BidiStream[v1.RecordTxRequest, v1.RecordTxResult]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.RecordTxStream is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) CancelTxs(context.Context, *connect.Request[v1.CancelTxsRequest]) (*connect.Response[v1.CancelTxsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.CancelTxs is not implemented"))
 }
 
@@ -214,10 +536,54 @@ func (UnimplementedBalanceServiceHandler) ListTx(context.Context, *connect.Reque
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.ListTx is not implemented"))
 }
 
-func (UnimplementedBalanceServiceHandler) OpenBalance(context.Context, *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[emptypb.Empty], error) {
+func (UnimplementedBalanceServiceHandler) GetTx(context.Context, *connect.Request[v1.GetTxRequest]) (*connect.Response[v1.Tx], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.GetTx is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) ListRelatedTxs(context.Context, *connect.Request[v1.ListRelatedTxsRequest]) (*connect.Response[v1.ListRelatedTxsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.ListRelatedTxs is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) CancelTxsByCriteria(context.Context, *connect.Request[v1.CancelTxsByCriteriaRequest]) (*connect.Response[v1.CancelTxsByCriteriaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.CancelTxsByCriteria is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) GetCancellation(context.Context, *connect.Request[v1.GetCancellationRequest]) (*connect.Response[v1.GetCancellationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.GetCancellation is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) OpenBalance(context.Context, *connect.Request[v1.OpenBalanceRequest]) (*connect.Response[v1.BalanceResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.OpenBalance is not implemented"))
 }
 
 func (UnimplementedBalanceServiceHandler) Balance(context.Context, *connect.Request[v1.BalanceRequest]) (*connect.Response[v1.BalanceResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.Balance is not implemented"))
 }
+
+func (UnimplementedBalanceServiceHandler) ListBalances(context.Context, *connect.Request[v1.ListBalancesRequest]) (*connect.Response[v1.ListBalancesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.ListBalances is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) WatchBalance(context.Context, *connect.Request[v1.WatchBalanceRequest], *connect.ServerStream[v1.BalanceEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.WatchBalance is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) ExportStatement(context.Context, *connect.Request[v1.ExportStatementRequest], *connect.ServerStream[v1.StatementChunk]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.ExportStatement is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) Aggregate(context.Context, *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.Aggregate is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) ListFlaggedEvents(context.Context, *connect.Request[v1.ListFlaggedEventsRequest]) (*connect.Response[v1.ListFlaggedEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.ListFlaggedEvents is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) ListDebtEntries(context.Context, *connect.Request[v1.ListDebtEntriesRequest]) (*connect.Response[v1.ListDebtEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.ListDebtEntries is not implemented"))
+}
+
+func (UnimplementedBalanceServiceHandler) Chargeback(context.Context, *connect.Request[v1.ChargebackRequest]) (*connect.Response[v1.ChargebackResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("balance.v1.BalanceService.Chargeback is not implemented"))
+}
@@ -0,0 +1,169 @@
+// Package aml detects txs crossing regulatory reporting thresholds.
+package aml
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Thresholds configure detection. Zero values disable corresponding checks.
+type Thresholds struct {
+	Single           decimal.Decimal // Amount of a single tx.
+	Aggregate        decimal.Decimal // Sum of txs of a balance within the window.
+	Window           time.Duration
+	StructuringRatio decimal.Decimal // Txs in [Single*StructuringRatio, Single) are just under the limit.
+	StructuringCount int             // Number of txs just under the limit within the window.
+}
+
+// DetectFunc returns cases caused by txs created after since.
+type DetectFunc func(txs []domain.Tx, since time.Time) []domain.AMLCase
+
+type Storage interface {
+	ScanTxs(ctx context.Context, window time.Duration, until time.Time, detect DetectFunc) (int, error)
+}
+
+type Config struct {
+	Thresholds Thresholds
+	Interval   time.Duration
+	Delay      time.Duration // Recent txs are scanned on the next run, so concurrent pgx txs have time to commit.
+}
+
+func NewMonitor(s Storage, c Config) *Monitor {
+	return &Monitor{
+		s: s,
+		c: c,
+	}
+}
+
+type Monitor struct {
+	s Storage
+	c Config
+}
+
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.c.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Scan(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to scan txs for aml cases", "error", err)
+			}
+		}
+	}
+}
+
+func (m *Monitor) Scan(ctx context.Context) error {
+	count, err := m.s.ScanTxs(ctx, m.c.Thresholds.Window, time.Now().Add(-m.c.Delay), func(txs []domain.Tx, since time.Time) []domain.AMLCase {
+		return Detect(txs, since, m.c.Thresholds)
+	})
+	if err != nil {
+		return fmt.Errorf("scan txs: %w", err)
+	}
+
+	if count > 0 {
+		slog.InfoContext(ctx, "detected aml cases", "count", count)
+	}
+
+	return nil
+}
+
+// Detect finds threshold crossings caused by txs created after since.
+// Txs must be sorted by tenant, balance, state and creation time and include txs from the window before since.
+func Detect(txs []domain.Tx, since time.Time, t Thresholds) []domain.AMLCase {
+	var cases []domain.AMLCase
+
+	for start := 0; start < len(txs); {
+		end := start
+		for end < len(txs) && sameGroup(txs[end], txs[start]) {
+			end++
+		}
+
+		cases = append(cases, detectGroup(txs[start:end], since, t)...)
+		start = end
+	}
+
+	return cases
+}
+
+// sameGroup reports whether txs are aggregated together. Balance IDs are unique only within a tenant.
+func sameGroup(a, b domain.Tx) bool {
+	return a.TenantID == b.TenantID && a.BalanceID == b.BalanceID && a.State == b.State
+}
+
+func detectGroup(txs []domain.Tx, since time.Time, t Thresholds) []domain.AMLCase {
+	var cases []domain.AMLCase
+
+	nearLimit := t.Single.Mul(t.StructuringRatio)
+	isNear := func(tx domain.Tx) bool {
+		return t.StructuringCount > 0 && tx.Amount.GreaterThanOrEqual(nearLimit) && tx.Amount.LessThan(t.Single)
+	}
+
+	var sum decimal.Decimal
+	first := 0
+
+	for i, tx := range txs {
+		for first < i && !txs[first].CreatedAt.After(tx.CreatedAt.Add(-t.Window)) {
+			sum = sum.Sub(txs[first].Amount)
+			first++
+		}
+		sum = sum.Add(tx.Amount)
+
+		if !tx.CreatedAt.After(since) {
+			continue
+		}
+
+		if t.Single.IsPositive() && tx.Amount.GreaterThanOrEqual(t.Single) {
+			cases = append(cases, newCase(domain.AMLCaseKindSingle, tx, tx.Amount, []domain.Tx{tx}))
+		}
+
+		// Only the tx crossing the threshold opens a case, so following txs in the window don't duplicate it.
+		if t.Aggregate.IsPositive() && sum.GreaterThanOrEqual(t.Aggregate) && sum.Sub(tx.Amount).LessThan(t.Aggregate) {
+			cases = append(cases, newCase(domain.AMLCaseKindAggregate, tx, sum, txs[first:i+1]))
+		}
+
+		if t.Single.IsPositive() && isNear(tx) {
+			var near []domain.Tx
+			var nearSum decimal.Decimal
+			for _, w := range txs[first : i+1] {
+				if isNear(w) {
+					near = append(near, w)
+					nearSum = nearSum.Add(w.Amount)
+				}
+			}
+
+			if len(near) == t.StructuringCount {
+				cases = append(cases, newCase(domain.AMLCaseKindStructuring, tx, nearSum, near))
+			}
+		}
+	}
+
+	return cases
+}
+
+func newCase(kind domain.AMLCaseKind, tx domain.Tx, amount decimal.Decimal, txs []domain.Tx) domain.AMLCase {
+	txIDs := make([]uuid.UUID, 0, len(txs))
+	for _, t := range txs {
+		txIDs = append(txIDs, t.TxID)
+	}
+
+	return domain.AMLCase{
+		DetectedAt: tx.CreatedAt,
+		TenantID:   tx.TenantID,
+		BalanceID:  tx.BalanceID,
+		Kind:       kind,
+		Status:     domain.AMLCaseStatusOpen,
+		State:      tx.State,
+		Amount:     amount,
+		TxIDs:      txIDs,
+	}
+}
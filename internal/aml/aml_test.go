@@ -0,0 +1,208 @@
+package aml_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/aml"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	balanceID := uuid.New()
+	otherBalanceID := uuid.New()
+	since := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	thresholds := aml.Thresholds{
+		Single:           decimal.NewFromInt(10000),
+		Aggregate:        decimal.NewFromInt(15000),
+		Window:           24 * time.Hour,
+		StructuringRatio: decimal.RequireFromString("0.9"),
+		StructuringCount: 3,
+	}
+
+	tx := func(balanceID uuid.UUID, state domain.State, amount int64, after time.Duration) domain.Tx {
+		return domain.Tx{
+			CreatedAt: since.Add(after),
+			TenantID:  "casino-1",
+			TxID:      uuid.New(),
+			BalanceID: balanceID,
+			Source:    domain.SourcePayment,
+			State:     state,
+			Amount:    decimal.NewFromInt(amount),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		txs       []domain.Tx
+		wantKinds []domain.AMLCaseKind
+	}{
+		{
+			name: "no txs",
+		},
+		{
+			name: "small txs",
+			txs: []domain.Tx{
+				tx(balanceID, domain.StateDeposit, 100, time.Minute),
+				tx(balanceID, domain.StateDeposit, 200, 2*time.Minute),
+			},
+		},
+		{
+			name: "single tx over threshold",
+			txs: []domain.Tx{
+				tx(balanceID, domain.StateDeposit, 12000, time.Minute),
+			},
+			wantKinds: []domain.AMLCaseKind{domain.AMLCaseKindSingle},
+		},
+		{
+			name: "single tx before since is ignored",
+			txs: []domain.Tx{
+				tx(balanceID, domain.StateDeposit, 12000, -time.Minute),
+			},
+		},
+		{
+			name: "aggregated txs cross threshold once",
+			txs: []domain.Tx{
+				tx(balanceID, domain.StateDeposit, 8000, -time.Hour),
+				tx(balanceID, domain.StateDeposit, 8000, time.Minute),
+				tx(balanceID, domain.StateDeposit, 1000, 2*time.Minute),
+			},
+			wantKinds: []domain.AMLCaseKind{domain.AMLCaseKindAggregate},
+		},
+		{
+			name: "aggregated txs outside window",
+			txs: []domain.Tx{
+				tx(balanceID, domain.StateDeposit, 8000, -25*time.Hour),
+				tx(balanceID, domain.StateDeposit, 8000, time.Minute),
+			},
+		},
+		{
+			name: "txs of different balances and states are not aggregated",
+			txs: []domain.Tx{
+				tx(balanceID, domain.StateDeposit, 8000, time.Minute),
+				tx(balanceID, domain.StateWithdraw, 8000, time.Minute),
+				tx(otherBalanceID, domain.StateDeposit, 8000, time.Minute),
+			},
+		},
+		{
+			name: "txs of balances with the same id in different tenants are not aggregated",
+			txs: func() []domain.Tx {
+				other := tx(balanceID, domain.StateDeposit, 8000, 2*time.Minute)
+				other.TenantID = "casino-2"
+				return []domain.Tx{
+					tx(balanceID, domain.StateDeposit, 8000, time.Minute),
+					other,
+				}
+			}(),
+		},
+		{
+			name: "structuring just under the limit",
+			txs: []domain.Tx{
+				tx(otherBalanceID, domain.StateWithdraw, 9500, time.Minute),
+				tx(otherBalanceID, domain.StateWithdraw, 9900, 2*time.Minute),
+				tx(otherBalanceID, domain.StateWithdraw, 9100, 3*time.Minute),
+			},
+			wantKinds: []domain.AMLCaseKind{domain.AMLCaseKindAggregate, domain.AMLCaseKindStructuring},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cases := aml.Detect(tt.txs, since, thresholds)
+
+			var kinds []domain.AMLCaseKind
+			for _, c := range cases {
+				assert.Equal(t, domain.AMLCaseStatusOpen, c.Status)
+				assert.NotEmpty(t, c.TxIDs)
+				kinds = append(kinds, c.Kind)
+			}
+
+			assert.Equal(t, tt.wantKinds, kinds)
+		})
+	}
+}
+
+func TestDetect_Structuring(t *testing.T) {
+	balanceID := uuid.New()
+	since := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var txs []domain.Tx
+	for i := range 3 {
+		txs = append(txs, domain.Tx{
+			CreatedAt: since.Add(time.Duration(i+1) * time.Minute),
+			TenantID:  "casino",
+			TxID:      uuid.New(),
+			BalanceID: balanceID,
+			State:     domain.StateDeposit,
+			Amount:    decimal.NewFromInt(9500),
+		})
+	}
+
+	cases := aml.Detect(txs, since, aml.Thresholds{
+		Single:           decimal.NewFromInt(10000),
+		Window:           time.Hour,
+		StructuringRatio: decimal.RequireFromString("0.9"),
+		StructuringCount: 3,
+	})
+
+	require.Len(t, cases, 1)
+	assert.Equal(t, domain.AMLCaseKindStructuring, cases[0].Kind)
+	assert.Equal(t, "casino", cases[0].TenantID)
+	assert.Equal(t, balanceID, cases[0].BalanceID)
+	assert.Equal(t, txs[2].CreatedAt, cases[0].DetectedAt)
+	assert.True(t, decimal.NewFromInt(28500).Equal(cases[0].Amount))
+	assert.Equal(t, []uuid.UUID{txs[0].TxID, txs[1].TxID, txs[2].TxID}, cases[0].TxIDs)
+}
+
+func TestWriteReport(t *testing.T) {
+	c := domain.AMLCase{
+		DetectedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		TenantID:   "casino",
+		CaseID:     uuid.New(),
+		BalanceID:  uuid.New(),
+		Kind:       domain.AMLCaseKindSingle,
+		Status:     domain.AMLCaseStatusReported,
+		State:      domain.StateDeposit,
+		Amount:     decimal.NewFromInt(12000),
+		TxIDs:      []uuid.UUID{uuid.New()},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, aml.WriteReport(&buf, aml.FormatCSV, []domain.AMLCase{c}))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "case_id,detected_at,tenant_id,balance_id,kind,status,state,amount,tx_ids", lines[0])
+		assert.Contains(t, lines[1], c.CaseID.String()+",2025-01-01T12:00:00Z,casino,")
+		assert.Contains(t, lines[1], "Single,Reported,Deposit,12000")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, aml.WriteReport(&buf, aml.FormatJSON, []domain.AMLCase{c}))
+
+		var rows []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+		require.Len(t, rows, 1)
+		assert.Equal(t, c.CaseID.String(), rows[0]["case_id"])
+		assert.Equal(t, "2025-01-01T12:00:00Z", rows[0]["detected_at"])
+		assert.Equal(t, "12000", rows[0]["amount"])
+		assert.Equal(t, "casino", rows[0]["tenant_id"])
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := aml.WriteReport(&bytes.Buffer{}, "xml", nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, aml.ErrUnknownFormat))
+	})
+}
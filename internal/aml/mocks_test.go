@@ -0,0 +1,117 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package aml
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStorage {
+	mock := &MockStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStorage is an autogenerated mock type for the Storage type
+type MockStorage struct {
+	mock.Mock
+}
+
+type MockStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
+	return &MockStorage_Expecter{mock: &_m.Mock}
+}
+
+// ScanTxs provides a mock function for the type MockStorage
+func (_mock *MockStorage) ScanTxs(ctx context.Context, window time.Duration, until time.Time, detect DetectFunc) (int, error) {
+	ret := _mock.Called(ctx, window, until, detect)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanTxs")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, time.Time, DetectFunc) (int, error)); ok {
+		return returnFunc(ctx, window, until, detect)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, time.Time, DetectFunc) int); ok {
+		r0 = returnFunc(ctx, window, until, detect)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration, time.Time, DetectFunc) error); ok {
+		r1 = returnFunc(ctx, window, until, detect)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ScanTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanTxs'
+type MockStorage_ScanTxs_Call struct {
+	*mock.Call
+}
+
+// ScanTxs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - window time.Duration
+//   - until time.Time
+//   - detect DetectFunc
+func (_e *MockStorage_Expecter) ScanTxs(ctx interface{}, window interface{}, until interface{}, detect interface{}) *MockStorage_ScanTxs_Call {
+	return &MockStorage_ScanTxs_Call{Call: _e.mock.On("ScanTxs", ctx, window, until, detect)}
+}
+
+func (_c *MockStorage_ScanTxs_Call) Run(run func(ctx context.Context, window time.Duration, until time.Time, detect DetectFunc)) *MockStorage_ScanTxs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 DetectFunc
+		if args[3] != nil {
+			arg3 = args[3].(DetectFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ScanTxs_Call) Return(n int, err error) *MockStorage_ScanTxs_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStorage_ScanTxs_Call) RunAndReturn(run func(ctx context.Context, window time.Duration, until time.Time, detect DetectFunc) (int, error)) *MockStorage_ScanTxs_Call {
+	_c.Call.Return(run)
+	return _c
+}
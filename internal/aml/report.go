@@ -0,0 +1,119 @@
+package aml
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// ReportFunc writes and flushes cases of a report. Cases are marked as reported only if it succeeds.
+type ReportFunc func(cases []domain.AMLCase) error
+
+type reportRow struct {
+	CaseID     string   `json:"case_id"`
+	DetectedAt string   `json:"detected_at"`
+	TenantID   string   `json:"tenant_id"`
+	BalanceID  string   `json:"balance_id"`
+	Kind       string   `json:"kind"`
+	Status     string   `json:"status"`
+	State      string   `json:"state"`
+	Amount     string   `json:"amount"`
+	TxIDs      []string `json:"tx_ids"`
+}
+
+func WriteReport(w io.Writer, f Format, cases []domain.AMLCase) error {
+	rows := make([]reportRow, 0, len(cases))
+	for _, c := range cases {
+		txIDs := make([]string, 0, len(c.TxIDs))
+		for _, id := range c.TxIDs {
+			txIDs = append(txIDs, id.String())
+		}
+
+		rows = append(rows, reportRow{
+			CaseID:     c.CaseID.String(),
+			DetectedAt: c.DetectedAt.UTC().Format(time.RFC3339Nano),
+			TenantID:   c.TenantID,
+			BalanceID:  c.BalanceID.String(),
+			Kind:       c.Kind.String(),
+			Status:     c.Status.String(),
+			State:      c.State.String(),
+			Amount:     c.Amount.String(),
+			TxIDs:      txIDs,
+		})
+	}
+
+	switch f {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatJSON:
+		return writeJSON(w, rows)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, f)
+	}
+}
+
+func writeCSV(w io.Writer, rows []reportRow) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"case_id", "detected_at", "tenant_id", "balance_id", "kind", "status", "state", "amount", "tx_ids"}); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	for _, r := range rows {
+		if err := cw.Write([]string{
+			r.CaseID,
+			r.DetectedAt,
+			r.TenantID,
+			r.BalanceID,
+			r.Kind,
+			r.Status,
+			r.State,
+			r.Amount,
+			strings.Join(r.TxIDs, " "),
+		}); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, rows []reportRow) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(rows); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	return nil
+}
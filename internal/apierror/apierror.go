@@ -0,0 +1,59 @@
+// Package apierror builds errors with ErrorInfo details, shared by services and interceptors.
+package apierror
+
+import (
+	"errors"
+	"strings"
+
+	"connectrpc.com/connect"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+)
+
+// Domain is the domain of ErrorInfo details of all services, reasons are unique within it.
+const Domain = "balance.v1"
+
+// New returns an error with ErrorInfo details, so clients branch on the reason instead of the message.
+func New(
+	code connect.Code,
+	reason balancev1.ErrorReason,
+	message string,
+	metadata map[string]string,
+	details ...proto.Message,
+) *connect.Error {
+	err := connect.NewError(code, errors.New(message))
+
+	details = append([]proto.Message{&errdetails.ErrorInfo{
+		Reason:   strings.TrimPrefix(reason.String(), "ERROR_REASON_"),
+		Domain:   Domain,
+		Metadata: metadata,
+	}}, details...)
+
+	for _, d := range details {
+		detail, detailErr := connect.NewErrorDetail(d)
+		if detailErr != nil {
+			continue
+		}
+
+		err.AddDetail(detail)
+	}
+
+	return err
+}
+
+// Reason returns the reason of ErrorInfo details of the error.
+func Reason(err *connect.Error) string {
+	for _, d := range err.Details() {
+		msg, valueErr := d.Value()
+		if valueErr != nil {
+			continue
+		}
+
+		if info, ok := msg.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+
+	return ""
+}
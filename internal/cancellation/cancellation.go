@@ -0,0 +1,67 @@
+// Package cancellation runs bulk cancellations of txs in the background.
+package cancellation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type Storage interface {
+	CancelNextBalance(ctx context.Context) (bool, error)
+}
+
+type Config struct {
+	Interval time.Duration // Pause between checks for pending balances once all of them are processed.
+}
+
+func NewWorker(s Storage, c Config) *Worker {
+	return &Worker{
+		s: s,
+		c: c,
+	}
+}
+
+type Worker struct {
+	s Storage
+	c Config
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.c.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Process(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to process cancellations", "error", err)
+			}
+		}
+	}
+}
+
+// Process cancels txs of pending balances one by one until none are left.
+func (w *Worker) Process(ctx context.Context) error {
+	count := 0
+	for ctx.Err() == nil {
+		processed, err := w.s.CancelNextBalance(ctx)
+		if err != nil {
+			return fmt.Errorf("cancel txs of next balance: %w", err)
+		}
+		if !processed {
+			break
+		}
+
+		count++
+	}
+
+	if count > 0 {
+		slog.InfoContext(ctx, "processed balances of cancellations", "count", count)
+	}
+
+	return nil
+}
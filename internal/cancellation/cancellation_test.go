@@ -0,0 +1,36 @@
+package cancellation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorker_Process(t *testing.T) {
+	t.Run("processes balances until none are pending", func(t *testing.T) {
+		s := NewMockStorage(t)
+		s.EXPECT().CancelNextBalance(context.Background()).Return(true, nil).Times(3)
+		s.EXPECT().CancelNextBalance(context.Background()).Return(false, nil).Once()
+
+		require.NoError(t, NewWorker(s, Config{}).Process(context.Background()))
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		s := NewMockStorage(t)
+		s.EXPECT().CancelNextBalance(context.Background()).Return(true, nil).Once()
+		s.EXPECT().CancelNextBalance(context.Background()).Return(false, errors.New("storage error")).Once()
+
+		err := NewWorker(s, Config{}).Process(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.NoError(t, NewWorker(NewMockStorage(t), Config{}).Process(ctx))
+	})
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package cancellation
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStorage {
+	mock := &MockStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStorage is an autogenerated mock type for the Storage type
+type MockStorage struct {
+	mock.Mock
+}
+
+type MockStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
+	return &MockStorage_Expecter{mock: &_m.Mock}
+}
+
+// CancelNextBalance provides a mock function for the type MockStorage
+func (_mock *MockStorage) CancelNextBalance(ctx context.Context) (bool, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelNextBalance")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_CancelNextBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelNextBalance'
+type MockStorage_CancelNextBalance_Call struct {
+	*mock.Call
+}
+
+// CancelNextBalance is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) CancelNextBalance(ctx interface{}) *MockStorage_CancelNextBalance_Call {
+	return &MockStorage_CancelNextBalance_Call{Call: _e.mock.On("CancelNextBalance", ctx)}
+}
+
+func (_c *MockStorage_CancelNextBalance_Call) Run(run func(ctx context.Context)) *MockStorage_CancelNextBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CancelNextBalance_Call) Return(b bool, err error) *MockStorage_CancelNextBalance_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStorage_CancelNextBalance_Call) RunAndReturn(run func(ctx context.Context) (bool, error)) *MockStorage_CancelNextBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
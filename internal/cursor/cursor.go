@@ -0,0 +1,77 @@
+// Package cursor encodes opaque page tokens.
+// A token carries the position of the last returned item and a hash of the filter it was issued for,
+// so it can't be reused with a different filter.
+package cursor
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalid        = errors.New("invalid cursor")
+	ErrFilterMismatch = errors.New("cursor was issued for another filter")
+)
+
+type token[P any] struct {
+	Position   P      `json:"p"`
+	FilterHash string `json:"f"`
+}
+
+// Encode returns a token pointing after position. Filter must be JSON serializable.
+func Encode[P any](position P, filter any) (string, error) {
+	hash, err := filterHash(filter)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(token[P]{
+		Position:   position,
+		FilterHash: hash,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Decode returns the position from the token if it was issued for the same filter.
+func Decode[P any](s string, filter any) (P, error) {
+	var zero P
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	var t token[P]
+	if err := json.Unmarshal(b, &t); err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	hash, err := filterHash(filter)
+	if err != nil {
+		return zero, err
+	}
+
+	if t.FilterHash != hash {
+		return zero, ErrFilterMismatch
+	}
+
+	return t.Position, nil
+}
+
+func filterHash(filter any) (string, error) {
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return "", fmt.Errorf("encode filter: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,68 @@
+package cursor_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/cursor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type filter struct {
+	Source string
+	Min    int
+}
+
+func TestEncodeDecode(t *testing.T) {
+	position := uuid.New()
+	f := filter{Source: "Game", Min: 10}
+
+	token, err := cursor.Encode(position, f)
+	require.NoError(t, err)
+	assert.NotContains(t, token, position.String())
+
+	got, err := cursor.Decode[uuid.UUID](token, f)
+	require.NoError(t, err)
+	assert.Equal(t, position, got)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	token, err := cursor.Encode(uuid.New(), filter{Source: "Game"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		filter  filter
+		wantErr error
+	}{
+		{
+			name:    "changed filter",
+			token:   token,
+			filter:  filter{Source: "Payment"},
+			wantErr: cursor.ErrFilterMismatch,
+		},
+		{
+			name:    "not base64",
+			token:   "not a cursor!",
+			filter:  filter{Source: "Game"},
+			wantErr: cursor.ErrInvalid,
+		},
+		{
+			name:    "raw uuid",
+			token:   uuid.New().String(),
+			filter:  filter{Source: "Game"},
+			wantErr: cursor.ErrInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cursor.Decode[uuid.UUID](tt.token, tt.filter)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.wantErr))
+		})
+	}
+}
@@ -14,12 +14,488 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+type AmlCaseKind string
+
+const (
+	AmlCaseKindSingle      AmlCaseKind = "Single"
+	AmlCaseKindAggregate   AmlCaseKind = "Aggregate"
+	AmlCaseKindStructuring AmlCaseKind = "Structuring"
+)
+
+func (e *AmlCaseKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AmlCaseKind(s)
+	case string:
+		*e = AmlCaseKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AmlCaseKind: %T", src)
+	}
+	return nil
+}
+
+type NullAmlCaseKind struct {
+	AmlCaseKind AmlCaseKind
+	Valid       bool // Valid is true if AmlCaseKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAmlCaseKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.AmlCaseKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AmlCaseKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAmlCaseKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AmlCaseKind), nil
+}
+
+type AmlCaseStatus string
+
+const (
+	AmlCaseStatusOpen     AmlCaseStatus = "Open"
+	AmlCaseStatusReported AmlCaseStatus = "Reported"
+	AmlCaseStatusClosed   AmlCaseStatus = "Closed"
+)
+
+func (e *AmlCaseStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AmlCaseStatus(s)
+	case string:
+		*e = AmlCaseStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AmlCaseStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAmlCaseStatus struct {
+	AmlCaseStatus AmlCaseStatus
+	Valid         bool // Valid is true if AmlCaseStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAmlCaseStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AmlCaseStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AmlCaseStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAmlCaseStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AmlCaseStatus), nil
+}
+
+type AuditAction string
+
+const (
+	AuditActionAdjustBalance    AuditAction = "AdjustBalance"
+	AuditActionForceCancelTx    AuditAction = "ForceCancelTx"
+	AuditActionInspectBalance   AuditAction = "InspectBalance"
+	AuditActionSetBalanceStatus AuditAction = "SetBalanceStatus"
+)
+
+func (e *AuditAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AuditAction(s)
+	case string:
+		*e = AuditAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AuditAction: %T", src)
+	}
+	return nil
+}
+
+type NullAuditAction struct {
+	AuditAction AuditAction
+	Valid       bool // Valid is true if AuditAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAuditAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.AuditAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AuditAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAuditAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AuditAction), nil
+}
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeAttempted AuditOutcome = "Attempted"
+	AuditOutcomeSucceeded AuditOutcome = "Succeeded"
+	AuditOutcomeFailed    AuditOutcome = "Failed"
+)
+
+func (e *AuditOutcome) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AuditOutcome(s)
+	case string:
+		*e = AuditOutcome(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AuditOutcome: %T", src)
+	}
+	return nil
+}
+
+type NullAuditOutcome struct {
+	AuditOutcome AuditOutcome
+	Valid        bool // Valid is true if AuditOutcome is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAuditOutcome) Scan(value interface{}) error {
+	if value == nil {
+		ns.AuditOutcome, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AuditOutcome.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAuditOutcome) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AuditOutcome), nil
+}
+
+type BalanceCancellationStatus string
+
+const (
+	BalanceCancellationStatusPending BalanceCancellationStatus = "Pending"
+	BalanceCancellationStatusDone    BalanceCancellationStatus = "Done"
+	BalanceCancellationStatusFailed  BalanceCancellationStatus = "Failed"
+)
+
+func (e *BalanceCancellationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BalanceCancellationStatus(s)
+	case string:
+		*e = BalanceCancellationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BalanceCancellationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBalanceCancellationStatus struct {
+	BalanceCancellationStatus BalanceCancellationStatus
+	Valid                     bool // Valid is true if BalanceCancellationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBalanceCancellationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BalanceCancellationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BalanceCancellationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBalanceCancellationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BalanceCancellationStatus), nil
+}
+
+type BalanceEventKind string
+
+const (
+	BalanceEventKindTxRecorded    BalanceEventKind = "TxRecorded"
+	BalanceEventKindTxsCancelled  BalanceEventKind = "TxsCancelled"
+	BalanceEventKindStatusChanged BalanceEventKind = "StatusChanged"
+)
+
+func (e *BalanceEventKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BalanceEventKind(s)
+	case string:
+		*e = BalanceEventKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BalanceEventKind: %T", src)
+	}
+	return nil
+}
+
+type NullBalanceEventKind struct {
+	BalanceEventKind BalanceEventKind
+	Valid            bool // Valid is true if BalanceEventKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBalanceEventKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.BalanceEventKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BalanceEventKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBalanceEventKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BalanceEventKind), nil
+}
+
+type BalanceStatus string
+
+const (
+	BalanceStatusActive            BalanceStatus = "Active"
+	BalanceStatusFrozen            BalanceStatus = "Frozen"
+	BalanceStatusWithdrawalsFrozen BalanceStatus = "WithdrawalsFrozen"
+)
+
+func (e *BalanceStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BalanceStatus(s)
+	case string:
+		*e = BalanceStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BalanceStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBalanceStatus struct {
+	BalanceStatus BalanceStatus
+	Valid         bool // Valid is true if BalanceStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBalanceStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BalanceStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BalanceStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBalanceStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BalanceStatus), nil
+}
+
+type CancellationStatus string
+
+const (
+	CancellationStatusPending CancellationStatus = "Pending"
+	CancellationStatusRunning CancellationStatus = "Running"
+	CancellationStatusDone    CancellationStatus = "Done"
+)
+
+func (e *CancellationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CancellationStatus(s)
+	case string:
+		*e = CancellationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CancellationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCancellationStatus struct {
+	CancellationStatus CancellationStatus
+	Valid              bool // Valid is true if CancellationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCancellationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CancellationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CancellationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCancellationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CancellationStatus), nil
+}
+
+type DebtEntryKind string
+
+const (
+	DebtEntryKindIncurred   DebtEntryKind = "Incurred"
+	DebtEntryKindSettled    DebtEntryKind = "Settled"
+	DebtEntryKindReinstated DebtEntryKind = "Reinstated"
+)
+
+func (e *DebtEntryKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DebtEntryKind(s)
+	case string:
+		*e = DebtEntryKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DebtEntryKind: %T", src)
+	}
+	return nil
+}
+
+type NullDebtEntryKind struct {
+	DebtEntryKind DebtEntryKind
+	Valid         bool // Valid is true if DebtEntryKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDebtEntryKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.DebtEntryKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DebtEntryKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDebtEntryKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DebtEntryKind), nil
+}
+
+type NegativePolicy string
+
+const (
+	NegativePolicyFail NegativePolicy = "Fail"
+	NegativePolicySkip NegativePolicy = "Skip"
+	NegativePolicyDebt NegativePolicy = "Debt"
+)
+
+func (e *NegativePolicy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NegativePolicy(s)
+	case string:
+		*e = NegativePolicy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NegativePolicy: %T", src)
+	}
+	return nil
+}
+
+type NullNegativePolicy struct {
+	NegativePolicy NegativePolicy
+	Valid          bool // Valid is true if NegativePolicy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNegativePolicy) Scan(value interface{}) error {
+	if value == nil {
+		ns.NegativePolicy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NegativePolicy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNegativePolicy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NegativePolicy), nil
+}
+
+type RuleAction string
+
+const (
+	RuleActionAllow  RuleAction = "Allow"
+	RuleActionFlag   RuleAction = "Flag"
+	RuleActionReject RuleAction = "Reject"
+	RuleActionFreeze RuleAction = "Freeze"
+)
+
+func (e *RuleAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RuleAction(s)
+	case string:
+		*e = RuleAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RuleAction: %T", src)
+	}
+	return nil
+}
+
+type NullRuleAction struct {
+	RuleAction RuleAction
+	Valid      bool // Valid is true if RuleAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRuleAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.RuleAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RuleAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRuleAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RuleAction), nil
+}
+
 type TxSource string
 
 const (
-	TxSourceGame    TxSource = "Game"
-	TxSourcePayment TxSource = "Payment"
-	TxSourceService TxSource = "Service"
+	TxSourceGame       TxSource = "Game"
+	TxSourcePayment    TxSource = "Payment"
+	TxSourceService    TxSource = "Service"
+	TxSourceAdjustment TxSource = "Adjustment"
 )
 
 func (e *TxSource) Scan(src interface{}) error {
@@ -99,17 +575,199 @@ func (ns NullTxState) Value() (driver.Value, error) {
 	return string(ns.TxState), nil
 }
 
+type WalletType string
+
+const (
+	WalletTypeMain  WalletType = "Main"
+	WalletTypeBonus WalletType = "Bonus"
+)
+
+func (e *WalletType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WalletType(s)
+	case string:
+		*e = WalletType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WalletType: %T", src)
+	}
+	return nil
+}
+
+type NullWalletType struct {
+	WalletType WalletType
+	Valid      bool // Valid is true if WalletType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWalletType) Scan(value interface{}) error {
+	if value == nil {
+		ns.WalletType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WalletType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWalletType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WalletType), nil
+}
+
+type AmlCase struct {
+	CreatedAt  time.Time
+	DetectedAt time.Time
+	CaseID     uuid.UUID
+	BalanceID  uuid.UUID
+	Kind       domain.AMLCaseKind
+	Status     domain.AMLCaseStatus
+	State      domain.State
+	Amount     decimal.Decimal
+	TxIds      []uuid.UUID
+	TenantID   string
+}
+
+type AmlCheckpoint struct {
+	Monitor      string
+	ScannedUntil time.Time
+}
+
+type AuditLog struct {
+	CreatedAt time.Time
+	TenantID  string
+	EntryID   uuid.UUID
+	Operator  string
+	Action    domain.AuditAction
+	BalanceID uuid.UUID
+	TxID      *uuid.UUID
+	Amount    *decimal.Decimal
+	Reason    string
+	TicketRef string
+	Outcome   domain.AuditOutcome
+	AttemptID *uuid.UUID
+	Error     *string
+	Status    *domain.BalanceStatus
+}
+
 type Balance struct {
+	BalanceID         uuid.UUID
+	Amount            decimal.Decimal
+	Status            domain.BalanceStatus
+	OwnerID           *uuid.UUID
+	ExternalPlayerRef *string
+	Currency          *string
+	WalletType        domain.WalletType
+	TenantID          string
+	LastTxSeq         int64
+	CreatedAt         time.Time
+	LastEventSeq      int64
+	UpdatedAt         time.Time
+	LastActivityAt    *time.Time
+	TxCount           int64
+	Version           int64
+	Debt              decimal.Decimal
+	LastDebtSeq       int64
+}
+
+type BalanceEvent struct {
+	CreatedAt      time.Time
+	TenantID       string
+	BalanceID      uuid.UUID
+	Seq            int64
+	Kind           domain.BalanceEventKind
+	Amount         decimal.Decimal
+	Status         domain.BalanceStatus
+	TxIds          []uuid.UUID
+	NegativePolicy *domain.NegativePolicy
+}
+
+type Cancellation struct {
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	FinishedAt        *time.Time
+	TenantID          string
+	CancellationID    uuid.UUID
+	Status            domain.CancellationStatus
+	Source            domain.Source
+	State             *domain.State
+	CreatedFrom       time.Time
+	CreatedTo         time.Time
+	ExternalRefPrefix *string
+	BalanceIds        []uuid.UUID
+	NegativePolicy    domain.NegativePolicy
+	Metadata          map[string]string
+}
+
+type CancellationBalance struct {
+	UpdatedAt      time.Time
+	CancellationID uuid.UUID
+	BalanceID      uuid.UUID
+	Status         domain.BalanceCancellationStatus
+	TxIds          []uuid.UUID
+	Correction     decimal.Decimal
+	Error          *string
+	SkippedTxIds   []uuid.UUID
+	Debt           decimal.Decimal
+}
+
+type Chargeback struct {
+	CreatedAt    time.Time
+	TenantID     string
+	ChargebackID uuid.UUID
+	BalanceID    uuid.UUID
+	TxID         uuid.UUID
+	Amount       decimal.Decimal
+	Correction   decimal.Decimal
+	Debt         decimal.Decimal
+	Reason       *string
+}
+
+type DebtEntry struct {
+	CreatedAt time.Time
+	TenantID  string
 	BalanceID uuid.UUID
+	Seq       int64
+	Kind      domain.DebtEntryKind
 	Amount    decimal.Decimal
+	Debt      decimal.Decimal
+	TxIds     []uuid.UUID
 }
 
-type Tx struct {
+type FlaggedEvent struct {
 	CreatedAt time.Time
-	DeletedAt *time.Time
+	EventID   uuid.UUID
+	BalanceID uuid.UUID
 	TxID      uuid.UUID
+	Rule      string
+	Action    domain.RuleAction
+	TenantID  string
+}
+
+type Tx struct {
+	CreatedAt   time.Time
+	DeletedAt   *time.Time
+	TxID        uuid.UUID
+	BalanceID   uuid.UUID
+	Source      domain.Source
+	State       domain.State
+	Amount      decimal.Decimal
+	TenantID    string
+	Seq         int64
+	ExternalRef *string
+	ParentTxID  *uuid.UUID
+	SettledDebt decimal.Decimal
+	Metadata    map[string]string
+}
+
+type TxDailyRollup struct {
+	TenantID  string
 	BalanceID uuid.UUID
+	Day       time.Time
 	Source    domain.Source
 	State     domain.State
+	TxCount   int64
 	Amount    decimal.Decimal
 }
@@ -7,66 +7,1529 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
 	"github.com/shopspring/decimal"
 )
 
+const aMLCases = `-- name: AMLCases :many
+select created_at, detected_at, case_id, balance_id, kind, status, state, amount, tx_ids, tenant_id
+from aml_cases
+where ($1::text is null or tenant_id = $1)
+    and detected_at >= $2::timestamptz and detected_at < $3::timestamptz
+order by detected_at, case_id
+`
+
+type AMLCasesParams struct {
+	TenantID     *string
+	DetectedFrom time.Time
+	DetectedTo   time.Time
+}
+
+func (q *Queries) AMLCases(ctx context.Context, arg AMLCasesParams) ([]AmlCase, error) {
+	rows, err := q.db.Query(ctx, aMLCases, arg.TenantID, arg.DetectedFrom, arg.DetectedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AmlCase
+	for rows.Next() {
+		var i AmlCase
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.DetectedAt,
+			&i.CaseID,
+			&i.BalanceID,
+			&i.Kind,
+			&i.Status,
+			&i.State,
+			&i.Amount,
+			&i.TxIds,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aggregateDailyRollups = `-- name: AggregateDailyRollups :many
+select (date_trunc($1::text, r.day::timestamp) at time zone 'UTC')::timestamptz as bucket_start, r.source, r.state,
+    sum(r.tx_count)::bigint as tx_count, sum(r.amount)::numeric as amount
+from tx_daily_rollups as r
+where r.tenant_id = $2
+    and ($3::uuid is null or r.balance_id = $3)
+    and r.day >= $4::date and r.day < $5::date
+group by 1, r.source, r.state
+having sum(r.tx_count) > 0
+order by 1, r.source, r.state
+`
+
+type AggregateDailyRollupsParams struct {
+	Bucket    string
+	TenantID  string
+	BalanceID *uuid.UUID
+	DayFrom   time.Time
+	DayTo     time.Time
+}
+
+type AggregateDailyRollupsRow struct {
+	BucketStart time.Time
+	Source      domain.Source
+	State       domain.State
+	TxCount     int64
+	Amount      decimal.Decimal
+}
+
+// Sums daily rollups per UTC bucket, buckets must not be shorter than a day.
+func (q *Queries) AggregateDailyRollups(ctx context.Context, arg AggregateDailyRollupsParams) ([]AggregateDailyRollupsRow, error) {
+	rows, err := q.db.Query(ctx, aggregateDailyRollups,
+		arg.Bucket,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.DayFrom,
+		arg.DayTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AggregateDailyRollupsRow
+	for rows.Next() {
+		var i AggregateDailyRollupsRow
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.Source,
+			&i.State,
+			&i.TxCount,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aggregateTxs = `-- name: AggregateTxs :many
+select date_trunc($1::text, t.created_at, $2::text)::timestamptz as bucket_start, t.source, t.state,
+    count(*) as tx_count, sum(t.amount)::numeric as amount
+from txs as t
+where t.tenant_id = $3
+    and ($4::uuid is null or t.balance_id = $4)
+    and t.deleted_at is null
+    and t.created_at >= $5::timestamptz and t.created_at < $6::timestamptz
+group by 1, t.source, t.state
+order by 1, t.source, t.state
+`
+
+type AggregateTxsParams struct {
+	Bucket      string
+	TimeZone    string
+	TenantID    string
+	BalanceID   *uuid.UUID
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+type AggregateTxsRow struct {
+	BucketStart time.Time
+	Source      domain.Source
+	State       domain.State
+	TxCount     int64
+	Amount      decimal.Decimal
+}
+
+// Sums not cancelled txs per bucket starting at the bucket unit boundary in the time zone.
+func (q *Queries) AggregateTxs(ctx context.Context, arg AggregateTxsParams) ([]AggregateTxsRow, error) {
+	rows, err := q.db.Query(ctx, aggregateTxs,
+		arg.Bucket,
+		arg.TimeZone,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AggregateTxsRow
+	for rows.Next() {
+		var i AggregateTxsRow
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.Source,
+			&i.State,
+			&i.TxCount,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const auditEntries = `-- name: AuditEntries :many
+select created_at, tenant_id, entry_id, operator, action, balance_id, tx_id, amount, reason, ticket_ref, outcome, attempt_id, error, status
+from audit_log
+where tenant_id = $1 and balance_id = $2
+order by created_at desc
+limit $3
+`
+
+type AuditEntriesParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	Limit     int32
+}
+
+func (q *Queries) AuditEntries(ctx context.Context, arg AuditEntriesParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, auditEntries, arg.TenantID, arg.BalanceID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.TenantID,
+			&i.EntryID,
+			&i.Operator,
+			&i.Action,
+			&i.BalanceID,
+			&i.TxID,
+			&i.Amount,
+			&i.Reason,
+			&i.TicketRef,
+			&i.Outcome,
+			&i.AttemptID,
+			&i.Error,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const balance = `-- name: Balance :one
-select balance_id, amount
+select balance_id, amount, status, owner_id, external_player_ref, currency, wallet_type, tenant_id, last_tx_seq, created_at, last_event_seq, updated_at, last_activity_at, tx_count, version, debt, last_debt_seq
 from balances
-where balance_id = $1
+where tenant_id = $1 and balance_id = $2
+`
+
+type BalanceParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+}
+
+func (q *Queries) Balance(ctx context.Context, arg BalanceParams) (Balance, error) {
+	row := q.db.QueryRow(ctx, balance, arg.TenantID, arg.BalanceID)
+	var i Balance
+	err := row.Scan(
+		&i.BalanceID,
+		&i.Amount,
+		&i.Status,
+		&i.OwnerID,
+		&i.ExternalPlayerRef,
+		&i.Currency,
+		&i.WalletType,
+		&i.TenantID,
+		&i.LastTxSeq,
+		&i.CreatedAt,
+		&i.LastEventSeq,
+		&i.UpdatedAt,
+		&i.LastActivityAt,
+		&i.TxCount,
+		&i.Version,
+		&i.Debt,
+		&i.LastDebtSeq,
+	)
+	return i, err
+}
+
+const balanceEvents = `-- name: BalanceEvents :many
+select created_at, tenant_id, balance_id, seq, kind, amount, status, tx_ids, negative_policy
+from balance_events
+where tenant_id = $1 and balance_id = $2 and seq > $3
+order by seq
+limit $4
+`
+
+type BalanceEventsParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	AfterSeq  int64
+	Limit     int32
+}
+
+func (q *Queries) BalanceEvents(ctx context.Context, arg BalanceEventsParams) ([]BalanceEvent, error) {
+	rows, err := q.db.Query(ctx, balanceEvents,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.AfterSeq,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BalanceEvent
+	for rows.Next() {
+		var i BalanceEvent
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.TenantID,
+			&i.BalanceID,
+			&i.Seq,
+			&i.Kind,
+			&i.Amount,
+			&i.Status,
+			&i.TxIds,
+			&i.NegativePolicy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cancellation = `-- name: Cancellation :one
+select created_at, updated_at, finished_at, tenant_id, cancellation_id, status, source, state, created_from, created_to, external_ref_prefix, balance_ids, negative_policy, metadata
+from cancellations
+where tenant_id = $1 and cancellation_id = $2
+`
+
+type CancellationParams struct {
+	TenantID       string
+	CancellationID uuid.UUID
+}
+
+func (q *Queries) Cancellation(ctx context.Context, arg CancellationParams) (Cancellation, error) {
+	row := q.db.QueryRow(ctx, cancellation, arg.TenantID, arg.CancellationID)
+	var i Cancellation
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.TenantID,
+		&i.CancellationID,
+		&i.Status,
+		&i.Source,
+		&i.State,
+		&i.CreatedFrom,
+		&i.CreatedTo,
+		&i.ExternalRefPrefix,
+		&i.BalanceIds,
+		&i.NegativePolicy,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const cancellationBalances = `-- name: CancellationBalances :many
+select updated_at, cancellation_id, balance_id, status, tx_ids, correction, error, skipped_tx_ids, debt
+from cancellation_balances
+where cancellation_id = $1 and ($2::uuid is null or balance_id > $2)
+order by balance_id
+limit $3
+`
+
+type CancellationBalancesParams struct {
+	CancellationID uuid.UUID
+	AfterBalanceID *uuid.UUID
+	Limit          int32
+}
+
+func (q *Queries) CancellationBalances(ctx context.Context, arg CancellationBalancesParams) ([]CancellationBalance, error) {
+	rows, err := q.db.Query(ctx, cancellationBalances, arg.CancellationID, arg.AfterBalanceID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CancellationBalance
+	for rows.Next() {
+		var i CancellationBalance
+		if err := rows.Scan(
+			&i.UpdatedAt,
+			&i.CancellationID,
+			&i.BalanceID,
+			&i.Status,
+			&i.TxIds,
+			&i.Correction,
+			&i.Error,
+			&i.SkippedTxIds,
+			&i.Debt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cancellationProgress = `-- name: CancellationProgress :one
+select count(*) as balances_total,
+    count(*) filter (where status = 'Done') as balances_done,
+    count(*) filter (where status = 'Failed') as balances_failed,
+    coalesce(sum(cardinality(tx_ids)), 0)::bigint as txs_cancelled
+from cancellation_balances
+where cancellation_id = $1
+`
+
+type CancellationProgressRow struct {
+	BalancesTotal  int64
+	BalancesDone   int64
+	BalancesFailed int64
+	TxsCancelled   int64
+}
+
+func (q *Queries) CancellationProgress(ctx context.Context, cancellationID uuid.UUID) (CancellationProgressRow, error) {
+	row := q.db.QueryRow(ctx, cancellationProgress, cancellationID)
+	var i CancellationProgressRow
+	err := row.Scan(
+		&i.BalancesTotal,
+		&i.BalancesDone,
+		&i.BalancesFailed,
+		&i.TxsCancelled,
+	)
+	return i, err
+}
+
+const criteriaTxs = `-- name: CriteriaTxs :many
+select t.created_at, t.deleted_at, t.tx_id, t.balance_id, t.source, t.state, t.amount, t.tenant_id, t.seq, t.external_ref, t.parent_tx_id, t.settled_debt, t.metadata
+from txs as t
+join cancellations as c on c.tenant_id = t.tenant_id
+where c.cancellation_id = $1 and t.balance_id = $2 and t.deleted_at is null
+    and t.source = c.source and (c.state is null or t.state = c.state)
+    and t.created_at >= c.created_from and t.created_at < c.created_to
+    and (c.external_ref_prefix is null or starts_with(t.external_ref, c.external_ref_prefix))
+    and t.metadata @> c.metadata
+order by t.seq
+`
+
+type CriteriaTxsParams struct {
+	CancellationID uuid.UUID
+	BalanceID      uuid.UUID
+}
+
+// Not cancelled txs of the balance matching criteria of the cancellation.
+func (q *Queries) CriteriaTxs(ctx context.Context, arg CriteriaTxsParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, criteriaTxs, arg.CancellationID, arg.BalanceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tx
+	for rows.Next() {
+		var i Tx
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.TxID,
+			&i.BalanceID,
+			&i.Source,
+			&i.State,
+			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const debtEntries = `-- name: DebtEntries :many
+select created_at, tenant_id, balance_id, seq, kind, amount, debt, tx_ids
+from debt_entries
+where tenant_id = $1 and balance_id = $2 and seq > $3
+order by seq
+limit $4
+`
+
+type DebtEntriesParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	AfterSeq  int64
+	Limit     int32
+}
+
+func (q *Queries) DebtEntries(ctx context.Context, arg DebtEntriesParams) ([]DebtEntry, error) {
+	rows, err := q.db.Query(ctx, debtEntries,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.AfterSeq,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DebtEntry
+	for rows.Next() {
+		var i DebtEntry
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.TenantID,
+			&i.BalanceID,
+			&i.Seq,
+			&i.Kind,
+			&i.Amount,
+			&i.Debt,
+			&i.TxIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const declareStatementCursor = `-- name: DeclareStatementCursor :exec
+declare statement_cursor no scroll cursor for
+select running.entry_at, running.seq, running.cancellation, running.tx_id, running.external_ref, running.source, running.state,
+    running.amount, running.change, coalesce(running.balance_after, running.summed_balance)::numeric as running_balance
+from (
+    select entries.entry_at, entries.seq, entries.cancellation, entries.tx_id, entries.external_ref, entries.source, entries.state, entries.amount, entries.change, entries.balance_after, sum(entries.change) over (order by entries.entry_at, entries.seq, entries.cancellation rows unbounded preceding)::numeric as summed_balance
+    from (
+        select t.created_at as entry_at, t.seq, false as cancellation, t.tx_id, t.external_ref, t.source, t.state, t.amount,
+            case t.state when 'Deposit' then t.amount else -t.amount end as change,
+            r.amount as balance_after
+        from txs as t
+        left join (
+            select unnest(e.tx_ids) as tx_id, e.amount
+            from balance_events as e
+            where e.tenant_id = $1 and e.balance_id = $2 and e.kind = 'TxRecorded'
+        ) as r on r.tx_id = t.tx_id
+        where t.tenant_id = $1 and t.balance_id = $2
+        union all
+        select t.deleted_at, t.seq, true, t.tx_id, t.external_ref, t.source, t.state, t.amount,
+            case t.state when 'Deposit' then -t.amount else t.amount end,
+            c.amount
+        from txs as t
+        left join (
+            select unnest(e.tx_ids) as tx_id, e.amount
+            from balance_events as e
+            where e.tenant_id = $1 and e.balance_id = $2 and e.kind = 'TxsCancelled'
+        ) as c on c.tx_id = t.tx_id
+        where t.tenant_id = $1 and t.balance_id = $2 and t.deleted_at is not null
+    ) as entries
+) as running
+where ($3::timestamptz is null or running.entry_at >= $3)
+    and ($4::timestamptz is null or running.entry_at < $4)
+order by running.entry_at, running.seq, running.cancellation
+`
+
+type DeclareStatementCursorParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	FromTime  *time.Time
+	ToTime    *time.Time
+}
+
+// Lists txs and their cancellations with the balance after each of them, rows are read with fetch in batches.
+// Balance after an entry is the amount of the event which recorded or cancelled the tx, so settled and incurred debt is counted.
+// Txs recorded before events were added have no events, their balance is summed over the whole history.
+func (q *Queries) DeclareStatementCursor(ctx context.Context, arg DeclareStatementCursorParams) error {
+	_, err := q.db.Exec(ctx, declareStatementCursor,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	return err
+}
+
+const deleteTxs = `-- name: DeleteTxs :execrows
+update txs
+set deleted_at = now()
+where tenant_id = $1 and balance_id = $2 and tx_id = any($3::uuid[]) and deleted_at is null
+`
+
+type DeleteTxsParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	TxIds     []uuid.UUID
+}
+
+func (q *Queries) DeleteTxs(ctx context.Context, arg DeleteTxsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTxs, arg.TenantID, arg.BalanceID, arg.TxIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const descendantTxs = `-- name: DescendantTxs :many
+with recursive descendants as (
+    select t.tx_id
+    from txs as t
+    where t.tenant_id = $1 and t.balance_id = $2 and t.parent_tx_id = any($3::uuid[])
+    union
+    select c.tx_id
+    from txs as c
+    join descendants as d on c.parent_tx_id = d.tx_id
+    where c.tenant_id = $1 and c.balance_id = $2
+)
+select txs.created_at, txs.deleted_at, txs.tx_id, txs.balance_id, txs.source, txs.state, txs.amount, txs.tenant_id, txs.seq, txs.external_ref, txs.parent_tx_id, txs.settled_debt, txs.metadata
+from txs
+where txs.tenant_id = $1 and txs.balance_id = $2 and txs.deleted_at is null
+    and txs.tx_id in (select descendants.tx_id from descendants)
+order by txs.seq
+`
+
+type DescendantTxsParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	TxIds     []uuid.UUID
+}
+
+// Not cancelled txs linked to the txs through parents at any depth.
+func (q *Queries) DescendantTxs(ctx context.Context, arg DescendantTxsParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, descendantTxs, arg.TenantID, arg.BalanceID, arg.TxIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tx
+	for rows.Next() {
+		var i Tx
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.TxID,
+			&i.BalanceID,
+			&i.Source,
+			&i.State,
+			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertAMLCase = `-- name: InsertAMLCase :execrows
+insert into aml_cases (tenant_id, case_id, detected_at, balance_id, kind, state, amount, tx_ids)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type InsertAMLCaseParams struct {
+	TenantID   string
+	CaseID     uuid.UUID
+	DetectedAt time.Time
+	BalanceID  uuid.UUID
+	Kind       domain.AMLCaseKind
+	State      domain.State
+	Amount     decimal.Decimal
+	TxIds      []uuid.UUID
+}
+
+func (q *Queries) InsertAMLCase(ctx context.Context, arg InsertAMLCaseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertAMLCase,
+		arg.TenantID,
+		arg.CaseID,
+		arg.DetectedAt,
+		arg.BalanceID,
+		arg.Kind,
+		arg.State,
+		arg.Amount,
+		arg.TxIds,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertAuditEntry = `-- name: InsertAuditEntry :execrows
+insert into audit_log (tenant_id, entry_id, operator, action, balance_id, tx_id, amount, reason, ticket_ref, outcome, attempt_id, error, status)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+`
+
+type InsertAuditEntryParams struct {
+	TenantID  string
+	EntryID   uuid.UUID
+	Operator  string
+	Action    domain.AuditAction
+	BalanceID uuid.UUID
+	TxID      *uuid.UUID
+	Amount    *decimal.Decimal
+	Reason    string
+	TicketRef string
+	Outcome   domain.AuditOutcome
+	AttemptID *uuid.UUID
+	Error     *string
+	Status    *domain.BalanceStatus
+}
+
+func (q *Queries) InsertAuditEntry(ctx context.Context, arg InsertAuditEntryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertAuditEntry,
+		arg.TenantID,
+		arg.EntryID,
+		arg.Operator,
+		arg.Action,
+		arg.BalanceID,
+		arg.TxID,
+		arg.Amount,
+		arg.Reason,
+		arg.TicketRef,
+		arg.Outcome,
+		arg.AttemptID,
+		arg.Error,
+		arg.Status,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertBalanceEvent = `-- name: InsertBalanceEvent :one
+with updated as (
+    update balances
+    set last_event_seq = balances.last_event_seq + 1
+    where balances.tenant_id = $4 and balances.balance_id = $5
+    returning balances.tenant_id, balances.balance_id, balances.last_event_seq, balances.amount, balances.status
+)
+insert into balance_events (tenant_id, balance_id, seq, kind, amount, status, tx_ids, negative_policy)
+select updated.tenant_id, updated.balance_id, updated.last_event_seq, $1::balance_event_kind, updated.amount, updated.status, $2::uuid[],
+    $3::negative_policy
+from updated
+returning seq
+`
+
+type InsertBalanceEventParams struct {
+	Kind           domain.BalanceEventKind
+	TxIds          []uuid.UUID
+	NegativePolicy *domain.NegativePolicy
+	TenantID       string
+	BalanceID      uuid.UUID
+}
+
+// Records the current amount and status of the balance, so it must be called after the balance is updated.
+func (q *Queries) InsertBalanceEvent(ctx context.Context, arg InsertBalanceEventParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertBalanceEvent,
+		arg.Kind,
+		arg.TxIds,
+		arg.NegativePolicy,
+		arg.TenantID,
+		arg.BalanceID,
+	)
+	var seq int64
+	err := row.Scan(&seq)
+	return seq, err
+}
+
+const insertCancellation = `-- name: InsertCancellation :one
+insert into cancellations (tenant_id, cancellation_id, source, state, created_from, created_to, external_ref_prefix, balance_ids, negative_policy, metadata)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+returning created_at, updated_at, finished_at, tenant_id, cancellation_id, status, source, state, created_from, created_to, external_ref_prefix, balance_ids, negative_policy, metadata
+`
+
+type InsertCancellationParams struct {
+	TenantID          string
+	CancellationID    uuid.UUID
+	Source            domain.Source
+	State             *domain.State
+	CreatedFrom       time.Time
+	CreatedTo         time.Time
+	ExternalRefPrefix *string
+	BalanceIds        []uuid.UUID
+	NegativePolicy    domain.NegativePolicy
+	Metadata          map[string]string
+}
+
+func (q *Queries) InsertCancellation(ctx context.Context, arg InsertCancellationParams) (Cancellation, error) {
+	row := q.db.QueryRow(ctx, insertCancellation,
+		arg.TenantID,
+		arg.CancellationID,
+		arg.Source,
+		arg.State,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.ExternalRefPrefix,
+		arg.BalanceIds,
+		arg.NegativePolicy,
+		arg.Metadata,
+	)
+	var i Cancellation
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.TenantID,
+		&i.CancellationID,
+		&i.Status,
+		&i.Source,
+		&i.State,
+		&i.CreatedFrom,
+		&i.CreatedTo,
+		&i.ExternalRefPrefix,
+		&i.BalanceIds,
+		&i.NegativePolicy,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const insertCancellationBalances = `-- name: InsertCancellationBalances :execrows
+insert into cancellation_balances (cancellation_id, balance_id)
+select distinct c.cancellation_id, t.balance_id
+from cancellations as c
+join txs as t on t.tenant_id = c.tenant_id
+where c.cancellation_id = $1 and t.deleted_at is null
+    and t.source = c.source and (c.state is null or t.state = c.state)
+    and t.created_at >= c.created_from and t.created_at < c.created_to
+    and (c.external_ref_prefix is null or starts_with(t.external_ref, c.external_ref_prefix))
+    and t.metadata @> c.metadata
+    and (cardinality(c.balance_ids) = 0 or t.balance_id = any(c.balance_ids))
+`
+
+// Empty balance IDs match all balances of the tenant, empty metadata matches all txs.
+func (q *Queries) InsertCancellationBalances(ctx context.Context, cancellationID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, insertCancellationBalances, cancellationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertChargeback = `-- name: InsertChargeback :one
+insert into chargebacks (tenant_id, chargeback_id, balance_id, tx_id, amount, correction, debt, reason)
+values ($1, $2, $3, $4, $5, $6, $7, $8)
+returning created_at, tenant_id, chargeback_id, balance_id, tx_id, amount, correction, debt, reason
+`
+
+type InsertChargebackParams struct {
+	TenantID     string
+	ChargebackID uuid.UUID
+	BalanceID    uuid.UUID
+	TxID         uuid.UUID
+	Amount       decimal.Decimal
+	Correction   decimal.Decimal
+	Debt         decimal.Decimal
+	Reason       *string
+}
+
+func (q *Queries) InsertChargeback(ctx context.Context, arg InsertChargebackParams) (Chargeback, error) {
+	row := q.db.QueryRow(ctx, insertChargeback,
+		arg.TenantID,
+		arg.ChargebackID,
+		arg.BalanceID,
+		arg.TxID,
+		arg.Amount,
+		arg.Correction,
+		arg.Debt,
+		arg.Reason,
+	)
+	var i Chargeback
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.TenantID,
+		&i.ChargebackID,
+		&i.BalanceID,
+		&i.TxID,
+		&i.Amount,
+		&i.Correction,
+		&i.Debt,
+		&i.Reason,
+	)
+	return i, err
+}
+
+const insertDebtEntry = `-- name: InsertDebtEntry :execrows
+with updated as (
+    update balances
+    set last_debt_seq = balances.last_debt_seq + 1
+    where balances.tenant_id = $5 and balances.balance_id = $6
+    returning balances.tenant_id, balances.balance_id, balances.last_debt_seq
+)
+insert into debt_entries (tenant_id, balance_id, seq, kind, amount, debt, tx_ids)
+select updated.tenant_id, updated.balance_id, updated.last_debt_seq, $1::debt_entry_kind, $2::numeric, $3::numeric, $4::uuid[]
+from updated
+`
+
+type InsertDebtEntryParams struct {
+	Kind      domain.DebtEntryKind
+	Amount    decimal.Decimal
+	Debt      decimal.Decimal
+	TxIds     []uuid.UUID
+	TenantID  string
+	BalanceID uuid.UUID
+}
+
+// Debt after the change is passed, so one balance update can be split into several entries.
+func (q *Queries) InsertDebtEntry(ctx context.Context, arg InsertDebtEntryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertDebtEntry,
+		arg.Kind,
+		arg.Amount,
+		arg.Debt,
+		arg.TxIds,
+		arg.TenantID,
+		arg.BalanceID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertFlaggedEvent = `-- name: InsertFlaggedEvent :execrows
+insert into flagged_events (tenant_id, event_id, balance_id, tx_id, rule, action)
+values ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertFlaggedEventParams struct {
+	TenantID  string
+	EventID   uuid.UUID
+	BalanceID uuid.UUID
+	TxID      uuid.UUID
+	Rule      string
+	Action    domain.RuleAction
+}
+
+func (q *Queries) InsertFlaggedEvent(ctx context.Context, arg InsertFlaggedEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertFlaggedEvent,
+		arg.TenantID,
+		arg.EventID,
+		arg.BalanceID,
+		arg.TxID,
+		arg.Rule,
+		arg.Action,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertTx = `-- name: InsertTx :execrows
+insert into txs (tenant_id, balance_id, source, state, amount, tx_id, seq, external_ref, parent_tx_id, settled_debt, metadata)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+
+type InsertTxParams struct {
+	TenantID    string
+	BalanceID   uuid.UUID
+	Source      domain.Source
+	State       domain.State
+	Amount      decimal.Decimal
+	TxID        uuid.UUID
+	Seq         int64
+	ExternalRef *string
+	ParentTxID  *uuid.UUID
+	SettledDebt decimal.Decimal
+	Metadata    map[string]string
+}
+
+func (q *Queries) InsertTx(ctx context.Context, arg InsertTxParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertTx,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.Source,
+		arg.State,
+		arg.Amount,
+		arg.TxID,
+		arg.Seq,
+		arg.ExternalRef,
+		arg.ParentTxID,
+		arg.SettledDebt,
+		arg.Metadata,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const lockBalance = `-- name: LockBalance :execrows
+SELECT pg_advisory_xact_lock(hashtext($1::text || ':' || ($2::uuid)::text))
+`
+
+type LockBalanceParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+}
+
+// Lock a single balance row. Tenant is a part of the key, so tenants never wait for each other.
+func (q *Queries) LockBalance(ctx context.Context, arg LockBalanceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockBalance, arg.TenantID, arg.BalanceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const lockCancellation = `-- name: LockCancellation :execrows
+select 1
+from cancellations
+where cancellation_id = $1
+for update
+`
+
+// Status is updated after other replicas commit their balances, so the last one sees no pending balances.
+func (q *Queries) LockCancellation(ctx context.Context, cancellationID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, lockCancellation, cancellationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const monitorCheckpoint = `-- name: MonitorCheckpoint :one
+select scanned_until
+from aml_checkpoints
+where monitor = $1
+`
+
+func (q *Queries) MonitorCheckpoint(ctx context.Context, monitor string) (time.Time, error) {
+	row := q.db.QueryRow(ctx, monitorCheckpoint, monitor)
+	var scanned_until time.Time
+	err := row.Scan(&scanned_until)
+	return scanned_until, err
+}
+
+const nextCancellationBalance = `-- name: NextCancellationBalance :one
+select c.created_at, c.updated_at, c.finished_at, c.tenant_id, c.cancellation_id, c.status, c.source, c.state, c.created_from, c.created_to, c.external_ref_prefix, c.balance_ids, c.negative_policy, c.metadata, cb.balance_id
+from cancellation_balances as cb
+join cancellations as c on c.cancellation_id = cb.cancellation_id
+where cb.status = 'Pending' and c.status <> 'Done'
+order by c.created_at, cb.balance_id
+limit 1
+for update of cb skip locked
+`
+
+type NextCancellationBalanceRow struct {
+	Cancellation Cancellation
+	BalanceID    uuid.UUID
+}
+
+// Replicas process different balances concurrently, older cancellations go first.
+func (q *Queries) NextCancellationBalance(ctx context.Context) (NextCancellationBalanceRow, error) {
+	row := q.db.QueryRow(ctx, nextCancellationBalance)
+	var i NextCancellationBalanceRow
+	err := row.Scan(
+		&i.Cancellation.CreatedAt,
+		&i.Cancellation.UpdatedAt,
+		&i.Cancellation.FinishedAt,
+		&i.Cancellation.TenantID,
+		&i.Cancellation.CancellationID,
+		&i.Cancellation.Status,
+		&i.Cancellation.Source,
+		&i.Cancellation.State,
+		&i.Cancellation.CreatedFrom,
+		&i.Cancellation.CreatedTo,
+		&i.Cancellation.ExternalRefPrefix,
+		&i.Cancellation.BalanceIds,
+		&i.Cancellation.NegativePolicy,
+		&i.Cancellation.Metadata,
+		&i.BalanceID,
+	)
+	return i, err
+}
+
+const nextTxSeq = `-- name: NextTxSeq :one
+update balances
+set last_tx_seq = last_tx_seq + 1
+where tenant_id = $1 and balance_id = $2
+returning last_tx_seq
+`
+
+type NextTxSeqParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+}
+
+// Must be called under the balance lock, so txs of a balance are numbered without gaps.
+func (q *Queries) NextTxSeq(ctx context.Context, arg NextTxSeqParams) (int64, error) {
+	row := q.db.QueryRow(ctx, nextTxSeq, arg.TenantID, arg.BalanceID)
+	var last_tx_seq int64
+	err := row.Scan(&last_tx_seq)
+	return last_tx_seq, err
+}
+
+const notifyBalanceEvent = `-- name: NotifyBalanceEvent :exec
+select pg_notify('balance_events', $1::text)
+`
+
+// Notifications are delivered on commit.
+func (q *Queries) NotifyBalanceEvent(ctx context.Context, payload string) error {
+	_, err := q.db.Exec(ctx, notifyBalanceEvent, payload)
+	return err
+}
+
+const openBalance = `-- name: OpenBalance :one
+insert into balances (tenant_id, balance_id, amount, owner_id, external_player_ref, currency, wallet_type)
+values ($1, $2, 0, $3, $4, $5, $6)
+returning balance_id, amount, status, owner_id, external_player_ref, currency, wallet_type, tenant_id, last_tx_seq, created_at, last_event_seq, updated_at, last_activity_at, tx_count, version, debt, last_debt_seq
+`
+
+type OpenBalanceParams struct {
+	TenantID          string
+	BalanceID         uuid.UUID
+	OwnerID           *uuid.UUID
+	ExternalPlayerRef *string
+	Currency          *string
+	WalletType        domain.WalletType
+}
+
+func (q *Queries) OpenBalance(ctx context.Context, arg OpenBalanceParams) (Balance, error) {
+	row := q.db.QueryRow(ctx, openBalance,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.OwnerID,
+		arg.ExternalPlayerRef,
+		arg.Currency,
+		arg.WalletType,
+	)
+	var i Balance
+	err := row.Scan(
+		&i.BalanceID,
+		&i.Amount,
+		&i.Status,
+		&i.OwnerID,
+		&i.ExternalPlayerRef,
+		&i.Currency,
+		&i.WalletType,
+		&i.TenantID,
+		&i.LastTxSeq,
+		&i.CreatedAt,
+		&i.LastEventSeq,
+		&i.UpdatedAt,
+		&i.LastActivityAt,
+		&i.TxCount,
+		&i.Version,
+		&i.Debt,
+		&i.LastDebtSeq,
+	)
+	return i, err
+}
+
+const previousFlaggedEvents = `-- name: PreviousFlaggedEvents :many
+select created_at, event_id, balance_id, tx_id, rule, action, tenant_id
+from flagged_events
+where tenant_id = $1 and ($2::uuid is null or balance_id = $2) and event_id < $3
+order by event_id desc
+limit $4
+`
+
+type PreviousFlaggedEventsParams struct {
+	TenantID  string
+	BalanceID *uuid.UUID
+	EventID   uuid.UUID
+	Limit     int32
+}
+
+func (q *Queries) PreviousFlaggedEvents(ctx context.Context, arg PreviousFlaggedEventsParams) ([]FlaggedEvent, error) {
+	rows, err := q.db.Query(ctx, previousFlaggedEvents,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.EventID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FlaggedEvent
+	for rows.Next() {
+		var i FlaggedEvent
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.EventID,
+			&i.BalanceID,
+			&i.TxID,
+			&i.Rule,
+			&i.Action,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recentFlaggedEvents = `-- name: RecentFlaggedEvents :many
+select created_at, event_id, balance_id, tx_id, rule, action, tenant_id
+from flagged_events
+where tenant_id = $1 and ($2::uuid is null or balance_id = $2)
+order by event_id desc
+limit $3
 `
 
-func (q *Queries) Balance(ctx context.Context, balanceID uuid.UUID) (Balance, error) {
-	row := q.db.QueryRow(ctx, balance, balanceID)
-	var i Balance
-	err := row.Scan(&i.BalanceID, &i.Amount)
-	return i, err
+type RecentFlaggedEventsParams struct {
+	TenantID  string
+	BalanceID *uuid.UUID
+	Limit     int32
 }
 
-const deleteTxs = `-- name: DeleteTxs :execrows
-update txs
-set deleted_at = now()
-where balance_id = $1 and tx_id = any($2::uuid[]) and deleted_at is null
+func (q *Queries) RecentFlaggedEvents(ctx context.Context, arg RecentFlaggedEventsParams) ([]FlaggedEvent, error) {
+	rows, err := q.db.Query(ctx, recentFlaggedEvents, arg.TenantID, arg.BalanceID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FlaggedEvent
+	for rows.Next() {
+		var i FlaggedEvent
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.EventID,
+			&i.BalanceID,
+			&i.TxID,
+			&i.Rule,
+			&i.Action,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const relatedTxs = `-- name: RelatedTxs :many
+with recursive ancestors as (
+    select t.tx_id, t.parent_tx_id, 0 as depth
+    from txs as t
+    where t.tenant_id = $1 and t.tx_id = $3
+    union all
+    select p.tx_id, p.parent_tx_id, a.depth + 1
+    from txs as p
+    join ancestors as a on p.tx_id = a.parent_tx_id
+    where p.tenant_id = $1
+), root as (
+    select a.tx_id
+    from ancestors as a
+    order by a.depth desc
+    limit 1
+), tree as (
+    select r.tx_id
+    from root as r
+    union
+    select c.tx_id
+    from txs as c
+    join tree as p on c.parent_tx_id = p.tx_id
+    where c.tenant_id = $1
+)
+select txs.created_at, txs.deleted_at, txs.tx_id, txs.balance_id, txs.source, txs.state, txs.amount, txs.tenant_id, txs.seq, txs.external_ref, txs.parent_tx_id, txs.settled_debt, txs.metadata
+from txs
+where txs.tenant_id = $1 and txs.tx_id in (select tree.tx_id from tree)
+order by txs.seq
+limit $2
 `
 
-type DeleteTxsParams struct {
+type RelatedTxsParams struct {
+	TenantID string
+	Limit    int32
+	TxID     uuid.UUID
+}
+
+// Txs sharing the root parent with the tx: the root, all its descendants and the tx itself.
+func (q *Queries) RelatedTxs(ctx context.Context, arg RelatedTxsParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, relatedTxs, arg.TenantID, arg.Limit, arg.TxID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tx
+	for rows.Next() {
+		var i Tx
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.TxID,
+			&i.BalanceID,
+			&i.Source,
+			&i.State,
+			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rollupTxs = `-- name: RollupTxs :exec
+insert into tx_daily_rollups (tenant_id, balance_id, day, source, state, tx_count, amount)
+select t.tenant_id, t.balance_id, (t.created_at at time zone 'UTC')::date, t.source, t.state,
+    count(*) * $1::int, sum(t.amount) * $1::int
+from txs as t
+where t.tenant_id = $2 and t.balance_id = $3 and t.tx_id = any($4::uuid[]) and t.deleted_at is null
+group by t.tenant_id, t.balance_id, (t.created_at at time zone 'UTC')::date, t.source, t.state
+on conflict (tenant_id, balance_id, day, source, state) do update
+set tx_count = tx_daily_rollups.tx_count + excluded.tx_count, amount = tx_daily_rollups.amount + excluded.amount
+`
+
+type RollupTxsParams struct {
+	Sign      int32
+	TenantID  string
 	BalanceID uuid.UUID
 	TxIds     []uuid.UUID
 }
 
-func (q *Queries) DeleteTxs(ctx context.Context, arg DeleteTxsParams) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteTxs, arg.BalanceID, arg.TxIds)
+// Adds not cancelled txs to daily rollups with sign 1 and removes them with sign -1.
+func (q *Queries) RollupTxs(ctx context.Context, arg RollupTxsParams) error {
+	_, err := q.db.Exec(ctx, rollupTxs,
+		arg.Sign,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.TxIds,
+	)
+	return err
+}
+
+const searchBalances = `-- name: SearchBalances :many
+select b.balance_id, b.amount, b.status, b.owner_id, b.external_player_ref, b.currency, b.wallet_type, b.tenant_id, b.last_tx_seq, b.created_at, b.last_event_seq, b.updated_at, b.last_activity_at, b.tx_count, b.version, b.debt, b.last_debt_seq, ((case $1::text
+    when 'Amount' then b.amount
+    when 'TxCount' then b.tx_count
+    when 'UpdatedAt' then extract(epoch from b.updated_at)
+    when 'LastActivityAt' then coalesce(extract(epoch from b.last_activity_at), 0)
+    else extract(epoch from b.created_at)
+end) * (case when $2::bool then -1 else 1 end))::numeric as sort_key
+from balances as b
+where b.tenant_id = $3
+    and ($4::uuid is null or b.owner_id = $4)
+    and ($5::balance_status is null or b.status = $5)
+    and ($6::numeric is null or b.amount >= $6)
+    and ($7::numeric is null or b.amount <= $7)
+    and ($8::timestamptz is null or b.created_at >= $8)
+    and ($9::timestamptz is null or b.created_at < $9)
+    and ($10::timestamptz is null or b.updated_at >= $10)
+    and ($11::timestamptz is null or b.updated_at < $11)
+    and ($12::timestamptz is null or b.last_activity_at >= $12)
+    and ($13::bigint is null or b.tx_count >= $13)
+    and ($14::bigint is null or b.tx_count <= $14)
+    and ($15::numeric is null or ((case $1::text
+    when 'Amount' then b.amount
+    when 'TxCount' then b.tx_count
+    when 'UpdatedAt' then extract(epoch from b.updated_at)
+    when 'LastActivityAt' then coalesce(extract(epoch from b.last_activity_at), 0)
+    else extract(epoch from b.created_at)
+end) * (case when $2::bool then -1 else 1 end), b.balance_id) > ($15, $16::uuid))
+order by sort_key, b.balance_id
+limit $17
+`
+
+type SearchBalancesParams struct {
+	SortBy         string
+	Descending     bool
+	TenantID       string
+	OwnerID        *uuid.UUID
+	Status         *domain.BalanceStatus
+	MinAmount      *decimal.Decimal
+	MaxAmount      *decimal.Decimal
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	UpdatedFrom    *time.Time
+	UpdatedTo      *time.Time
+	ActiveSince    *time.Time
+	MinTxCount     *int64
+	MaxTxCount     *int64
+	AfterSortKey   *decimal.Decimal
+	AfterBalanceID *uuid.UUID
+	Limit          int32
+}
+
+type SearchBalancesRow struct {
+	Balance Balance
+	SortKey decimal.Decimal
+}
+
+// Balances are sorted by the sort key and balance ID. Sort key is negated for descending order, so keyset pagination works the same way for all sorts.
+// Balances without activity are sorted by last activity as if it was at the epoch.
+func (q *Queries) SearchBalances(ctx context.Context, arg SearchBalancesParams) ([]SearchBalancesRow, error) {
+	rows, err := q.db.Query(ctx, searchBalances,
+		arg.SortBy,
+		arg.Descending,
+		arg.TenantID,
+		arg.OwnerID,
+		arg.Status,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.UpdatedFrom,
+		arg.UpdatedTo,
+		arg.ActiveSince,
+		arg.MinTxCount,
+		arg.MaxTxCount,
+		arg.AfterSortKey,
+		arg.AfterBalanceID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchBalancesRow
+	for rows.Next() {
+		var i SearchBalancesRow
+		if err := rows.Scan(
+			&i.Balance.BalanceID,
+			&i.Balance.Amount,
+			&i.Balance.Status,
+			&i.Balance.OwnerID,
+			&i.Balance.ExternalPlayerRef,
+			&i.Balance.Currency,
+			&i.Balance.WalletType,
+			&i.Balance.TenantID,
+			&i.Balance.LastTxSeq,
+			&i.Balance.CreatedAt,
+			&i.Balance.LastEventSeq,
+			&i.Balance.UpdatedAt,
+			&i.Balance.LastActivityAt,
+			&i.Balance.TxCount,
+			&i.Balance.Version,
+			&i.Balance.Debt,
+			&i.Balance.LastDebtSeq,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAMLCasesStatus = `-- name: SetAMLCasesStatus :execrows
+update aml_cases
+set status = $1
+where case_id = any($2::uuid[]) and status = $3
+`
+
+type SetAMLCasesStatusParams struct {
+	Status        domain.AMLCaseStatus
+	CaseIds       []uuid.UUID
+	CurrentStatus domain.AMLCaseStatus
+}
+
+func (q *Queries) SetAMLCasesStatus(ctx context.Context, arg SetAMLCasesStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setAMLCasesStatus, arg.Status, arg.CaseIds, arg.CurrentStatus)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
-const insertTx = `-- name: InsertTx :execrows
-
-insert into txs (balance_id, source, state, amount, tx_id)
-values ($1, $2, $3, $4, $5)
+const setBalanceStatus = `-- name: SetBalanceStatus :execrows
+update balances
+set status = $1, version = version + 1, updated_at = now()
+where tenant_id = $2 and balance_id = $3
 `
 
-type InsertTxParams struct {
+type SetBalanceStatusParams struct {
+	Status    domain.BalanceStatus
+	TenantID  string
 	BalanceID uuid.UUID
-	Source    domain.Source
-	State     domain.State
-	Amount    decimal.Decimal
-	TxID      uuid.UUID
 }
 
-// Lock a single balance row.
-func (q *Queries) InsertTx(ctx context.Context, arg InsertTxParams) (int64, error) {
-	result, err := q.db.Exec(ctx, insertTx,
+func (q *Queries) SetBalanceStatus(ctx context.Context, arg SetBalanceStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setBalanceStatus, arg.Status, arg.TenantID, arg.BalanceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setCancellationBalance = `-- name: SetCancellationBalance :execrows
+update cancellation_balances
+set status = $1, tx_ids = $2, skipped_tx_ids = $3, correction = $4, debt = $5, error = $6,
+    updated_at = now()
+where cancellation_id = $7 and balance_id = $8
+`
+
+type SetCancellationBalanceParams struct {
+	Status         domain.BalanceCancellationStatus
+	TxIds          []uuid.UUID
+	SkippedTxIds   []uuid.UUID
+	Correction     decimal.Decimal
+	Debt           decimal.Decimal
+	Error          *string
+	CancellationID uuid.UUID
+	BalanceID      uuid.UUID
+}
+
+func (q *Queries) SetCancellationBalance(ctx context.Context, arg SetCancellationBalanceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setCancellationBalance,
+		arg.Status,
+		arg.TxIds,
+		arg.SkippedTxIds,
+		arg.Correction,
+		arg.Debt,
+		arg.Error,
+		arg.CancellationID,
 		arg.BalanceID,
-		arg.Source,
-		arg.State,
-		arg.Amount,
-		arg.TxID,
 	)
 	if err != nil {
 		return 0, err
@@ -74,53 +1537,185 @@ func (q *Queries) InsertTx(ctx context.Context, arg InsertTxParams) (int64, erro
 	return result.RowsAffected(), nil
 }
 
-const lockBalance = `-- name: LockBalance :execrows
-SELECT pg_advisory_xact_lock(hashtext(($1::uuid)::text))
+const setCancellationStatus = `-- name: SetCancellationStatus :execrows
+update cancellations as c
+set status = case when p.pending then 'Running'::cancellation_status else 'Done'::cancellation_status end,
+    finished_at = case when p.pending then null else now() end,
+    updated_at = now()
+from (
+    select exists (
+        select 1
+        from cancellation_balances as cb
+        where cb.cancellation_id = $1 and cb.status = 'Pending'
+    ) as pending
+) as p
+where c.cancellation_id = $1
 `
 
-func (q *Queries) LockBalance(ctx context.Context, balanceID uuid.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, lockBalance, balanceID)
+// Cancellations are done when no balances are pending.
+func (q *Queries) SetCancellationStatus(ctx context.Context, cancellationID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, setCancellationStatus, cancellationID)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
-const openBalance = `-- name: OpenBalance :execrows
-insert into balances (balance_id, amount)
-values ($1, 0)
+const setMonitorCheckpoint = `-- name: SetMonitorCheckpoint :execrows
+update aml_checkpoints
+set scanned_until = $2
+where monitor = $1
 `
 
-func (q *Queries) OpenBalance(ctx context.Context, balanceID uuid.UUID) (int64, error) {
-	result, err := q.db.Exec(ctx, openBalance, balanceID)
+type SetMonitorCheckpointParams struct {
+	Monitor      string
+	ScannedUntil time.Time
+}
+
+func (q *Queries) SetMonitorCheckpoint(ctx context.Context, arg SetMonitorCheckpointParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setMonitorCheckpoint, arg.Monitor, arg.ScannedUntil)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
 
-const previousTxs = `-- name: PreviousTxs :many
-select created_at, deleted_at, tx_id, balance_id, source, state, amount
+const tryLockMonitor = `-- name: TryLockMonitor :one
+SELECT pg_try_advisory_xact_lock(hashtext($1::text))
+`
+
+// Only one replica runs a monitor at a time.
+func (q *Queries) TryLockMonitor(ctx context.Context, monitor string) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockMonitor, monitor)
+	var pg_try_advisory_xact_lock bool
+	err := row.Scan(&pg_try_advisory_xact_lock)
+	return pg_try_advisory_xact_lock, err
+}
+
+const txByExternalRef = `-- name: TxByExternalRef :one
+select created_at, deleted_at, tx_id, balance_id, source, state, amount, tenant_id, seq, external_ref, parent_tx_id, settled_debt, metadata
 from txs
-where balance_id = $1 and tx_id < $2 and (deleted_at is null or $4::bool)
-order by tx_id desc
-limit $3
+where tenant_id = $1 and external_ref = $2
 `
 
-type PreviousTxsParams struct {
-	BalanceID      uuid.UUID
-	TxID           uuid.UUID
-	Limit          int32
-	IncludeDeleted bool
+type TxByExternalRefParams struct {
+	TenantID    string
+	ExternalRef *string
 }
 
-func (q *Queries) PreviousTxs(ctx context.Context, arg PreviousTxsParams) ([]Tx, error) {
-	rows, err := q.db.Query(ctx, previousTxs,
-		arg.BalanceID,
-		arg.TxID,
-		arg.Limit,
-		arg.IncludeDeleted,
+func (q *Queries) TxByExternalRef(ctx context.Context, arg TxByExternalRefParams) (Tx, error) {
+	row := q.db.QueryRow(ctx, txByExternalRef, arg.TenantID, arg.ExternalRef)
+	var i Tx
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.TxID,
+		&i.BalanceID,
+		&i.Source,
+		&i.State,
+		&i.Amount,
+		&i.TenantID,
+		&i.Seq,
+		&i.ExternalRef,
+		&i.ParentTxID,
+		&i.SettledDebt,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const txByID = `-- name: TxByID :one
+select created_at, deleted_at, tx_id, balance_id, source, state, amount, tenant_id, seq, external_ref, parent_tx_id, settled_debt, metadata
+from txs
+where tenant_id = $1 and tx_id = $2
+`
+
+type TxByIDParams struct {
+	TenantID string
+	TxID     uuid.UUID
+}
+
+func (q *Queries) TxByID(ctx context.Context, arg TxByIDParams) (Tx, error) {
+	row := q.db.QueryRow(ctx, txByID, arg.TenantID, arg.TxID)
+	var i Tx
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.TxID,
+		&i.BalanceID,
+		&i.Source,
+		&i.State,
+		&i.Amount,
+		&i.TenantID,
+		&i.Seq,
+		&i.ExternalRef,
+		&i.ParentTxID,
+		&i.SettledDebt,
+		&i.Metadata,
 	)
+	return i, err
+}
+
+const txStats = `-- name: TxStats :many
+select source, state, count(*) as count, coalesce(sum(amount), 0)::numeric as sum
+from txs
+where tenant_id = $1 and balance_id = $2 and created_at >= $3::timestamptz and deleted_at is null
+group by source, state
+`
+
+type TxStatsParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	Since     time.Time
+}
+
+type TxStatsRow struct {
+	Source domain.Source
+	State  domain.State
+	Count  int64
+	Sum    decimal.Decimal
+}
+
+// Cancelled txs were reversed, so rules don't count them.
+func (q *Queries) TxStats(ctx context.Context, arg TxStatsParams) ([]TxStatsRow, error) {
+	rows, err := q.db.Query(ctx, txStats, arg.TenantID, arg.BalanceID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TxStatsRow
+	for rows.Next() {
+		var i TxStatsRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.State,
+			&i.Count,
+			&i.Sum,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const txsByID = `-- name: TxsByID :many
+select created_at, deleted_at, tx_id, balance_id, source, state, amount, tenant_id, seq, external_ref, parent_tx_id, settled_debt, metadata
+from txs
+where tenant_id = $1 and balance_id = $2 and tx_id = any($3::uuid[])
+`
+
+type TxsByIDParams struct {
+	TenantID  string
+	BalanceID uuid.UUID
+	TxIds     []uuid.UUID
+}
+
+func (q *Queries) TxsByID(ctx context.Context, arg TxsByIDParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, txsByID, arg.TenantID, arg.BalanceID, arg.TxIds)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +1731,12 @@ func (q *Queries) PreviousTxs(ctx context.Context, arg PreviousTxsParams) ([]Tx,
 			&i.Source,
 			&i.State,
 			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
 		); err != nil {
 			return nil, err
 		}
@@ -147,22 +1748,99 @@ func (q *Queries) PreviousTxs(ctx context.Context, arg PreviousTxsParams) ([]Tx,
 	return items, nil
 }
 
-const recentTxs = `-- name: RecentTxs :many
-select created_at, deleted_at, tx_id, balance_id, source, state, amount
+const txsCreatedBetween = `-- name: TxsCreatedBetween :many
+select created_at, deleted_at, tx_id, balance_id, source, state, amount, tenant_id, seq, external_ref, parent_tx_id, settled_debt, metadata
 from txs
-where balance_id = $1 and (deleted_at is null or $3::bool)
-order by tx_id desc
-limit $2
+where created_at > $1::timestamptz and created_at <= $2::timestamptz
+order by tenant_id, balance_id, state, created_at, tx_id
+`
+
+type TxsCreatedBetweenParams struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// Monitors scan txs of all tenants.
+func (q *Queries) TxsCreatedBetween(ctx context.Context, arg TxsCreatedBetweenParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, txsCreatedBetween, arg.CreatedAfter, arg.CreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tx
+	for rows.Next() {
+		var i Tx
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.TxID,
+			&i.BalanceID,
+			&i.Source,
+			&i.State,
+			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const txsNewestFirst = `-- name: TxsNewestFirst :many
+select created_at, deleted_at, tx_id, balance_id, source, state, amount, tenant_id, seq, external_ref, parent_tx_id, settled_debt, metadata
+from txs
+where tenant_id = $1 and balance_id = $2 and ($3::bigint is null or seq < $3)
+    and (deleted_at is null or $4::bool)
+    and (deleted_at is not null or not $5::bool)
+    and ($6::tx_source is null or source = $6)
+    and ($7::tx_state is null or state = $7)
+    and ($8::timestamptz is null or created_at >= $8)
+    and ($9::timestamptz is null or created_at < $9)
+    and ($10::numeric is null or amount >= $10)
+    and ($11::numeric is null or amount <= $11)
+order by seq desc
+limit $12
 `
 
-type RecentTxsParams struct {
+type TxsNewestFirstParams struct {
+	TenantID       string
 	BalanceID      uuid.UUID
-	Limit          int32
+	AfterSeq       *int64
 	IncludeDeleted bool
+	CancelledOnly  bool
+	Source         *domain.Source
+	State          *domain.State
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	MinAmount      *decimal.Decimal
+	MaxAmount      *decimal.Decimal
+	Limit          int32
 }
 
-func (q *Queries) RecentTxs(ctx context.Context, arg RecentTxsParams) ([]Tx, error) {
-	rows, err := q.db.Query(ctx, recentTxs, arg.BalanceID, arg.Limit, arg.IncludeDeleted)
+func (q *Queries) TxsNewestFirst(ctx context.Context, arg TxsNewestFirstParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, txsNewestFirst,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.AfterSeq,
+		arg.IncludeDeleted,
+		arg.CancelledOnly,
+		arg.Source,
+		arg.State,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +1856,12 @@ func (q *Queries) RecentTxs(ctx context.Context, arg RecentTxsParams) ([]Tx, err
 			&i.Source,
 			&i.State,
 			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
 		); err != nil {
 			return nil, err
 		}
@@ -189,19 +1873,52 @@ func (q *Queries) RecentTxs(ctx context.Context, arg RecentTxsParams) ([]Tx, err
 	return items, nil
 }
 
-const txsByID = `-- name: TxsByID :many
-select created_at, deleted_at, tx_id, balance_id, source, state, amount
+const txsOldestFirst = `-- name: TxsOldestFirst :many
+select created_at, deleted_at, tx_id, balance_id, source, state, amount, tenant_id, seq, external_ref, parent_tx_id, settled_debt, metadata
 from txs
-where balance_id = $1 and tx_id = any($2::uuid[])
+where tenant_id = $1 and balance_id = $2 and ($3::bigint is null or seq > $3)
+    and (deleted_at is null or $4::bool)
+    and (deleted_at is not null or not $5::bool)
+    and ($6::tx_source is null or source = $6)
+    and ($7::tx_state is null or state = $7)
+    and ($8::timestamptz is null or created_at >= $8)
+    and ($9::timestamptz is null or created_at < $9)
+    and ($10::numeric is null or amount >= $10)
+    and ($11::numeric is null or amount <= $11)
+order by seq
+limit $12
 `
 
-type TxsByIDParams struct {
-	BalanceID uuid.UUID
-	TxIds     []uuid.UUID
+type TxsOldestFirstParams struct {
+	TenantID       string
+	BalanceID      uuid.UUID
+	AfterSeq       *int64
+	IncludeDeleted bool
+	CancelledOnly  bool
+	Source         *domain.Source
+	State          *domain.State
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	MinAmount      *decimal.Decimal
+	MaxAmount      *decimal.Decimal
+	Limit          int32
 }
 
-func (q *Queries) TxsByID(ctx context.Context, arg TxsByIDParams) ([]Tx, error) {
-	rows, err := q.db.Query(ctx, txsByID, arg.BalanceID, arg.TxIds)
+func (q *Queries) TxsOldestFirst(ctx context.Context, arg TxsOldestFirstParams) ([]Tx, error) {
+	rows, err := q.db.Query(ctx, txsOldestFirst,
+		arg.TenantID,
+		arg.BalanceID,
+		arg.AfterSeq,
+		arg.IncludeDeleted,
+		arg.CancelledOnly,
+		arg.Source,
+		arg.State,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +1934,12 @@ func (q *Queries) TxsByID(ctx context.Context, arg TxsByIDParams) ([]Tx, error)
 			&i.Source,
 			&i.State,
 			&i.Amount,
+			&i.TenantID,
+			&i.Seq,
+			&i.ExternalRef,
+			&i.ParentTxID,
+			&i.SettledDebt,
+			&i.Metadata,
 		); err != nil {
 			return nil, err
 		}
@@ -230,17 +1953,29 @@ func (q *Queries) TxsByID(ctx context.Context, arg TxsByIDParams) ([]Tx, error)
 
 const updateBalance = `-- name: UpdateBalance :execrows
 update balances
-set amount = amount + $2
-where balance_id = $1
+set amount = amount + $1, debt = debt + $2, tx_count = tx_count + $3::bigint, version = version + 1, updated_at = now(), last_activity_at = now()
+where tenant_id = $4 and balance_id = $5
 `
 
 type UpdateBalanceParams struct {
-	BalanceID uuid.UUID
 	Amount    decimal.Decimal
+	Debt      decimal.Decimal
+	TxCount   int64
+	TenantID  string
+	BalanceID uuid.UUID
 }
 
+// Changes the amount by recorded or cancelled txs, tx count is changed by the number of txs.
+// Debt grows by what cancellations with the debt policy couldn't take from the amount.
+// Every change of a balance increments its version.
 func (q *Queries) UpdateBalance(ctx context.Context, arg UpdateBalanceParams) (int64, error) {
-	result, err := q.db.Exec(ctx, updateBalance, arg.BalanceID, arg.Amount)
+	result, err := q.db.Exec(ctx, updateBalance,
+		arg.Amount,
+		arg.Debt,
+		arg.TxCount,
+		arg.TenantID,
+		arg.BalanceID,
+	)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=AggregateBucket -trimprefix=AggregateBucket -json -text -yaml -sql
+
+const (
+	AggregateBucketUnknown AggregateBucket = iota
+	AggregateBucketHour
+	AggregateBucketDay
+	AggregateBucketMonth
+)
+
+type AggregateBucket int
+
+// AggregateQuery selects not cancelled txs to sum.
+type AggregateQuery struct {
+	BalanceID *uuid.UUID // All balances of the tenant if nil.
+	From      time.Time  // Inclusive.
+	To        time.Time  // Exclusive.
+	Bucket    AggregateBucket
+	Location  *time.Location // Buckets start at unit boundaries in the location.
+}
+
+// AggregateRow sums txs of a source and state in a bucket.
+type AggregateRow struct {
+	BucketStart time.Time
+	Source      Source
+	State       State
+	Count       int64
+	Amount      decimal.Decimal
+}
+
+// Revenue sums txs in a bucket from the operator's point of view.
+type Revenue struct {
+	BucketStart time.Time
+	GGR         decimal.Decimal // Gross gaming revenue, game withdrawals minus game deposits.
+	Turnover    decimal.Decimal // Game withdrawals, i.e. bets.
+	Deposits    decimal.Decimal // Payment deposits.
+	Payouts     decimal.Decimal // Payment withdrawals.
+}
@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=AggregateBucket -trimprefix=AggregateBucket -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _AggregateBucketName = "UnknownHourDayMonth"
+
+var _AggregateBucketIndex = [...]uint8{0, 7, 11, 14, 19}
+
+const _AggregateBucketLowerName = "unknownhourdaymonth"
+
+func (i AggregateBucket) String() string {
+	if i < 0 || i >= AggregateBucket(len(_AggregateBucketIndex)-1) {
+		return fmt.Sprintf("AggregateBucket(%d)", i)
+	}
+	return _AggregateBucketName[_AggregateBucketIndex[i]:_AggregateBucketIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _AggregateBucketNoOp() {
+	var x [1]struct{}
+	_ = x[AggregateBucketUnknown-(0)]
+	_ = x[AggregateBucketHour-(1)]
+	_ = x[AggregateBucketDay-(2)]
+	_ = x[AggregateBucketMonth-(3)]
+}
+
+var _AggregateBucketValues = []AggregateBucket{AggregateBucketUnknown, AggregateBucketHour, AggregateBucketDay, AggregateBucketMonth}
+
+var _AggregateBucketNameToValueMap = map[string]AggregateBucket{
+	_AggregateBucketName[0:7]:        AggregateBucketUnknown,
+	_AggregateBucketLowerName[0:7]:   AggregateBucketUnknown,
+	_AggregateBucketName[7:11]:       AggregateBucketHour,
+	_AggregateBucketLowerName[7:11]:  AggregateBucketHour,
+	_AggregateBucketName[11:14]:      AggregateBucketDay,
+	_AggregateBucketLowerName[11:14]: AggregateBucketDay,
+	_AggregateBucketName[14:19]:      AggregateBucketMonth,
+	_AggregateBucketLowerName[14:19]: AggregateBucketMonth,
+}
+
+var _AggregateBucketNames = []string{
+	_AggregateBucketName[0:7],
+	_AggregateBucketName[7:11],
+	_AggregateBucketName[11:14],
+	_AggregateBucketName[14:19],
+}
+
+// AggregateBucketString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func AggregateBucketString(s string) (AggregateBucket, error) {
+	if val, ok := _AggregateBucketNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _AggregateBucketNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to AggregateBucket values", s)
+}
+
+// AggregateBucketValues returns all values of the enum
+func AggregateBucketValues() []AggregateBucket {
+	return _AggregateBucketValues
+}
+
+// AggregateBucketStrings returns a slice of all String values of the enum
+func AggregateBucketStrings() []string {
+	strs := make([]string, len(_AggregateBucketNames))
+	copy(strs, _AggregateBucketNames)
+	return strs
+}
+
+// IsAAggregateBucket returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i AggregateBucket) IsAAggregateBucket() bool {
+	for _, v := range _AggregateBucketValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for AggregateBucket
+func (i AggregateBucket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for AggregateBucket
+func (i *AggregateBucket) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("AggregateBucket should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = AggregateBucketString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for AggregateBucket
+func (i AggregateBucket) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for AggregateBucket
+func (i *AggregateBucket) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = AggregateBucketString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for AggregateBucket
+func (i AggregateBucket) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for AggregateBucket
+func (i *AggregateBucket) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = AggregateBucketString(s)
+	return err
+}
+
+func (i AggregateBucket) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *AggregateBucket) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of AggregateBucket: %[1]T(%[1]v)", value)
+	}
+
+	val, err := AggregateBucketString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=AMLCaseKind -trimprefix=AMLCaseKind -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=AMLCaseStatus -trimprefix=AMLCaseStatus -json -text -yaml -sql
+
+const (
+	AMLCaseKindUnknown     AMLCaseKind = iota
+	AMLCaseKindSingle                  // A single tx over the threshold.
+	AMLCaseKindAggregate               // Txs of a balance summing over the threshold within the window.
+	AMLCaseKindStructuring             // Repeated txs just under the single tx threshold within the window.
+)
+
+type AMLCaseKind int
+
+const (
+	AMLCaseStatusUnknown AMLCaseStatus = iota
+	AMLCaseStatusOpen
+	AMLCaseStatusReported
+	AMLCaseStatusClosed
+)
+
+type AMLCaseStatus int
+
+type AMLCase struct {
+	CreatedAt  time.Time
+	DetectedAt time.Time // Creation time of the tx that crossed the threshold.
+	TenantID   string
+	CaseID     uuid.UUID
+	BalanceID  uuid.UUID
+	Kind       AMLCaseKind
+	Status     AMLCaseStatus
+	State      State
+	Amount     decimal.Decimal
+	TxIDs      []uuid.UUID
+}
@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=AMLCaseKind -trimprefix=AMLCaseKind -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _AMLCaseKindName = "UnknownSingleAggregateStructuring"
+
+var _AMLCaseKindIndex = [...]uint8{0, 7, 13, 22, 33}
+
+const _AMLCaseKindLowerName = "unknownsingleaggregatestructuring"
+
+func (i AMLCaseKind) String() string {
+	if i < 0 || i >= AMLCaseKind(len(_AMLCaseKindIndex)-1) {
+		return fmt.Sprintf("AMLCaseKind(%d)", i)
+	}
+	return _AMLCaseKindName[_AMLCaseKindIndex[i]:_AMLCaseKindIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _AMLCaseKindNoOp() {
+	var x [1]struct{}
+	_ = x[AMLCaseKindUnknown-(0)]
+	_ = x[AMLCaseKindSingle-(1)]
+	_ = x[AMLCaseKindAggregate-(2)]
+	_ = x[AMLCaseKindStructuring-(3)]
+}
+
+var _AMLCaseKindValues = []AMLCaseKind{AMLCaseKindUnknown, AMLCaseKindSingle, AMLCaseKindAggregate, AMLCaseKindStructuring}
+
+var _AMLCaseKindNameToValueMap = map[string]AMLCaseKind{
+	_AMLCaseKindName[0:7]:        AMLCaseKindUnknown,
+	_AMLCaseKindLowerName[0:7]:   AMLCaseKindUnknown,
+	_AMLCaseKindName[7:13]:       AMLCaseKindSingle,
+	_AMLCaseKindLowerName[7:13]:  AMLCaseKindSingle,
+	_AMLCaseKindName[13:22]:      AMLCaseKindAggregate,
+	_AMLCaseKindLowerName[13:22]: AMLCaseKindAggregate,
+	_AMLCaseKindName[22:33]:      AMLCaseKindStructuring,
+	_AMLCaseKindLowerName[22:33]: AMLCaseKindStructuring,
+}
+
+var _AMLCaseKindNames = []string{
+	_AMLCaseKindName[0:7],
+	_AMLCaseKindName[7:13],
+	_AMLCaseKindName[13:22],
+	_AMLCaseKindName[22:33],
+}
+
+// AMLCaseKindString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func AMLCaseKindString(s string) (AMLCaseKind, error) {
+	if val, ok := _AMLCaseKindNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _AMLCaseKindNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to AMLCaseKind values", s)
+}
+
+// AMLCaseKindValues returns all values of the enum
+func AMLCaseKindValues() []AMLCaseKind {
+	return _AMLCaseKindValues
+}
+
+// AMLCaseKindStrings returns a slice of all String values of the enum
+func AMLCaseKindStrings() []string {
+	strs := make([]string, len(_AMLCaseKindNames))
+	copy(strs, _AMLCaseKindNames)
+	return strs
+}
+
+// IsAAMLCaseKind returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i AMLCaseKind) IsAAMLCaseKind() bool {
+	for _, v := range _AMLCaseKindValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for AMLCaseKind
+func (i AMLCaseKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for AMLCaseKind
+func (i *AMLCaseKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("AMLCaseKind should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = AMLCaseKindString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for AMLCaseKind
+func (i AMLCaseKind) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for AMLCaseKind
+func (i *AMLCaseKind) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = AMLCaseKindString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for AMLCaseKind
+func (i AMLCaseKind) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for AMLCaseKind
+func (i *AMLCaseKind) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = AMLCaseKindString(s)
+	return err
+}
+
+func (i AMLCaseKind) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *AMLCaseKind) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of AMLCaseKind: %[1]T(%[1]v)", value)
+	}
+
+	val, err := AMLCaseKindString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=AMLCaseStatus -trimprefix=AMLCaseStatus -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _AMLCaseStatusName = "UnknownOpenReportedClosed"
+
+var _AMLCaseStatusIndex = [...]uint8{0, 7, 11, 19, 25}
+
+const _AMLCaseStatusLowerName = "unknownopenreportedclosed"
+
+func (i AMLCaseStatus) String() string {
+	if i < 0 || i >= AMLCaseStatus(len(_AMLCaseStatusIndex)-1) {
+		return fmt.Sprintf("AMLCaseStatus(%d)", i)
+	}
+	return _AMLCaseStatusName[_AMLCaseStatusIndex[i]:_AMLCaseStatusIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _AMLCaseStatusNoOp() {
+	var x [1]struct{}
+	_ = x[AMLCaseStatusUnknown-(0)]
+	_ = x[AMLCaseStatusOpen-(1)]
+	_ = x[AMLCaseStatusReported-(2)]
+	_ = x[AMLCaseStatusClosed-(3)]
+}
+
+var _AMLCaseStatusValues = []AMLCaseStatus{AMLCaseStatusUnknown, AMLCaseStatusOpen, AMLCaseStatusReported, AMLCaseStatusClosed}
+
+var _AMLCaseStatusNameToValueMap = map[string]AMLCaseStatus{
+	_AMLCaseStatusName[0:7]:        AMLCaseStatusUnknown,
+	_AMLCaseStatusLowerName[0:7]:   AMLCaseStatusUnknown,
+	_AMLCaseStatusName[7:11]:       AMLCaseStatusOpen,
+	_AMLCaseStatusLowerName[7:11]:  AMLCaseStatusOpen,
+	_AMLCaseStatusName[11:19]:      AMLCaseStatusReported,
+	_AMLCaseStatusLowerName[11:19]: AMLCaseStatusReported,
+	_AMLCaseStatusName[19:25]:      AMLCaseStatusClosed,
+	_AMLCaseStatusLowerName[19:25]: AMLCaseStatusClosed,
+}
+
+var _AMLCaseStatusNames = []string{
+	_AMLCaseStatusName[0:7],
+	_AMLCaseStatusName[7:11],
+	_AMLCaseStatusName[11:19],
+	_AMLCaseStatusName[19:25],
+}
+
+// AMLCaseStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func AMLCaseStatusString(s string) (AMLCaseStatus, error) {
+	if val, ok := _AMLCaseStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _AMLCaseStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to AMLCaseStatus values", s)
+}
+
+// AMLCaseStatusValues returns all values of the enum
+func AMLCaseStatusValues() []AMLCaseStatus {
+	return _AMLCaseStatusValues
+}
+
+// AMLCaseStatusStrings returns a slice of all String values of the enum
+func AMLCaseStatusStrings() []string {
+	strs := make([]string, len(_AMLCaseStatusNames))
+	copy(strs, _AMLCaseStatusNames)
+	return strs
+}
+
+// IsAAMLCaseStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i AMLCaseStatus) IsAAMLCaseStatus() bool {
+	for _, v := range _AMLCaseStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for AMLCaseStatus
+func (i AMLCaseStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for AMLCaseStatus
+func (i *AMLCaseStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("AMLCaseStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = AMLCaseStatusString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for AMLCaseStatus
+func (i AMLCaseStatus) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for AMLCaseStatus
+func (i *AMLCaseStatus) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = AMLCaseStatusString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for AMLCaseStatus
+func (i AMLCaseStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for AMLCaseStatus
+func (i *AMLCaseStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = AMLCaseStatusString(s)
+	return err
+}
+
+func (i AMLCaseStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *AMLCaseStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of AMLCaseStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := AMLCaseStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=AuditAction -trimprefix=AuditAction -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=AuditOutcome -trimprefix=AuditOutcome -json -text -yaml -sql
+
+const (
+	AuditActionUnknown AuditAction = iota
+	AuditActionAdjustBalance
+	AuditActionForceCancelTx
+	AuditActionInspectBalance
+	AuditActionSetBalanceStatus
+)
+
+type AuditAction int
+
+const (
+	AuditOutcomeUnknown   AuditOutcome = iota
+	AuditOutcomeAttempted              // Written before the change, so interrupted actions are audited too.
+	AuditOutcomeSucceeded
+	AuditOutcomeFailed
+)
+
+type AuditOutcome int
+
+// AuditEntry is an attempt of an action made with an admin key or its outcome.
+type AuditEntry struct {
+	CreatedAt time.Time
+	TenantID  string
+	EntryID   uuid.UUID
+	Operator  string // Person who made the action, resolved from the admin key.
+	Action    AuditAction
+	BalanceID uuid.UUID
+	TxID      *uuid.UUID
+	Amount    *decimal.Decimal // Change of the balance, negative for withdrawals. Requested in attempts if known, made in succeeded outcomes.
+	Reason    string
+	TicketRef string // Support ticket which requested the action.
+	Outcome   AuditOutcome
+	AttemptID *uuid.UUID     // Attempt entry of an outcome entry.
+	Error     *string        // Why the action failed.
+	Status    *BalanceStatus // Status set by the action.
+}
+
+// BalanceInspection is a balance with its latest txs, including cancelled ones, and audit entries.
+type BalanceInspection struct {
+	Balance      Balance
+	Txs          []Tx
+	AuditEntries []AuditEntry
+}
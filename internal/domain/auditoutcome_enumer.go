@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=AuditOutcome -trimprefix=AuditOutcome -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _AuditOutcomeName = "UnknownAttemptedSucceededFailed"
+
+var _AuditOutcomeIndex = [...]uint8{0, 7, 16, 25, 31}
+
+const _AuditOutcomeLowerName = "unknownattemptedsucceededfailed"
+
+func (i AuditOutcome) String() string {
+	if i < 0 || i >= AuditOutcome(len(_AuditOutcomeIndex)-1) {
+		return fmt.Sprintf("AuditOutcome(%d)", i)
+	}
+	return _AuditOutcomeName[_AuditOutcomeIndex[i]:_AuditOutcomeIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _AuditOutcomeNoOp() {
+	var x [1]struct{}
+	_ = x[AuditOutcomeUnknown-(0)]
+	_ = x[AuditOutcomeAttempted-(1)]
+	_ = x[AuditOutcomeSucceeded-(2)]
+	_ = x[AuditOutcomeFailed-(3)]
+}
+
+var _AuditOutcomeValues = []AuditOutcome{AuditOutcomeUnknown, AuditOutcomeAttempted, AuditOutcomeSucceeded, AuditOutcomeFailed}
+
+var _AuditOutcomeNameToValueMap = map[string]AuditOutcome{
+	_AuditOutcomeName[0:7]:        AuditOutcomeUnknown,
+	_AuditOutcomeLowerName[0:7]:   AuditOutcomeUnknown,
+	_AuditOutcomeName[7:16]:       AuditOutcomeAttempted,
+	_AuditOutcomeLowerName[7:16]:  AuditOutcomeAttempted,
+	_AuditOutcomeName[16:25]:      AuditOutcomeSucceeded,
+	_AuditOutcomeLowerName[16:25]: AuditOutcomeSucceeded,
+	_AuditOutcomeName[25:31]:      AuditOutcomeFailed,
+	_AuditOutcomeLowerName[25:31]: AuditOutcomeFailed,
+}
+
+var _AuditOutcomeNames = []string{
+	_AuditOutcomeName[0:7],
+	_AuditOutcomeName[7:16],
+	_AuditOutcomeName[16:25],
+	_AuditOutcomeName[25:31],
+}
+
+// AuditOutcomeString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func AuditOutcomeString(s string) (AuditOutcome, error) {
+	if val, ok := _AuditOutcomeNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _AuditOutcomeNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to AuditOutcome values", s)
+}
+
+// AuditOutcomeValues returns all values of the enum
+func AuditOutcomeValues() []AuditOutcome {
+	return _AuditOutcomeValues
+}
+
+// AuditOutcomeStrings returns a slice of all String values of the enum
+func AuditOutcomeStrings() []string {
+	strs := make([]string, len(_AuditOutcomeNames))
+	copy(strs, _AuditOutcomeNames)
+	return strs
+}
+
+// IsAAuditOutcome returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i AuditOutcome) IsAAuditOutcome() bool {
+	for _, v := range _AuditOutcomeValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for AuditOutcome
+func (i AuditOutcome) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for AuditOutcome
+func (i *AuditOutcome) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("AuditOutcome should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = AuditOutcomeString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for AuditOutcome
+func (i AuditOutcome) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for AuditOutcome
+func (i *AuditOutcome) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = AuditOutcomeString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for AuditOutcome
+func (i AuditOutcome) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for AuditOutcome
+func (i *AuditOutcome) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = AuditOutcomeString(s)
+	return err
+}
+
+func (i AuditOutcome) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *AuditOutcome) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of AuditOutcome: %[1]T(%[1]v)", value)
+	}
+
+	val, err := AuditOutcomeString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -1,11 +1,83 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+//go:generate go run github.com/dmarkham/enumer -type=BalanceStatus -trimprefix=BalanceStatus -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=WalletType -trimprefix=WalletType -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=BalanceSortField -trimprefix=BalanceSortField -json -text -yaml -sql
+
+const (
+	BalanceStatusUnknown BalanceStatus = iota
+	BalanceStatusActive
+	BalanceStatusFrozen
+	BalanceStatusWithdrawalsFrozen // Deposits are recorded, withdrawals aren't.
+)
+
+type BalanceStatus int
+
+const (
+	WalletTypeUnknown WalletType = iota
+	WalletTypeMain
+	WalletTypeBonus
+)
+
+type WalletType int
+
+const (
+	BalanceSortFieldUnknown BalanceSortField = iota
+	BalanceSortFieldCreatedAt
+	BalanceSortFieldAmount
+	BalanceSortFieldUpdatedAt
+	BalanceSortFieldLastActivityAt
+	BalanceSortFieldTxCount
+)
+
+type BalanceSortField int
+
 type Balance struct {
+	CreatedAt         time.Time
+	BalanceID         uuid.UUID
+	Amount            decimal.Decimal
+	Status            BalanceStatus
+	OwnerID           *uuid.UUID // Player owning the balance.
+	ExternalPlayerRef *string    // Player ID in operator's systems.
+	Currency          *string    // ISO 4217 code.
+	WalletType        WalletType
+	EventSeq          int64 // Seq of the last balance event.
+	UpdatedAt         time.Time
+	LastActivityAt    *time.Time      // Last tx recorded or cancelled, nil if there were none.
+	TxCount           int64           // Not cancelled txs.
+	Version           int64           // Incremented with every change of the balance.
+	Debt              decimal.Decimal // Owed by the player, recovered from future deposits.
+}
+
+// BalanceFilter narrows listed balances. Zero values don't filter.
+type BalanceFilter struct {
+	OwnerID     *uuid.UUID
+	Status      BalanceStatus
+	MinAmount   *decimal.Decimal
+	MaxAmount   *decimal.Decimal
+	CreatedFrom *time.Time // Inclusive.
+	CreatedTo   *time.Time // Exclusive.
+	UpdatedFrom *time.Time // Inclusive.
+	UpdatedTo   *time.Time // Exclusive.
+	ActiveSince *time.Time // Balance has txs recorded or cancelled since.
+	MinTxCount  *int64     // Inclusive.
+	MaxTxCount  *int64     // Inclusive.
+}
+
+type BalanceSort struct {
+	Field      BalanceSortField
+	Descending bool
+}
+
+// BalancePosition points to a balance in a sorted list.
+type BalancePosition struct {
+	SortKey   decimal.Decimal
 	BalanceID uuid.UUID
-	Amount    decimal.Decimal
 }
@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=BalanceCancellationStatus -trimprefix=BalanceCancellationStatus -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _BalanceCancellationStatusName = "UnknownPendingDoneFailed"
+
+var _BalanceCancellationStatusIndex = [...]uint8{0, 7, 14, 18, 24}
+
+const _BalanceCancellationStatusLowerName = "unknownpendingdonefailed"
+
+func (i BalanceCancellationStatus) String() string {
+	if i < 0 || i >= BalanceCancellationStatus(len(_BalanceCancellationStatusIndex)-1) {
+		return fmt.Sprintf("BalanceCancellationStatus(%d)", i)
+	}
+	return _BalanceCancellationStatusName[_BalanceCancellationStatusIndex[i]:_BalanceCancellationStatusIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _BalanceCancellationStatusNoOp() {
+	var x [1]struct{}
+	_ = x[BalanceCancellationStatusUnknown-(0)]
+	_ = x[BalanceCancellationStatusPending-(1)]
+	_ = x[BalanceCancellationStatusDone-(2)]
+	_ = x[BalanceCancellationStatusFailed-(3)]
+}
+
+var _BalanceCancellationStatusValues = []BalanceCancellationStatus{BalanceCancellationStatusUnknown, BalanceCancellationStatusPending, BalanceCancellationStatusDone, BalanceCancellationStatusFailed}
+
+var _BalanceCancellationStatusNameToValueMap = map[string]BalanceCancellationStatus{
+	_BalanceCancellationStatusName[0:7]:        BalanceCancellationStatusUnknown,
+	_BalanceCancellationStatusLowerName[0:7]:   BalanceCancellationStatusUnknown,
+	_BalanceCancellationStatusName[7:14]:       BalanceCancellationStatusPending,
+	_BalanceCancellationStatusLowerName[7:14]:  BalanceCancellationStatusPending,
+	_BalanceCancellationStatusName[14:18]:      BalanceCancellationStatusDone,
+	_BalanceCancellationStatusLowerName[14:18]: BalanceCancellationStatusDone,
+	_BalanceCancellationStatusName[18:24]:      BalanceCancellationStatusFailed,
+	_BalanceCancellationStatusLowerName[18:24]: BalanceCancellationStatusFailed,
+}
+
+var _BalanceCancellationStatusNames = []string{
+	_BalanceCancellationStatusName[0:7],
+	_BalanceCancellationStatusName[7:14],
+	_BalanceCancellationStatusName[14:18],
+	_BalanceCancellationStatusName[18:24],
+}
+
+// BalanceCancellationStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func BalanceCancellationStatusString(s string) (BalanceCancellationStatus, error) {
+	if val, ok := _BalanceCancellationStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _BalanceCancellationStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to BalanceCancellationStatus values", s)
+}
+
+// BalanceCancellationStatusValues returns all values of the enum
+func BalanceCancellationStatusValues() []BalanceCancellationStatus {
+	return _BalanceCancellationStatusValues
+}
+
+// BalanceCancellationStatusStrings returns a slice of all String values of the enum
+func BalanceCancellationStatusStrings() []string {
+	strs := make([]string, len(_BalanceCancellationStatusNames))
+	copy(strs, _BalanceCancellationStatusNames)
+	return strs
+}
+
+// IsABalanceCancellationStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i BalanceCancellationStatus) IsABalanceCancellationStatus() bool {
+	for _, v := range _BalanceCancellationStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for BalanceCancellationStatus
+func (i BalanceCancellationStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for BalanceCancellationStatus
+func (i *BalanceCancellationStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BalanceCancellationStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = BalanceCancellationStatusString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for BalanceCancellationStatus
+func (i BalanceCancellationStatus) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for BalanceCancellationStatus
+func (i *BalanceCancellationStatus) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = BalanceCancellationStatusString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for BalanceCancellationStatus
+func (i BalanceCancellationStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for BalanceCancellationStatus
+func (i *BalanceCancellationStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = BalanceCancellationStatusString(s)
+	return err
+}
+
+func (i BalanceCancellationStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *BalanceCancellationStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of BalanceCancellationStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := BalanceCancellationStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,168 @@
+// Code generated by "enumer -type=BalanceEventKind -trimprefix=BalanceEventKind -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _BalanceEventKindName = "UnknownSnapshotTxRecordedTxsCancelledStatusChanged"
+
+var _BalanceEventKindIndex = [...]uint8{0, 7, 15, 25, 37, 50}
+
+const _BalanceEventKindLowerName = "unknownsnapshottxrecordedtxscancelledstatuschanged"
+
+func (i BalanceEventKind) String() string {
+	if i < 0 || i >= BalanceEventKind(len(_BalanceEventKindIndex)-1) {
+		return fmt.Sprintf("BalanceEventKind(%d)", i)
+	}
+	return _BalanceEventKindName[_BalanceEventKindIndex[i]:_BalanceEventKindIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _BalanceEventKindNoOp() {
+	var x [1]struct{}
+	_ = x[BalanceEventKindUnknown-(0)]
+	_ = x[BalanceEventKindSnapshot-(1)]
+	_ = x[BalanceEventKindTxRecorded-(2)]
+	_ = x[BalanceEventKindTxsCancelled-(3)]
+	_ = x[BalanceEventKindStatusChanged-(4)]
+}
+
+var _BalanceEventKindValues = []BalanceEventKind{BalanceEventKindUnknown, BalanceEventKindSnapshot, BalanceEventKindTxRecorded, BalanceEventKindTxsCancelled, BalanceEventKindStatusChanged}
+
+var _BalanceEventKindNameToValueMap = map[string]BalanceEventKind{
+	_BalanceEventKindName[0:7]:        BalanceEventKindUnknown,
+	_BalanceEventKindLowerName[0:7]:   BalanceEventKindUnknown,
+	_BalanceEventKindName[7:15]:       BalanceEventKindSnapshot,
+	_BalanceEventKindLowerName[7:15]:  BalanceEventKindSnapshot,
+	_BalanceEventKindName[15:25]:      BalanceEventKindTxRecorded,
+	_BalanceEventKindLowerName[15:25]: BalanceEventKindTxRecorded,
+	_BalanceEventKindName[25:37]:      BalanceEventKindTxsCancelled,
+	_BalanceEventKindLowerName[25:37]: BalanceEventKindTxsCancelled,
+	_BalanceEventKindName[37:50]:      BalanceEventKindStatusChanged,
+	_BalanceEventKindLowerName[37:50]: BalanceEventKindStatusChanged,
+}
+
+var _BalanceEventKindNames = []string{
+	_BalanceEventKindName[0:7],
+	_BalanceEventKindName[7:15],
+	_BalanceEventKindName[15:25],
+	_BalanceEventKindName[25:37],
+	_BalanceEventKindName[37:50],
+}
+
+// BalanceEventKindString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func BalanceEventKindString(s string) (BalanceEventKind, error) {
+	if val, ok := _BalanceEventKindNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _BalanceEventKindNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to BalanceEventKind values", s)
+}
+
+// BalanceEventKindValues returns all values of the enum
+func BalanceEventKindValues() []BalanceEventKind {
+	return _BalanceEventKindValues
+}
+
+// BalanceEventKindStrings returns a slice of all String values of the enum
+func BalanceEventKindStrings() []string {
+	strs := make([]string, len(_BalanceEventKindNames))
+	copy(strs, _BalanceEventKindNames)
+	return strs
+}
+
+// IsABalanceEventKind returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i BalanceEventKind) IsABalanceEventKind() bool {
+	for _, v := range _BalanceEventKindValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for BalanceEventKind
+func (i BalanceEventKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for BalanceEventKind
+func (i *BalanceEventKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BalanceEventKind should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = BalanceEventKindString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for BalanceEventKind
+func (i BalanceEventKind) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for BalanceEventKind
+func (i *BalanceEventKind) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = BalanceEventKindString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for BalanceEventKind
+func (i BalanceEventKind) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for BalanceEventKind
+func (i *BalanceEventKind) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = BalanceEventKindString(s)
+	return err
+}
+
+func (i BalanceEventKind) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *BalanceEventKind) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of BalanceEventKind: %[1]T(%[1]v)", value)
+	}
+
+	val, err := BalanceEventKindString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,172 @@
+// Code generated by "enumer -type=BalanceSortField -trimprefix=BalanceSortField -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _BalanceSortFieldName = "UnknownCreatedAtAmountUpdatedAtLastActivityAtTxCount"
+
+var _BalanceSortFieldIndex = [...]uint8{0, 7, 16, 22, 31, 45, 52}
+
+const _BalanceSortFieldLowerName = "unknowncreatedatamountupdatedatlastactivityattxcount"
+
+func (i BalanceSortField) String() string {
+	if i < 0 || i >= BalanceSortField(len(_BalanceSortFieldIndex)-1) {
+		return fmt.Sprintf("BalanceSortField(%d)", i)
+	}
+	return _BalanceSortFieldName[_BalanceSortFieldIndex[i]:_BalanceSortFieldIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _BalanceSortFieldNoOp() {
+	var x [1]struct{}
+	_ = x[BalanceSortFieldUnknown-(0)]
+	_ = x[BalanceSortFieldCreatedAt-(1)]
+	_ = x[BalanceSortFieldAmount-(2)]
+	_ = x[BalanceSortFieldUpdatedAt-(3)]
+	_ = x[BalanceSortFieldLastActivityAt-(4)]
+	_ = x[BalanceSortFieldTxCount-(5)]
+}
+
+var _BalanceSortFieldValues = []BalanceSortField{BalanceSortFieldUnknown, BalanceSortFieldCreatedAt, BalanceSortFieldAmount, BalanceSortFieldUpdatedAt, BalanceSortFieldLastActivityAt, BalanceSortFieldTxCount}
+
+var _BalanceSortFieldNameToValueMap = map[string]BalanceSortField{
+	_BalanceSortFieldName[0:7]:        BalanceSortFieldUnknown,
+	_BalanceSortFieldLowerName[0:7]:   BalanceSortFieldUnknown,
+	_BalanceSortFieldName[7:16]:       BalanceSortFieldCreatedAt,
+	_BalanceSortFieldLowerName[7:16]:  BalanceSortFieldCreatedAt,
+	_BalanceSortFieldName[16:22]:      BalanceSortFieldAmount,
+	_BalanceSortFieldLowerName[16:22]: BalanceSortFieldAmount,
+	_BalanceSortFieldName[22:31]:      BalanceSortFieldUpdatedAt,
+	_BalanceSortFieldLowerName[22:31]: BalanceSortFieldUpdatedAt,
+	_BalanceSortFieldName[31:45]:      BalanceSortFieldLastActivityAt,
+	_BalanceSortFieldLowerName[31:45]: BalanceSortFieldLastActivityAt,
+	_BalanceSortFieldName[45:52]:      BalanceSortFieldTxCount,
+	_BalanceSortFieldLowerName[45:52]: BalanceSortFieldTxCount,
+}
+
+var _BalanceSortFieldNames = []string{
+	_BalanceSortFieldName[0:7],
+	_BalanceSortFieldName[7:16],
+	_BalanceSortFieldName[16:22],
+	_BalanceSortFieldName[22:31],
+	_BalanceSortFieldName[31:45],
+	_BalanceSortFieldName[45:52],
+}
+
+// BalanceSortFieldString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func BalanceSortFieldString(s string) (BalanceSortField, error) {
+	if val, ok := _BalanceSortFieldNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _BalanceSortFieldNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to BalanceSortField values", s)
+}
+
+// BalanceSortFieldValues returns all values of the enum
+func BalanceSortFieldValues() []BalanceSortField {
+	return _BalanceSortFieldValues
+}
+
+// BalanceSortFieldStrings returns a slice of all String values of the enum
+func BalanceSortFieldStrings() []string {
+	strs := make([]string, len(_BalanceSortFieldNames))
+	copy(strs, _BalanceSortFieldNames)
+	return strs
+}
+
+// IsABalanceSortField returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i BalanceSortField) IsABalanceSortField() bool {
+	for _, v := range _BalanceSortFieldValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for BalanceSortField
+func (i BalanceSortField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for BalanceSortField
+func (i *BalanceSortField) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BalanceSortField should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = BalanceSortFieldString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for BalanceSortField
+func (i BalanceSortField) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for BalanceSortField
+func (i *BalanceSortField) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = BalanceSortFieldString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for BalanceSortField
+func (i BalanceSortField) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for BalanceSortField
+func (i *BalanceSortField) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = BalanceSortFieldString(s)
+	return err
+}
+
+func (i BalanceSortField) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *BalanceSortField) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of BalanceSortField: %[1]T(%[1]v)", value)
+	}
+
+	val, err := BalanceSortFieldString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
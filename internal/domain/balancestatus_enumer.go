@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=BalanceStatus -trimprefix=BalanceStatus -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _BalanceStatusName = "UnknownActiveFrozenWithdrawalsFrozen"
+
+var _BalanceStatusIndex = [...]uint8{0, 7, 13, 19, 36}
+
+const _BalanceStatusLowerName = "unknownactivefrozenwithdrawalsfrozen"
+
+func (i BalanceStatus) String() string {
+	if i < 0 || i >= BalanceStatus(len(_BalanceStatusIndex)-1) {
+		return fmt.Sprintf("BalanceStatus(%d)", i)
+	}
+	return _BalanceStatusName[_BalanceStatusIndex[i]:_BalanceStatusIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _BalanceStatusNoOp() {
+	var x [1]struct{}
+	_ = x[BalanceStatusUnknown-(0)]
+	_ = x[BalanceStatusActive-(1)]
+	_ = x[BalanceStatusFrozen-(2)]
+	_ = x[BalanceStatusWithdrawalsFrozen-(3)]
+}
+
+var _BalanceStatusValues = []BalanceStatus{BalanceStatusUnknown, BalanceStatusActive, BalanceStatusFrozen, BalanceStatusWithdrawalsFrozen}
+
+var _BalanceStatusNameToValueMap = map[string]BalanceStatus{
+	_BalanceStatusName[0:7]:        BalanceStatusUnknown,
+	_BalanceStatusLowerName[0:7]:   BalanceStatusUnknown,
+	_BalanceStatusName[7:13]:       BalanceStatusActive,
+	_BalanceStatusLowerName[7:13]:  BalanceStatusActive,
+	_BalanceStatusName[13:19]:      BalanceStatusFrozen,
+	_BalanceStatusLowerName[13:19]: BalanceStatusFrozen,
+	_BalanceStatusName[19:36]:      BalanceStatusWithdrawalsFrozen,
+	_BalanceStatusLowerName[19:36]: BalanceStatusWithdrawalsFrozen,
+}
+
+var _BalanceStatusNames = []string{
+	_BalanceStatusName[0:7],
+	_BalanceStatusName[7:13],
+	_BalanceStatusName[13:19],
+	_BalanceStatusName[19:36],
+}
+
+// BalanceStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func BalanceStatusString(s string) (BalanceStatus, error) {
+	if val, ok := _BalanceStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _BalanceStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to BalanceStatus values", s)
+}
+
+// BalanceStatusValues returns all values of the enum
+func BalanceStatusValues() []BalanceStatus {
+	return _BalanceStatusValues
+}
+
+// BalanceStatusStrings returns a slice of all String values of the enum
+func BalanceStatusStrings() []string {
+	strs := make([]string, len(_BalanceStatusNames))
+	copy(strs, _BalanceStatusNames)
+	return strs
+}
+
+// IsABalanceStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i BalanceStatus) IsABalanceStatus() bool {
+	for _, v := range _BalanceStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for BalanceStatus
+func (i BalanceStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for BalanceStatus
+func (i *BalanceStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BalanceStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = BalanceStatusString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for BalanceStatus
+func (i BalanceStatus) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for BalanceStatus
+func (i *BalanceStatus) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = BalanceStatusString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for BalanceStatus
+func (i BalanceStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for BalanceStatus
+func (i *BalanceStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = BalanceStatusString(s)
+	return err
+}
+
+func (i BalanceStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *BalanceStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of BalanceStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := BalanceStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=CancellationStatus -trimprefix=CancellationStatus -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=BalanceCancellationStatus -trimprefix=BalanceCancellationStatus -json -text -yaml -sql
+
+const (
+	CancellationStatusUnknown CancellationStatus = iota
+	CancellationStatusPending
+	CancellationStatusRunning
+	CancellationStatusDone // All balances are processed, some of them may have failed.
+)
+
+type CancellationStatus int
+
+const (
+	BalanceCancellationStatusUnknown BalanceCancellationStatus = iota
+	BalanceCancellationStatusPending
+	BalanceCancellationStatusDone
+	BalanceCancellationStatusFailed
+)
+
+type BalanceCancellationStatus int
+
+// CancellationCriteria selects not cancelled txs of a tenant for bulk cancellation.
+type CancellationCriteria struct {
+	Source            Source
+	State             State             // Zero matches both states.
+	CreatedFrom       time.Time         // Inclusive.
+	CreatedTo         time.Time         // Exclusive.
+	ExternalRefPrefix *string           // Operators encode providers and rounds in external refs, e.g. "provider-x:".
+	BalanceIDs        []uuid.UUID       // Empty matches all balances.
+	Metadata          map[string]string // Matches txs having all of the pairs, empty matches all txs.
+}
+
+// Cancellation is a bulk cancellation processed in the background balance by balance.
+type Cancellation struct {
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	FinishedAt     *time.Time
+	TenantID       string
+	CancellationID uuid.UUID
+	Status         CancellationStatus
+	Criteria       CancellationCriteria
+	NegativePolicy NegativePolicy
+	Progress       CancellationProgress
+}
+
+type CancellationProgress struct {
+	BalancesTotal  int64
+	BalancesDone   int64
+	BalancesFailed int64
+	TxsCancelled   int64
+}
+
+// BalanceCancellation is the outcome of a bulk cancellation for one balance.
+type BalanceCancellation struct {
+	UpdatedAt    time.Time
+	BalanceID    uuid.UUID
+	Status       BalanceCancellationStatus
+	TxIDs        []uuid.UUID     // Cancelled txs, including not matching descendants.
+	Correction   decimal.Decimal // Change of the balance amount.
+	SkippedTxIDs []uuid.UUID     // Deposits not cancelled by the skip policy.
+	Debt         decimal.Decimal // Part of the reverted amount recorded as debt by the debt policy.
+	Error        *string         // Reason the balance failed, e.g. insufficient funds.
+}
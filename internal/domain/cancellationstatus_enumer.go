@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=CancellationStatus -trimprefix=CancellationStatus -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _CancellationStatusName = "UnknownPendingRunningDone"
+
+var _CancellationStatusIndex = [...]uint8{0, 7, 14, 21, 25}
+
+const _CancellationStatusLowerName = "unknownpendingrunningdone"
+
+func (i CancellationStatus) String() string {
+	if i < 0 || i >= CancellationStatus(len(_CancellationStatusIndex)-1) {
+		return fmt.Sprintf("CancellationStatus(%d)", i)
+	}
+	return _CancellationStatusName[_CancellationStatusIndex[i]:_CancellationStatusIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _CancellationStatusNoOp() {
+	var x [1]struct{}
+	_ = x[CancellationStatusUnknown-(0)]
+	_ = x[CancellationStatusPending-(1)]
+	_ = x[CancellationStatusRunning-(2)]
+	_ = x[CancellationStatusDone-(3)]
+}
+
+var _CancellationStatusValues = []CancellationStatus{CancellationStatusUnknown, CancellationStatusPending, CancellationStatusRunning, CancellationStatusDone}
+
+var _CancellationStatusNameToValueMap = map[string]CancellationStatus{
+	_CancellationStatusName[0:7]:        CancellationStatusUnknown,
+	_CancellationStatusLowerName[0:7]:   CancellationStatusUnknown,
+	_CancellationStatusName[7:14]:       CancellationStatusPending,
+	_CancellationStatusLowerName[7:14]:  CancellationStatusPending,
+	_CancellationStatusName[14:21]:      CancellationStatusRunning,
+	_CancellationStatusLowerName[14:21]: CancellationStatusRunning,
+	_CancellationStatusName[21:25]:      CancellationStatusDone,
+	_CancellationStatusLowerName[21:25]: CancellationStatusDone,
+}
+
+var _CancellationStatusNames = []string{
+	_CancellationStatusName[0:7],
+	_CancellationStatusName[7:14],
+	_CancellationStatusName[14:21],
+	_CancellationStatusName[21:25],
+}
+
+// CancellationStatusString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func CancellationStatusString(s string) (CancellationStatus, error) {
+	if val, ok := _CancellationStatusNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _CancellationStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to CancellationStatus values", s)
+}
+
+// CancellationStatusValues returns all values of the enum
+func CancellationStatusValues() []CancellationStatus {
+	return _CancellationStatusValues
+}
+
+// CancellationStatusStrings returns a slice of all String values of the enum
+func CancellationStatusStrings() []string {
+	strs := make([]string, len(_CancellationStatusNames))
+	copy(strs, _CancellationStatusNames)
+	return strs
+}
+
+// IsACancellationStatus returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i CancellationStatus) IsACancellationStatus() bool {
+	for _, v := range _CancellationStatusValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for CancellationStatus
+func (i CancellationStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for CancellationStatus
+func (i *CancellationStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("CancellationStatus should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = CancellationStatusString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for CancellationStatus
+func (i CancellationStatus) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for CancellationStatus
+func (i *CancellationStatus) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = CancellationStatusString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for CancellationStatus
+func (i CancellationStatus) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for CancellationStatus
+func (i *CancellationStatus) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = CancellationStatusString(s)
+	return err
+}
+
+func (i CancellationStatus) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *CancellationStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of CancellationStatus: %[1]T(%[1]v)", value)
+	}
+
+	val, err := CancellationStatusString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
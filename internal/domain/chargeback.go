@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Chargeback is a payment deposit reversed by a card scheme.
+type Chargeback struct {
+	CreatedAt    time.Time
+	TenantID     string
+	ChargebackID uuid.UUID
+	BalanceID    uuid.UUID
+	TxID         uuid.UUID       // Charged back deposit.
+	Amount       decimal.Decimal // Amount of the deposit.
+	Correction   decimal.Decimal // Change of the balance amount.
+	Debt         decimal.Decimal // Part of the deposit the balance couldn't cover.
+	Reason       *string
+}
+
+// ChargebackOutcome is what a chargeback persisted.
+type ChargebackOutcome struct {
+	Chargeback Chargeback
+	Tx         Tx      // Charged back deposit with its cancellation time.
+	Balance    Balance // Balance after the chargeback.
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=DebtEntryKind -trimprefix=DebtEntryKind -json -text -yaml -sql
+
+const (
+	DebtEntryKindUnknown    DebtEntryKind = iota
+	DebtEntryKindIncurred                 // Cancellations took more than the balance had.
+	DebtEntryKindSettled                  // A deposit paid the debt off.
+	DebtEntryKindReinstated               // Cancelled deposits had paid the debt off, so it's owed again.
+)
+
+type DebtEntryKind int
+
+// DebtEntry is a change of the debt of a balance. Debt is the debt after the change.
+type DebtEntry struct {
+	CreatedAt time.Time
+	BalanceID uuid.UUID
+	Seq       int64
+	Kind      DebtEntryKind
+	Amount    decimal.Decimal // Always positive, the kind tells the direction.
+	Debt      decimal.Decimal
+	TxIDs     []uuid.UUID // Cancelled txs or the settling deposit.
+}
@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=DebtEntryKind -trimprefix=DebtEntryKind -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _DebtEntryKindName = "UnknownIncurredSettledReinstated"
+
+var _DebtEntryKindIndex = [...]uint8{0, 7, 15, 22, 32}
+
+const _DebtEntryKindLowerName = "unknownincurredsettledreinstated"
+
+func (i DebtEntryKind) String() string {
+	if i < 0 || i >= DebtEntryKind(len(_DebtEntryKindIndex)-1) {
+		return fmt.Sprintf("DebtEntryKind(%d)", i)
+	}
+	return _DebtEntryKindName[_DebtEntryKindIndex[i]:_DebtEntryKindIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _DebtEntryKindNoOp() {
+	var x [1]struct{}
+	_ = x[DebtEntryKindUnknown-(0)]
+	_ = x[DebtEntryKindIncurred-(1)]
+	_ = x[DebtEntryKindSettled-(2)]
+	_ = x[DebtEntryKindReinstated-(3)]
+}
+
+var _DebtEntryKindValues = []DebtEntryKind{DebtEntryKindUnknown, DebtEntryKindIncurred, DebtEntryKindSettled, DebtEntryKindReinstated}
+
+var _DebtEntryKindNameToValueMap = map[string]DebtEntryKind{
+	_DebtEntryKindName[0:7]:        DebtEntryKindUnknown,
+	_DebtEntryKindLowerName[0:7]:   DebtEntryKindUnknown,
+	_DebtEntryKindName[7:15]:       DebtEntryKindIncurred,
+	_DebtEntryKindLowerName[7:15]:  DebtEntryKindIncurred,
+	_DebtEntryKindName[15:22]:      DebtEntryKindSettled,
+	_DebtEntryKindLowerName[15:22]: DebtEntryKindSettled,
+	_DebtEntryKindName[22:32]:      DebtEntryKindReinstated,
+	_DebtEntryKindLowerName[22:32]: DebtEntryKindReinstated,
+}
+
+var _DebtEntryKindNames = []string{
+	_DebtEntryKindName[0:7],
+	_DebtEntryKindName[7:15],
+	_DebtEntryKindName[15:22],
+	_DebtEntryKindName[22:32],
+}
+
+// DebtEntryKindString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func DebtEntryKindString(s string) (DebtEntryKind, error) {
+	if val, ok := _DebtEntryKindNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _DebtEntryKindNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to DebtEntryKind values", s)
+}
+
+// DebtEntryKindValues returns all values of the enum
+func DebtEntryKindValues() []DebtEntryKind {
+	return _DebtEntryKindValues
+}
+
+// DebtEntryKindStrings returns a slice of all String values of the enum
+func DebtEntryKindStrings() []string {
+	strs := make([]string, len(_DebtEntryKindNames))
+	copy(strs, _DebtEntryKindNames)
+	return strs
+}
+
+// IsADebtEntryKind returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i DebtEntryKind) IsADebtEntryKind() bool {
+	for _, v := range _DebtEntryKindValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for DebtEntryKind
+func (i DebtEntryKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for DebtEntryKind
+func (i *DebtEntryKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("DebtEntryKind should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = DebtEntryKindString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for DebtEntryKind
+func (i DebtEntryKind) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for DebtEntryKind
+func (i *DebtEntryKind) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = DebtEntryKindString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for DebtEntryKind
+func (i DebtEntryKind) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for DebtEntryKind
+func (i *DebtEntryKind) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = DebtEntryKindString(s)
+	return err
+}
+
+func (i DebtEntryKind) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *DebtEntryKind) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of DebtEntryKind: %[1]T(%[1]v)", value)
+	}
+
+	val, err := DebtEntryKindString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=BalanceEventKind -trimprefix=BalanceEventKind -json -text -yaml -sql
+
+const (
+	BalanceEventKindUnknown BalanceEventKind = iota
+	BalanceEventKindSnapshot
+	BalanceEventKindTxRecorded
+	BalanceEventKindTxsCancelled
+	BalanceEventKindStatusChanged
+)
+
+type BalanceEventKind int
+
+// BalanceEvent is a change of a balance. Amount and status are the state of the balance after the change.
+type BalanceEvent struct {
+	CreatedAt time.Time
+	BalanceID uuid.UUID
+	Seq       int64
+	Kind      BalanceEventKind
+	Amount    decimal.Decimal
+	Status    BalanceStatus
+	TxIDs     []uuid.UUID
+	Txs       []Tx // Txs which caused the change.
+
+	NegativePolicy NegativePolicy // Set for cancellations only.
+}
@@ -0,0 +1,164 @@
+// Code generated by "enumer -type=NegativePolicy -trimprefix=NegativePolicy -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _NegativePolicyName = "UnknownFailSkipDebt"
+
+var _NegativePolicyIndex = [...]uint8{0, 7, 11, 15, 19}
+
+const _NegativePolicyLowerName = "unknownfailskipdebt"
+
+func (i NegativePolicy) String() string {
+	if i < 0 || i >= NegativePolicy(len(_NegativePolicyIndex)-1) {
+		return fmt.Sprintf("NegativePolicy(%d)", i)
+	}
+	return _NegativePolicyName[_NegativePolicyIndex[i]:_NegativePolicyIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _NegativePolicyNoOp() {
+	var x [1]struct{}
+	_ = x[NegativePolicyUnknown-(0)]
+	_ = x[NegativePolicyFail-(1)]
+	_ = x[NegativePolicySkip-(2)]
+	_ = x[NegativePolicyDebt-(3)]
+}
+
+var _NegativePolicyValues = []NegativePolicy{NegativePolicyUnknown, NegativePolicyFail, NegativePolicySkip, NegativePolicyDebt}
+
+var _NegativePolicyNameToValueMap = map[string]NegativePolicy{
+	_NegativePolicyName[0:7]:        NegativePolicyUnknown,
+	_NegativePolicyLowerName[0:7]:   NegativePolicyUnknown,
+	_NegativePolicyName[7:11]:       NegativePolicyFail,
+	_NegativePolicyLowerName[7:11]:  NegativePolicyFail,
+	_NegativePolicyName[11:15]:      NegativePolicySkip,
+	_NegativePolicyLowerName[11:15]: NegativePolicySkip,
+	_NegativePolicyName[15:19]:      NegativePolicyDebt,
+	_NegativePolicyLowerName[15:19]: NegativePolicyDebt,
+}
+
+var _NegativePolicyNames = []string{
+	_NegativePolicyName[0:7],
+	_NegativePolicyName[7:11],
+	_NegativePolicyName[11:15],
+	_NegativePolicyName[15:19],
+}
+
+// NegativePolicyString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func NegativePolicyString(s string) (NegativePolicy, error) {
+	if val, ok := _NegativePolicyNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _NegativePolicyNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to NegativePolicy values", s)
+}
+
+// NegativePolicyValues returns all values of the enum
+func NegativePolicyValues() []NegativePolicy {
+	return _NegativePolicyValues
+}
+
+// NegativePolicyStrings returns a slice of all String values of the enum
+func NegativePolicyStrings() []string {
+	strs := make([]string, len(_NegativePolicyNames))
+	copy(strs, _NegativePolicyNames)
+	return strs
+}
+
+// IsANegativePolicy returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i NegativePolicy) IsANegativePolicy() bool {
+	for _, v := range _NegativePolicyValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for NegativePolicy
+func (i NegativePolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for NegativePolicy
+func (i *NegativePolicy) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("NegativePolicy should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = NegativePolicyString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for NegativePolicy
+func (i NegativePolicy) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for NegativePolicy
+func (i *NegativePolicy) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = NegativePolicyString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for NegativePolicy
+func (i NegativePolicy) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for NegativePolicy
+func (i *NegativePolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = NegativePolicyString(s)
+	return err
+}
+
+func (i NegativePolicy) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *NegativePolicy) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of NegativePolicy: %[1]T(%[1]v)", value)
+	}
+
+	val, err := NegativePolicyString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=RuleAction -trimprefix=RuleAction -json -text -yaml -sql
+
+// Actions are ordered by severity, so the strongest action of several matched rules wins.
+const (
+	RuleActionUnknown RuleAction = iota
+	RuleActionAllow
+	RuleActionFlag
+	RuleActionReject
+	RuleActionFreeze
+)
+
+type RuleAction int
+
+type RuleHit struct {
+	Rule   string
+	Action RuleAction
+}
+
+// TxStats aggregates balance txs of a single source and state over a window.
+type TxStats struct {
+	Source Source
+	State  State
+	Count  int64
+	Sum    decimal.Decimal
+}
+
+type FlaggedEvent struct {
+	CreatedAt time.Time
+	EventID   uuid.UUID
+	BalanceID uuid.UUID
+	TxID      uuid.UUID
+	Rule      string
+	Action    RuleAction
+}
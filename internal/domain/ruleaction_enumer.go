@@ -0,0 +1,168 @@
+// Code generated by "enumer -type=RuleAction -trimprefix=RuleAction -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _RuleActionName = "UnknownAllowFlagRejectFreeze"
+
+var _RuleActionIndex = [...]uint8{0, 7, 12, 16, 22, 28}
+
+const _RuleActionLowerName = "unknownallowflagrejectfreeze"
+
+func (i RuleAction) String() string {
+	if i < 0 || i >= RuleAction(len(_RuleActionIndex)-1) {
+		return fmt.Sprintf("RuleAction(%d)", i)
+	}
+	return _RuleActionName[_RuleActionIndex[i]:_RuleActionIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _RuleActionNoOp() {
+	var x [1]struct{}
+	_ = x[RuleActionUnknown-(0)]
+	_ = x[RuleActionAllow-(1)]
+	_ = x[RuleActionFlag-(2)]
+	_ = x[RuleActionReject-(3)]
+	_ = x[RuleActionFreeze-(4)]
+}
+
+var _RuleActionValues = []RuleAction{RuleActionUnknown, RuleActionAllow, RuleActionFlag, RuleActionReject, RuleActionFreeze}
+
+var _RuleActionNameToValueMap = map[string]RuleAction{
+	_RuleActionName[0:7]:        RuleActionUnknown,
+	_RuleActionLowerName[0:7]:   RuleActionUnknown,
+	_RuleActionName[7:12]:       RuleActionAllow,
+	_RuleActionLowerName[7:12]:  RuleActionAllow,
+	_RuleActionName[12:16]:      RuleActionFlag,
+	_RuleActionLowerName[12:16]: RuleActionFlag,
+	_RuleActionName[16:22]:      RuleActionReject,
+	_RuleActionLowerName[16:22]: RuleActionReject,
+	_RuleActionName[22:28]:      RuleActionFreeze,
+	_RuleActionLowerName[22:28]: RuleActionFreeze,
+}
+
+var _RuleActionNames = []string{
+	_RuleActionName[0:7],
+	_RuleActionName[7:12],
+	_RuleActionName[12:16],
+	_RuleActionName[16:22],
+	_RuleActionName[22:28],
+}
+
+// RuleActionString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func RuleActionString(s string) (RuleAction, error) {
+	if val, ok := _RuleActionNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _RuleActionNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to RuleAction values", s)
+}
+
+// RuleActionValues returns all values of the enum
+func RuleActionValues() []RuleAction {
+	return _RuleActionValues
+}
+
+// RuleActionStrings returns a slice of all String values of the enum
+func RuleActionStrings() []string {
+	strs := make([]string, len(_RuleActionNames))
+	copy(strs, _RuleActionNames)
+	return strs
+}
+
+// IsARuleAction returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i RuleAction) IsARuleAction() bool {
+	for _, v := range _RuleActionValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for RuleAction
+func (i RuleAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for RuleAction
+func (i *RuleAction) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("RuleAction should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = RuleActionString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for RuleAction
+func (i RuleAction) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for RuleAction
+func (i *RuleAction) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = RuleActionString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for RuleAction
+func (i RuleAction) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for RuleAction
+func (i *RuleAction) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = RuleActionString(s)
+	return err
+}
+
+func (i RuleAction) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *RuleAction) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of RuleAction: %[1]T(%[1]v)", value)
+	}
+
+	val, err := RuleActionString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
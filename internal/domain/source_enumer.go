@@ -9,11 +9,11 @@ import (
 	"strings"
 )
 
-const _SourceName = "UnknownGamePaymentService"
+const _SourceName = "UnknownGamePaymentServiceAdjustment"
 
-var _SourceIndex = [...]uint8{0, 7, 11, 18, 25}
+var _SourceIndex = [...]uint8{0, 7, 11, 18, 25, 35}
 
-const _SourceLowerName = "unknowngamepaymentservice"
+const _SourceLowerName = "unknowngamepaymentserviceadjustment"
 
 func (i Source) String() string {
 	if i < 0 || i >= Source(len(_SourceIndex)-1) {
@@ -30,9 +30,10 @@ func _SourceNoOp() {
 	_ = x[SourceGame-(1)]
 	_ = x[SourcePayment-(2)]
 	_ = x[SourceService-(3)]
+	_ = x[SourceAdjustment-(4)]
 }
 
-var _SourceValues = []Source{SourceUnknown, SourceGame, SourcePayment, SourceService}
+var _SourceValues = []Source{SourceUnknown, SourceGame, SourcePayment, SourceService, SourceAdjustment}
 
 var _SourceNameToValueMap = map[string]Source{
 	_SourceName[0:7]:        SourceUnknown,
@@ -43,6 +44,8 @@ var _SourceNameToValueMap = map[string]Source{
 	_SourceLowerName[11:18]: SourcePayment,
 	_SourceName[18:25]:      SourceService,
 	_SourceLowerName[18:25]: SourceService,
+	_SourceName[25:35]:      SourceAdjustment,
+	_SourceLowerName[25:35]: SourceAdjustment,
 }
 
 var _SourceNames = []string{
@@ -50,6 +53,7 @@ var _SourceNames = []string{
 	_SourceName[7:11],
 	_SourceName[11:18],
 	_SourceName[18:25],
+	_SourceName[25:35],
 }
 
 // SourceString retrieves an enum value from the enum constants string name.
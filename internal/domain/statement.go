@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+//go:generate go run github.com/dmarkham/enumer -type=StatementFormat -trimprefix=StatementFormat -json -text -yaml -sql
+
+const (
+	StatementFormatUnknown StatementFormat = iota
+	StatementFormatCSV
+	StatementFormatJSONL
+)
+
+type StatementFormat int
+
+// StatementEntry is a tx or its cancellation with the balance after it.
+type StatementEntry struct {
+	At             time.Time
+	Seq            int64
+	Cancellation   bool
+	TxID           uuid.UUID
+	ExternalRef    *string
+	Source         Source
+	State          State
+	Amount         decimal.Decimal
+	Change         decimal.Decimal // Signed amount of the tx or its cancellation.
+	RunningBalance decimal.Decimal // Balance after the entry, it differs from the sum of changes once debt is settled or incurred.
+}
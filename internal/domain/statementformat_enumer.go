@@ -0,0 +1,160 @@
+// Code generated by "enumer -type=StatementFormat -trimprefix=StatementFormat -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _StatementFormatName = "UnknownCSVJSONL"
+
+var _StatementFormatIndex = [...]uint8{0, 7, 10, 15}
+
+const _StatementFormatLowerName = "unknowncsvjsonl"
+
+func (i StatementFormat) String() string {
+	if i < 0 || i >= StatementFormat(len(_StatementFormatIndex)-1) {
+		return fmt.Sprintf("StatementFormat(%d)", i)
+	}
+	return _StatementFormatName[_StatementFormatIndex[i]:_StatementFormatIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _StatementFormatNoOp() {
+	var x [1]struct{}
+	_ = x[StatementFormatUnknown-(0)]
+	_ = x[StatementFormatCSV-(1)]
+	_ = x[StatementFormatJSONL-(2)]
+}
+
+var _StatementFormatValues = []StatementFormat{StatementFormatUnknown, StatementFormatCSV, StatementFormatJSONL}
+
+var _StatementFormatNameToValueMap = map[string]StatementFormat{
+	_StatementFormatName[0:7]:        StatementFormatUnknown,
+	_StatementFormatLowerName[0:7]:   StatementFormatUnknown,
+	_StatementFormatName[7:10]:       StatementFormatCSV,
+	_StatementFormatLowerName[7:10]:  StatementFormatCSV,
+	_StatementFormatName[10:15]:      StatementFormatJSONL,
+	_StatementFormatLowerName[10:15]: StatementFormatJSONL,
+}
+
+var _StatementFormatNames = []string{
+	_StatementFormatName[0:7],
+	_StatementFormatName[7:10],
+	_StatementFormatName[10:15],
+}
+
+// StatementFormatString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func StatementFormatString(s string) (StatementFormat, error) {
+	if val, ok := _StatementFormatNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _StatementFormatNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to StatementFormat values", s)
+}
+
+// StatementFormatValues returns all values of the enum
+func StatementFormatValues() []StatementFormat {
+	return _StatementFormatValues
+}
+
+// StatementFormatStrings returns a slice of all String values of the enum
+func StatementFormatStrings() []string {
+	strs := make([]string, len(_StatementFormatNames))
+	copy(strs, _StatementFormatNames)
+	return strs
+}
+
+// IsAStatementFormat returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i StatementFormat) IsAStatementFormat() bool {
+	for _, v := range _StatementFormatValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for StatementFormat
+func (i StatementFormat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for StatementFormat
+func (i *StatementFormat) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("StatementFormat should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = StatementFormatString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for StatementFormat
+func (i StatementFormat) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for StatementFormat
+func (i *StatementFormat) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = StatementFormatString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for StatementFormat
+func (i StatementFormat) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for StatementFormat
+func (i *StatementFormat) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = StatementFormatString(s)
+	return err
+}
+
+func (i StatementFormat) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *StatementFormat) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of StatementFormat: %[1]T(%[1]v)", value)
+	}
+
+	val, err := StatementFormatString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
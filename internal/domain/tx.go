@@ -9,12 +9,15 @@ import (
 
 //go:generate go run github.com/dmarkham/enumer -type=Source -trimprefix=Source -json -text -yaml -sql
 //go:generate go run github.com/dmarkham/enumer -type=State -trimprefix=State -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=TxOrder -trimprefix=TxOrder -json -text -yaml -sql
+//go:generate go run github.com/dmarkham/enumer -type=NegativePolicy -trimprefix=NegativePolicy -json -text -yaml -sql
 
 const (
 	SourceUnknown Source = iota
 	SourceGame
 	SourcePayment
 	SourceService
+	SourceAdjustment // Manual correction by support, recorded only with admin keys.
 )
 
 type Source int
@@ -27,12 +30,73 @@ const (
 
 type State int
 
+const (
+	TxOrderUnknown TxOrder = iota
+	TxOrderNewestFirst
+	TxOrderOldestFirst
+)
+
+type TxOrder int
+
+// NegativePolicy decides what happens when cancelling deposits would make the balance negative.
+const (
+	NegativePolicyUnknown NegativePolicy = iota
+	NegativePolicyFail                   // Nothing is cancelled.
+	NegativePolicySkip                   // Deposits the balance can't cover aren't cancelled, the rest is.
+	NegativePolicyDebt                   // Everything is cancelled, the balance drops to zero and the rest becomes a debt.
+)
+
+type NegativePolicy int
+
+// TxFilter narrows listed txs. Zero values don't filter.
+type TxFilter struct {
+	Source         Source
+	State          State
+	CreatedFrom    *time.Time // Inclusive.
+	CreatedTo      *time.Time // Exclusive.
+	MinAmount      *decimal.Decimal
+	MaxAmount      *decimal.Decimal
+	IncludeDeleted bool
+	CancelledOnly  bool
+}
+
 type Tx struct {
-	CreatedAt time.Time
-	DeletedAt *time.Time // Use soft deletes.
-	TxID      uuid.UUID
-	BalanceID uuid.UUID
-	Source    Source
-	State     State
-	Amount    decimal.Decimal
+	CreatedAt   time.Time
+	DeletedAt   *time.Time // Use soft deletes.
+	TenantID    string
+	TxID        uuid.UUID
+	BalanceID   uuid.UUID
+	Seq         int64      // Position of the tx in the balance history, assigned on insert.
+	ExternalRef *string    // Tx ID in operator's systems.
+	ParentTxID  *uuid.UUID // Earlier tx of the balance this tx relates to, e.g. a bet of a win.
+	Source      Source
+	State       State
+	Amount      decimal.Decimal
+	SettledDebt decimal.Decimal   // Part of a deposit which paid debt off instead of adding to the amount.
+	Metadata    map[string]string // Tags set by operators, e.g. provider, game and round.
+}
+
+// WriteOptions control how txs are recorded or cancelled.
+type WriteOptions struct {
+	ExpectedVersion *int64         // Write only if the balance is still at this version.
+	DryRun          bool           // Run all checks and rules, then roll back.
+	Cascade         bool           // Cancel not cancelled descendants of cancelled txs too.
+	NegativePolicy  NegativePolicy // What to do if cancelled deposits would make the balance negative, fail if unknown.
+}
+
+// RecordOutcome is the result of recording a tx.
+type RecordOutcome struct {
+	Tx      Tx         // Recorded tx with server fields, zero if the tx isn't recorded.
+	Balance Balance    // Balance after the tx, projected for dry runs.
+	Action  RuleAction // Strongest action of matched rules, the tx isn't recorded if it's reject or freeze.
+	Hits    []RuleHit
+}
+
+// CancelOutcome is the result of cancelling txs.
+type CancelOutcome struct {
+	Balance      Balance         // Balance after the cancellation, projected for dry runs.
+	Txs          []Tx            // Cancelled txs, txs cancelled before are skipped.
+	Correction   decimal.Decimal // Change of the balance amount reverting the txs.
+	SkippedTxIDs []uuid.UUID     // Deposits not cancelled by the skip policy.
+	Debt         decimal.Decimal // Part of the reverted amount the balance couldn't cover, recorded as debt.
 }
@@ -0,0 +1,160 @@
+// Code generated by "enumer -type=TxOrder -trimprefix=TxOrder -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _TxOrderName = "UnknownNewestFirstOldestFirst"
+
+var _TxOrderIndex = [...]uint8{0, 7, 18, 29}
+
+const _TxOrderLowerName = "unknownnewestfirstoldestfirst"
+
+func (i TxOrder) String() string {
+	if i < 0 || i >= TxOrder(len(_TxOrderIndex)-1) {
+		return fmt.Sprintf("TxOrder(%d)", i)
+	}
+	return _TxOrderName[_TxOrderIndex[i]:_TxOrderIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _TxOrderNoOp() {
+	var x [1]struct{}
+	_ = x[TxOrderUnknown-(0)]
+	_ = x[TxOrderNewestFirst-(1)]
+	_ = x[TxOrderOldestFirst-(2)]
+}
+
+var _TxOrderValues = []TxOrder{TxOrderUnknown, TxOrderNewestFirst, TxOrderOldestFirst}
+
+var _TxOrderNameToValueMap = map[string]TxOrder{
+	_TxOrderName[0:7]:        TxOrderUnknown,
+	_TxOrderLowerName[0:7]:   TxOrderUnknown,
+	_TxOrderName[7:18]:       TxOrderNewestFirst,
+	_TxOrderLowerName[7:18]:  TxOrderNewestFirst,
+	_TxOrderName[18:29]:      TxOrderOldestFirst,
+	_TxOrderLowerName[18:29]: TxOrderOldestFirst,
+}
+
+var _TxOrderNames = []string{
+	_TxOrderName[0:7],
+	_TxOrderName[7:18],
+	_TxOrderName[18:29],
+}
+
+// TxOrderString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func TxOrderString(s string) (TxOrder, error) {
+	if val, ok := _TxOrderNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _TxOrderNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to TxOrder values", s)
+}
+
+// TxOrderValues returns all values of the enum
+func TxOrderValues() []TxOrder {
+	return _TxOrderValues
+}
+
+// TxOrderStrings returns a slice of all String values of the enum
+func TxOrderStrings() []string {
+	strs := make([]string, len(_TxOrderNames))
+	copy(strs, _TxOrderNames)
+	return strs
+}
+
+// IsATxOrder returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i TxOrder) IsATxOrder() bool {
+	for _, v := range _TxOrderValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for TxOrder
+func (i TxOrder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for TxOrder
+func (i *TxOrder) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("TxOrder should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = TxOrderString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for TxOrder
+func (i TxOrder) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for TxOrder
+func (i *TxOrder) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = TxOrderString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for TxOrder
+func (i TxOrder) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for TxOrder
+func (i *TxOrder) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = TxOrderString(s)
+	return err
+}
+
+func (i TxOrder) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *TxOrder) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of TxOrder: %[1]T(%[1]v)", value)
+	}
+
+	val, err := TxOrderString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
@@ -0,0 +1,160 @@
+// Code generated by "enumer -type=WalletType -trimprefix=WalletType -json -text -yaml -sql"; DO NOT EDIT.
+
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const _WalletTypeName = "UnknownMainBonus"
+
+var _WalletTypeIndex = [...]uint8{0, 7, 11, 16}
+
+const _WalletTypeLowerName = "unknownmainbonus"
+
+func (i WalletType) String() string {
+	if i < 0 || i >= WalletType(len(_WalletTypeIndex)-1) {
+		return fmt.Sprintf("WalletType(%d)", i)
+	}
+	return _WalletTypeName[_WalletTypeIndex[i]:_WalletTypeIndex[i+1]]
+}
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _WalletTypeNoOp() {
+	var x [1]struct{}
+	_ = x[WalletTypeUnknown-(0)]
+	_ = x[WalletTypeMain-(1)]
+	_ = x[WalletTypeBonus-(2)]
+}
+
+var _WalletTypeValues = []WalletType{WalletTypeUnknown, WalletTypeMain, WalletTypeBonus}
+
+var _WalletTypeNameToValueMap = map[string]WalletType{
+	_WalletTypeName[0:7]:        WalletTypeUnknown,
+	_WalletTypeLowerName[0:7]:   WalletTypeUnknown,
+	_WalletTypeName[7:11]:       WalletTypeMain,
+	_WalletTypeLowerName[7:11]:  WalletTypeMain,
+	_WalletTypeName[11:16]:      WalletTypeBonus,
+	_WalletTypeLowerName[11:16]: WalletTypeBonus,
+}
+
+var _WalletTypeNames = []string{
+	_WalletTypeName[0:7],
+	_WalletTypeName[7:11],
+	_WalletTypeName[11:16],
+}
+
+// WalletTypeString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func WalletTypeString(s string) (WalletType, error) {
+	if val, ok := _WalletTypeNameToValueMap[s]; ok {
+		return val, nil
+	}
+
+	if val, ok := _WalletTypeNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to WalletType values", s)
+}
+
+// WalletTypeValues returns all values of the enum
+func WalletTypeValues() []WalletType {
+	return _WalletTypeValues
+}
+
+// WalletTypeStrings returns a slice of all String values of the enum
+func WalletTypeStrings() []string {
+	strs := make([]string, len(_WalletTypeNames))
+	copy(strs, _WalletTypeNames)
+	return strs
+}
+
+// IsAWalletType returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i WalletType) IsAWalletType() bool {
+	for _, v := range _WalletTypeValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements the json.Marshaler interface for WalletType
+func (i WalletType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for WalletType
+func (i *WalletType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("WalletType should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = WalletTypeString(s)
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for WalletType
+func (i WalletType) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for WalletType
+func (i *WalletType) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = WalletTypeString(string(text))
+	return err
+}
+
+// MarshalYAML implements a YAML Marshaler for WalletType
+func (i WalletType) MarshalYAML() (interface{}, error) {
+	return i.String(), nil
+}
+
+// UnmarshalYAML implements a YAML Unmarshaler for WalletType
+func (i *WalletType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var err error
+	*i, err = WalletTypeString(s)
+	return err
+}
+
+func (i WalletType) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+func (i *WalletType) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	case fmt.Stringer:
+		str = v.String()
+	default:
+		return fmt.Errorf("invalid value of WalletType: %[1]T(%[1]v)", value)
+	}
+
+	val, err := WalletTypeString(str)
+	if err != nil {
+		return err
+	}
+
+	*i = val
+	return nil
+}
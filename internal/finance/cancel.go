@@ -0,0 +1,110 @@
+package finance
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// SkipOffending returns deposits which can't be cancelled without making the balance negative.
+// Withdrawals are reverted first, then deposits in history order while the balance covers them.
+// Ancestors of skipped txs are skipped too, so no tx is cancelled while its child stays.
+func SkipOffending(amount decimal.Decimal, txs []domain.Tx) []uuid.UUID {
+	byID := make(map[uuid.UUID]domain.Tx, len(txs))
+	for _, tx := range txs {
+		byID[tx.TxID] = tx
+	}
+
+	sorted := slices.Clone(txs)
+	slices.SortFunc(sorted, func(a, b domain.Tx) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	skipped := make(map[uuid.UUID]struct{})
+
+	// Skipped withdrawals leave less to cover deposits, so it repeats until nothing else is skipped.
+	for changed := true; changed; {
+		changed = false
+
+		balance := amount
+		for _, tx := range sorted {
+			if _, ok := skipped[tx.TxID]; !ok && tx.State == domain.StateWithdraw {
+				balance = balance.Add(tx.Amount)
+			}
+		}
+
+		for _, tx := range sorted {
+			if _, ok := skipped[tx.TxID]; ok || tx.State != domain.StateDeposit {
+				continue
+			}
+
+			if credit := credited(tx); balance.GreaterThanOrEqual(credit) {
+				balance = balance.Sub(credit)
+				continue
+			}
+
+			skipped[tx.TxID] = struct{}{}
+			changed = true
+		}
+
+		for id := range skipped {
+			for parentID := byID[id].ParentTxID; parentID != nil; parentID = byID[*parentID].ParentTxID {
+				if _, ok := byID[*parentID]; !ok {
+					break
+				}
+				if _, ok := skipped[*parentID]; ok {
+					break
+				}
+
+				skipped[*parentID] = struct{}{}
+				changed = true
+			}
+		}
+	}
+
+	var ids []uuid.UUID
+	for _, tx := range sorted {
+		if _, ok := skipped[tx.TxID]; ok {
+			ids = append(ids, tx.TxID)
+		}
+	}
+
+	return ids
+}
+
+// Reversal is how cancelling txs changes a balance.
+type Reversal struct {
+	Correction decimal.Decimal // Change of the amount, it never takes the amount below zero.
+	Reinstated decimal.Decimal // Debt paid off by cancelled deposits, owed again.
+	Incurred   decimal.Decimal // Part of the reverted amount the balance couldn't cover.
+}
+
+// Revert returns how cancelling txs changes a balance with the amount.
+// Deposits take back only what they added to the amount, the debt they paid off is reinstated.
+// Whatever the amount can't cover is incurred as debt, so callers not allowing debt fail if it's positive.
+func Revert(amount decimal.Decimal, txs []domain.Tx) Reversal {
+	var r Reversal
+	for _, tx := range txs {
+		if tx.State == domain.StateDeposit {
+			r.Correction = r.Correction.Sub(credited(tx))
+			r.Reinstated = r.Reinstated.Add(tx.SettledDebt)
+		} else {
+			r.Correction = r.Correction.Add(tx.Amount)
+		}
+	}
+
+	if after := amount.Add(r.Correction); after.IsNegative() {
+		r.Incurred = after.Neg()
+		r.Correction = amount.Neg()
+	}
+
+	return r
+}
+
+// credited returns what the deposit added to the amount, the rest paid debt off.
+func credited(tx domain.Tx) decimal.Decimal {
+	return tx.Amount.Sub(tx.SettledDebt)
+}
@@ -0,0 +1,155 @@
+package finance_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/finance"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipOffending(t *testing.T) {
+	tx := func(seq int64, state domain.State, amount int64, parent *domain.Tx) domain.Tx {
+		t := domain.Tx{
+			TxID:   uuid.New(),
+			Seq:    seq,
+			State:  state,
+			Amount: decimal.NewFromInt(amount),
+		}
+		if parent != nil {
+			t.ParentTxID = &parent.TxID
+		}
+		return t
+	}
+
+	deposit1 := tx(1, domain.StateDeposit, 50, nil)
+	deposit2 := tx(2, domain.StateDeposit, 80, nil)
+	bet := tx(3, domain.StateWithdraw, 30, nil)
+	win := tx(4, domain.StateDeposit, 100, &bet)
+	settling := tx(5, domain.StateDeposit, 100, nil)
+	settling.SettledDebt = decimal.NewFromInt(30)
+
+	tests := []struct {
+		name     string
+		amount   int64
+		txs      []domain.Tx
+		expected []uuid.UUID
+	}{
+		{
+			name:   "balance covers all deposits",
+			amount: 130,
+			txs:    []domain.Tx{deposit1, deposit2},
+		},
+		{
+			name:     "later deposit is skipped",
+			amount:   100,
+			txs:      []domain.Tx{deposit2, deposit1},
+			expected: []uuid.UUID{deposit2.TxID},
+		},
+		{
+			name:   "reverted withdrawals cover deposits",
+			amount: 50,
+			txs:    []domain.Tx{deposit1, bet},
+		},
+		{
+			name:     "parent of skipped tx is skipped",
+			amount:   60,
+			txs:      []domain.Tx{deposit1, bet, win},
+			expected: []uuid.UUID{bet.TxID, win.TxID},
+		},
+		{
+			name:   "deposit which settled debt added only the rest",
+			amount: 70,
+			txs:    []domain.Tx{settling},
+		},
+		{
+			name:     "skipped parent leaves less for other deposits",
+			amount:   25,
+			txs:      []domain.Tx{deposit1, bet, win},
+			expected: []uuid.UUID{deposit1.TxID, bet.TxID, win.TxID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, finance.SkipOffending(decimal.NewFromInt(tt.amount), tt.txs))
+		})
+	}
+}
+
+func TestRevert(t *testing.T) {
+	deposit := func(amount, settledDebt int64) domain.Tx {
+		return domain.Tx{
+			TxID:        uuid.New(),
+			State:       domain.StateDeposit,
+			Amount:      decimal.NewFromInt(amount),
+			SettledDebt: decimal.NewFromInt(settledDebt),
+		}
+	}
+	withdrawal := func(amount int64) domain.Tx {
+		return domain.Tx{
+			TxID:   uuid.New(),
+			State:  domain.StateWithdraw,
+			Amount: decimal.NewFromInt(amount),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		amount     int64
+		txs        []domain.Tx
+		correction int64
+		reinstated int64
+		incurred   int64
+	}{
+		{
+			name:       "deposit and withdrawal",
+			amount:     100,
+			txs:        []domain.Tx{deposit(50, 0), withdrawal(30)},
+			correction: -20,
+		},
+		{
+			name:       "balance can't cover deposit",
+			amount:     20,
+			txs:        []domain.Tx{deposit(50, 0)},
+			correction: -20,
+			incurred:   30,
+		},
+		{
+			// Debt of 30 was settled by the deposit of 100, so it added 70 and nothing was spent.
+			name:       "deposit settled debt and is cancelled",
+			amount:     70,
+			txs:        []domain.Tx{deposit(100, 30)},
+			correction: -70,
+			reinstated: 30,
+		},
+		{
+			// Same deposit charged back after 50 of it was spent.
+			name:       "deposit settled debt and is charged back",
+			amount:     20,
+			txs:        []domain.Tx{deposit(100, 30)},
+			correction: -20,
+			reinstated: 30,
+			incurred:   50,
+		},
+		{
+			name:       "deposit only settled debt",
+			amount:     5,
+			txs:        []domain.Tx{deposit(20, 20)},
+			correction: 0,
+			reinstated: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := finance.Revert(decimal.NewFromInt(tt.amount), tt.txs)
+
+			assert.Equal(t, decimal.NewFromInt(tt.correction).String(), r.Correction.String())
+			assert.Equal(t, decimal.NewFromInt(tt.reinstated).String(), r.Reinstated.String())
+			assert.Equal(t, decimal.NewFromInt(tt.incurred).String(), r.Incurred.String())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package finance computes financial reports from aggregated txs and balance changes of cancellations.
+package finance
+
+import (
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+)
+
+// Revenues sums rows of every bucket into revenue in bucket order. Rows must be sorted by bucket start.
+func Revenues(rows []domain.AggregateRow) []domain.Revenue {
+	var revenues []domain.Revenue
+	for _, r := range rows {
+		if len(revenues) == 0 || !revenues[len(revenues)-1].BucketStart.Equal(r.BucketStart) {
+			revenues = append(revenues, domain.Revenue{BucketStart: r.BucketStart})
+		}
+
+		rev := &revenues[len(revenues)-1]
+		switch {
+		case r.Source == domain.SourceGame && r.State == domain.StateWithdraw:
+			rev.Turnover = rev.Turnover.Add(r.Amount)
+			rev.GGR = rev.GGR.Add(r.Amount)
+		case r.Source == domain.SourceGame && r.State == domain.StateDeposit:
+			rev.GGR = rev.GGR.Sub(r.Amount)
+		case r.Source == domain.SourcePayment && r.State == domain.StateDeposit:
+			rev.Deposits = rev.Deposits.Add(r.Amount)
+		case r.Source == domain.SourcePayment && r.State == domain.StateWithdraw:
+			rev.Payouts = rev.Payouts.Add(r.Amount)
+		}
+	}
+
+	return revenues
+}
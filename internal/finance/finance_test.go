@@ -0,0 +1,88 @@
+package finance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/finance"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevenues(t *testing.T) {
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	row := func(bucket time.Time, source domain.Source, state domain.State, amount int64) domain.AggregateRow {
+		return domain.AggregateRow{
+			BucketStart: bucket,
+			Source:      source,
+			State:       state,
+			Count:       1,
+			Amount:      decimal.NewFromInt(amount),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rows     []domain.AggregateRow
+		expected []domain.Revenue
+	}{
+		{
+			name: "no rows",
+		},
+		{
+			name: "bets, wins and payments",
+			rows: []domain.AggregateRow{
+				row(day1, domain.SourceGame, domain.StateDeposit, 30),
+				row(day1, domain.SourceGame, domain.StateWithdraw, 100),
+				row(day1, domain.SourcePayment, domain.StateDeposit, 500),
+				row(day1, domain.SourcePayment, domain.StateWithdraw, 200),
+				row(day1, domain.SourceService, domain.StateDeposit, 10),
+			},
+			expected: []domain.Revenue{
+				{
+					BucketStart: day1,
+					GGR:         decimal.NewFromInt(70),
+					Turnover:    decimal.NewFromInt(100),
+					Deposits:    decimal.NewFromInt(500),
+					Payouts:     decimal.NewFromInt(200),
+				},
+			},
+		},
+		{
+			name: "negative ggr in separate buckets",
+			rows: []domain.AggregateRow{
+				row(day1, domain.SourceGame, domain.StateWithdraw, 10),
+				row(day2, domain.SourceGame, domain.StateDeposit, 50),
+			},
+			expected: []domain.Revenue{
+				{
+					BucketStart: day1,
+					GGR:         decimal.NewFromInt(10),
+					Turnover:    decimal.NewFromInt(10),
+				},
+				{
+					BucketStart: day2,
+					GGR:         decimal.NewFromInt(-50),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revenues := finance.Revenues(tt.rows)
+
+			assert.Len(t, revenues, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, tt.expected[i].BucketStart.Equal(revenues[i].BucketStart))
+				assert.True(t, tt.expected[i].GGR.Equal(revenues[i].GGR), "ggr %s", revenues[i].GGR)
+				assert.True(t, tt.expected[i].Turnover.Equal(revenues[i].Turnover), "turnover %s", revenues[i].Turnover)
+				assert.True(t, tt.expected[i].Deposits.Equal(revenues[i].Deposits), "deposits %s", revenues[i].Deposits)
+				assert.True(t, tt.expected[i].Payouts.Equal(revenues[i].Payouts), "payouts %s", revenues[i].Payouts)
+			}
+		})
+	}
+}
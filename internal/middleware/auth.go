@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/iskorotkov/igaming-balance-backend/internal/operator"
+	"github.com/iskorotkov/igaming-balance-backend/internal/tenant"
+)
+
+const bearerPrefix = "Bearer "
+
+// Authenticate resolves the tenant by the API key from the Authorization header.
+// Keys map API keys to tenant IDs.
+func Authenticate(keys map[string]string) connect.Interceptor {
+	return &authInterceptor{
+		keys: keys,
+	}
+}
+
+// AuthenticateAdmin resolves the tenant like Authenticate and the operator by the admin key.
+// Operators map admin keys to support staff, every key must have an operator.
+func AuthenticateAdmin(keys, operators map[string]string) connect.Interceptor {
+	return &authInterceptor{
+		keys:      keys,
+		admin:     true,
+		operators: operators,
+	}
+}
+
+type authInterceptor struct {
+	keys      map[string]string
+	admin     bool
+	operators map[string]string
+}
+
+func (a *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := a.authenticate(ctx, req.Header())
+		if err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (a *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := a.authenticate(ctx, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+
+		return next(ctx, conn)
+	}
+}
+
+func (a *authInterceptor) authenticate(ctx context.Context, header http.Header) (context.Context, error) {
+	tenantID, err := tenantByKey(a.keys, header)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	ctx = tenant.WithID(ctx, tenantID)
+
+	if !a.admin {
+		return ctx, nil
+	}
+
+	name, err := operatorByKey(a.operators, header)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	return operator.WithName(ctx, name), nil
+}
+
+// AuthenticateHTTP resolves the tenant like Authenticate for plain HTTP handlers.
+// Failures are Connect errors with CodeUnauthenticated written by writeError,
+// so they have the same shape as other errors of the handlers.
+func AuthenticateHTTP(keys map[string]string, next http.Handler, writeError func(http.ResponseWriter, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, err := tenantByKey(keys, r.Header)
+		if err != nil {
+			writeError(w, connect.NewError(connect.CodeUnauthenticated, err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), tenantID)))
+	})
+}
+
+func tenantByKey(keys map[string]string, header http.Header) (string, error) {
+	key, ok := strings.CutPrefix(header.Get("Authorization"), bearerPrefix)
+	if !ok || key == "" {
+		return "", errors.New("missing api key")
+	}
+
+	tenantID, ok := keys[key]
+	if !ok {
+		return "", errors.New("unknown api key")
+	}
+
+	return tenantID, nil
+}
+
+func operatorByKey(operators map[string]string, header http.Header) (string, error) {
+	key, _ := strings.CutPrefix(header.Get("Authorization"), bearerPrefix)
+
+	name, ok := operators[key]
+	if !ok || name == "" {
+		return "", errors.New("admin key without operator")
+	}
+
+	return name, nil
+}
+
+// WithAPIKey adds the API key to client requests.
+func WithAPIKey(key string) connect.Interceptor {
+	return apiKeyInterceptor(key)
+}
+
+type apiKeyInterceptor string
+
+func (k apiKeyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", bearerPrefix+string(k))
+		return next(ctx, req)
+	}
+}
+
+func (k apiKeyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", bearerPrefix+string(k))
+		return conn
+	}
+}
+
+func (k apiKeyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,188 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/iskorotkov/igaming-balance-backend/internal/middleware"
+	"github.com/iskorotkov/igaming-balance-backend/internal/operator"
+	"github.com/iskorotkov/igaming-balance-backend/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestAuthenticate(t *testing.T) {
+	keys := map[string]string{
+		"key-1": "casino-1",
+		"key-2": "casino-2",
+	}
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedTenant string
+		expectedStatus connect.Code
+	}{
+		{
+			name:           "known key",
+			header:         "Bearer key-2",
+			expectedTenant: "casino-2",
+		},
+		{
+			name:           "missing header",
+			expectedStatus: connect.CodeUnauthenticated,
+		},
+		{
+			name:           "missing bearer prefix",
+			header:         "key-1",
+			expectedStatus: connect.CodeUnauthenticated,
+		},
+		{
+			name:           "unknown key",
+			header:         "Bearer key-3",
+			expectedStatus: connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTenant string
+			next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				gotTenant, _ = tenant.FromContext(ctx)
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			}
+
+			req := connect.NewRequest(&emptypb.Empty{})
+			if tt.header != "" {
+				req.Header().Set("Authorization", tt.header)
+			}
+
+			_, err := middleware.Authenticate(keys).WrapUnary(next)(context.Background(), req)
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedStatus, connect.CodeOf(err))
+				assert.Empty(t, gotTenant)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTenant, gotTenant)
+		})
+	}
+}
+
+func TestAuthenticateAdmin(t *testing.T) {
+	keys := map[string]string{
+		"admin-1": "casino-1",
+		"admin-2": "casino-1",
+	}
+	operators := map[string]string{
+		"admin-1": "alice@casino.example",
+	}
+
+	tests := []struct {
+		name             string
+		header           string
+		expectedOperator string
+		expectedStatus   connect.Code
+	}{
+		{
+			name:             "key with operator",
+			header:           "Bearer admin-1",
+			expectedOperator: "alice@casino.example",
+		},
+		{
+			name:           "key without operator",
+			header:         "Bearer admin-2",
+			expectedStatus: connect.CodeUnauthenticated,
+		},
+		{
+			name:           "unknown key",
+			header:         "Bearer admin-3",
+			expectedStatus: connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOperator string
+			next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				gotOperator, _ = operator.FromContext(ctx)
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			}
+
+			req := connect.NewRequest(&emptypb.Empty{})
+			req.Header().Set("Authorization", tt.header)
+
+			_, err := middleware.AuthenticateAdmin(keys, operators).WrapUnary(next)(context.Background(), req)
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedStatus, connect.CodeOf(err))
+				assert.Empty(t, gotOperator)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOperator, gotOperator)
+		})
+	}
+}
+
+func TestAuthenticateHTTP(t *testing.T) {
+	keys := map[string]string{
+		"key-1": "casino-1",
+	}
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedTenant string
+		expectedStatus int
+	}{
+		{
+			name:           "known key",
+			header:         "Bearer key-1",
+			expectedTenant: "casino-1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing header",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown key",
+			header:         "Bearer key-2",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTenant string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotTenant, _ = tenant.FromContext(r.Context())
+			})
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/balances", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			writeError := func(w http.ResponseWriter, err error) {
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+
+			middleware.AuthenticateHTTP(keys, next, writeError).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedTenant, gotTenant)
+		})
+	}
+}
@@ -7,28 +7,58 @@ import (
 	"connectrpc.com/connect"
 )
 
-func LogRequests() connect.UnaryInterceptorFunc {
-	return func(next connect.UnaryFunc) connect.UnaryFunc {
-		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-			attrs := []any{
-				"req", req.Any(),
-				"method", req.HTTPMethod(),
-				"header", req.Header(),
-				"peer", req.Peer(),
-			}
-
-			slog.DebugContext(ctx, "got request", attrs...)
-
-			resp, err := next(ctx, req)
-			if err != nil {
-				attrs = append(attrs, "err", err)
-				slog.DebugContext(ctx, "request not processed", attrs...)
-				return resp, err
-			}
-
-			attrs = append(attrs, "resp", resp.Any())
-			slog.DebugContext(ctx, "request processed", attrs...)
-			return resp, nil
+func LogRequests() connect.Interceptor {
+	return logInterceptor{}
+}
+
+type logInterceptor struct{}
+
+func (logInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		attrs := []any{
+			"req", req.Any(),
+			"method", req.HTTPMethod(),
+			"header", req.Header(),
+			"peer", req.Peer(),
+		}
+
+		slog.DebugContext(ctx, "got request", attrs...)
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			attrs = append(attrs, "err", err)
+			slog.DebugContext(ctx, "request not processed", attrs...)
+			return resp, err
+		}
+
+		attrs = append(attrs, "resp", resp.Any())
+		slog.DebugContext(ctx, "request processed", attrs...)
+		return resp, nil
+	}
+}
+
+func (logInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (logInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		attrs := []any{
+			"procedure", conn.Spec().Procedure,
+			"header", conn.RequestHeader(),
+			"peer", conn.Peer(),
 		}
+
+		slog.DebugContext(ctx, "got stream", attrs...)
+
+		if err := next(ctx, conn); err != nil {
+			attrs = append(attrs, "err", err)
+			slog.DebugContext(ctx, "stream failed", attrs...)
+			return err
+		}
+
+		slog.DebugContext(ctx, "stream closed", attrs...)
+
+		return nil
 	}
 }
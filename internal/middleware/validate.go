@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"google.golang.org/protobuf/proto"
+)
+
+// Validate rejects requests breaking protovalidate rules of the schema before they reach handlers.
+// Messages of bidi streams are left to handlers, so they can report an invalid message without closing the stream.
+func Validate() connect.Interceptor {
+	return validateInterceptor{}
+}
+
+type validateInterceptor struct{}
+
+func (validateInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := validate(req.Any()); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (validateInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (validateInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if conn.Spec().StreamType == connect.StreamTypeBidi {
+			return next(ctx, conn)
+		}
+
+		return next(ctx, validatingConn{StreamingHandlerConn: conn})
+	}
+}
+
+type validatingConn struct {
+	connect.StreamingHandlerConn
+}
+
+func (c validatingConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+
+	return validate(msg)
+}
+
+func validate(msg any) error {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := protovalidate.Validate(m)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		slog.Error("failed to validate request", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to validate request"))
+	}
+
+	return invalidRequest(validationErr)
+}
+
+// invalidRequest returns InvalidArgument with ErrorInfo and field violations like errors of handlers have.
+func invalidRequest(err *protovalidate.ValidationError) *connect.Error {
+	return apierror.New(connect.CodeInvalidArgument, balancev1.ErrorReason_ERROR_REASON_INVALID_ARGUMENT, err.Error(), nil,
+		transform.BadRequestFromValidation(err))
+}
@@ -0,0 +1,133 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestValidate(t *testing.T) {
+	balanceID := uuid.NewString()
+
+	recordTx := func(amount string) *balancev1.RecordTxRequest {
+		return &balancev1.RecordTxRequest{
+			BalanceId: balanceID,
+			TxId:      uuid.NewString(),
+			Source:    balancev1.Source_SOURCE_GAME,
+			State:     balancev1.State_STATE_WITHDRAW,
+			Amount:    &balancev1.Decimal{Value: amount},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		request        connect.AnyRequest
+		expectedFields []string
+	}{
+		{
+			name:    "valid tx",
+			request: connect.NewRequest(recordTx("10.15")),
+		},
+		{
+			name:           "negative amount",
+			request:        connect.NewRequest(recordTx("-10.15")),
+			expectedFields: []string{"amount"},
+		},
+		{
+			name:           "zero amount",
+			request:        connect.NewRequest(recordTx("0.00")),
+			expectedFields: []string{"amount"},
+		},
+		{
+			name:           "too many digits after the point",
+			request:        connect.NewRequest(recordTx("0.123456789")),
+			expectedFields: []string{"amount.value"},
+		},
+		{
+			name: "missing amount and unspecified source",
+			request: connect.NewRequest(&balancev1.RecordTxRequest{
+				BalanceId: balanceID,
+				TxId:      uuid.NewString(),
+				State:     balancev1.State_STATE_DEPOSIT,
+			}),
+			expectedFields: []string{"source", "amount"},
+		},
+		{
+			name:           "invalid balance ID",
+			request:        connect.NewRequest(&balancev1.BalanceRequest{BalanceId: "invalid-uuid"}),
+			expectedFields: []string{"balance_id"},
+		},
+		{
+			name:           "no tx IDs",
+			request:        connect.NewRequest(&balancev1.CancelTxsRequest{BalanceId: balanceID}),
+			expectedFields: []string{"tx_ids"},
+		},
+		{
+			name: "invalid tx ID",
+			request: connect.NewRequest(&balancev1.CancelTxsRequest{
+				BalanceId: balanceID,
+				TxIds:     []string{uuid.NewString(), "invalid-uuid"},
+			}),
+			expectedFields: []string{"tx_ids[1]"},
+		},
+		{
+			name:           "page size out of bounds",
+			request:        connect.NewRequest(&balancev1.ListTxRequest{BalanceId: balanceID, PageSize: 1001}),
+			expectedFields: []string{"page_size"},
+		},
+		{
+			name:    "optional owner ID is empty",
+			request: connect.NewRequest(&balancev1.OpenBalanceRequest{BalanceId: balanceID}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				called = true
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			}
+
+			_, err := middleware.Validate().WrapUnary(next)(context.Background(), tt.request)
+
+			if len(tt.expectedFields) == 0 {
+				require.NoError(t, err)
+				assert.True(t, called)
+				return
+			}
+
+			var connectErr *connect.Error
+			require.ErrorAs(t, err, &connectErr)
+			assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+			assert.False(t, called)
+
+			var fields []string
+			for _, d := range connectErr.Details() {
+				msg, err := d.Value()
+				require.NoError(t, err)
+
+				if info, ok := msg.(*errdetails.ErrorInfo); ok {
+					assert.Equal(t, apierror.Domain, info.GetDomain())
+					assert.Equal(t, "INVALID_ARGUMENT", info.GetReason())
+				}
+
+				if badRequest, ok := msg.(*errdetails.BadRequest); ok {
+					for _, v := range badRequest.GetFieldViolations() {
+						fields = append(fields, v.GetField())
+					}
+				}
+			}
+			assert.ElementsMatch(t, tt.expectedFields, fields)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+// Package operator passes the support staff member making an admin request through context.
+package operator
+
+import "context"
+
+type contextKey struct{}
+
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+func FromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(contextKey{}).(string)
+	return name, ok && name != ""
+}
@@ -0,0 +1,131 @@
+// Package rest serves plain HTTP endpoints for clients not speaking Connect or gRPC.
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type errorResponse struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason,omitempty"` // Like the reason of ErrorInfo details of Connect errors.
+}
+
+type Storage interface {
+	ExportStatement(ctx context.Context, balanceID uuid.UUID, from, to *time.Time, fn func(domain.StatementEntry) error) error
+}
+
+// Balances records txs, so plain HTTP writes have the validation, logging and errors of Connect ones.
+type Balances interface {
+	RecordTx(ctx context.Context, req *connect.Request[balancev1.RecordTxRequest]) (*connect.Response[balancev1.RecordTxResponse], error)
+}
+
+// NewHandler returns a handler calling b through interceptors, the first interceptor is the outermost.
+func NewHandler(s Storage, b Balances, interceptors ...connect.Interceptor) *Handler {
+	recordTx := connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return b.RecordTx(ctx, req.(*connect.Request[balancev1.RecordTxRequest]))
+	})
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		recordTx = interceptors[i].WrapUnary(recordTx)
+	}
+
+	return &Handler{
+		s:        s,
+		recordTx: recordTx,
+	}
+}
+
+type Handler struct {
+	s        Storage
+	recordTx connect.UnaryFunc
+}
+
+// Register adds routes of the handler to the mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/balances/{balance_id}/txs", h.RecordTx)
+	mux.HandleFunc("GET /v1/balances/{balance_id}/statement", h.ExportStatement)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// WriteError writes the error like handlers of the package do, so wrappers of the handlers
+// such as authentication return errors of the same shape.
+func WriteError(w http.ResponseWriter, err error) {
+	writeConnectError(w, err)
+}
+
+// writeConnectError writes the error of a Connect handler with the HTTP status of its code.
+func writeConnectError(w http.ResponseWriter, err error) {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		slog.Error("failed to handle request", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+		return
+	}
+
+	writeJSON(w, httpStatus(connectErr.Code()), errorResponse{
+		Error:  connectErr.Message(),
+		Reason: apierror.Reason(connectErr),
+	})
+}
+
+func httpStatus(code connect.Code) int {
+	switch code {
+	case connect.CodeInvalidArgument, connect.CodeOutOfRange:
+		return http.StatusBadRequest
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists, connect.CodeAborted:
+		return http.StatusConflict
+	case connect.CodeFailedPrecondition:
+		return http.StatusUnprocessableEntity
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeProtoJSON writes the message with field names of the schema like in JSON models of requests.
+func writeProtoJSON(w http.ResponseWriter, status int, m proto.Message) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		slog.Error("failed to encode response", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("failed to encode response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(b); err != nil {
+		slog.Error("failed to write response", "error", err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iskorotkov/igaming-balance-backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError_Unauthenticated(t *testing.T) {
+	keys := map[string]string{
+		"key-1": "casino-1",
+	}
+
+	tests := []struct {
+		name          string
+		header        string
+		expectedError string
+	}{
+		{
+			name:          "missing key",
+			expectedError: "missing api key",
+		},
+		{
+			name:          "unknown key",
+			header:        "Bearer key-2",
+			expectedError: "unknown api key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			NewHandler(NewMockStorage(t), NewMockBalances(t)).Register(mux)
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/balances/00000000-0000-0000-0000-000000000001/statement", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			middleware.AuthenticateHTTP(keys, mux, WriteError).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var resp errorResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, tt.expectedError, resp.Error)
+		})
+	}
+}
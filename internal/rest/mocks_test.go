@@ -0,0 +1,213 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package rest
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStorage {
+	mock := &MockStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStorage is an autogenerated mock type for the Storage type
+type MockStorage struct {
+	mock.Mock
+}
+
+type MockStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
+	return &MockStorage_Expecter{mock: &_m.Mock}
+}
+
+// ExportStatement provides a mock function for the type MockStorage
+func (_mock *MockStorage) ExportStatement(ctx context.Context, balanceID uuid.UUID, from *time.Time, to *time.Time, fn func(domain.StatementEntry) error) error {
+	ret := _mock.Called(ctx, balanceID, from, to, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportStatement")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time, *time.Time, func(domain.StatementEntry) error) error); ok {
+		r0 = returnFunc(ctx, balanceID, from, to, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_ExportStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportStatement'
+type MockStorage_ExportStatement_Call struct {
+	*mock.Call
+}
+
+// ExportStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+//   - from *time.Time
+//   - to *time.Time
+//   - fn func(domain.StatementEntry) error
+func (_e *MockStorage_Expecter) ExportStatement(ctx interface{}, balanceID interface{}, from interface{}, to interface{}, fn interface{}) *MockStorage_ExportStatement_Call {
+	return &MockStorage_ExportStatement_Call{Call: _e.mock.On("ExportStatement", ctx, balanceID, from, to, fn)}
+}
+
+func (_c *MockStorage_ExportStatement_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, from *time.Time, to *time.Time, fn func(domain.StatementEntry) error)) *MockStorage_ExportStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		var arg3 *time.Time
+		if args[3] != nil {
+			arg3 = args[3].(*time.Time)
+		}
+		var arg4 func(domain.StatementEntry) error
+		if args[4] != nil {
+			arg4 = args[4].(func(domain.StatementEntry) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ExportStatement_Call) Return(err error) *MockStorage_ExportStatement_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_ExportStatement_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, from *time.Time, to *time.Time, fn func(domain.StatementEntry) error) error) *MockStorage_ExportStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBalances creates a new instance of MockBalances. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBalances(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBalances {
+	mock := &MockBalances{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBalances is an autogenerated mock type for the Balances type
+type MockBalances struct {
+	mock.Mock
+}
+
+type MockBalances_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBalances) EXPECT() *MockBalances_Expecter {
+	return &MockBalances_Expecter{mock: &_m.Mock}
+}
+
+// RecordTx provides a mock function for the type MockBalances
+func (_mock *MockBalances) RecordTx(ctx context.Context, req *connect.Request[balancev1.RecordTxRequest]) (*connect.Response[balancev1.RecordTxResponse], error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordTx")
+	}
+
+	var r0 *connect.Response[balancev1.RecordTxResponse]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *connect.Request[balancev1.RecordTxRequest]) (*connect.Response[balancev1.RecordTxResponse], error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *connect.Request[balancev1.RecordTxRequest]) *connect.Response[balancev1.RecordTxResponse]); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[balancev1.RecordTxResponse])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *connect.Request[balancev1.RecordTxRequest]) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBalances_RecordTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordTx'
+type MockBalances_RecordTx_Call struct {
+	*mock.Call
+}
+
+// RecordTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *connect.Request[balancev1.RecordTxRequest]
+func (_e *MockBalances_Expecter) RecordTx(ctx interface{}, req interface{}) *MockBalances_RecordTx_Call {
+	return &MockBalances_RecordTx_Call{Call: _e.mock.On("RecordTx", ctx, req)}
+}
+
+func (_c *MockBalances_RecordTx_Call) Run(run func(ctx context.Context, req *connect.Request[balancev1.RecordTxRequest])) *MockBalances_RecordTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *connect.Request[balancev1.RecordTxRequest]
+		if args[1] != nil {
+			arg1 = args[1].(*connect.Request[balancev1.RecordTxRequest])
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBalances_RecordTx_Call) Return(response *connect.Response[balancev1.RecordTxResponse], err error) *MockBalances_RecordTx_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockBalances_RecordTx_Call) RunAndReturn(run func(ctx context.Context, req *connect.Request[balancev1.RecordTxRequest]) (*connect.Response[balancev1.RecordTxResponse], error)) *MockBalances_RecordTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/statement"
+	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+)
+
+// ExportStatement downloads txs and cancellations of the balance with the balance after each of them.
+// Query parameters are optional: from (inclusive) and to (exclusive) in RFC 3339 and format (csv or jsonl, csv by default).
+func (h *Handler) ExportStatement(w http.ResponseWriter, r *http.Request) {
+	balanceID, err := uuid.Parse(r.PathValue("balance_id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %v", transform.ErrInvalidBalanceID, err))
+		return
+	}
+
+	query := r.URL.Query()
+
+	format := domain.StatementFormatCSV
+	if query.Has("format") {
+		format, err = domain.StatementFormatString(query.Get("format"))
+		if err != nil || format == domain.StatementFormatUnknown {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %q", transform.ErrInvalidFormat, query.Get("format")))
+			return
+		}
+	}
+
+	from, err := optionalTime(query.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %v", transform.ErrInvalidRange, err))
+		return
+	}
+
+	to, err := optionalTime(query.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %v", transform.ErrInvalidRange, err))
+		return
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %v", transform.ErrInvalidRange, "from must be before to"))
+		return
+	}
+
+	// Statements of long histories take longer than the server write timeout.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		slog.Error("failed to disable write timeout", "error", err)
+	}
+
+	download := &downloadWriter{
+		w:        w,
+		filename: fmt.Sprintf("statement-%s.%s", balanceID, statement.FileExtension(format)),
+		format:   format,
+	}
+
+	sw, err := statement.NewWriter(download, format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = h.s.ExportStatement(r.Context(), balanceID, from, to, sw.Write)
+	if err == nil {
+		err = sw.Flush()
+	}
+	if err != nil {
+		if download.started {
+			// Status is already sent, so the response is aborted to not pass a truncated statement as a complete one.
+			slog.Error("failed to export statement", "error", err)
+			panic(http.ErrAbortHandler)
+		}
+
+		if errors.Is(err, storage.ErrNotFound) {
+			writeError(w, http.StatusNotFound, errors.New("balance not found"))
+			return
+		}
+		slog.Error("failed to export statement", "error", err)
+		writeError(w, http.StatusInternalServerError, errors.New("failed to export statement"))
+		return
+	}
+
+	// Empty statements may have nothing to write.
+	download.start()
+}
+
+// downloadWriter sends headers of a file download with the first write, so errors before it can still be reported.
+type downloadWriter struct {
+	w        http.ResponseWriter
+	filename string
+	format   domain.StatementFormat
+	started  bool
+}
+
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	d.start()
+	return d.w.Write(p)
+}
+
+func (d *downloadWriter) start() {
+	if d.started {
+		return
+	}
+
+	d.w.Header().Set("Content-Type", statement.ContentType(d.format))
+	d.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", d.filename))
+	d.w.WriteHeader(http.StatusOK)
+	d.started = true
+}
+
+func optionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ExportStatement(t *testing.T) {
+	balanceID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	entry := domain.StatementEntry{
+		At:             from,
+		Seq:            1,
+		TxID:           uuid.New(),
+		Source:         domain.SourceGame,
+		State:          domain.StateDeposit,
+		Amount:         decimal.NewFromInt(10),
+		Change:         decimal.NewFromInt(10),
+		RunningBalance: decimal.NewFromInt(10),
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		setupMock           func(*MockStorage)
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{
+			name:  "csv by default",
+			query: "?from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z",
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ExportStatement(mock.Anything, balanceID, &from, &to, mock.Anything).
+					RunAndReturn(func(ctx context.Context, id uuid.UUID, from, to *time.Time, fn func(domain.StatementEntry) error) error {
+						return fn(entry)
+					})
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+		},
+		{
+			name:  "empty jsonl",
+			query: "?format=jsonl",
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ExportStatement(mock.Anything, balanceID, (*time.Time)(nil), (*time.Time)(nil), mock.Anything).Return(nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/jsonl",
+		},
+		{
+			name:                "invalid format",
+			query:               "?format=xml",
+			setupMock:           func(m *MockStorage) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+		},
+		{
+			name:                "invalid range",
+			query:               "?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			setupMock:           func(m *MockStorage) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+		},
+		{
+			name: "balance not found",
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ExportStatement(mock.Anything, balanceID, (*time.Time)(nil), (*time.Time)(nil), mock.Anything).Return(storage.ErrNotFound)
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			mux := http.NewServeMux()
+			NewHandler(mockStorage, NewMockBalances(t)).Register(mux)
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/v1/balances/"+balanceID.String()+"/statement"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+
+			if tt.expectedContentType == "text/csv" {
+				assert.Contains(t, rec.Body.String(), entry.TxID.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+)
+
+// maxBodySize limits request bodies, a tx request is much smaller.
+const maxBodySize = 64 << 10
+
+// TxRequest is the JSON model of a tx with lowercase string enums and a string amount.
+type TxRequest struct {
+	Source      string            `json:"source"`
+	State       string            `json:"state"`
+	Amount      string            `json:"amount"`
+	TxID        string            `json:"tx_id"`
+	ExternalRef string            `json:"external_ref,omitempty"`
+	ParentTxID  string            `json:"parent_tx_id,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// RecordTx records the tx like RecordTx of the Connect service and responds with its response:
+// the recorded tx with server fields and the balance after it.
+func (h *Handler) RecordTx(w http.ResponseWriter, r *http.Request) {
+	var req TxRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return
+	}
+
+	msg, err := RecordTxRequestFromJSON(r.PathValue("balance_id"), req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := h.recordTx(r.Context(), connect.NewRequest(msg))
+	if err != nil {
+		writeConnectError(w, err)
+		return
+	}
+
+	writeProtoJSON(w, http.StatusCreated, resp.Any().(*balancev1.RecordTxResponse))
+}
+
+// RecordTxRequestFromJSON maps the JSON model to the Connect request, source and state are case-insensitive names of domain values.
+// Other fields are validated with the request like for Connect clients.
+func RecordTxRequestFromJSON(balanceID string, req TxRequest) (*balancev1.RecordTxRequest, error) {
+	source, err := domain.SourceString(req.Source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", transform.ErrInvalidSource, req.Source)
+	}
+
+	state, err := domain.StateString(req.State)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", transform.ErrInvalidState, req.State)
+	}
+
+	return &balancev1.RecordTxRequest{
+		BalanceId:   balanceID,
+		Source:      balancev1.Source(source),
+		State:       balancev1.State(state),
+		Amount:      &balancev1.Decimal{Value: req.Amount},
+		TxId:        req.TxID,
+		ExternalRef: req.ExternalRef,
+		ParentTxId:  req.ParentTxID,
+		Metadata:    req.Metadata,
+	}, nil
+}
@@ -0,0 +1,185 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_RecordTx(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+
+	msg := &balancev1.RecordTxRequest{
+		BalanceId: balanceID.String(),
+		Source:    balancev1.Source_SOURCE_GAME,
+		State:     balancev1.State_STATE_DEPOSIT,
+		Amount:    &balancev1.Decimal{Value: "10.15"},
+		TxId:      txID.String(),
+		Metadata:  map[string]string{"provider": "provider-x"},
+	}
+	body := `{"source":"game","state":"deposit","amount":"10.15","tx_id":"` + txID.String() + `","metadata":{"provider":"provider-x"}}`
+
+	isMsg := mock.MatchedBy(func(req *connect.Request[balancev1.RecordTxRequest]) bool {
+		return proto.Equal(msg, req.Msg)
+	})
+
+	tests := []struct {
+		name           string
+		balanceID      string
+		body           string
+		setupMock      func(*MockBalances)
+		expectedStatus int
+		expectedReason string
+	}{
+		{
+			name:      "record transaction success",
+			balanceID: balanceID.String(),
+			body:      body,
+			setupMock: func(m *MockBalances) {
+				m.EXPECT().RecordTx(mock.Anything, isMsg).Return(connect.NewResponse(&balancev1.RecordTxResponse{
+					Version: 1,
+					Balance: &balancev1.BalanceResponse{
+						BalanceId: balanceID.String(),
+						Amount:    &balancev1.Decimal{Value: "10.15"},
+						Version:   1,
+					},
+					Tx: &balancev1.Tx{
+						TxId:      txID.String(),
+						BalanceId: balanceID.String(),
+						Source:    balancev1.Source_SOURCE_GAME,
+						State:     balancev1.State_STATE_DEPOSIT,
+						Amount:    &balancev1.Decimal{Value: "10.15"},
+						Seq:       1,
+					},
+				}), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid source",
+			balanceID:      balanceID.String(),
+			body:           `{"source":"client","state":"deposit","amount":"10.15","tx_id":"` + txID.String() + `"}`,
+			setupMock:      func(m *MockBalances) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "adjustment source",
+			balanceID:      balanceID.String(),
+			body:           `{"source":"adjustment","state":"deposit","amount":"10.15","tx_id":"` + txID.String() + `"}`,
+			setupMock:      func(m *MockBalances) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedReason: "INVALID_ARGUMENT",
+		},
+		{
+			name:           "negative amount",
+			balanceID:      balanceID.String(),
+			body:           `{"source":"game","state":"deposit","amount":"-10.15","tx_id":"` + txID.String() + `"}`,
+			setupMock:      func(m *MockBalances) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedReason: "INVALID_ARGUMENT",
+		},
+		{
+			name:           "unknown field",
+			balanceID:      balanceID.String(),
+			body:           `{"source":"game","state":"deposit","amount":"10.15","tx_id":"` + txID.String() + `","extra":1}`,
+			setupMock:      func(m *MockBalances) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid balance id",
+			balanceID:      "invalid-uuid",
+			body:           body,
+			setupMock:      func(m *MockBalances) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedReason: "INVALID_ARGUMENT",
+		},
+		{
+			name:      "balance not found",
+			balanceID: balanceID.String(),
+			body:      body,
+			setupMock: func(m *MockBalances) {
+				m.EXPECT().RecordTx(mock.Anything, isMsg).Return(nil, apierror.New(connect.CodeNotFound,
+					balancev1.ErrorReason_ERROR_REASON_BALANCE_NOT_FOUND, "balance not found", nil))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedReason: "BALANCE_NOT_FOUND",
+		},
+		{
+			name:      "transaction already exists",
+			balanceID: balanceID.String(),
+			body:      body,
+			setupMock: func(m *MockBalances) {
+				m.EXPECT().RecordTx(mock.Anything, isMsg).Return(nil, apierror.New(connect.CodeAlreadyExists,
+					balancev1.ErrorReason_ERROR_REASON_TX_ALREADY_EXISTS, "transaction already exists", nil))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedReason: "TX_ALREADY_EXISTS",
+		},
+		{
+			name:      "insufficient funds",
+			balanceID: balanceID.String(),
+			body:      body,
+			setupMock: func(m *MockBalances) {
+				m.EXPECT().RecordTx(mock.Anything, isMsg).Return(nil, apierror.New(connect.CodeFailedPrecondition,
+					balancev1.ErrorReason_ERROR_REASON_INSUFFICIENT_FUNDS, "insufficient funds", nil))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedReason: "INSUFFICIENT_FUNDS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBalances := NewMockBalances(t)
+			tt.setupMock(mockBalances)
+
+			mux := http.NewServeMux()
+			NewHandler(NewMockStorage(t), mockBalances, middleware.Validate()).Register(mux)
+
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/v1/balances/"+tt.balanceID+"/txs", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			if tt.expectedStatus != http.StatusCreated {
+				var resp errorResponse
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.NotEmpty(t, resp.Error)
+				assert.Equal(t, tt.expectedReason, resp.Reason)
+				return
+			}
+
+			var resp struct {
+				Version int64 `json:"version,string"`
+				Balance struct {
+					BalanceID string `json:"balance_id"`
+				} `json:"balance"`
+				Tx struct {
+					TxID   string `json:"tx_id"`
+					Source string `json:"source"`
+				} `json:"tx"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, int64(1), resp.Version)
+			assert.Equal(t, balanceID.String(), resp.Balance.BalanceID)
+			assert.Equal(t, txID.String(), resp.Tx.TxID)
+			assert.Equal(t, "SOURCE_GAME", resp.Tx.Source)
+		})
+	}
+}
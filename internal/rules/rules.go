@@ -0,0 +1,210 @@
+// Package rules evaluates declarative velocity rules against recent balance activity.
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidRule = errors.New("invalid rule")
+
+type Aggregate string
+
+const (
+	AggregateCount Aggregate = "count"
+	AggregateSum   Aggregate = "sum"
+)
+
+// Duration is a time.Duration that is written as "1m" or "24h" in rule files.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+	return nil
+}
+
+// Condition holds when the aggregate over balance txs in the window is within [Min, Max].
+// Unknown source or state matches txs of any source or state.
+type Condition struct {
+	Aggregate Aggregate        `json:"aggregate"`
+	Source    domain.Source    `json:"source"`
+	State     domain.State     `json:"state"`
+	Window    Duration         `json:"window"`
+	Min       *decimal.Decimal `json:"min,omitempty"`
+	Max       *decimal.Decimal `json:"max,omitempty"`
+}
+
+// Rule applies to incoming txs of matching source and state and fires when all conditions hold.
+type Rule struct {
+	Name       string            `json:"name"`
+	Source     domain.Source     `json:"source"`
+	State      domain.State      `json:"state"`
+	Conditions []Condition       `json:"conditions"`
+	Action     domain.RuleAction `json:"action"`
+}
+
+// StatsFunc returns aggregated balance txs created within the window.
+type StatsFunc func(window time.Duration) ([]domain.TxStats, error)
+
+func New(rules []Rule) (*Engine, error) {
+	for _, r := range rules {
+		if err := validate(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Engine{
+		rules: rules,
+	}, nil
+}
+
+// Load reads rules from a JSON file. Empty path means no rules.
+func Load(path string) (*Engine, error) {
+	if path == "" {
+		return New(nil)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	return New(rules)
+}
+
+type Engine struct {
+	rules []Rule
+}
+
+// Evaluate checks rules in order as if tx was already recorded.
+// A matched allow rule stops evaluation, otherwise the most severe action of matched rules is returned.
+func (e *Engine) Evaluate(tx domain.Tx, stats StatsFunc) (domain.RuleAction, []domain.RuleHit, error) {
+	cache := make(map[Duration][]domain.TxStats)
+
+	action := domain.RuleActionAllow
+	var hits []domain.RuleHit
+
+	for _, r := range e.rules {
+		if !matches(r.Source, r.State, tx.Source, tx.State) {
+			continue
+		}
+
+		ok := true
+		for _, c := range r.Conditions {
+			s, found := cache[c.Window]
+			if !found {
+				var err error
+				s, err = stats(time.Duration(c.Window))
+				if err != nil {
+					return domain.RuleActionUnknown, nil, fmt.Errorf("fetch stats for %v: %w", time.Duration(c.Window), err)
+				}
+
+				cache[c.Window] = s
+			}
+
+			if !c.holds(tx, s) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		if r.Action == domain.RuleActionAllow {
+			return domain.RuleActionAllow, nil, nil
+		}
+
+		hits = append(hits, domain.RuleHit{
+			Rule:   r.Name,
+			Action: r.Action,
+		})
+		action = max(action, r.Action)
+	}
+
+	return action, hits, nil
+}
+
+func (c Condition) holds(tx domain.Tx, stats []domain.TxStats) bool {
+	var count int64
+	var sum decimal.Decimal
+
+	for _, s := range stats {
+		if matches(c.Source, c.State, s.Source, s.State) {
+			count += s.Count
+			sum = sum.Add(s.Sum)
+		}
+	}
+
+	if matches(c.Source, c.State, tx.Source, tx.State) {
+		count++
+		sum = sum.Add(tx.Amount)
+	}
+
+	value := sum
+	if c.Aggregate == AggregateCount {
+		value = decimal.NewFromInt(count)
+	}
+
+	if c.Min != nil && value.LessThan(*c.Min) {
+		return false
+	}
+
+	if c.Max != nil && value.GreaterThan(*c.Max) {
+		return false
+	}
+
+	return true
+}
+
+func matches(wantSource domain.Source, wantState domain.State, source domain.Source, state domain.State) bool {
+	return (wantSource == domain.SourceUnknown || wantSource == source) &&
+		(wantState == domain.StateUnknown || wantState == state)
+}
+
+func validate(r Rule) error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidRule)
+	}
+
+	if !r.Action.IsARuleAction() || r.Action == domain.RuleActionUnknown {
+		return fmt.Errorf("%w: rule %q has unknown action", ErrInvalidRule, r.Name)
+	}
+
+	for _, c := range r.Conditions {
+		if c.Aggregate != AggregateCount && c.Aggregate != AggregateSum {
+			return fmt.Errorf("%w: rule %q has unknown aggregate %q", ErrInvalidRule, r.Name, c.Aggregate)
+		}
+
+		if c.Window <= 0 {
+			return fmt.Errorf("%w: rule %q has non-positive window", ErrInvalidRule, r.Name)
+		}
+
+		if c.Min == nil && c.Max == nil {
+			return fmt.Errorf("%w: rule %q has condition without bounds", ErrInvalidRule, r.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package rules_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/rules"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	engine, err := rules.Load("../../config/rules.json")
+	require.NoError(t, err)
+	require.NotNil(t, engine)
+
+	engine, err = rules.Load("")
+	require.NoError(t, err)
+	require.NotNil(t, engine)
+}
+
+func TestNew(t *testing.T) {
+	one := decimal.NewFromInt(1)
+
+	tests := []struct {
+		name    string
+		rule    rules.Rule
+		wantErr error
+	}{
+		{
+			name: "valid rule",
+			rule: rules.Rule{
+				Name:       "valid",
+				Conditions: []rules.Condition{{Aggregate: rules.AggregateCount, Window: rules.Duration(time.Minute), Min: &one}},
+				Action:     domain.RuleActionFlag,
+			},
+		},
+		{
+			name: "missing name",
+			rule: rules.Rule{
+				Action: domain.RuleActionFlag,
+			},
+			wantErr: rules.ErrInvalidRule,
+		},
+		{
+			name: "unknown action",
+			rule: rules.Rule{
+				Name: "unknown action",
+			},
+			wantErr: rules.ErrInvalidRule,
+		},
+		{
+			name: "unknown aggregate",
+			rule: rules.Rule{
+				Name:       "unknown aggregate",
+				Conditions: []rules.Condition{{Aggregate: "avg", Window: rules.Duration(time.Minute), Min: &one}},
+				Action:     domain.RuleActionFlag,
+			},
+			wantErr: rules.ErrInvalidRule,
+		},
+		{
+			name: "empty window",
+			rule: rules.Rule{
+				Name:       "empty window",
+				Conditions: []rules.Condition{{Aggregate: rules.AggregateCount, Min: &one}},
+				Action:     domain.RuleActionFlag,
+			},
+			wantErr: rules.ErrInvalidRule,
+		},
+		{
+			name: "no bounds",
+			rule: rules.Rule{
+				Name:       "no bounds",
+				Conditions: []rules.Condition{{Aggregate: rules.AggregateCount, Window: rules.Duration(time.Minute)}},
+				Action:     domain.RuleActionFlag,
+			},
+			wantErr: rules.ErrInvalidRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rules.New([]rules.Rule{tt.rule})
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	zero := decimal.Zero
+	one := decimal.NewFromInt(1)
+	twenty := decimal.NewFromInt(20)
+	thousand := decimal.NewFromInt(1000)
+
+	velocity := rules.Rule{
+		Name:  "withdraw-velocity",
+		State: domain.StateWithdraw,
+		Conditions: []rules.Condition{
+			{Aggregate: rules.AggregateCount, State: domain.StateWithdraw, Window: rules.Duration(time.Minute), Min: &twenty},
+		},
+		Action: domain.RuleActionReject,
+	}
+	pingPong := rules.Rule{
+		Name:   "payment-ping-pong",
+		Source: domain.SourcePayment,
+		State:  domain.StateWithdraw,
+		Conditions: []rules.Condition{
+			{Aggregate: rules.AggregateCount, Source: domain.SourcePayment, State: domain.StateDeposit, Window: rules.Duration(time.Hour), Min: &one},
+			{Aggregate: rules.AggregateCount, Source: domain.SourceGame, Window: rules.Duration(time.Hour), Max: &zero},
+		},
+		Action: domain.RuleActionFlag,
+	}
+	largeWithdraw := rules.Rule{
+		Name:  "large-withdraw",
+		State: domain.StateWithdraw,
+		Conditions: []rules.Condition{
+			{Aggregate: rules.AggregateSum, State: domain.StateWithdraw, Window: rules.Duration(time.Hour), Min: &thousand},
+		},
+		Action: domain.RuleActionFreeze,
+	}
+	allowService := rules.Rule{
+		Name:   "allow-service",
+		Source: domain.SourceService,
+		Action: domain.RuleActionAllow,
+	}
+
+	withdraw := domain.Tx{
+		TxID:      uuid.New(),
+		BalanceID: uuid.New(),
+		Source:    domain.SourcePayment,
+		State:     domain.StateWithdraw,
+		Amount:    decimal.NewFromInt(100),
+	}
+
+	tests := []struct {
+		name       string
+		rules      []rules.Rule
+		tx         domain.Tx
+		stats      []domain.TxStats
+		wantAction domain.RuleAction
+		wantHits   []domain.RuleHit
+	}{
+		{
+			name:       "no rules",
+			tx:         withdraw,
+			wantAction: domain.RuleActionAllow,
+		},
+		{
+			name:  "below velocity limit",
+			rules: []rules.Rule{velocity},
+			tx:    withdraw,
+			stats: []domain.TxStats{
+				{Source: domain.SourceGame, State: domain.StateWithdraw, Count: 18, Sum: decimal.NewFromInt(180)},
+			},
+			wantAction: domain.RuleActionAllow,
+		},
+		{
+			name:  "velocity limit counts incoming tx",
+			rules: []rules.Rule{velocity},
+			tx:    withdraw,
+			stats: []domain.TxStats{
+				{Source: domain.SourceGame, State: domain.StateWithdraw, Count: 10, Sum: decimal.NewFromInt(100)},
+				{Source: domain.SourcePayment, State: domain.StateWithdraw, Count: 9, Sum: decimal.NewFromInt(90)},
+			},
+			wantAction: domain.RuleActionReject,
+			wantHits:   []domain.RuleHit{{Rule: "withdraw-velocity", Action: domain.RuleActionReject}},
+		},
+		{
+			name:  "ping-pong without play",
+			rules: []rules.Rule{pingPong},
+			tx:    withdraw,
+			stats: []domain.TxStats{
+				{Source: domain.SourcePayment, State: domain.StateDeposit, Count: 1, Sum: decimal.NewFromInt(100)},
+			},
+			wantAction: domain.RuleActionFlag,
+			wantHits:   []domain.RuleHit{{Rule: "payment-ping-pong", Action: domain.RuleActionFlag}},
+		},
+		{
+			name:  "ping-pong with play",
+			rules: []rules.Rule{pingPong},
+			tx:    withdraw,
+			stats: []domain.TxStats{
+				{Source: domain.SourcePayment, State: domain.StateDeposit, Count: 1, Sum: decimal.NewFromInt(100)},
+				{Source: domain.SourceGame, State: domain.StateDeposit, Count: 1, Sum: decimal.NewFromInt(5)},
+			},
+			wantAction: domain.RuleActionAllow,
+		},
+		{
+			name:  "most severe action wins",
+			rules: []rules.Rule{pingPong, largeWithdraw},
+			tx:    withdraw,
+			stats: []domain.TxStats{
+				{Source: domain.SourcePayment, State: domain.StateDeposit, Count: 1, Sum: decimal.NewFromInt(2000)},
+				{Source: domain.SourcePayment, State: domain.StateWithdraw, Count: 1, Sum: decimal.NewFromInt(900)},
+			},
+			wantAction: domain.RuleActionFreeze,
+			wantHits: []domain.RuleHit{
+				{Rule: "payment-ping-pong", Action: domain.RuleActionFlag},
+				{Rule: "large-withdraw", Action: domain.RuleActionFreeze},
+			},
+		},
+		{
+			name:  "allow rule stops evaluation",
+			rules: []rules.Rule{allowService, largeWithdraw},
+			tx: domain.Tx{
+				Source: domain.SourceService,
+				State:  domain.StateWithdraw,
+				Amount: decimal.NewFromInt(5000),
+			},
+			wantAction: domain.RuleActionAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := rules.New(tt.rules)
+			require.NoError(t, err)
+
+			action, hits, err := engine.Evaluate(tt.tx, func(window time.Duration) ([]domain.TxStats, error) {
+				return tt.stats, nil
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, action)
+			assert.Equal(t, tt.wantHits, hits)
+		})
+	}
+}
+
+func TestEngine_Evaluate_StatsError(t *testing.T) {
+	one := decimal.NewFromInt(1)
+
+	engine, err := rules.New([]rules.Rule{
+		{
+			Name:       "any",
+			Conditions: []rules.Condition{{Aggregate: rules.AggregateCount, Window: rules.Duration(time.Minute), Min: &one}},
+			Action:     domain.RuleActionFlag,
+		},
+	})
+	require.NoError(t, err)
+
+	_, _, err = engine.Evaluate(domain.Tx{}, func(window time.Duration) ([]domain.TxStats, error) {
+		return nil, errors.New("storage error")
+	})
+	require.Error(t, err)
+}
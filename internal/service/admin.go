@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	adminv1 "github.com/iskorotkov/igaming-balance-backend/gen/admin/v1"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/operator"
+	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type AdminStorage interface {
+	AdjustBalance(ctx context.Context, tx domain.Tx, entry domain.AuditEntry) error
+	ForceCancelTx(ctx context.Context, balanceID, txID uuid.UUID, cascade bool, entry domain.AuditEntry) error
+	InspectBalance(ctx context.Context, balanceID uuid.UUID, entry domain.AuditEntry) (domain.BalanceInspection, error)
+	SetBalanceStatus(ctx context.Context, balanceID uuid.UUID, status domain.BalanceStatus, entry domain.AuditEntry) (domain.Balance, error)
+}
+
+func NewAdmin(s AdminStorage) *Admin {
+	return &Admin{
+		s: s,
+	}
+}
+
+// Admin serves back-office actions of support staff, it must be mounted behind admin keys resolving operators.
+type Admin struct {
+	s AdminStorage
+}
+
+func (a *Admin) AdjustBalance(
+	ctx context.Context,
+	req *connect.Request[adminv1.AdjustBalanceRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	tx, err := transform.AdjustmentFromProto(req.Msg)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	entry, err := auditEntry(ctx, req.Msg.GetAudit())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.s.AdjustBalance(ctx, tx, entry); err != nil {
+		return nil, recordTxError(tx, err)
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (a *Admin) ForceCancelTx(
+	ctx context.Context,
+	req *connect.Request[adminv1.ForceCancelTxRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return nil, invalidField("balance_id", err)
+	}
+
+	txID, err := uuid.Parse(req.Msg.GetTxId())
+	if err != nil {
+		return nil, invalidField("tx_id", err)
+	}
+
+	entry, err := auditEntry(ctx, req.Msg.GetAudit())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.s.ForceCancelTx(ctx, balanceID, txID, req.Msg.GetCascade(), entry); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, newError(connect.CodeNotFound, balancev1.ErrorReason_ERROR_REASON_TX_NOT_FOUND, "transaction not found",
+				map[string]string{"balance_id": balanceID.String(), "tx_id": txID.String()})
+		}
+		if errors.Is(err, storage.ErrHasChildren) {
+			return nil, hasChildren(balanceID.String(), err)
+		}
+		if errors.Is(err, storage.ErrNegativeBalance) {
+			return nil, insufficientFunds(balanceID.String(), err)
+		}
+		slog.Error("failed to force cancel transaction", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to cancel transaction"))
+	}
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (a *Admin) InspectBalance(
+	ctx context.Context,
+	req *connect.Request[adminv1.InspectBalanceRequest],
+) (*connect.Response[adminv1.InspectBalanceResponse], error) {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return nil, invalidField("balance_id", err)
+	}
+
+	entry, err := auditEntry(ctx, req.Msg.GetAudit())
+	if err != nil {
+		return nil, err
+	}
+
+	inspection, err := a.s.InspectBalance(ctx, balanceID, entry)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, balanceNotFound(balanceID.String())
+		}
+		slog.Error("failed to inspect balance", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to inspect balance"))
+	}
+
+	resp, err := transform.BalanceInspectionToProto(inspection)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+func (a *Admin) SetBalanceStatus(
+	ctx context.Context,
+	req *connect.Request[adminv1.SetBalanceStatusRequest],
+) (*connect.Response[balancev1.BalanceResponse], error) {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return nil, invalidField("balance_id", err)
+	}
+
+	status, err := transform.BalanceStatusFromProto(req.Msg.GetStatus())
+	if err != nil {
+		return nil, invalidField("status", err)
+	}
+
+	entry, err := auditEntry(ctx, req.Msg.GetAudit())
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := a.s.SetBalanceStatus(ctx, balanceID, status, entry)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, balanceNotFound(balanceID.String())
+		}
+		slog.Error("failed to set balance status", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to set balance status"))
+	}
+
+	resp, err := transform.BalanceToProto(balance)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// auditEntry returns the audit entry of the request made by the operator of the admin key.
+func auditEntry(ctx context.Context, a *adminv1.Audit) (domain.AuditEntry, error) {
+	name, ok := operator.FromContext(ctx)
+	if !ok {
+		return domain.AuditEntry{}, connect.NewError(connect.CodeUnauthenticated, errors.New("missing operator"))
+	}
+
+	entry, err := transform.AuditEntryFromProto(name, a)
+	if err != nil {
+		return domain.AuditEntry{}, invalidRequest(err)
+	}
+
+	return entry, nil
+}
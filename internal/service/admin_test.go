@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	adminv1 "github.com/iskorotkov/igaming-balance-backend/gen/admin/v1"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/operator"
+	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin_AdjustBalance(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	amount := decimal.NewFromInt(50)
+
+	ctx := operator.WithName(context.Background(), "support@casino.example")
+	audit := &adminv1.Audit{
+		Reason:    "lost deposit",
+		TicketRef: "SUP-1",
+	}
+	entry := domain.AuditEntry{
+		Operator:  "support@casino.example",
+		Reason:    "lost deposit",
+		TicketRef: "SUP-1",
+	}
+	tx := domain.Tx{
+		BalanceID: balanceID,
+		TxID:      txID,
+		Source:    domain.SourceAdjustment,
+		State:     domain.StateDeposit,
+		Amount:    amount,
+	}
+
+	tests := []struct {
+		name           string
+		request        *adminv1.AdjustBalanceRequest
+		setupMock      func(*MockAdminStorage)
+		ctx            context.Context
+		expectedStatus connect.Code
+		expectedReason string
+	}{
+		{
+			name: "adjust balance success",
+			request: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_DEPOSIT,
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Audit:     audit,
+			},
+			setupMock: func(m *MockAdminStorage) {
+				m.EXPECT().AdjustBalance(ctx, tx, entry).Return(nil)
+			},
+		},
+		{
+			name: "missing audit",
+			request: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_DEPOSIT,
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+			},
+			setupMock:      func(m *MockAdminStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+			expectedReason: "INVALID_ARGUMENT",
+		},
+		{
+			name: "missing operator",
+			request: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_DEPOSIT,
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Audit:     audit,
+			},
+			setupMock:      func(m *MockAdminStorage) {},
+			ctx:            context.Background(),
+			expectedStatus: connect.CodeUnauthenticated,
+		},
+		{
+			name: "insufficient funds",
+			request: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_DEPOSIT,
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Audit:     audit,
+			},
+			setupMock: func(m *MockAdminStorage) {
+				m.EXPECT().AdjustBalance(ctx, tx, entry).Return(storage.ErrNegativeBalance)
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+			expectedReason: "INSUFFICIENT_FUNDS",
+		},
+		{
+			name: "balance not found",
+			request: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_DEPOSIT,
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Audit:     audit,
+			},
+			setupMock: func(m *MockAdminStorage) {
+				m.EXPECT().AdjustBalance(ctx, tx, entry).Return(storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+			expectedReason: "BALANCE_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockAdminStorage(t)
+			tt.setupMock(mockStorage)
+
+			reqCtx := ctx
+			if tt.ctx != nil {
+				reqCtx = tt.ctx
+			}
+
+			_, err := NewAdmin(mockStorage).AdjustBalance(reqCtx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				var connectErr *connect.Error
+				require.ErrorAs(t, err, &connectErr)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				assert.Equal(t, tt.expectedReason, apierror.Reason(connectErr))
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAdmin_ForceCancelTx(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+
+	childID := uuid.New()
+	ctx := operator.WithName(context.Background(), "support@casino.example")
+
+	audit := &adminv1.Audit{
+		Reason:    "wrong adjustment",
+		TicketRef: "SUP-2",
+	}
+	entry := domain.AuditEntry{
+		Operator:  "support@casino.example",
+		Reason:    "wrong adjustment",
+		TicketRef: "SUP-2",
+	}
+
+	tests := []struct {
+		name           string
+		cascade        bool
+		storageErr     error
+		expectedStatus connect.Code
+		expectedReason string
+	}{
+		{
+			name: "force cancel success",
+		},
+		{
+			name:    "force cancel with descendants",
+			cascade: true,
+		},
+		{
+			name:           "transaction has children",
+			storageErr:     &storage.HasChildrenError{Children: []uuid.UUID{childID}},
+			expectedStatus: connect.CodeFailedPrecondition,
+			expectedReason: "TX_HAS_CHILDREN",
+		},
+		{
+			name:           "transaction not found",
+			storageErr:     storage.ErrNotFound,
+			expectedStatus: connect.CodeNotFound,
+			expectedReason: "TX_NOT_FOUND",
+		},
+		{
+			name:           "storage error",
+			storageErr:     errors.New("storage error"),
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockAdminStorage(t)
+			mockStorage.EXPECT().ForceCancelTx(ctx, balanceID, txID, tt.cascade, entry).Return(tt.storageErr)
+
+			_, err := NewAdmin(mockStorage).ForceCancelTx(ctx, connect.NewRequest(&adminv1.ForceCancelTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Audit:     audit,
+				Cascade:   tt.cascade,
+			}))
+
+			if tt.expectedStatus != 0 {
+				var connectErr *connect.Error
+				require.ErrorAs(t, err, &connectErr)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				assert.Equal(t, tt.expectedReason, apierror.Reason(connectErr))
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAdmin_InspectBalance(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	amount := decimal.NewFromInt(-20)
+
+	ctx := operator.WithName(context.Background(), "support@casino.example")
+	entry := domain.AuditEntry{
+		Operator:  "support@casino.example",
+		Reason:    "player complaint",
+		TicketRef: "SUP-3",
+	}
+
+	mockStorage := NewMockAdminStorage(t)
+	mockStorage.EXPECT().InspectBalance(ctx, balanceID, entry).Return(domain.BalanceInspection{
+		Balance: domain.Balance{
+			BalanceID: balanceID,
+			Amount:    decimal.NewFromInt(80),
+			Status:    domain.BalanceStatusActive,
+		},
+		Txs: []domain.Tx{
+			{
+				TxID:      txID,
+				BalanceID: balanceID,
+				Source:    domain.SourceAdjustment,
+				State:     domain.StateWithdraw,
+				Amount:    amount.Neg(),
+			},
+		},
+		AuditEntries: []domain.AuditEntry{
+			{
+				EntryID:   uuid.New(),
+				Operator:  "support@casino.example",
+				Action:    domain.AuditActionAdjustBalance,
+				BalanceID: balanceID,
+				TxID:      &txID,
+				Amount:    &amount,
+				Reason:    "bonus abuse",
+				TicketRef: "SUP-0",
+			},
+		},
+	}, nil)
+
+	resp, err := NewAdmin(mockStorage).InspectBalance(ctx, connect.NewRequest(&adminv1.InspectBalanceRequest{
+		BalanceId: balanceID.String(),
+		Audit: &adminv1.Audit{
+			Reason:    "player complaint",
+			TicketRef: "SUP-3",
+		},
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "80", resp.Msg.GetBalance().GetAmount().GetValue())
+	require.Len(t, resp.Msg.GetTxs(), 1)
+	assert.Equal(t, balancev1.Source_SOURCE_ADJUSTMENT, resp.Msg.GetTxs()[0].GetSource())
+	require.Len(t, resp.Msg.GetAuditEntries(), 1)
+	assert.Equal(t, adminv1.AuditAction_AUDIT_ACTION_ADJUST_BALANCE, resp.Msg.GetAuditEntries()[0].GetAction())
+	assert.Equal(t, "-20", resp.Msg.GetAuditEntries()[0].GetAmount().GetValue())
+	assert.Equal(t, txID.String(), resp.Msg.GetAuditEntries()[0].GetTxId())
+}
+
+func TestAdmin_SetBalanceStatus(t *testing.T) {
+	balanceID := uuid.New()
+
+	ctx := operator.WithName(context.Background(), "support@casino.example")
+	audit := &adminv1.Audit{
+		Reason:    "chargeback reviewed",
+		TicketRef: "SUP-4",
+	}
+	entry := domain.AuditEntry{
+		Operator:  "support@casino.example",
+		Reason:    "chargeback reviewed",
+		TicketRef: "SUP-4",
+	}
+
+	tests := []struct {
+		name           string
+		status         balancev1.BalanceStatus
+		setupMock      func(*MockAdminStorage)
+		expectedStatus connect.Code
+		expectedReason string
+	}{
+		{
+			name:   "unfreeze withdrawals",
+			status: balancev1.BalanceStatus_BALANCE_STATUS_ACTIVE,
+			setupMock: func(m *MockAdminStorage) {
+				m.EXPECT().SetBalanceStatus(ctx, balanceID, domain.BalanceStatusActive, entry).Return(domain.Balance{
+					BalanceID: balanceID,
+					Amount:    decimal.NewFromInt(10),
+					Status:    domain.BalanceStatusActive,
+				}, nil)
+			},
+		},
+		{
+			name:           "unspecified status",
+			status:         balancev1.BalanceStatus_BALANCE_STATUS_UNSPECIFIED,
+			setupMock:      func(m *MockAdminStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+			expectedReason: "INVALID_ARGUMENT",
+		},
+		{
+			name:   "balance not found",
+			status: balancev1.BalanceStatus_BALANCE_STATUS_FROZEN,
+			setupMock: func(m *MockAdminStorage) {
+				m.EXPECT().SetBalanceStatus(ctx, balanceID, domain.BalanceStatusFrozen, entry).Return(domain.Balance{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+			expectedReason: "BALANCE_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockAdminStorage(t)
+			tt.setupMock(mockStorage)
+
+			resp, err := NewAdmin(mockStorage).SetBalanceStatus(ctx, connect.NewRequest(&adminv1.SetBalanceStatusRequest{
+				BalanceId: balanceID.String(),
+				Status:    tt.status,
+				Audit:     audit,
+			}))
+
+			if tt.expectedStatus != 0 {
+				var connectErr *connect.Error
+				require.ErrorAs(t, err, &connectErr)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				assert.Equal(t, tt.expectedReason, apierror.Reason(connectErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.Msg.GetStatus())
+		})
+	}
+}
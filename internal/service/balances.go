@@ -1,26 +1,90 @@
 package service
 
 import (
+	"bufio"
 	"context"
 	"errors"
+	"hash/fnv"
+	"io"
 	"log/slog"
+	"sync"
+	"time"
 
+	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
 	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/cursor"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/finance"
+	"github.com/iskorotkov/igaming-balance-backend/internal/statement"
 	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
 	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	// streamWorkers is the number of balances whose streamed txs are recorded concurrently.
+	streamWorkers = 8
+	// streamQueueSize limits streamed txs waiting for a worker.
+	streamQueueSize = 64
+)
+
+// statementChunkSize is the size of statement chunks sent to the stream.
+const statementChunkSize = 32 << 10
+
+// watchBatchSize limits balance events read at once, so slow watchers catch up in batches.
+const watchBatchSize = 100
+
+// txsCursorFilter binds ListTx page tokens to the balance, filter and order they were issued for.
+type txsCursorFilter struct {
+	BalanceID uuid.UUID
+	Filter    domain.TxFilter
+	Order     domain.TxOrder
+}
+
+// balancesCursorFilter binds ListBalances page tokens to the filter and sorting they were issued for.
+type balancesCursorFilter struct {
+	Filter domain.BalanceFilter
+	Sort   domain.BalanceSort
+}
+
+// debtEntriesCursorFilter binds ListDebtEntries page tokens to the balance they were issued for.
+type debtEntriesCursorFilter struct {
+	BalanceID uuid.UUID
+}
+
+// cancellationCursorFilter binds GetCancellation page tokens to the cancellation they were issued for.
+type cancellationCursorFilter struct {
+	CancellationID uuid.UUID
+}
+
+// flaggedEventsCursorFilter binds ListFlaggedEvents page tokens to the balance they were issued for.
+type flaggedEventsCursorFilter struct {
+	BalanceID *uuid.UUID
+}
+
 type Storage interface {
-	RecordTx(ctx context.Context, tx domain.Tx) error
-	CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID) error
-	RecentTxs(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, limit int) ([]domain.Tx, error)
-	PreviousTxs(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, before uuid.UUID, limit int) ([]domain.Tx, error)
-	OpenBalance(ctx context.Context, balanceID uuid.UUID) error
+	RecordTx(ctx context.Context, tx domain.Tx, opts domain.WriteOptions) (domain.RecordOutcome, error)
+	CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID, opts domain.WriteOptions) (domain.CancelOutcome, error)
+	Tx(ctx context.Context, txID uuid.UUID) (domain.Tx, error)
+	RelatedTxs(ctx context.Context, txID uuid.UUID) ([]domain.Tx, error)
+	StartCancellation(ctx context.Context, criteria domain.CancellationCriteria, policy domain.NegativePolicy) (domain.Cancellation, error)
+	Cancellation(ctx context.Context, cancellationID uuid.UUID) (domain.Cancellation, error)
+	CancellationBalances(ctx context.Context, cancellationID uuid.UUID, afterBalanceID *uuid.UUID, limit int) ([]domain.BalanceCancellation, error)
+	TxByExternalRef(ctx context.Context, externalRef string) (domain.Tx, error)
+	ListTxs(ctx context.Context, balanceID uuid.UUID, filter domain.TxFilter, order domain.TxOrder, afterSeq *int64, limit int) ([]domain.Tx, error)
+	OpenBalance(ctx context.Context, balance domain.Balance) (domain.Balance, error)
 	Balance(ctx context.Context, balanceID uuid.UUID) (domain.Balance, error)
+	ListBalances(ctx context.Context, filter domain.BalanceFilter, sort domain.BalanceSort, after *domain.BalancePosition, limit int) ([]domain.Balance, *domain.BalancePosition, error)
+	Subscribe(ctx context.Context, balanceID uuid.UUID) (<-chan struct{}, func(), error)
+	BalanceEvents(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.BalanceEvent, error)
+	ExportStatement(ctx context.Context, balanceID uuid.UUID, from, to *time.Time, fn func(domain.StatementEntry) error) error
+	Aggregate(ctx context.Context, q domain.AggregateQuery) ([]domain.AggregateRow, error)
+	RecentFlaggedEvents(ctx context.Context, balanceID *uuid.UUID, limit int) ([]domain.FlaggedEvent, error)
+	PreviousFlaggedEvents(ctx context.Context, balanceID *uuid.UUID, before uuid.UUID, limit int) ([]domain.FlaggedEvent, error)
+	DebtEntries(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.DebtEntry, error)
+	Chargeback(ctx context.Context, balanceID, txID uuid.UUID, reason *string) (domain.ChargebackOutcome, error)
 }
 
 func NewBalances(s Storage) *Balances {
@@ -39,27 +103,39 @@ func (b *Balances) ListTx(
 ) (*connect.Response[balancev1.ListTxResponse], error) {
 	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, invalidField("balance_id", err)
 	}
 
-	var txs []domain.Tx
-	if req.Msg.GetPageToken() == "" {
-		txs, err = b.s.RecentTxs(ctx, balanceID, req.Msg.GetIncludeDeleted(), int(req.Msg.PageSize))
-		if err != nil {
-			slog.Error("failed to get recent transactions", "error", err)
-			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get transactions"))
-		}
-	} else {
-		beforeUUID, err := uuid.Parse(req.Msg.GetPageToken())
-		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
-		}
+	filter, err := transform.TxFilterFromProto(req.Msg)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	order, err := transform.TxOrderFromProto(req.Msg.GetOrder())
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	cursorFilter := txsCursorFilter{
+		BalanceID: balanceID,
+		Filter:    filter,
+		Order:     order,
+	}
 
-		txs, err = b.s.PreviousTxs(ctx, balanceID, req.Msg.GetIncludeDeleted(), beforeUUID, int(req.Msg.PageSize))
+	var afterSeq *int64
+	if req.Msg.GetPageToken() != "" {
+		seq, err := cursor.Decode[int64](req.Msg.GetPageToken(), cursorFilter)
 		if err != nil {
-			slog.Error("failed to get previous transactions", "error", err)
-			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get transactions"))
+			return nil, invalidRequest(err)
 		}
+
+		afterSeq = &seq
+	}
+
+	txs, err := b.s.ListTxs(ctx, balanceID, filter, order, afterSeq, int(req.Msg.PageSize))
+	if err != nil {
+		slog.Error("failed to get transactions", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get transactions"))
 	}
 
 	if len(txs) == 0 {
@@ -79,93 +155,449 @@ func (b *Balances) ListTx(
 		protoTxs = append(protoTxs, t)
 	}
 
+	nextPageToken, err := cursor.Encode(txs[len(txs)-1].Seq, cursorFilter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	return connect.NewResponse(&balancev1.ListTxResponse{
 		Txs:           protoTxs,
-		NextPageToken: protoTxs[len(protoTxs)-1].TxId,
+		NextPageToken: nextPageToken,
 	}), nil
 }
 
 func (b *Balances) RecordTx(
 	ctx context.Context,
 	req *connect.Request[balancev1.RecordTxRequest],
-) (*connect.Response[emptypb.Empty], error) {
+) (*connect.Response[balancev1.RecordTxResponse], error) {
 	tx, err := transform.TxFromProto(req.Msg)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, invalidRequest(err)
 	}
 
-	if err := b.s.RecordTx(ctx, tx); err != nil {
-		if errors.Is(err, storage.ErrNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("balance not found"))
+	outcome, err := b.s.RecordTx(ctx, tx, domain.WriteOptions{
+		ExpectedVersion: req.Msg.ExpectedVersion,
+		DryRun:          req.Msg.GetDryRun(),
+	})
+	if err != nil {
+		return nil, recordTxError(tx, err)
+	}
+
+	resp, err := transform.RecordOutcomeToProto(outcome)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// RecordTxStream records txs of every balance in the order they are received by a worker owning the balance.
+// Workers have bounded queues, so the server stops reading the stream when they fall behind.
+func (b *Balances) RecordTxStream(
+	ctx context.Context,
+	stream *connect.BidiStream[balancev1.RecordTxRequest, balancev1.RecordTxResult],
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan *balancev1.RecordTxResult, streamWorkers*streamQueueSize)
+
+	var wg sync.WaitGroup
+	queues := make([]chan *balancev1.RecordTxRequest, streamWorkers)
+	for i := range queues {
+		queues[i] = make(chan *balancev1.RecordTxRequest, streamQueueSize)
+
+		wg.Go(func() {
+			for req := range queues[i] {
+				results <- b.recordStreamedTx(ctx, req)
+			}
+		})
+	}
+
+	// Stream sends aren't concurrency safe, so results of all workers are sent from one goroutine.
+	sent := make(chan error, 1)
+	go func() {
+		for r := range results {
+			if err := stream.Send(r); err != nil {
+				cancel()
+				for range results {
+				}
+				sent <- err
+				return
+			}
 		}
-		if errors.Is(err, storage.ErrAlreadyExists) {
-			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("transaction already exists"))
+		sent <- nil
+	}()
+
+	recvErr := receiveTxs(ctx, stream, queues)
+
+	for _, q := range queues {
+		close(q)
+	}
+	wg.Wait()
+	close(results)
+
+	if err := <-sent; err != nil {
+		return err
+	}
+
+	return recvErr
+}
+
+func receiveTxs(
+	ctx context.Context,
+	stream *connect.BidiStream[balancev1.RecordTxRequest, balancev1.RecordTxResult],
+	queues []chan *balancev1.RecordTxRequest,
+) error {
+	for {
+		req, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			return nil
 		}
-		if errors.Is(err, storage.ErrNegativeBalance) {
-			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("negative balance"))
+		if err != nil {
+			return err
+		}
+
+		// Txs of a balance always go to the same worker, so they are recorded in order.
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(req.GetBalanceId()))
+		queue := queues[h.Sum32()%uint32(len(queues))]
+
+		select {
+		case queue <- req:
+		case <-ctx.Done():
+			return ctx.Err()
 		}
-		slog.Error("failed to record transaction", "error", err)
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to record transaction"))
+	}
+}
+
+func (b *Balances) recordStreamedTx(ctx context.Context, req *balancev1.RecordTxRequest) *balancev1.RecordTxResult {
+	// Validation interceptors skip bidi streams, so invalid txs are reported without closing the stream.
+	if err := protovalidate.Validate(req); err != nil {
+		return streamedTxResult(req, invalidMessage(err))
+	}
+
+	tx, err := transform.TxFromProto(req)
+	if err != nil {
+		return streamedTxResult(req, invalidRequest(err))
+	}
+
+	outcome, err := b.s.RecordTx(ctx, tx, domain.WriteOptions{
+		ExpectedVersion: req.ExpectedVersion,
+		DryRun:          req.GetDryRun(),
+	})
+	if err != nil {
+		return streamedTxResult(req, recordTxError(tx, err))
+	}
+
+	resp, err := transform.RecordOutcomeToProto(outcome)
+	if err != nil {
+		return streamedTxResult(req, connect.NewError(connect.CodeInternal, err))
 	}
 
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	return &balancev1.RecordTxResult{
+		TxId:       req.GetTxId(),
+		Version:    resp.GetVersion(),
+		Balance:    resp.GetBalance(),
+		RuleAction: resp.GetRuleAction(),
+		RuleHits:   resp.GetRuleHits(),
+		Tx:         resp.GetTx(),
+	}
+}
+
+func streamedTxResult(req *balancev1.RecordTxRequest, err *connect.Error) *balancev1.RecordTxResult {
+	return &balancev1.RecordTxResult{
+		TxId:    req.GetTxId(),
+		Code:    err.Code().String(),
+		Message: err.Message(),
+		Reason:  apierror.Reason(err),
+	}
+}
+
+func recordTxError(tx domain.Tx, err error) *connect.Error {
+	balanceID := tx.BalanceID.String()
+
+	if errors.Is(err, storage.ErrNotFound) {
+		return balanceNotFound(balanceID)
+	}
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return newError(connect.CodeAlreadyExists, balancev1.ErrorReason_ERROR_REASON_TX_ALREADY_EXISTS, "transaction already exists",
+			map[string]string{"tx_id": tx.TxID.String()})
+	}
+	if errors.Is(err, storage.ErrNegativeBalance) {
+		return insufficientFunds(balanceID, err)
+	}
+	if errors.Is(err, storage.ErrRejected) {
+		return newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_TX_REJECTED, "transaction rejected",
+			map[string]string{"balance_id": balanceID})
+	}
+	if errors.Is(err, storage.ErrFrozen) {
+		return newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_BALANCE_FROZEN, "balance frozen",
+			map[string]string{"balance_id": balanceID})
+	}
+	if errors.Is(err, storage.ErrVersionMismatch) {
+		return versionMismatch(balanceID, err)
+	}
+	if errors.Is(err, storage.ErrParentNotFound) {
+		return newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_PARENT_TX_NOT_FOUND, "parent transaction not found",
+			map[string]string{"balance_id": balanceID, "parent_tx_id": uuidString(tx.ParentTxID)})
+	}
+
+	slog.Error("failed to record transaction", "error", err)
+	return connect.NewError(connect.CodeInternal, errors.New("failed to record transaction"))
 }
 
 func (b *Balances) CancelTxs(
 	ctx context.Context,
 	req *connect.Request[balancev1.CancelTxsRequest],
-) (*connect.Response[emptypb.Empty], error) {
+) (*connect.Response[balancev1.CancelTxsResponse], error) {
 	if len(req.Msg.GetTxIds()) == 0 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no transaction ids provided"))
+		return nil, invalidField("tx_ids", errors.New("no transaction ids provided"))
 	}
 
 	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, invalidField("balance_id", err)
 	}
 
 	txIDs := make([]uuid.UUID, 0, len(req.Msg.GetTxIds()))
 	for _, txID := range req.Msg.GetTxIds() {
 		id, err := uuid.Parse(txID)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			return nil, invalidField("tx_ids", err)
 		}
 
 		txIDs = append(txIDs, id)
 	}
 
-	if err := b.s.CancelTxs(ctx, balanceID, txIDs); err != nil {
+	outcome, err := b.s.CancelTxs(ctx, balanceID, txIDs, domain.WriteOptions{
+		ExpectedVersion: req.Msg.ExpectedVersion,
+		DryRun:          req.Msg.GetDryRun(),
+		Cascade:         req.Msg.GetCascade(),
+		NegativePolicy:  domain.NegativePolicy(req.Msg.GetNegativePolicy()),
+	})
+	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("transactions not found"))
+			return nil, newError(connect.CodeNotFound, balancev1.ErrorReason_ERROR_REASON_TX_NOT_FOUND, "transactions not found",
+				map[string]string{"balance_id": balanceID.String()})
 		}
 		if errors.Is(err, storage.ErrNegativeBalance) {
-			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("negative balance"))
+			return nil, insufficientFunds(balanceID.String(), err)
+		}
+		if errors.Is(err, storage.ErrRejected) {
+			return nil, newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_TX_REJECTED, "transactions rejected",
+				map[string]string{"balance_id": balanceID.String()})
+		}
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			return nil, versionMismatch(balanceID.String(), err)
+		}
+		if errors.Is(err, storage.ErrHasChildren) {
+			return nil, hasChildren(balanceID.String(), err)
 		}
 		slog.Error("failed to cancel transactions", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to cancel transactions"))
 	}
 
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	resp, err := transform.CancelOutcomeToProto(outcome)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+func (b *Balances) GetTx(
+	ctx context.Context,
+	req *connect.Request[balancev1.GetTxRequest],
+) (*connect.Response[balancev1.Tx], error) {
+	if (req.Msg.GetTxId() == "") == (req.Msg.GetExternalRef() == "") {
+		return nil, invalidField("", errors.New("exactly one of tx_id and external_ref must be set"))
+	}
+
+	var tx domain.Tx
+	if req.Msg.GetTxId() != "" {
+		txID, err := uuid.Parse(req.Msg.GetTxId())
+		if err != nil {
+			return nil, invalidField("tx_id", err)
+		}
+
+		tx, err = b.s.Tx(ctx, txID)
+		if err != nil {
+			return nil, txLookupError(err)
+		}
+	} else {
+		var err error
+		tx, err = b.s.TxByExternalRef(ctx, req.Msg.GetExternalRef())
+		if err != nil {
+			return nil, txLookupError(err)
+		}
+	}
+
+	protoTx, err := transform.TxToProto(tx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(protoTx), nil
+}
+
+func (b *Balances) CancelTxsByCriteria(
+	ctx context.Context,
+	req *connect.Request[balancev1.CancelTxsByCriteriaRequest],
+) (*connect.Response[balancev1.CancelTxsByCriteriaResponse], error) {
+	criteria, err := transform.CancellationCriteriaFromProto(req.Msg.GetCriteria())
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	cancellation, err := b.s.StartCancellation(ctx, criteria, domain.NegativePolicy(req.Msg.GetNegativePolicy()))
+	if err != nil {
+		slog.Error("failed to start cancellation", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to start cancellation"))
+	}
+
+	protoCancellation, err := transform.CancellationToProto(cancellation)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&balancev1.CancelTxsByCriteriaResponse{
+		Cancellation: protoCancellation,
+	}), nil
+}
+
+func (b *Balances) GetCancellation(
+	ctx context.Context,
+	req *connect.Request[balancev1.GetCancellationRequest],
+) (*connect.Response[balancev1.GetCancellationResponse], error) {
+	cancellationID, err := uuid.Parse(req.Msg.GetCancellationId())
+	if err != nil {
+		return nil, invalidField("cancellation_id", err)
+	}
+
+	cursorFilter := cancellationCursorFilter{
+		CancellationID: cancellationID,
+	}
+
+	var afterBalanceID *uuid.UUID
+	if req.Msg.GetPageToken() != "" {
+		id, err := cursor.Decode[uuid.UUID](req.Msg.GetPageToken(), cursorFilter)
+		if err != nil {
+			return nil, invalidRequest(err)
+		}
+
+		afterBalanceID = &id
+	}
+
+	cancellation, err := b.s.Cancellation(ctx, cancellationID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, newError(connect.CodeNotFound, balancev1.ErrorReason_ERROR_REASON_CANCELLATION_NOT_FOUND, "cancellation not found",
+				map[string]string{"cancellation_id": cancellationID.String()})
+		}
+		slog.Error("failed to get cancellation", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get cancellation"))
+	}
+
+	balances, err := b.s.CancellationBalances(ctx, cancellationID, afterBalanceID, int(req.Msg.GetPageSize()))
+	if err != nil {
+		slog.Error("failed to get cancellation balances", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get cancellation"))
+	}
+
+	protoCancellation, err := transform.CancellationToProto(cancellation)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	protoBalances := make([]*balancev1.BalanceCancellation, 0, len(balances))
+	for _, c := range balances {
+		pc, err := transform.BalanceCancellationToProto(c)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoBalances = append(protoBalances, pc)
+	}
+
+	var nextPageToken string
+	if len(balances) > 0 {
+		nextPageToken, err = cursor.Encode(balances[len(balances)-1].BalanceID, cursorFilter)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	return connect.NewResponse(&balancev1.GetCancellationResponse{
+		Cancellation:  protoCancellation,
+		Balances:      protoBalances,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+func (b *Balances) ListRelatedTxs(
+	ctx context.Context,
+	req *connect.Request[balancev1.ListRelatedTxsRequest],
+) (*connect.Response[balancev1.ListRelatedTxsResponse], error) {
+	txID, err := uuid.Parse(req.Msg.GetTxId())
+	if err != nil {
+		return nil, invalidField("tx_id", err)
+	}
+
+	txs, err := b.s.RelatedTxs(ctx, txID)
+	if err != nil {
+		return nil, txLookupError(err)
+	}
+
+	protoTxs := make([]*balancev1.Tx, 0, len(txs))
+	for _, tx := range txs {
+		protoTx, err := transform.TxToProto(tx)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoTxs = append(protoTxs, protoTx)
+	}
+
+	return connect.NewResponse(&balancev1.ListRelatedTxsResponse{
+		Txs: protoTxs,
+	}), nil
+}
+
+func txLookupError(err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return newError(connect.CodeNotFound, balancev1.ErrorReason_ERROR_REASON_TX_NOT_FOUND, "transaction not found", nil)
+	}
+
+	slog.Error("failed to get transaction", "error", err)
+	return connect.NewError(connect.CodeInternal, errors.New("failed to get transaction"))
 }
 
 func (b *Balances) OpenBalance(
 	ctx context.Context,
 	req *connect.Request[balancev1.OpenBalanceRequest],
-) (*connect.Response[emptypb.Empty], error) {
-	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+) (*connect.Response[balancev1.BalanceResponse], error) {
+	balance, err := transform.OpenBalanceFromProto(req.Msg)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, invalidRequest(err)
 	}
 
-	if err := b.s.OpenBalance(ctx, balanceID); err != nil {
+	opened, err := b.s.OpenBalance(ctx, balance)
+	if err != nil {
 		if errors.Is(err, storage.ErrAlreadyExists) {
-			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("balance already open"))
+			return nil, newError(connect.CodeAlreadyExists, balancev1.ErrorReason_ERROR_REASON_BALANCE_ALREADY_EXISTS, "balance already open",
+				map[string]string{"balance_id": balance.BalanceID.String()})
 		}
 		slog.Error("failed to open balance", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to open balance"))
 	}
 
-	return connect.NewResponse(&emptypb.Empty{}), nil
+	protoBalance, err := transform.BalanceToProto(opened)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(protoBalance), nil
 }
 
 func (b *Balances) Balance(
@@ -174,13 +606,13 @@ func (b *Balances) Balance(
 ) (*connect.Response[balancev1.BalanceResponse], error) {
 	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, invalidField("balance_id", err)
 	}
 
 	balance, err := b.s.Balance(ctx, balanceID)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("balance not found"))
+			return nil, balanceNotFound(balanceID.String())
 		}
 		slog.Error("failed to get balance", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get balance"))
@@ -193,3 +625,429 @@ func (b *Balances) Balance(
 
 	return connect.NewResponse(protoBalance), nil
 }
+
+func (b *Balances) ListBalances(
+	ctx context.Context,
+	req *connect.Request[balancev1.ListBalancesRequest],
+) (*connect.Response[balancev1.ListBalancesResponse], error) {
+	filter, err := transform.BalanceFilterFromProto(req.Msg)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	sort, err := transform.BalanceSortFromProto(req.Msg)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	cursorFilter := balancesCursorFilter{
+		Filter: filter,
+		Sort:   sort,
+	}
+
+	var after *domain.BalancePosition
+	if req.Msg.GetPageToken() != "" {
+		position, err := cursor.Decode[domain.BalancePosition](req.Msg.GetPageToken(), cursorFilter)
+		if err != nil {
+			return nil, invalidRequest(err)
+		}
+
+		after = &position
+	}
+
+	balances, last, err := b.s.ListBalances(ctx, filter, sort, after, int(req.Msg.GetPageSize()))
+	if err != nil {
+		slog.Error("failed to list balances", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get balances"))
+	}
+
+	if len(balances) == 0 || last == nil {
+		return connect.NewResponse(&balancev1.ListBalancesResponse{
+			Balances:      nil,
+			NextPageToken: "",
+		}), nil
+	}
+
+	protoBalances := make([]*balancev1.BalanceResponse, 0, len(balances))
+	for _, balance := range balances {
+		pb, err := transform.BalanceToProto(balance)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoBalances = append(protoBalances, pb)
+	}
+
+	nextPageToken, err := cursor.Encode(*last, cursorFilter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&balancev1.ListBalancesResponse{
+		Balances:      protoBalances,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+// WatchBalance streams changes of the balance. It starts with a snapshot of the balance,
+// or resumes after the event with after_seq, so reconnecting watchers don't miss changes.
+func (b *Balances) WatchBalance(
+	ctx context.Context,
+	req *connect.Request[balancev1.WatchBalanceRequest],
+	stream *connect.ServerStream[balancev1.BalanceEvent],
+) error {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return invalidField("balance_id", err)
+	}
+
+	if req.Msg.GetAfterSeq() < 0 {
+		return invalidField("after_seq", errors.New("after_seq must not be negative"))
+	}
+
+	// Subscribing before reading the balance guarantees that changes made in between wake up the watcher.
+	changed, cancel, err := b.s.Subscribe(ctx, balanceID)
+	if err != nil {
+		slog.Error("failed to subscribe to balance", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to watch balance"))
+	}
+	defer cancel()
+
+	balance, err := b.s.Balance(ctx, balanceID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return balanceNotFound(balanceID.String())
+		}
+		slog.Error("failed to get balance", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to watch balance"))
+	}
+
+	afterSeq := req.Msg.GetAfterSeq()
+	if afterSeq == 0 {
+		snapshot, err := transform.BalanceEventToProto(domain.BalanceEvent{
+			CreatedAt: time.Now(),
+			BalanceID: balance.BalanceID,
+			Seq:       balance.EventSeq,
+			Kind:      domain.BalanceEventKindSnapshot,
+			Amount:    balance.Amount,
+			Status:    balance.Status,
+		})
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := stream.Send(snapshot); err != nil {
+			return err
+		}
+
+		afterSeq = balance.EventSeq
+	}
+
+	for {
+		events, err := b.s.BalanceEvents(ctx, balanceID, afterSeq, watchBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			slog.Error("failed to get balance events", "error", err)
+			return connect.NewError(connect.CodeInternal, errors.New("failed to watch balance"))
+		}
+
+		for _, e := range events {
+			pe, err := transform.BalanceEventToProto(e)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+
+			if err := stream.Send(pe); err != nil {
+				return err
+			}
+
+			afterSeq = e.Seq
+		}
+
+		if len(events) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-changed:
+			if !ok {
+				return connect.NewError(connect.CodeUnavailable, errors.New("server is stopping"))
+			}
+		}
+	}
+}
+
+func (b *Balances) ExportStatement(
+	ctx context.Context,
+	req *connect.Request[balancev1.ExportStatementRequest],
+	stream *connect.ServerStream[balancev1.StatementChunk],
+) error {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return invalidField("balance_id", err)
+	}
+
+	format, err := transform.StatementFormatFromProto(req.Msg.GetFormat())
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	var from, to *time.Time
+	if req.Msg.GetFrom() != nil {
+		t := req.Msg.GetFrom().AsTime()
+		from = &t
+	}
+	if req.Msg.GetTo() != nil {
+		t := req.Msg.GetTo().AsTime()
+		to = &t
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return invalidField("from", errors.New("from must be before to"))
+	}
+
+	chunks := bufio.NewWriterSize(chunkWriter{stream: stream}, statementChunkSize)
+	w, err := statement.NewWriter(chunks, format)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := b.s.ExportStatement(ctx, balanceID, from, to, w.Write); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return balanceNotFound(balanceID.String())
+		}
+		slog.Error("failed to export statement", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to export statement"))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return chunks.Flush()
+}
+
+// chunkWriter sends every write as a statement chunk.
+type chunkWriter struct {
+	stream *connect.ServerStream[balancev1.StatementChunk]
+}
+
+func (c chunkWriter) Write(p []byte) (int, error) {
+	if err := c.stream.Send(&balancev1.StatementChunk{Data: p}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (b *Balances) Aggregate(
+	ctx context.Context,
+	req *connect.Request[balancev1.AggregateRequest],
+) (*connect.Response[balancev1.AggregateResponse], error) {
+	query, err := transform.AggregateQueryFromProto(req.Msg)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	rows, err := b.s.Aggregate(ctx, query)
+	if err != nil {
+		slog.Error("failed to aggregate transactions", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to aggregate transactions"))
+	}
+
+	protoRows := make([]*balancev1.AggregateRow, 0, len(rows))
+	for _, r := range rows {
+		pr, err := transform.AggregateRowToProto(r)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoRows = append(protoRows, pr)
+	}
+
+	revenues := finance.Revenues(rows)
+	protoRevenues := make([]*balancev1.Revenue, 0, len(revenues))
+	for _, r := range revenues {
+		pr, err := transform.RevenueToProto(r)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoRevenues = append(protoRevenues, pr)
+	}
+
+	return connect.NewResponse(&balancev1.AggregateResponse{
+		Rows:     protoRows,
+		Revenues: protoRevenues,
+	}), nil
+}
+
+func (b *Balances) ListFlaggedEvents(
+	ctx context.Context,
+	req *connect.Request[balancev1.ListFlaggedEventsRequest],
+) (*connect.Response[balancev1.ListFlaggedEventsResponse], error) {
+	var balanceID *uuid.UUID
+	if req.Msg.GetBalanceId() != "" {
+		id, err := uuid.Parse(req.Msg.GetBalanceId())
+		if err != nil {
+			return nil, invalidField("balance_id", err)
+		}
+
+		balanceID = &id
+	}
+
+	cursorFilter := flaggedEventsCursorFilter{
+		BalanceID: balanceID,
+	}
+
+	var events []domain.FlaggedEvent
+	if req.Msg.GetPageToken() == "" {
+		var err error
+		events, err = b.s.RecentFlaggedEvents(ctx, balanceID, int(req.Msg.GetPageSize()))
+		if err != nil {
+			slog.Error("failed to get recent flagged events", "error", err)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get flagged events"))
+		}
+	} else {
+		beforeUUID, err := cursor.Decode[uuid.UUID](req.Msg.GetPageToken(), cursorFilter)
+		if err != nil {
+			return nil, invalidRequest(err)
+		}
+
+		events, err = b.s.PreviousFlaggedEvents(ctx, balanceID, beforeUUID, int(req.Msg.GetPageSize()))
+		if err != nil {
+			slog.Error("failed to get previous flagged events", "error", err)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get flagged events"))
+		}
+	}
+
+	if len(events) == 0 {
+		return connect.NewResponse(&balancev1.ListFlaggedEventsResponse{
+			Events:        nil,
+			NextPageToken: "",
+		}), nil
+	}
+
+	protoEvents := make([]*balancev1.FlaggedEvent, 0, len(events))
+	for _, e := range events {
+		pe, err := transform.FlaggedEventToProto(e)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoEvents = append(protoEvents, pe)
+	}
+
+	nextPageToken, err := cursor.Encode(events[len(events)-1].EventID, cursorFilter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&balancev1.ListFlaggedEventsResponse{
+		Events:        protoEvents,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+func (b *Balances) ListDebtEntries(
+	ctx context.Context,
+	req *connect.Request[balancev1.ListDebtEntriesRequest],
+) (*connect.Response[balancev1.ListDebtEntriesResponse], error) {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return nil, invalidField("balance_id", err)
+	}
+
+	cursorFilter := debtEntriesCursorFilter{
+		BalanceID: balanceID,
+	}
+
+	var afterSeq int64
+	if req.Msg.GetPageToken() != "" {
+		afterSeq, err = cursor.Decode[int64](req.Msg.GetPageToken(), cursorFilter)
+		if err != nil {
+			return nil, invalidRequest(err)
+		}
+	}
+
+	entries, err := b.s.DebtEntries(ctx, balanceID, afterSeq, int(req.Msg.GetPageSize()))
+	if err != nil {
+		slog.Error("failed to get debt entries", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get debt entries"))
+	}
+
+	if len(entries) == 0 {
+		return connect.NewResponse(&balancev1.ListDebtEntriesResponse{
+			Entries:       nil,
+			NextPageToken: "",
+		}), nil
+	}
+
+	protoEntries := make([]*balancev1.DebtEntry, 0, len(entries))
+	for _, e := range entries {
+		pe, err := transform.DebtEntryToProto(e)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		protoEntries = append(protoEntries, pe)
+	}
+
+	nextPageToken, err := cursor.Encode(entries[len(entries)-1].Seq, cursorFilter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&balancev1.ListDebtEntriesResponse{
+		Entries:       protoEntries,
+		NextPageToken: nextPageToken,
+	}), nil
+}
+
+func (b *Balances) Chargeback(
+	ctx context.Context,
+	req *connect.Request[balancev1.ChargebackRequest],
+) (*connect.Response[balancev1.ChargebackResponse], error) {
+	balanceID, err := uuid.Parse(req.Msg.GetBalanceId())
+	if err != nil {
+		return nil, invalidField("balance_id", err)
+	}
+
+	txID, err := uuid.Parse(req.Msg.GetTxId())
+	if err != nil {
+		return nil, invalidField("tx_id", err)
+	}
+
+	var reason *string
+	if req.Msg.GetReason() != "" {
+		r := req.Msg.GetReason()
+		reason = &r
+	}
+
+	outcome, err := b.s.Chargeback(ctx, balanceID, txID, reason)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, newError(connect.CodeNotFound, balancev1.ErrorReason_ERROR_REASON_TX_NOT_FOUND, "transaction not found",
+				map[string]string{"balance_id": balanceID.String(), "tx_id": txID.String()})
+		}
+		if errors.Is(err, storage.ErrNotChargeable) {
+			return nil, newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_TX_NOT_CHARGEABLE, "transaction can't be charged back",
+				map[string]string{"balance_id": balanceID.String(), "tx_id": txID.String()})
+		}
+		if errors.Is(err, storage.ErrHasChildren) {
+			return nil, hasChildren(balanceID.String(), err)
+		}
+		slog.Error("failed to charge back transaction", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to charge back transaction"))
+	}
+
+	resp, err := transform.ChargebackOutcomeToProto(outcome)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(resp), nil
+}
@@ -3,6 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
 	"testing"
 
 	"time"
@@ -10,12 +15,18 @@ import (
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
 	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/gen/balance/v1/balancev1connect"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/cursor"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
 	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestBalances_ListTx(t *testing.T) {
@@ -23,6 +34,10 @@ func TestBalances_ListTx(t *testing.T) {
 	txID := uuid.New()
 	amount := decimal.NewFromInt(100)
 	createdAt := time.Now().UTC().Truncate(time.Second)
+	seq := int64(42)
+
+	pageToken, err := cursor.Encode(seq, txsCursorFilter{BalanceID: balanceID, Order: domain.TxOrderNewestFirst})
+	require.NoError(t, err)
 
 	tests := []struct {
 		name           string
@@ -46,15 +61,1615 @@ func TestBalances_ListTx(t *testing.T) {
 						Source:    domain.SourcePayment,
 						State:     domain.StateDeposit,
 						CreatedAt: createdAt,
+						Seq:       seq,
 					},
 				}
-				m.EXPECT().RecentTxs(context.Background(), balanceID, false, 10).Return(txs, nil)
+				m.EXPECT().ListTxs(context.Background(), balanceID, domain.TxFilter{}, domain.TxOrderNewestFirst, (*int64)(nil), 10).Return(txs, nil)
+			},
+			expectedTxs: 1,
+		},
+		{
+			name: "list filtered transactions success",
+			request: &balancev1.ListTxRequest{
+				BalanceId:     balanceID.String(),
+				PageSize:      10,
+				Source:        balancev1.Source_SOURCE_PAYMENT,
+				MinAmount:     &balancev1.Decimal{Value: "50"},
+				CancelledOnly: true,
+			},
+			setupMock: func(m *MockStorage) {
+				minAmount := decimal.NewFromInt(50)
+				filter := domain.TxFilter{
+					Source:         domain.SourcePayment,
+					MinAmount:      &minAmount,
+					IncludeDeleted: true,
+					CancelledOnly:  true,
+				}
+				m.EXPECT().ListTxs(context.Background(), balanceID, filter, domain.TxOrderNewestFirst, (*int64)(nil), 10).Return(nil, nil)
+			},
+		},
+		{
+			name: "list previous transactions success",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+				PageToken: pageToken,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ListTxs(context.Background(), balanceID, domain.TxFilter{}, domain.TxOrderNewestFirst, &seq, 10).Return(nil, nil)
+			},
+		},
+		{
+			name: "list oldest transactions first",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+				Order:     balancev1.TxOrder_TX_ORDER_OLDEST_FIRST,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ListTxs(context.Background(), balanceID, domain.TxFilter{}, domain.TxOrderOldestFirst, (*int64)(nil), 10).Return(nil, nil)
+			},
+		},
+		{
+			name: "page token with another order",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+				PageToken: pageToken,
+				Order:     balancev1.TxOrder_TX_ORDER_OLDEST_FIRST,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "page token with another filter",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+				PageToken: pageToken,
+				State:     balancev1.State_STATE_WITHDRAW,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "raw tx ID as page token",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+				PageToken: txID.String(),
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid amount range",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+				MinAmount: &balancev1.Decimal{Value: "100"},
+				MaxAmount: &balancev1.Decimal{Value: "10"},
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid balance ID",
+			request: &balancev1.ListTxRequest{
+				BalanceId: "invalid-uuid",
+				PageSize:  10,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.ListTxRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ListTxs(context.Background(), balanceID, domain.TxFilter{}, domain.TxOrderNewestFirst, (*int64)(nil), 10).Return([]domain.Tx{}, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.ListTx(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, resp.Msg.Txs, tt.expectedTxs)
+			if tt.expectedTxs > 0 {
+				assert.Equal(t, pageToken, resp.Msg.NextPageToken)
+			}
+		})
+	}
+}
+
+func TestBalances_GetTx(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	externalRef := "operator-tx-1"
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+
+	tx := domain.Tx{
+		CreatedAt:   deletedAt.Add(-time.Hour),
+		DeletedAt:   &deletedAt,
+		TxID:        txID,
+		BalanceID:   balanceID,
+		Seq:         7,
+		ExternalRef: &externalRef,
+		Source:      domain.SourceGame,
+		State:       domain.StateWithdraw,
+		Amount:      decimal.NewFromInt(100),
+	}
+
+	tests := []struct {
+		name           string
+		request        *balancev1.GetTxRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+	}{
+		{
+			name: "get tx by ID success",
+			request: &balancev1.GetTxRequest{
+				TxId: txID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Tx(context.Background(), txID).Return(tx, nil)
+			},
+		},
+		{
+			name: "get tx by external reference success",
+			request: &balancev1.GetTxRequest{
+				ExternalRef: externalRef,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().TxByExternalRef(context.Background(), externalRef).Return(tx, nil)
+			},
+		},
+		{
+			name: "tx not found",
+			request: &balancev1.GetTxRequest{
+				TxId: txID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Tx(context.Background(), txID).Return(domain.Tx{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+		},
+		{
+			name:           "no lookup key",
+			request:        &balancev1.GetTxRequest{},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "both lookup keys",
+			request: &balancev1.GetTxRequest{
+				TxId:        txID.String(),
+				ExternalRef: externalRef,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid tx ID",
+			request: &balancev1.GetTxRequest{
+				TxId: "invalid-uuid",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.GetTxRequest{
+				ExternalRef: externalRef,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().TxByExternalRef(context.Background(), externalRef).Return(domain.Tx{}, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.GetTx(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, txID.String(), resp.Msg.TxId)
+			assert.Equal(t, balanceID.String(), resp.Msg.BalanceId)
+			assert.Equal(t, externalRef, resp.Msg.ExternalRef)
+			assert.Equal(t, deletedAt, resp.Msg.DeletedAt.AsTime())
+		})
+	}
+}
+
+func TestBalances_ListRelatedTxs(t *testing.T) {
+	balanceID := uuid.New()
+	rootID := uuid.New()
+	childID := uuid.New()
+
+	txs := []domain.Tx{
+		{
+			TxID:      rootID,
+			BalanceID: balanceID,
+			Seq:       1,
+			Source:    domain.SourceGame,
+			State:     domain.StateWithdraw,
+			Amount:    decimal.NewFromInt(100),
+		},
+		{
+			TxID:       childID,
+			BalanceID:  balanceID,
+			ParentTxID: &rootID,
+			Seq:        2,
+			Source:     domain.SourceGame,
+			State:      domain.StateDeposit,
+			Amount:     decimal.NewFromInt(250),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		request        *balancev1.ListRelatedTxsRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+	}{
+		{
+			name: "list related txs success",
+			request: &balancev1.ListRelatedTxsRequest{
+				TxId: childID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().RelatedTxs(context.Background(), childID).Return(txs, nil)
+			},
+		},
+		{
+			name: "tx not found",
+			request: &balancev1.ListRelatedTxsRequest{
+				TxId: childID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().RelatedTxs(context.Background(), childID).Return(nil, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+		},
+		{
+			name: "invalid tx ID",
+			request: &balancev1.ListRelatedTxsRequest{
+				TxId: "invalid-uuid",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.ListRelatedTxsRequest{
+				TxId: childID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().RelatedTxs(context.Background(), childID).Return(nil, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.ListRelatedTxs(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.Msg.GetTxs(), 2)
+			assert.Equal(t, rootID.String(), resp.Msg.GetTxs()[0].GetTxId())
+			assert.Empty(t, resp.Msg.GetTxs()[0].GetParentTxId())
+			assert.Equal(t, rootID.String(), resp.Msg.GetTxs()[1].GetParentTxId())
+		})
+	}
+}
+
+func TestBalances_RecordTx(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	amount := decimal.NewFromInt(100)
+	expectedVersion := int64(3)
+
+	tests := []struct {
+		name           string
+		request        *balancev1.RecordTxRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+		expectedReason string
+	}{
+		{
+			name: "record transaction success",
+			request: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_PAYMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			setupMock: func(m *MockStorage) {
+				tx := domain.Tx{
+					BalanceID: balanceID,
+					TxID:      txID,
+					Amount:    amount,
+					Source:    domain.SourcePayment,
+					State:     domain.StateDeposit,
+				}
+				m.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{}).Return(domain.RecordOutcome{Balance: domain.Balance{Version: 1}}, nil)
+			},
+		},
+		{
+			name: "version mismatch",
+			request: &balancev1.RecordTxRequest{
+				BalanceId:       balanceID.String(),
+				TxId:            txID.String(),
+				Amount:          &balancev1.Decimal{Value: amount.String()},
+				Source:          balancev1.Source_SOURCE_PAYMENT,
+				State:           balancev1.State_STATE_DEPOSIT,
+				ExpectedVersion: &expectedVersion,
+			},
+			setupMock: func(m *MockStorage) {
+				tx := domain.Tx{
+					BalanceID: balanceID,
+					TxID:      txID,
+					Amount:    amount,
+					Source:    domain.SourcePayment,
+					State:     domain.StateDeposit,
+				}
+				m.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{ExpectedVersion: &expectedVersion}).Return(domain.RecordOutcome{}, &storage.VersionMismatchError{
+					Expected: expectedVersion,
+					Current:  expectedVersion + 1,
+				})
+			},
+			expectedStatus: connect.CodeAborted,
+			expectedReason: "VERSION_MISMATCH",
+		},
+		{
+			name: "balance not found",
+			request: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_PAYMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			setupMock: func(m *MockStorage) {
+				tx := domain.Tx{
+					BalanceID: balanceID,
+					TxID:      txID,
+					Amount:    amount,
+					Source:    domain.SourcePayment,
+					State:     domain.StateDeposit,
+				}
+				m.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{}).Return(domain.RecordOutcome{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+			expectedReason: "BALANCE_NOT_FOUND",
+		},
+		{
+			name: "rejected by rules",
+			request: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_PAYMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			setupMock: func(m *MockStorage) {
+				tx := domain.Tx{
+					BalanceID: balanceID,
+					TxID:      txID,
+					Amount:    amount,
+					Source:    domain.SourcePayment,
+					State:     domain.StateDeposit,
+				}
+				m.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{}).Return(domain.RecordOutcome{}, storage.ErrRejected)
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+			expectedReason: "TX_REJECTED",
+		},
+		{
+			name: "balance frozen",
+			request: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_PAYMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			setupMock: func(m *MockStorage) {
+				tx := domain.Tx{
+					BalanceID: balanceID,
+					TxID:      txID,
+					Amount:    amount,
+					Source:    domain.SourcePayment,
+					State:     domain.StateDeposit,
+				}
+				m.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{}).Return(domain.RecordOutcome{}, storage.ErrFrozen)
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+			expectedReason: "BALANCE_FROZEN",
+		},
+		{
+			name: "transaction already exists",
+			request: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_PAYMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			setupMock: func(m *MockStorage) {
+				tx := domain.Tx{
+					BalanceID: balanceID,
+					TxID:      txID,
+					Amount:    amount,
+					Source:    domain.SourcePayment,
+					State:     domain.StateDeposit,
+				}
+				m.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{}).Return(domain.RecordOutcome{}, storage.ErrAlreadyExists)
+			},
+			expectedStatus: connect.CodeAlreadyExists,
+			expectedReason: "TX_ALREADY_EXISTS",
+		},
+		{
+			name: "invalid amount",
+			request: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: "abc"},
+				Source:    balancev1.Source_SOURCE_PAYMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+			expectedReason: "INVALID_ARGUMENT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.RecordTx(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				assert.Equal(t, tt.expectedReason, apierror.Reason(connectErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), resp.Msg.GetVersion())
+		})
+	}
+}
+
+func TestBalances_RecordTx_DryRun(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	amount := decimal.NewFromInt(500)
+
+	tx := domain.Tx{
+		BalanceID: balanceID,
+		TxID:      txID,
+		Amount:    amount,
+		Source:    domain.SourcePayment,
+		State:     domain.StateWithdraw,
+	}
+
+	mockStorage := NewMockStorage(t)
+	mockStorage.EXPECT().RecordTx(context.Background(), tx, domain.WriteOptions{DryRun: true}).Return(domain.RecordOutcome{
+		Balance: domain.Balance{
+			BalanceID: balanceID,
+			Amount:    decimal.NewFromInt(700),
+			Version:   4,
+		},
+		Action: domain.RuleActionReject,
+		Hits: []domain.RuleHit{
+			{Rule: "large-withdrawal", Action: domain.RuleActionReject},
+		},
+	}, nil)
+
+	resp, err := NewBalances(mockStorage).RecordTx(context.Background(), connect.NewRequest(&balancev1.RecordTxRequest{
+		BalanceId: balanceID.String(),
+		TxId:      txID.String(),
+		Amount:    &balancev1.Decimal{Value: amount.String()},
+		Source:    balancev1.Source_SOURCE_PAYMENT,
+		State:     balancev1.State_STATE_WITHDRAW,
+		DryRun:    true,
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "700", resp.Msg.GetBalance().GetAmount().GetValue())
+	assert.Equal(t, int64(4), resp.Msg.GetVersion())
+	assert.Equal(t, balancev1.RuleAction_RULE_ACTION_REJECT, resp.Msg.GetRuleAction())
+	require.Len(t, resp.Msg.GetRuleHits(), 1)
+	assert.Equal(t, "large-withdrawal", resp.Msg.GetRuleHits()[0].GetRule())
+}
+
+func TestBalances_RecordTx_ErrorDetails(t *testing.T) {
+	balanceID := uuid.New()
+	amount := decimal.NewFromInt(100)
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		mockStorage := NewMockStorage(t)
+		mockStorage.EXPECT().RecordTx(context.Background(), mock.Anything, domain.WriteOptions{}).Return(domain.RecordOutcome{}, &storage.InsufficientFundsError{
+			Current:  decimal.NewFromInt(30),
+			Required: amount,
+		})
+
+		_, err := NewBalances(mockStorage).RecordTx(context.Background(), connect.NewRequest(&balancev1.RecordTxRequest{
+			BalanceId: balanceID.String(),
+			TxId:      uuid.NewString(),
+			Amount:    &balancev1.Decimal{Value: amount.String()},
+			Source:    balancev1.Source_SOURCE_GAME,
+			State:     balancev1.State_STATE_WITHDRAW,
+		}))
+
+		var connectErr *connect.Error
+		require.ErrorAs(t, err, &connectErr)
+		assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+		info := errorDetail[*errdetails.ErrorInfo](t, connectErr)
+		assert.Equal(t, "INSUFFICIENT_FUNDS", info.GetReason())
+		assert.Equal(t, apierror.Domain, info.GetDomain())
+		assert.Equal(t, map[string]string{
+			"balance_id": balanceID.String(),
+			"current":    "30",
+			"required":   "100",
+		}, info.GetMetadata())
+
+		funds := errorDetail[*balancev1.InsufficientFunds](t, connectErr)
+		assert.Equal(t, "30", funds.GetCurrent().GetValue())
+		assert.Equal(t, "100", funds.GetRequired().GetValue())
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		expectedVersion := int64(7)
+
+		mockStorage := NewMockStorage(t)
+		mockStorage.EXPECT().RecordTx(context.Background(), mock.Anything, domain.WriteOptions{ExpectedVersion: &expectedVersion}).Return(domain.RecordOutcome{}, &storage.VersionMismatchError{
+			Expected: expectedVersion,
+			Current:  9,
+		})
+
+		_, err := NewBalances(mockStorage).RecordTx(context.Background(), connect.NewRequest(&balancev1.RecordTxRequest{
+			BalanceId:       balanceID.String(),
+			TxId:            uuid.NewString(),
+			Amount:          &balancev1.Decimal{Value: amount.String()},
+			Source:          balancev1.Source_SOURCE_GAME,
+			State:           balancev1.State_STATE_WITHDRAW,
+			ExpectedVersion: &expectedVersion,
+		}))
+
+		var connectErr *connect.Error
+		require.ErrorAs(t, err, &connectErr)
+		assert.Equal(t, connect.CodeAborted, connectErr.Code())
+
+		info := errorDetail[*errdetails.ErrorInfo](t, connectErr)
+		assert.Equal(t, "VERSION_MISMATCH", info.GetReason())
+		assert.Equal(t, map[string]string{
+			"balance_id":       balanceID.String(),
+			"expected_version": "7",
+			"current_version":  "9",
+		}, info.GetMetadata())
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		parentTxID := uuid.New()
+
+		mockStorage := NewMockStorage(t)
+		mockStorage.EXPECT().RecordTx(context.Background(), mock.Anything, domain.WriteOptions{}).Return(domain.RecordOutcome{}, storage.ErrParentNotFound)
+
+		_, err := NewBalances(mockStorage).RecordTx(context.Background(), connect.NewRequest(&balancev1.RecordTxRequest{
+			BalanceId:  balanceID.String(),
+			TxId:       uuid.NewString(),
+			Amount:     &balancev1.Decimal{Value: amount.String()},
+			Source:     balancev1.Source_SOURCE_GAME,
+			State:      balancev1.State_STATE_DEPOSIT,
+			ParentTxId: parentTxID.String(),
+		}))
+
+		var connectErr *connect.Error
+		require.ErrorAs(t, err, &connectErr)
+		assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+		info := errorDetail[*errdetails.ErrorInfo](t, connectErr)
+		assert.Equal(t, "PARENT_TX_NOT_FOUND", info.GetReason())
+		assert.Equal(t, map[string]string{
+			"balance_id":   balanceID.String(),
+			"parent_tx_id": parentTxID.String(),
+		}, info.GetMetadata())
+	})
+
+	t.Run("invalid balance ID", func(t *testing.T) {
+		_, err := NewBalances(NewMockStorage(t)).RecordTx(context.Background(), connect.NewRequest(&balancev1.RecordTxRequest{
+			BalanceId: "invalid-uuid",
+			TxId:      uuid.NewString(),
+			Amount:    &balancev1.Decimal{Value: amount.String()},
+			Source:    balancev1.Source_SOURCE_GAME,
+			State:     balancev1.State_STATE_WITHDRAW,
+		}))
+
+		var connectErr *connect.Error
+		require.ErrorAs(t, err, &connectErr)
+		assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+		assert.Equal(t, "INVALID_ARGUMENT", apierror.Reason(connectErr))
+
+		badRequest := errorDetail[*errdetails.BadRequest](t, connectErr)
+		require.Len(t, badRequest.GetFieldViolations(), 1)
+		assert.Equal(t, "balance_id", badRequest.GetFieldViolations()[0].GetField())
+	})
+}
+
+func errorDetail[T proto.Message](t *testing.T, err *connect.Error) T {
+	t.Helper()
+
+	for _, d := range err.Details() {
+		msg, detailErr := d.Value()
+		require.NoError(t, detailErr)
+
+		if v, ok := msg.(T); ok {
+			return v
+		}
+	}
+
+	var zero T
+	t.Fatalf("error has no %T details", zero)
+	return zero
+}
+
+func TestBalances_RecordTxStream(t *testing.T) {
+	balanceID := uuid.New()
+	missingBalanceID := uuid.New()
+	amount := decimal.NewFromInt(100)
+
+	requests := []*balancev1.RecordTxRequest{
+		{BalanceId: balanceID.String(), TxId: uuid.NewString()},
+		{BalanceId: missingBalanceID.String(), TxId: uuid.NewString()},
+		{BalanceId: balanceID.String(), TxId: uuid.NewString()},
+		{BalanceId: "invalid-uuid", TxId: uuid.NewString()},
+	}
+	for _, r := range requests {
+		r.Amount = &balancev1.Decimal{Value: amount.String()}
+		r.Source = balancev1.Source_SOURCE_GAME
+		r.State = balancev1.State_STATE_WITHDRAW
+	}
+
+	var mu sync.Mutex
+	var recorded []string
+	mockStorage := NewMockStorage(t)
+	mockStorage.EXPECT().RecordTx(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, tx domain.Tx, opts domain.WriteOptions) {
+			mu.Lock()
+			defer mu.Unlock()
+			recorded = append(recorded, tx.TxID.String())
+		}).
+		RunAndReturn(func(ctx context.Context, tx domain.Tx, opts domain.WriteOptions) (domain.RecordOutcome, error) {
+			switch tx.TxID.String() {
+			case requests[1].TxId:
+				return domain.RecordOutcome{}, storage.ErrNotFound
+			case requests[2].TxId:
+				return domain.RecordOutcome{}, storage.ErrNegativeBalance
+			}
+			return domain.RecordOutcome{Balance: domain.Balance{Version: 1}}, nil
+		})
+
+	// Bidi streams require HTTP/2.
+	mux := http.NewServeMux()
+	mux.Handle(balancev1connect.NewBalanceServiceHandler(NewBalances(mockStorage)))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client := balancev1connect.NewBalanceServiceClient(server.Client(), server.URL)
+	stream := client.RecordTxStream(context.Background())
+
+	for _, r := range requests {
+		require.NoError(t, stream.Send(r))
+	}
+	require.NoError(t, stream.CloseRequest())
+
+	codes := make(map[string]string)
+	reasons := make(map[string]string)
+	for {
+		result, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		codes[result.GetTxId()] = result.GetCode()
+		reasons[result.GetTxId()] = result.GetReason()
+	}
+	require.NoError(t, stream.CloseResponse())
+
+	assert.Equal(t, map[string]string{
+		requests[0].TxId: "",
+		requests[1].TxId: connect.CodeNotFound.String(),
+		requests[2].TxId: connect.CodeFailedPrecondition.String(),
+		requests[3].TxId: connect.CodeInvalidArgument.String(),
+	}, codes)
+	assert.Equal(t, map[string]string{
+		requests[0].TxId: "",
+		requests[1].TxId: "BALANCE_NOT_FOUND",
+		requests[2].TxId: "INSUFFICIENT_FUNDS",
+		requests[3].TxId: "INVALID_ARGUMENT",
+	}, reasons)
+
+	// Txs of the same balance are recorded in the order they were sent.
+	assert.Less(t, slices.Index(recorded, requests[0].TxId), slices.Index(recorded, requests[2].TxId))
+}
+
+func TestBalances_OpenBalance(t *testing.T) {
+	balanceID := uuid.New()
+	ownerID := uuid.New()
+	currency := "EUR"
+
+	tests := []struct {
+		name           string
+		request        *balancev1.OpenBalanceRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+	}{
+		{
+			name: "open balance success",
+			request: &balancev1.OpenBalanceRequest{
+				BalanceId: balanceID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().OpenBalance(context.Background(), domain.Balance{
+					BalanceID:  balanceID,
+					Status:     domain.BalanceStatusActive,
+					WalletType: domain.WalletTypeMain,
+				}).Return(domain.Balance{BalanceID: balanceID, Status: domain.BalanceStatusActive}, nil)
+			},
+		},
+		{
+			name: "open owned balance success",
+			request: &balancev1.OpenBalanceRequest{
+				BalanceId:  balanceID.String(),
+				OwnerId:    ownerID.String(),
+				Currency:   currency,
+				WalletType: balancev1.WalletType_WALLET_TYPE_BONUS,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().OpenBalance(context.Background(), domain.Balance{
+					BalanceID:  balanceID,
+					Status:     domain.BalanceStatusActive,
+					OwnerID:    &ownerID,
+					Currency:   &currency,
+					WalletType: domain.WalletTypeBonus,
+				}).Return(domain.Balance{BalanceID: balanceID, Status: domain.BalanceStatusActive}, nil)
+			},
+		},
+		{
+			name: "wallet already exists",
+			request: &balancev1.OpenBalanceRequest{
+				BalanceId: balanceID.String(),
+				OwnerId:   ownerID.String(),
+				Currency:  currency,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().OpenBalance(context.Background(), domain.Balance{
+					BalanceID:  balanceID,
+					Status:     domain.BalanceStatusActive,
+					OwnerID:    &ownerID,
+					Currency:   &currency,
+					WalletType: domain.WalletTypeMain,
+				}).Return(domain.Balance{}, storage.ErrAlreadyExists)
+			},
+			expectedStatus: connect.CodeAlreadyExists,
+		},
+		{
+			name: "invalid currency",
+			request: &balancev1.OpenBalanceRequest{
+				BalanceId: balanceID.String(),
+				Currency:  "euro",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid balance ID",
+			request: &balancev1.OpenBalanceRequest{
+				BalanceId: "invalid-uuid",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.OpenBalance(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, balanceID.String(), resp.Msg.GetBalanceId())
+			assert.Equal(t, balancev1.BalanceStatus_BALANCE_STATUS_ACTIVE, resp.Msg.GetStatus())
+		})
+	}
+}
+
+func TestBalances_ListBalances(t *testing.T) {
+	ownerID := uuid.New()
+	balanceID := uuid.New()
+	currency := "EUR"
+	defaultSort := domain.BalanceSort{Field: domain.BalanceSortFieldCreatedAt}
+	position := domain.BalancePosition{
+		SortKey:   decimal.NewFromInt(1735689600),
+		BalanceID: balanceID,
+	}
+
+	pageToken, err := cursor.Encode(position, balancesCursorFilter{
+		Filter: domain.BalanceFilter{OwnerID: &ownerID},
+		Sort:   defaultSort,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		request        *balancev1.ListBalancesRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+		expectedCount  int
+	}{
+		{
+			name: "list owner balances success",
+			request: &balancev1.ListBalancesRequest{
+				OwnerId:  ownerID.String(),
+				PageSize: 10,
+			},
+			setupMock: func(m *MockStorage) {
+				balances := []domain.Balance{
+					{
+						BalanceID:  balanceID,
+						Amount:     decimal.NewFromInt(100),
+						Status:     domain.BalanceStatusActive,
+						OwnerID:    &ownerID,
+						Currency:   &currency,
+						WalletType: domain.WalletTypeMain,
+					},
+				}
+				filter := domain.BalanceFilter{OwnerID: &ownerID}
+				m.EXPECT().ListBalances(context.Background(), filter, defaultSort, (*domain.BalancePosition)(nil), 10).Return(balances, &position, nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "search balances success",
+			request: &balancev1.ListBalancesRequest{
+				PageSize:   10,
+				Status:     balancev1.BalanceStatus_BALANCE_STATUS_FROZEN,
+				MinAmount:  &balancev1.Decimal{Value: "1000"},
+				SortBy:     balancev1.BalanceSortField_BALANCE_SORT_FIELD_AMOUNT,
+				Descending: true,
+			},
+			setupMock: func(m *MockStorage) {
+				minAmount := decimal.NewFromInt(1000)
+				filter := domain.BalanceFilter{
+					Status:    domain.BalanceStatusFrozen,
+					MinAmount: &minAmount,
+				}
+				sort := domain.BalanceSort{
+					Field:      domain.BalanceSortFieldAmount,
+					Descending: true,
+				}
+				m.EXPECT().ListBalances(context.Background(), filter, sort, (*domain.BalancePosition)(nil), 10).Return(nil, nil, nil)
+			},
+		},
+		{
+			name: "list balances with page token",
+			request: &balancev1.ListBalancesRequest{
+				OwnerId:   ownerID.String(),
+				PageSize:  10,
+				PageToken: pageToken,
+			},
+			setupMock: func(m *MockStorage) {
+				filter := domain.BalanceFilter{OwnerID: &ownerID}
+				m.EXPECT().ListBalances(context.Background(), filter, defaultSort, &position, 10).Return(nil, nil, nil)
+			},
+		},
+		{
+			name: "page token with another sort",
+			request: &balancev1.ListBalancesRequest{
+				OwnerId:   ownerID.String(),
+				PageSize:  10,
+				PageToken: pageToken,
+				SortBy:    balancev1.BalanceSortField_BALANCE_SORT_FIELD_AMOUNT,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid owner ID",
+			request: &balancev1.ListBalancesRequest{
+				OwnerId: "invalid-uuid",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.ListBalancesRequest{
+				OwnerId:  ownerID.String(),
+				PageSize: 10,
+			},
+			setupMock: func(m *MockStorage) {
+				filter := domain.BalanceFilter{OwnerID: &ownerID}
+				m.EXPECT().ListBalances(context.Background(), filter, defaultSort, (*domain.BalancePosition)(nil), 10).Return(nil, nil, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.ListBalances(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, resp.Msg.Balances, tt.expectedCount)
+			if tt.expectedCount > 0 {
+				assert.Equal(t, pageToken, resp.Msg.NextPageToken)
+				assert.Equal(t, ownerID.String(), resp.Msg.Balances[0].OwnerId)
+				assert.Equal(t, currency, resp.Msg.Balances[0].Currency)
+			}
+		})
+	}
+}
+
+func TestBalances_Balance(t *testing.T) {
+	balanceID := uuid.New()
+	amount := decimal.NewFromInt(1000)
+
+	tests := []struct {
+		name           string
+		request        *balancev1.BalanceRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+	}{
+		{
+			name: "get balance success",
+			request: &balancev1.BalanceRequest{
+				BalanceId: balanceID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				balance := domain.Balance{
+					BalanceID: balanceID,
+					Amount:    amount,
+				}
+				m.EXPECT().Balance(context.Background(), balanceID).Return(balance, nil)
+			},
+		},
+		{
+			name: "balance not found",
+			request: &balancev1.BalanceRequest{
+				BalanceId: balanceID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Balance(context.Background(), balanceID).Return(domain.Balance{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.Balance(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, balanceID.String(), resp.Msg.BalanceId)
+			assert.Equal(t, amount.String(), resp.Msg.Amount.Value)
+		})
+	}
+}
+
+func TestBalances_CancelTxs(t *testing.T) {
+	balanceID := uuid.New()
+	txID1 := uuid.New()
+	txID2 := uuid.New()
+	expectedVersion := int64(5)
+
+	tests := []struct {
+		name           string
+		request        *balancev1.CancelTxsRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+	}{
+		{
+			name: "cancel transactions success",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String(), txID2.String()},
+			},
+			setupMock: func(m *MockStorage) {
+				expectedTxIDs := []uuid.UUID{txID1, txID2}
+				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs, domain.WriteOptions{}).Return(domain.CancelOutcome{Balance: domain.Balance{Version: 1}}, nil)
+			},
+		},
+		{
+			name: "version mismatch",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId:       balanceID.String(),
+				TxIds:           []string{txID1.String()},
+				ExpectedVersion: &expectedVersion,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1}, domain.WriteOptions{ExpectedVersion: &expectedVersion}).Return(domain.CancelOutcome{}, &storage.VersionMismatchError{
+					Expected: expectedVersion,
+					Current:  expectedVersion + 2,
+				})
+			},
+			expectedStatus: connect.CodeAborted,
+		},
+		{
+			name: "dry run",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+				DryRun:    true,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1}, domain.WriteOptions{DryRun: true}).Return(domain.CancelOutcome{Balance: domain.Balance{Version: 1}}, nil)
+			},
+		},
+		{
+			name: "cascade",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+				Cascade:   true,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1}, domain.WriteOptions{Cascade: true}).Return(domain.CancelOutcome{Balance: domain.Balance{Version: 1}}, nil)
+			},
+		},
+		{
+			name: "skip negative",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId:      balanceID.String(),
+				TxIds:          []string{txID1.String(), txID2.String()},
+				NegativePolicy: balancev1.NegativePolicy_NEGATIVE_POLICY_SKIP,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1, txID2}, domain.WriteOptions{NegativePolicy: domain.NegativePolicySkip}).Return(domain.CancelOutcome{
+					Balance:      domain.Balance{Version: 1},
+					SkippedTxIDs: []uuid.UUID{txID2},
+				}, nil)
+			},
+		},
+		{
+			name: "debt on negative",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId:      balanceID.String(),
+				TxIds:          []string{txID1.String()},
+				NegativePolicy: balancev1.NegativePolicy_NEGATIVE_POLICY_DEBT,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1}, domain.WriteOptions{NegativePolicy: domain.NegativePolicyDebt}).Return(domain.CancelOutcome{
+					Balance: domain.Balance{Version: 1},
+					Debt:    decimal.NewFromInt(10),
+				}, nil)
+			},
+		},
+		{
+			name: "has children",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1}, domain.WriteOptions{}).Return(domain.CancelOutcome{}, &storage.HasChildrenError{
+					Children: []uuid.UUID{txID2},
+				})
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "adjustment rejected",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().CancelTxs(context.Background(), balanceID, []uuid.UUID{txID1}, domain.WriteOptions{}).Return(domain.CancelOutcome{}, storage.ErrRejected)
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "no transaction IDs provided",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{},
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid balance ID",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: "invalid-uuid",
+				TxIds:     []string{txID1.String()},
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid transaction ID",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{"invalid-uuid"},
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "transactions not found",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+			},
+			setupMock: func(m *MockStorage) {
+				expectedTxIDs := []uuid.UUID{txID1}
+				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs, domain.WriteOptions{}).Return(domain.CancelOutcome{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+		},
+		{
+			name: "negative balance error",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+			},
+			setupMock: func(m *MockStorage) {
+				expectedTxIDs := []uuid.UUID{txID1}
+				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs, domain.WriteOptions{}).Return(domain.CancelOutcome{}, storage.ErrNegativeBalance)
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.CancelTxsRequest{
+				BalanceId: balanceID.String(),
+				TxIds:     []string{txID1.String()},
+			},
+			setupMock: func(m *MockStorage) {
+				expectedTxIDs := []uuid.UUID{txID1}
+				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs, domain.WriteOptions{}).Return(domain.CancelOutcome{}, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.CancelTxs(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), resp.Msg.GetVersion())
+		})
+	}
+}
+
+func TestBalances_CancelTxsByCriteria(t *testing.T) {
+	cancellationID := uuid.New()
+	from := time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC)
+	to := from.Add(20 * time.Minute)
+
+	criteria := domain.CancellationCriteria{
+		Source:      domain.SourceGame,
+		CreatedFrom: from,
+		CreatedTo:   to,
+		BalanceIDs:  []uuid.UUID{},
+	}
+
+	tests := []struct {
+		name           string
+		request        *balancev1.CancelTxsByCriteriaRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+	}{
+		{
+			name: "start cancellation success",
+			request: &balancev1.CancelTxsByCriteriaRequest{
+				Criteria: &balancev1.CancellationCriteria{
+					Source:      balancev1.Source_SOURCE_GAME,
+					CreatedFrom: timestamppb.New(from),
+					CreatedTo:   timestamppb.New(to),
+				},
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().StartCancellation(context.Background(), criteria, domain.NegativePolicyUnknown).Return(domain.Cancellation{
+					CancellationID: cancellationID,
+					Status:         domain.CancellationStatusPending,
+					Criteria:       criteria,
+					Progress:       domain.CancellationProgress{BalancesTotal: 3},
+				}, nil)
+			},
+		},
+		{
+			name: "debt policy",
+			request: &balancev1.CancelTxsByCriteriaRequest{
+				Criteria: &balancev1.CancellationCriteria{
+					Source:      balancev1.Source_SOURCE_GAME,
+					CreatedFrom: timestamppb.New(from),
+					CreatedTo:   timestamppb.New(to),
+				},
+				NegativePolicy: balancev1.NegativePolicy_NEGATIVE_POLICY_DEBT,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().StartCancellation(context.Background(), criteria, domain.NegativePolicyDebt).Return(domain.Cancellation{
+					CancellationID: cancellationID,
+					Status:         domain.CancellationStatusPending,
+					Criteria:       criteria,
+					Progress:       domain.CancellationProgress{BalancesTotal: 3},
+					NegativePolicy: domain.NegativePolicyDebt,
+				}, nil)
+			},
+		},
+		{
+			name: "adjustment source",
+			request: &balancev1.CancelTxsByCriteriaRequest{
+				Criteria: &balancev1.CancellationCriteria{
+					Source:      balancev1.Source_SOURCE_ADJUSTMENT,
+					CreatedFrom: timestamppb.New(from),
+					CreatedTo:   timestamppb.New(to),
+				},
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "empty range",
+			request: &balancev1.CancelTxsByCriteriaRequest{
+				Criteria: &balancev1.CancellationCriteria{
+					Source:      balancev1.Source_SOURCE_GAME,
+					CreatedFrom: timestamppb.New(to),
+					CreatedTo:   timestamppb.New(from),
+				},
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.CancelTxsByCriteriaRequest{
+				Criteria: &balancev1.CancellationCriteria{
+					Source:      balancev1.Source_SOURCE_GAME,
+					CreatedFrom: timestamppb.New(from),
+					CreatedTo:   timestamppb.New(to),
+				},
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().StartCancellation(context.Background(), criteria, domain.NegativePolicyUnknown).Return(domain.Cancellation{}, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.CancelTxsByCriteria(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, cancellationID.String(), resp.Msg.GetCancellation().GetCancellationId())
+			assert.Equal(t, balancev1.CancellationStatus_CANCELLATION_STATUS_PENDING, resp.Msg.GetCancellation().GetStatus())
+			assert.Equal(t, int64(3), resp.Msg.GetCancellation().GetBalancesTotal())
+			assert.Equal(t, balancev1.Source_SOURCE_GAME, resp.Msg.GetCancellation().GetCriteria().GetSource())
+			assert.Equal(t, tt.request.GetNegativePolicy(), resp.Msg.GetCancellation().GetNegativePolicy())
+		})
+	}
+}
+
+func TestBalances_GetCancellation(t *testing.T) {
+	cancellationID := uuid.New()
+	balanceID := uuid.New()
+	txID := uuid.New()
+
+	cancellation := domain.Cancellation{
+		CancellationID: cancellationID,
+		Status:         domain.CancellationStatusRunning,
+		Criteria:       domain.CancellationCriteria{Source: domain.SourceGame},
+		Progress: domain.CancellationProgress{
+			BalancesTotal: 2,
+			BalancesDone:  1,
+			TxsCancelled:  1,
+		},
+	}
+
+	pageToken, err := cursor.Encode(balanceID, cancellationCursorFilter{CancellationID: cancellationID})
+	require.NoError(t, err)
+
+	otherPageToken, err := cursor.Encode(balanceID, cancellationCursorFilter{CancellationID: uuid.New()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		request        *balancev1.GetCancellationRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+		expectedToken  string
+	}{
+		{
+			name: "get cancellation success",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: cancellationID.String(),
+				PageSize:       10,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Cancellation(context.Background(), cancellationID).Return(cancellation, nil)
+				m.EXPECT().CancellationBalances(context.Background(), cancellationID, (*uuid.UUID)(nil), 10).Return([]domain.BalanceCancellation{
+					{
+						BalanceID:  balanceID,
+						Status:     domain.BalanceCancellationStatusDone,
+						TxIDs:      []uuid.UUID{txID},
+						Correction: decimal.NewFromInt(-25),
+					},
+				}, nil)
+			},
+			expectedToken: pageToken,
+		},
+		{
+			name: "next page",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: cancellationID.String(),
+				PageSize:       10,
+				PageToken:      pageToken,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Cancellation(context.Background(), cancellationID).Return(cancellation, nil)
+				m.EXPECT().CancellationBalances(context.Background(), cancellationID, &balanceID, 10).Return(nil, nil)
+			},
+		},
+		{
+			name: "cancellation not found",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: cancellationID.String(),
+				PageSize:       10,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Cancellation(context.Background(), cancellationID).Return(domain.Cancellation{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+		},
+		{
+			name: "invalid cancellation ID",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: "invalid-uuid",
+				PageSize:       10,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid page token",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: cancellationID.String(),
+				PageSize:       10,
+				PageToken:      "invalid-token",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "page token of another cancellation",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: cancellationID.String(),
+				PageSize:       10,
+				PageToken:      otherPageToken,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.GetCancellationRequest{
+				CancellationId: cancellationID.String(),
+				PageSize:       10,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Cancellation(context.Background(), cancellationID).Return(domain.Cancellation{}, errors.New("storage error"))
+			},
+			expectedStatus: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.GetCancellation(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), resp.Msg.GetCancellation().GetBalancesTotal())
+			assert.Equal(t, int64(1), resp.Msg.GetCancellation().GetBalancesDone())
+			assert.Equal(t, tt.expectedToken, resp.Msg.GetNextPageToken())
+		})
+	}
+}
+
+func TestBalances_ListFlaggedEvents(t *testing.T) {
+	balanceID := uuid.New()
+	eventID := uuid.New()
+	txID := uuid.New()
+
+	pageToken, err := cursor.Encode(eventID, flaggedEventsCursorFilter{BalanceID: &balanceID})
+	require.NoError(t, err)
+
+	allBalancesPageToken, err := cursor.Encode(eventID, flaggedEventsCursorFilter{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		request        *balancev1.ListFlaggedEventsRequest
+		setupMock      func(*MockStorage)
+		expectedStatus connect.Code
+		expectedEvents int
+		expectedToken  string
+	}{
+		{
+			name: "list flagged events of balance",
+			request: &balancev1.ListFlaggedEventsRequest{
+				BalanceId: balanceID.String(),
+				PageSize:  10,
+			},
+			setupMock: func(m *MockStorage) {
+				events := []domain.FlaggedEvent{
+					{
+						EventID:   eventID,
+						BalanceID: balanceID,
+						TxID:      txID,
+						Rule:      "withdraw-velocity",
+						Action:    domain.RuleActionReject,
+					},
+				}
+				m.EXPECT().RecentFlaggedEvents(context.Background(), &balanceID, 10).Return(events, nil)
+			},
+			expectedEvents: 1,
+			expectedToken:  pageToken,
+		},
+		{
+			name: "list flagged events of all balances",
+			request: &balancev1.ListFlaggedEventsRequest{
+				PageSize:  10,
+				PageToken: allBalancesPageToken,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().PreviousFlaggedEvents(context.Background(), (*uuid.UUID)(nil), eventID, 10).Return(nil, nil)
 			},
-			expectedTxs: 1,
 		},
 		{
 			name: "invalid balance ID",
-			request: &balancev1.ListTxRequest{
+			request: &balancev1.ListFlaggedEventsRequest{
 				BalanceId: "invalid-uuid",
 				PageSize:  10,
 			},
@@ -62,13 +1677,30 @@ func TestBalances_ListTx(t *testing.T) {
 			expectedStatus: connect.CodeInvalidArgument,
 		},
 		{
-			name: "storage error",
-			request: &balancev1.ListTxRequest{
-				BalanceId: balanceID.String(),
+			name: "invalid page token",
+			request: &balancev1.ListFlaggedEventsRequest{
+				PageSize:  10,
+				PageToken: "invalid-token",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "page token of another balance",
+			request: &balancev1.ListFlaggedEventsRequest{
 				PageSize:  10,
+				PageToken: pageToken,
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "storage error",
+			request: &balancev1.ListFlaggedEventsRequest{
+				PageSize: 10,
 			},
 			setupMock: func(m *MockStorage) {
-				m.EXPECT().RecentTxs(context.Background(), balanceID, false, 10).Return([]domain.Tx{}, errors.New("storage error"))
+				m.EXPECT().RecentFlaggedEvents(context.Background(), (*uuid.UUID)(nil), 10).Return(nil, errors.New("storage error"))
 			},
 			expectedStatus: connect.CodeInternal,
 		},
@@ -82,7 +1714,7 @@ func TestBalances_ListTx(t *testing.T) {
 			service := NewBalances(mockStorage)
 			ctx := context.Background()
 
-			resp, err := service.ListTx(ctx, connect.NewRequest(tt.request))
+			resp, err := service.ListFlaggedEvents(ctx, connect.NewRequest(tt.request))
 
 			if tt.expectedStatus != 0 {
 				require.Error(t, err)
@@ -92,63 +1724,106 @@ func TestBalances_ListTx(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			assert.Len(t, resp.Msg.Txs, tt.expectedTxs)
+			assert.Len(t, resp.Msg.Events, tt.expectedEvents)
+			assert.Equal(t, tt.expectedToken, resp.Msg.GetNextPageToken())
 		})
 	}
 }
 
-func TestBalances_RecordTx(t *testing.T) {
+func TestBalances_WatchBalance(t *testing.T) {
 	balanceID := uuid.New()
 	txID := uuid.New()
-	amount := decimal.NewFromInt(100)
+	amount := decimal.NewFromInt(1000)
+
+	// changed returns a subscription which wakes up the watcher once and then closes like on server stop.
+	changed := func() <-chan struct{} {
+		ch := make(chan struct{}, 1)
+		ch <- struct{}{}
+		close(ch)
+		return ch
+	}
+
+	recorded := domain.BalanceEvent{
+		BalanceID: balanceID,
+		Seq:       6,
+		Kind:      domain.BalanceEventKindTxRecorded,
+		Amount:    amount.Add(decimal.NewFromInt(10)),
+		Status:    domain.BalanceStatusActive,
+		TxIDs:     []uuid.UUID{txID},
+		Txs: []domain.Tx{
+			{
+				BalanceID: balanceID,
+				TxID:      txID,
+				Amount:    decimal.NewFromInt(10),
+				Source:    domain.SourceGame,
+				State:     domain.StateDeposit,
+			},
+		},
+	}
 
 	tests := []struct {
 		name           string
-		request        *balancev1.RecordTxRequest
+		request        *balancev1.WatchBalanceRequest
 		setupMock      func(*MockStorage)
 		expectedStatus connect.Code
+		expectedKinds  []balancev1.BalanceEventKind
+		expectedSeqs   []int64
 	}{
 		{
-			name: "record transaction success",
-			request: &balancev1.RecordTxRequest{
+			name: "snapshot and changes",
+			request: &balancev1.WatchBalanceRequest{
 				BalanceId: balanceID.String(),
-				TxId:      txID.String(),
-				Amount:    &balancev1.Decimal{Value: amount.String()},
-				Source:    balancev1.Source_SOURCE_PAYMENT,
-				State:     balancev1.State_STATE_DEPOSIT,
 			},
 			setupMock: func(m *MockStorage) {
-				tx := domain.Tx{
+				m.EXPECT().Subscribe(mock.Anything, balanceID).Return(changed(), func() {}, nil)
+				m.EXPECT().Balance(mock.Anything, balanceID).Return(domain.Balance{
 					BalanceID: balanceID,
-					TxID:      txID,
 					Amount:    amount,
-					Source:    domain.SourcePayment,
-					State:     domain.StateDeposit,
-				}
-				m.EXPECT().RecordTx(context.Background(), tx).Return(nil)
+					Status:    domain.BalanceStatusActive,
+					EventSeq:  5,
+				}, nil)
+				m.EXPECT().BalanceEvents(mock.Anything, balanceID, int64(5), watchBatchSize).Return([]domain.BalanceEvent{recorded}, nil)
+				m.EXPECT().BalanceEvents(mock.Anything, balanceID, int64(6), watchBatchSize).Return(nil, nil)
+			},
+			expectedStatus: connect.CodeUnavailable,
+			expectedKinds:  []balancev1.BalanceEventKind{balancev1.BalanceEventKind_BALANCE_EVENT_KIND_SNAPSHOT, balancev1.BalanceEventKind_BALANCE_EVENT_KIND_TX_RECORDED},
+			expectedSeqs:   []int64{5, 6},
+		},
+		{
+			name: "resume after seq",
+			request: &balancev1.WatchBalanceRequest{
+				BalanceId: balanceID.String(),
+				AfterSeq:  5,
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Subscribe(mock.Anything, balanceID).Return(changed(), func() {}, nil)
+				m.EXPECT().Balance(mock.Anything, balanceID).Return(domain.Balance{BalanceID: balanceID, EventSeq: 6}, nil)
+				m.EXPECT().BalanceEvents(mock.Anything, balanceID, int64(5), watchBatchSize).Return([]domain.BalanceEvent{recorded}, nil)
+				m.EXPECT().BalanceEvents(mock.Anything, balanceID, int64(6), watchBatchSize).Return(nil, nil)
 			},
+			expectedStatus: connect.CodeUnavailable,
+			expectedKinds:  []balancev1.BalanceEventKind{balancev1.BalanceEventKind_BALANCE_EVENT_KIND_TX_RECORDED},
+			expectedSeqs:   []int64{6},
 		},
 		{
 			name: "balance not found",
-			request: &balancev1.RecordTxRequest{
+			request: &balancev1.WatchBalanceRequest{
 				BalanceId: balanceID.String(),
-				TxId:      txID.String(),
-				Amount:    &balancev1.Decimal{Value: amount.String()},
-				Source:    balancev1.Source_SOURCE_PAYMENT,
-				State:     balancev1.State_STATE_DEPOSIT,
 			},
 			setupMock: func(m *MockStorage) {
-				tx := domain.Tx{
-					BalanceID: balanceID,
-					TxID:      txID,
-					Amount:    amount,
-					Source:    domain.SourcePayment,
-					State:     domain.StateDeposit,
-				}
-				m.EXPECT().RecordTx(context.Background(), tx).Return(storage.ErrNotFound)
+				m.EXPECT().Subscribe(mock.Anything, balanceID).Return(changed(), func() {}, nil)
+				m.EXPECT().Balance(mock.Anything, balanceID).Return(domain.Balance{}, storage.ErrNotFound)
 			},
 			expectedStatus: connect.CodeNotFound,
 		},
+		{
+			name: "invalid balance id",
+			request: &balancev1.WatchBalanceRequest{
+				BalanceId: "invalid-uuid",
+			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
 	}
 
 	for _, tt := range tests {
@@ -156,46 +1831,83 @@ func TestBalances_RecordTx(t *testing.T) {
 			mockStorage := NewMockStorage(t)
 			tt.setupMock(mockStorage)
 
-			service := NewBalances(mockStorage)
-			ctx := context.Background()
+			mux := http.NewServeMux()
+			mux.Handle(balancev1connect.NewBalanceServiceHandler(NewBalances(mockStorage)))
+			server := httptest.NewServer(mux)
+			defer server.Close()
 
-			resp, err := service.RecordTx(ctx, connect.NewRequest(tt.request))
+			client := balancev1connect.NewBalanceServiceClient(server.Client(), server.URL)
 
-			if tt.expectedStatus != 0 {
-				require.Error(t, err)
-				connectErr := err.(*connect.Error)
-				assert.Equal(t, tt.expectedStatus, connectErr.Code())
-				return
+			stream, err := client.WatchBalance(context.Background(), connect.NewRequest(tt.request))
+			require.NoError(t, err)
+			defer func() { _ = stream.Close() }()
+
+			var kinds []balancev1.BalanceEventKind
+			var seqs []int64
+			for stream.Receive() {
+				kinds = append(kinds, stream.Msg().GetKind())
+				seqs = append(seqs, stream.Msg().GetSeq())
 			}
 
-			require.NoError(t, err)
-			assert.IsType(t, &connect.Response[emptypb.Empty]{}, resp)
+			require.Error(t, stream.Err())
+			assert.Equal(t, tt.expectedStatus, connect.CodeOf(stream.Err()))
+			assert.Equal(t, tt.expectedKinds, kinds)
+			assert.Equal(t, tt.expectedSeqs, seqs)
 		})
 	}
 }
 
-func TestBalances_OpenBalance(t *testing.T) {
+func TestBalances_ExportStatement(t *testing.T) {
 	balanceID := uuid.New()
+	txID := uuid.New()
+
+	entry := domain.StatementEntry{
+		Seq:            1,
+		TxID:           txID,
+		Source:         domain.SourceGame,
+		State:          domain.StateDeposit,
+		Amount:         decimal.NewFromInt(10),
+		Change:         decimal.NewFromInt(10),
+		RunningBalance: decimal.NewFromInt(10),
+	}
 
 	tests := []struct {
 		name           string
-		request        *balancev1.OpenBalanceRequest
+		request        *balancev1.ExportStatementRequest
 		setupMock      func(*MockStorage)
 		expectedStatus connect.Code
+		expectedData   string
 	}{
 		{
-			name: "open balance success",
-			request: &balancev1.OpenBalanceRequest{
+			name: "export jsonl",
+			request: &balancev1.ExportStatementRequest{
 				BalanceId: balanceID.String(),
+				Format:    balancev1.StatementFormat_STATEMENT_FORMAT_JSONL,
 			},
 			setupMock: func(m *MockStorage) {
-				m.EXPECT().OpenBalance(context.Background(), balanceID).Return(nil)
+				m.EXPECT().ExportStatement(mock.Anything, balanceID, (*time.Time)(nil), (*time.Time)(nil), mock.Anything).
+					RunAndReturn(func(ctx context.Context, id uuid.UUID, from, to *time.Time, fn func(domain.StatementEntry) error) error {
+						return fn(entry)
+					})
 			},
+			expectedData: `"tx_id":"` + txID.String() + `"`,
 		},
 		{
-			name: "invalid balance ID",
-			request: &balancev1.OpenBalanceRequest{
-				BalanceId: "invalid-uuid",
+			name: "balance not found",
+			request: &balancev1.ExportStatementRequest{
+				BalanceId: balanceID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().ExportStatement(mock.Anything, balanceID, (*time.Time)(nil), (*time.Time)(nil), mock.Anything).Return(storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+		},
+		{
+			name: "invalid range",
+			request: &balancev1.ExportStatementRequest{
+				BalanceId: balanceID.String(),
+				From:      timestamppb.New(time.Now()),
+				To:        timestamppb.New(time.Now().Add(-time.Hour)),
 			},
 			setupMock:      func(m *MockStorage) {},
 			expectedStatus: connect.CodeInvalidArgument,
@@ -207,56 +1919,118 @@ func TestBalances_OpenBalance(t *testing.T) {
 			mockStorage := NewMockStorage(t)
 			tt.setupMock(mockStorage)
 
-			service := NewBalances(mockStorage)
-			ctx := context.Background()
+			mux := http.NewServeMux()
+			mux.Handle(balancev1connect.NewBalanceServiceHandler(NewBalances(mockStorage)))
+			server := httptest.NewServer(mux)
+			defer server.Close()
 
-			resp, err := service.OpenBalance(ctx, connect.NewRequest(tt.request))
+			client := balancev1connect.NewBalanceServiceClient(server.Client(), server.URL)
+
+			stream, err := client.ExportStatement(context.Background(), connect.NewRequest(tt.request))
+			require.NoError(t, err)
+			defer func() { _ = stream.Close() }()
+
+			var data []byte
+			for stream.Receive() {
+				data = append(data, stream.Msg().GetData()...)
+			}
 
 			if tt.expectedStatus != 0 {
-				require.Error(t, err)
-				connectErr := err.(*connect.Error)
-				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				require.Error(t, stream.Err())
+				assert.Equal(t, tt.expectedStatus, connect.CodeOf(stream.Err()))
 				return
 			}
 
-			require.NoError(t, err)
-			assert.IsType(t, &connect.Response[emptypb.Empty]{}, resp)
+			require.NoError(t, stream.Err())
+			assert.Contains(t, string(data), tt.expectedData)
 		})
 	}
 }
 
-func TestBalances_Balance(t *testing.T) {
+func TestBalances_ListDebtEntries(t *testing.T) {
 	balanceID := uuid.New()
-	amount := decimal.NewFromInt(1000)
+	txID := uuid.New()
+
+	entries := []domain.DebtEntry{
+		{
+			BalanceID: balanceID,
+			Seq:       1,
+			Kind:      domain.DebtEntryKindIncurred,
+			Amount:    decimal.NewFromInt(50),
+			Debt:      decimal.NewFromInt(50),
+		},
+		{
+			BalanceID: balanceID,
+			Seq:       2,
+			Kind:      domain.DebtEntryKindSettled,
+			Amount:    decimal.NewFromInt(30),
+			Debt:      decimal.NewFromInt(20),
+			TxIDs:     []uuid.UUID{txID},
+		},
+	}
+
+	mockStorage := NewMockStorage(t)
+	mockStorage.EXPECT().DebtEntries(context.Background(), balanceID, int64(0), 2).Return(entries, nil)
+	mockStorage.EXPECT().DebtEntries(context.Background(), balanceID, int64(2), 2).Return(nil, nil)
+
+	service := NewBalances(mockStorage)
+
+	resp, err := service.ListDebtEntries(context.Background(), connect.NewRequest(&balancev1.ListDebtEntriesRequest{
+		BalanceId: balanceID.String(),
+		PageSize:  2,
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEntries(), 2)
+	assert.Equal(t, balancev1.DebtEntryKind_DEBT_ENTRY_KIND_INCURRED, resp.Msg.GetEntries()[0].GetKind())
+	assert.Equal(t, "20", resp.Msg.GetEntries()[1].GetDebt().GetValue())
+	assert.Equal(t, []string{txID.String()}, resp.Msg.GetEntries()[1].GetTxIds())
+	pageToken := resp.Msg.GetNextPageToken()
+	require.NotEmpty(t, pageToken)
+
+	resp, err = service.ListDebtEntries(context.Background(), connect.NewRequest(&balancev1.ListDebtEntriesRequest{
+		BalanceId: balanceID.String(),
+		PageSize:  2,
+		PageToken: pageToken,
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.GetEntries())
+	assert.Empty(t, resp.Msg.GetNextPageToken())
 
 	tests := []struct {
 		name           string
-		request        *balancev1.BalanceRequest
+		request        *balancev1.ListDebtEntriesRequest
 		setupMock      func(*MockStorage)
 		expectedStatus connect.Code
 	}{
 		{
-			name: "get balance success",
-			request: &balancev1.BalanceRequest{
-				BalanceId: balanceID.String(),
+			name: "invalid balance ID",
+			request: &balancev1.ListDebtEntriesRequest{
+				BalanceId: "invalid-uuid",
+				PageSize:  2,
 			},
-			setupMock: func(m *MockStorage) {
-				balance := domain.Balance{
-					BalanceID: balanceID,
-					Amount:    amount,
-				}
-				m.EXPECT().Balance(context.Background(), balanceID).Return(balance, nil)
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
+		},
+		{
+			name: "token of another balance",
+			request: &balancev1.ListDebtEntriesRequest{
+				BalanceId: uuid.NewString(),
+				PageSize:  2,
+				PageToken: pageToken,
 			},
+			setupMock:      func(m *MockStorage) {},
+			expectedStatus: connect.CodeInvalidArgument,
 		},
 		{
-			name: "balance not found",
-			request: &balancev1.BalanceRequest{
+			name: "storage error",
+			request: &balancev1.ListDebtEntriesRequest{
 				BalanceId: balanceID.String(),
+				PageSize:  2,
 			},
 			setupMock: func(m *MockStorage) {
-				m.EXPECT().Balance(context.Background(), balanceID).Return(domain.Balance{}, storage.ErrNotFound)
+				m.EXPECT().DebtEntries(context.Background(), balanceID, int64(0), 2).Return(nil, errors.New("storage error"))
 			},
-			expectedStatus: connect.CodeNotFound,
+			expectedStatus: connect.CodeInternal,
 		},
 	}
 
@@ -265,107 +2039,203 @@ func TestBalances_Balance(t *testing.T) {
 			mockStorage := NewMockStorage(t)
 			tt.setupMock(mockStorage)
 
-			service := NewBalances(mockStorage)
-			ctx := context.Background()
-
-			resp, err := service.Balance(ctx, connect.NewRequest(tt.request))
-
-			if tt.expectedStatus != 0 {
-				require.Error(t, err)
-				connectErr := err.(*connect.Error)
-				assert.Equal(t, tt.expectedStatus, connectErr.Code())
-				return
-			}
+			_, err := NewBalances(mockStorage).ListDebtEntries(context.Background(), connect.NewRequest(tt.request))
 
-			require.NoError(t, err)
-			assert.Equal(t, balanceID.String(), resp.Msg.BalanceId)
-			assert.Equal(t, amount.String(), resp.Msg.Amount.Value)
+			require.Error(t, err)
+			connectErr := err.(*connect.Error)
+			assert.Equal(t, tt.expectedStatus, connectErr.Code())
 		})
 	}
 }
 
-func TestBalances_CancelTxs(t *testing.T) {
+func TestBalances_Chargeback(t *testing.T) {
 	balanceID := uuid.New()
-	txID1 := uuid.New()
-	txID2 := uuid.New()
+	txID := uuid.New()
+	reason := "fraud"
 
 	tests := []struct {
 		name           string
-		request        *balancev1.CancelTxsRequest
+		request        *balancev1.ChargebackRequest
 		setupMock      func(*MockStorage)
 		expectedStatus connect.Code
+		expectedReason string
 	}{
 		{
-			name: "cancel transactions success",
-			request: &balancev1.CancelTxsRequest{
+			name: "chargeback success",
+			request: &balancev1.ChargebackRequest{
 				BalanceId: balanceID.String(),
-				TxIds:     []string{txID1.String(), txID2.String()},
+				TxId:      txID.String(),
+				Reason:    reason,
 			},
 			setupMock: func(m *MockStorage) {
-				expectedTxIDs := []uuid.UUID{txID1, txID2}
-				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs).Return(nil)
+				m.EXPECT().Chargeback(context.Background(), balanceID, txID, &reason).Return(domain.ChargebackOutcome{
+					Chargeback: domain.Chargeback{
+						BalanceID:  balanceID,
+						TxID:       txID,
+						Amount:     decimal.NewFromInt(100),
+						Correction: decimal.NewFromInt(-40),
+						Debt:       decimal.NewFromInt(60),
+						Reason:     &reason,
+					},
+					Tx: domain.Tx{
+						TxID:      txID,
+						BalanceID: balanceID,
+						Source:    domain.SourcePayment,
+						State:     domain.StateDeposit,
+						Amount:    decimal.NewFromInt(100),
+					},
+					Balance: domain.Balance{
+						BalanceID: balanceID,
+						Status:    domain.BalanceStatusWithdrawalsFrozen,
+						Debt:      decimal.NewFromInt(60),
+						Version:   4,
+					},
+				}, nil)
 			},
 		},
 		{
-			name: "no transaction IDs provided",
-			request: &balancev1.CancelTxsRequest{
+			name: "tx not found",
+			request: &balancev1.ChargebackRequest{
 				BalanceId: balanceID.String(),
-				TxIds:     []string{},
+				TxId:      txID.String(),
 			},
-			setupMock:      func(m *MockStorage) {},
-			expectedStatus: connect.CodeInvalidArgument,
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Chargeback(context.Background(), balanceID, txID, (*string)(nil)).Return(domain.ChargebackOutcome{}, storage.ErrNotFound)
+			},
+			expectedStatus: connect.CodeNotFound,
+			expectedReason: "TX_NOT_FOUND",
 		},
 		{
-			name: "invalid balance ID",
-			request: &balancev1.CancelTxsRequest{
-				BalanceId: "invalid-uuid",
-				TxIds:     []string{txID1.String()},
+			name: "not a payment deposit",
+			request: &balancev1.ChargebackRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
 			},
-			setupMock:      func(m *MockStorage) {},
-			expectedStatus: connect.CodeInvalidArgument,
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Chargeback(context.Background(), balanceID, txID, (*string)(nil)).Return(domain.ChargebackOutcome{}, storage.ErrNotChargeable)
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+			expectedReason: "TX_NOT_CHARGEABLE",
 		},
 		{
-			name: "invalid transaction ID",
-			request: &balancev1.CancelTxsRequest{
+			name: "has children",
+			request: &balancev1.ChargebackRequest{
 				BalanceId: balanceID.String(),
-				TxIds:     []string{"invalid-uuid"},
+				TxId:      txID.String(),
+			},
+			setupMock: func(m *MockStorage) {
+				m.EXPECT().Chargeback(context.Background(), balanceID, txID, (*string)(nil)).Return(domain.ChargebackOutcome{}, &storage.HasChildrenError{
+					Children: []uuid.UUID{uuid.New()},
+				})
+			},
+			expectedStatus: connect.CodeFailedPrecondition,
+			expectedReason: "TX_HAS_CHILDREN",
+		},
+		{
+			name: "invalid tx ID",
+			request: &balancev1.ChargebackRequest{
+				BalanceId: balanceID.String(),
+				TxId:      "invalid-uuid",
 			},
 			setupMock:      func(m *MockStorage) {},
 			expectedStatus: connect.CodeInvalidArgument,
+			expectedReason: "INVALID_ARGUMENT",
 		},
 		{
-			name: "transactions not found",
-			request: &balancev1.CancelTxsRequest{
+			name: "storage error",
+			request: &balancev1.ChargebackRequest{
 				BalanceId: balanceID.String(),
-				TxIds:     []string{txID1.String()},
+				TxId:      txID.String(),
 			},
 			setupMock: func(m *MockStorage) {
-				expectedTxIDs := []uuid.UUID{txID1}
-				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs).Return(storage.ErrNotFound)
+				m.EXPECT().Chargeback(context.Background(), balanceID, txID, (*string)(nil)).Return(domain.ChargebackOutcome{}, errors.New("storage error"))
 			},
-			expectedStatus: connect.CodeNotFound,
+			expectedStatus: connect.CodeInternal,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := NewMockStorage(t)
+			tt.setupMock(mockStorage)
+
+			service := NewBalances(mockStorage)
+			ctx := context.Background()
+
+			resp, err := service.Chargeback(ctx, connect.NewRequest(tt.request))
+
+			if tt.expectedStatus != 0 {
+				require.Error(t, err)
+				connectErr := err.(*connect.Error)
+				assert.Equal(t, tt.expectedStatus, connectErr.Code())
+				assert.Equal(t, tt.expectedReason, apierror.Reason(connectErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, txID.String(), resp.Msg.GetChargeback().GetTxId())
+			assert.Equal(t, "60", resp.Msg.GetChargeback().GetDebt().GetValue())
+			assert.Equal(t, reason, resp.Msg.GetChargeback().GetReason())
+			assert.Equal(t, balancev1.BalanceStatus_BALANCE_STATUS_WITHDRAWALS_FROZEN, resp.Msg.GetBalance().GetStatus())
+			assert.Equal(t, "60", resp.Msg.GetBalance().GetDebt().GetValue())
+		})
+	}
+}
+
+func TestBalances_Aggregate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	query := domain.AggregateQuery{
+		From:     from,
+		To:       to,
+		Bucket:   domain.AggregateBucketDay,
+		Location: time.UTC,
+	}
+
+	tests := []struct {
+		name            string
+		request         *balancev1.AggregateRequest
+		setupMock       func(*MockStorage)
+		expectedStatus  connect.Code
+		expectedRows    int
+		expectedGGR     string
+		expectedDeposit string
+	}{
 		{
-			name: "negative balance error",
-			request: &balancev1.CancelTxsRequest{
-				BalanceId: balanceID.String(),
-				TxIds:     []string{txID1.String()},
+			name: "aggregate success",
+			request: &balancev1.AggregateRequest{
+				From: timestamppb.New(from),
+				To:   timestamppb.New(to),
 			},
 			setupMock: func(m *MockStorage) {
-				expectedTxIDs := []uuid.UUID{txID1}
-				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs).Return(storage.ErrNegativeBalance)
+				rows := []domain.AggregateRow{
+					{BucketStart: from, Source: domain.SourceGame, State: domain.StateDeposit, Count: 1, Amount: decimal.NewFromInt(30)},
+					{BucketStart: from, Source: domain.SourceGame, State: domain.StateWithdraw, Count: 2, Amount: decimal.NewFromInt(100)},
+					{BucketStart: from, Source: domain.SourcePayment, State: domain.StateDeposit, Count: 1, Amount: decimal.NewFromInt(500)},
+				}
+				m.EXPECT().Aggregate(context.Background(), query).Return(rows, nil)
+			},
+			expectedRows:    3,
+			expectedGGR:     "70",
+			expectedDeposit: "500",
+		},
+		{
+			name: "missing range",
+			request: &balancev1.AggregateRequest{
+				From: timestamppb.New(from),
 			},
+			setupMock:      func(m *MockStorage) {},
 			expectedStatus: connect.CodeInvalidArgument,
 		},
 		{
 			name: "storage error",
-			request: &balancev1.CancelTxsRequest{
-				BalanceId: balanceID.String(),
-				TxIds:     []string{txID1.String()},
+			request: &balancev1.AggregateRequest{
+				From: timestamppb.New(from),
+				To:   timestamppb.New(to),
 			},
 			setupMock: func(m *MockStorage) {
-				expectedTxIDs := []uuid.UUID{txID1}
-				m.EXPECT().CancelTxs(context.Background(), balanceID, expectedTxIDs).Return(errors.New("storage error"))
+				m.EXPECT().Aggregate(context.Background(), query).Return(nil, errors.New("db error"))
 			},
 			expectedStatus: connect.CodeInternal,
 		},
@@ -379,7 +2249,7 @@ func TestBalances_CancelTxs(t *testing.T) {
 			service := NewBalances(mockStorage)
 			ctx := context.Background()
 
-			resp, err := service.CancelTxs(ctx, connect.NewRequest(tt.request))
+			resp, err := service.Aggregate(ctx, connect.NewRequest(tt.request))
 
 			if tt.expectedStatus != 0 {
 				require.Error(t, err)
@@ -389,7 +2259,10 @@ func TestBalances_CancelTxs(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			assert.IsType(t, &connect.Response[emptypb.Empty]{}, resp)
+			assert.Len(t, resp.Msg.Rows, tt.expectedRows)
+			require.Len(t, resp.Msg.Revenues, 1)
+			assert.Equal(t, tt.expectedGGR, resp.Msg.Revenues[0].Ggr.Value)
+			assert.Equal(t, tt.expectedDeposit, resp.Msg.Revenues[0].Deposits.Value)
 		})
 	}
 }
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/apierror"
+	"github.com/iskorotkov/igaming-balance-backend/internal/cursor"
+	"github.com/iskorotkov/igaming-balance-backend/internal/storage"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// requestFields maps validation errors to request fields they are about.
+var requestFields = []struct {
+	err   error
+	field string
+}{
+	{transform.ErrInvalidBalanceID, "balance_id"},
+	{transform.ErrInvalidTxID, "tx_id"},
+	{transform.ErrInvalidParentTxID, "parent_tx_id"},
+	{transform.ErrInvalidAmount, "amount"},
+	{transform.ErrInvalidSource, "source"},
+	{transform.ErrInvalidState, "state"},
+	{transform.ErrInvalidOwnerID, "owner_id"},
+	{transform.ErrInvalidCurrency, "currency"},
+	{transform.ErrInvalidWalletType, "wallet_type"},
+	{transform.ErrInvalidStatus, "status"},
+	{transform.ErrInvalidSort, "sort_by"},
+	{transform.ErrInvalidOrder, "order"},
+	{transform.ErrInvalidFormat, "format"},
+	{transform.ErrInvalidBucket, "bucket"},
+	{transform.ErrInvalidTimeZone, "time_zone"},
+	{transform.ErrInvalidAudit, "audit"},
+	{cursor.ErrInvalid, "page_token"},
+	{cursor.ErrFilterMismatch, "page_token"},
+}
+
+// newError returns an error with ErrorInfo details, so clients branch on the reason instead of the message.
+var newError = apierror.New
+
+// invalidRequest returns InvalidArgument with a violation of the field the validation error is about.
+func invalidRequest(err error) *connect.Error {
+	for _, f := range requestFields {
+		if errors.Is(err, f.err) {
+			return invalidField(f.field, err)
+		}
+	}
+
+	return invalidField("", err)
+}
+
+// invalidMessage returns InvalidArgument with violations of schema rules of the message.
+func invalidMessage(err error) *connect.Error {
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		return invalidField("", err)
+	}
+
+	return newError(connect.CodeInvalidArgument, balancev1.ErrorReason_ERROR_REASON_INVALID_ARGUMENT, err.Error(), nil,
+		transform.BadRequestFromValidation(validationErr))
+}
+
+// invalidField returns InvalidArgument with a violation of the field, empty field means the whole request.
+func invalidField(field string, err error) *connect.Error {
+	return newError(connect.CodeInvalidArgument, balancev1.ErrorReason_ERROR_REASON_INVALID_ARGUMENT, err.Error(), nil,
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       field,
+					Description: err.Error(),
+				},
+			},
+		},
+	)
+}
+
+func balanceNotFound(balanceID string) *connect.Error {
+	return newError(connect.CodeNotFound, balancev1.ErrorReason_ERROR_REASON_BALANCE_NOT_FOUND, "balance not found",
+		map[string]string{"balance_id": balanceID})
+}
+
+// insufficientFunds returns FailedPrecondition with amounts if storage knows them.
+func insufficientFunds(balanceID string, err error) *connect.Error {
+	metadata := map[string]string{"balance_id": balanceID}
+
+	var fundsErr *storage.InsufficientFundsError
+	if !errors.As(err, &fundsErr) {
+		return newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_INSUFFICIENT_FUNDS, "insufficient funds", metadata)
+	}
+
+	metadata["current"] = fundsErr.Current.String()
+	metadata["required"] = fundsErr.Required.String()
+
+	return newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_INSUFFICIENT_FUNDS, "insufficient funds", metadata,
+		&balancev1.InsufficientFunds{
+			Current:  &balancev1.Decimal{Value: fundsErr.Current.String()},
+			Required: &balancev1.Decimal{Value: fundsErr.Required.String()},
+		},
+	)
+}
+
+// versionMismatch returns Aborted with versions if storage knows them, so the client can re-read the balance and retry.
+func versionMismatch(balanceID string, err error) *connect.Error {
+	metadata := map[string]string{"balance_id": balanceID}
+
+	var versionErr *storage.VersionMismatchError
+	if errors.As(err, &versionErr) {
+		metadata["expected_version"] = strconv.FormatInt(versionErr.Expected, 10)
+		metadata["current_version"] = strconv.FormatInt(versionErr.Current, 10)
+	}
+
+	return newError(connect.CodeAborted, balancev1.ErrorReason_ERROR_REASON_VERSION_MISMATCH, "balance version mismatch", metadata)
+}
+
+// hasChildren returns FailedPrecondition with the number of children blocking the cancellation.
+func hasChildren(balanceID string, err error) *connect.Error {
+	metadata := map[string]string{"balance_id": balanceID}
+
+	var childrenErr *storage.HasChildrenError
+	if errors.As(err, &childrenErr) {
+		metadata["children"] = strconv.Itoa(len(childrenErr.Children))
+	}
+
+	return newError(connect.CodeFailedPrecondition, balancev1.ErrorReason_ERROR_REASON_TX_HAS_CHILDREN,
+		"transactions have children, cancel them too or request cascade", metadata)
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -6,19 +6,20 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockAdminStorage creates a new instance of MockAdminStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockStorage(t interface {
+func NewMockAdminStorage(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockStorage {
-	mock := &MockStorage{}
+}) *MockAdminStorage {
+	mock := &MockAdminStorage{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,116 +27,115 @@ func NewMockStorage(t interface {
 	return mock
 }
 
-// MockStorage is an autogenerated mock type for the Storage type
-type MockStorage struct {
+// MockAdminStorage is an autogenerated mock type for the AdminStorage type
+type MockAdminStorage struct {
 	mock.Mock
 }
 
-type MockStorage_Expecter struct {
+type MockAdminStorage_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
-	return &MockStorage_Expecter{mock: &_m.Mock}
+func (_m *MockAdminStorage) EXPECT() *MockAdminStorage_Expecter {
+	return &MockAdminStorage_Expecter{mock: &_m.Mock}
 }
 
-// Balance provides a mock function for the type MockStorage
-func (_mock *MockStorage) Balance(ctx context.Context, balanceID uuid.UUID) (domain.Balance, error) {
-	ret := _mock.Called(ctx, balanceID)
+// AdjustBalance provides a mock function for the type MockAdminStorage
+func (_mock *MockAdminStorage) AdjustBalance(ctx context.Context, tx domain.Tx, entry domain.AuditEntry) error {
+	ret := _mock.Called(ctx, tx, entry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Balance")
+		panic("no return value specified for AdjustBalance")
 	}
 
-	var r0 domain.Balance
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Balance, error)); ok {
-		return returnFunc(ctx, balanceID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Balance); ok {
-		r0 = returnFunc(ctx, balanceID)
-	} else {
-		r0 = ret.Get(0).(domain.Balance)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, balanceID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Tx, domain.AuditEntry) error); ok {
+		r0 = returnFunc(ctx, tx, entry)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_Balance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Balance'
-type MockStorage_Balance_Call struct {
+// MockAdminStorage_AdjustBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustBalance'
+type MockAdminStorage_AdjustBalance_Call struct {
 	*mock.Call
 }
 
-// Balance is a helper method to define mock.On call
+// AdjustBalance is a helper method to define mock.On call
 //   - ctx context.Context
-//   - balanceID uuid.UUID
-func (_e *MockStorage_Expecter) Balance(ctx interface{}, balanceID interface{}) *MockStorage_Balance_Call {
-	return &MockStorage_Balance_Call{Call: _e.mock.On("Balance", ctx, balanceID)}
+//   - tx domain.Tx
+//   - entry domain.AuditEntry
+func (_e *MockAdminStorage_Expecter) AdjustBalance(ctx interface{}, tx interface{}, entry interface{}) *MockAdminStorage_AdjustBalance_Call {
+	return &MockAdminStorage_AdjustBalance_Call{Call: _e.mock.On("AdjustBalance", ctx, tx, entry)}
 }
 
-func (_c *MockStorage_Balance_Call) Run(run func(ctx context.Context, balanceID uuid.UUID)) *MockStorage_Balance_Call {
+func (_c *MockAdminStorage_AdjustBalance_Call) Run(run func(ctx context.Context, tx domain.Tx, entry domain.AuditEntry)) *MockAdminStorage_AdjustBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 domain.Tx
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(domain.Tx)
+		}
+		var arg2 domain.AuditEntry
+		if args[2] != nil {
+			arg2 = args[2].(domain.AuditEntry)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_Balance_Call) Return(balance domain.Balance, err error) *MockStorage_Balance_Call {
-	_c.Call.Return(balance, err)
+func (_c *MockAdminStorage_AdjustBalance_Call) Return(err error) *MockAdminStorage_AdjustBalance_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_Balance_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID) (domain.Balance, error)) *MockStorage_Balance_Call {
+func (_c *MockAdminStorage_AdjustBalance_Call) RunAndReturn(run func(ctx context.Context, tx domain.Tx, entry domain.AuditEntry) error) *MockAdminStorage_AdjustBalance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CancelTxs provides a mock function for the type MockStorage
-func (_mock *MockStorage) CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID) error {
-	ret := _mock.Called(ctx, balanceID, txIDs)
+// ForceCancelTx provides a mock function for the type MockAdminStorage
+func (_mock *MockAdminStorage) ForceCancelTx(ctx context.Context, balanceID uuid.UUID, txID uuid.UUID, cascade bool, entry domain.AuditEntry) error {
+	ret := _mock.Called(ctx, balanceID, txID, cascade, entry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CancelTxs")
+		panic("no return value specified for ForceCancelTx")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, balanceID, txIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, bool, domain.AuditEntry) error); ok {
+		r0 = returnFunc(ctx, balanceID, txID, cascade, entry)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_CancelTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTxs'
-type MockStorage_CancelTxs_Call struct {
+// MockAdminStorage_ForceCancelTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceCancelTx'
+type MockAdminStorage_ForceCancelTx_Call struct {
 	*mock.Call
 }
 
-// CancelTxs is a helper method to define mock.On call
+// ForceCancelTx is a helper method to define mock.On call
 //   - ctx context.Context
 //   - balanceID uuid.UUID
-//   - txIDs []uuid.UUID
-func (_e *MockStorage_Expecter) CancelTxs(ctx interface{}, balanceID interface{}, txIDs interface{}) *MockStorage_CancelTxs_Call {
-	return &MockStorage_CancelTxs_Call{Call: _e.mock.On("CancelTxs", ctx, balanceID, txIDs)}
+//   - txID uuid.UUID
+//   - cascade bool
+//   - entry domain.AuditEntry
+func (_e *MockAdminStorage_Expecter) ForceCancelTx(ctx interface{}, balanceID interface{}, txID interface{}, cascade interface{}, entry interface{}) *MockAdminStorage_ForceCancelTx_Call {
+	return &MockAdminStorage_ForceCancelTx_Call{Call: _e.mock.On("ForceCancelTx", ctx, balanceID, txID, cascade, entry)}
 }
 
-func (_c *MockStorage_CancelTxs_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID)) *MockStorage_CancelTxs_Call {
+func (_c *MockAdminStorage_ForceCancelTx_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, txID uuid.UUID, cascade bool, entry domain.AuditEntry)) *MockAdminStorage_ForceCancelTx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -145,59 +145,79 @@ func (_c *MockStorage_CancelTxs_Call) Run(run func(ctx context.Context, balanceI
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 []uuid.UUID
+		var arg2 uuid.UUID
 		if args[2] != nil {
-			arg2 = args[2].([]uuid.UUID)
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 domain.AuditEntry
+		if args[4] != nil {
+			arg4 = args[4].(domain.AuditEntry)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_CancelTxs_Call) Return(err error) *MockStorage_CancelTxs_Call {
+func (_c *MockAdminStorage_ForceCancelTx_Call) Return(err error) *MockAdminStorage_ForceCancelTx_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_CancelTxs_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID) error) *MockStorage_CancelTxs_Call {
+func (_c *MockAdminStorage_ForceCancelTx_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, txID uuid.UUID, cascade bool, entry domain.AuditEntry) error) *MockAdminStorage_ForceCancelTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// OpenBalance provides a mock function for the type MockStorage
-func (_mock *MockStorage) OpenBalance(ctx context.Context, balanceID uuid.UUID) error {
-	ret := _mock.Called(ctx, balanceID)
+// InspectBalance provides a mock function for the type MockAdminStorage
+func (_mock *MockAdminStorage) InspectBalance(ctx context.Context, balanceID uuid.UUID, entry domain.AuditEntry) (domain.BalanceInspection, error) {
+	ret := _mock.Called(ctx, balanceID, entry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for OpenBalance")
+		panic("no return value specified for InspectBalance")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, balanceID)
+	var r0 domain.BalanceInspection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.AuditEntry) (domain.BalanceInspection, error)); ok {
+		return returnFunc(ctx, balanceID, entry)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.AuditEntry) domain.BalanceInspection); ok {
+		r0 = returnFunc(ctx, balanceID, entry)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(domain.BalanceInspection)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.AuditEntry) error); ok {
+		r1 = returnFunc(ctx, balanceID, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_OpenBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBalance'
-type MockStorage_OpenBalance_Call struct {
+// MockAdminStorage_InspectBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InspectBalance'
+type MockAdminStorage_InspectBalance_Call struct {
 	*mock.Call
 }
 
-// OpenBalance is a helper method to define mock.On call
+// InspectBalance is a helper method to define mock.On call
 //   - ctx context.Context
 //   - balanceID uuid.UUID
-func (_e *MockStorage_Expecter) OpenBalance(ctx interface{}, balanceID interface{}) *MockStorage_OpenBalance_Call {
-	return &MockStorage_OpenBalance_Call{Call: _e.mock.On("OpenBalance", ctx, balanceID)}
+//   - entry domain.AuditEntry
+func (_e *MockAdminStorage_Expecter) InspectBalance(ctx interface{}, balanceID interface{}, entry interface{}) *MockAdminStorage_InspectBalance_Call {
+	return &MockAdminStorage_InspectBalance_Call{Call: _e.mock.On("InspectBalance", ctx, balanceID, entry)}
 }
 
-func (_c *MockStorage_OpenBalance_Call) Run(run func(ctx context.Context, balanceID uuid.UUID)) *MockStorage_OpenBalance_Call {
+func (_c *MockAdminStorage_InspectBalance_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, entry domain.AuditEntry)) *MockAdminStorage_InspectBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -207,68 +227,70 @@ func (_c *MockStorage_OpenBalance_Call) Run(run func(ctx context.Context, balanc
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 domain.AuditEntry
+		if args[2] != nil {
+			arg2 = args[2].(domain.AuditEntry)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_OpenBalance_Call) Return(err error) *MockStorage_OpenBalance_Call {
-	_c.Call.Return(err)
+func (_c *MockAdminStorage_InspectBalance_Call) Return(balanceInspection domain.BalanceInspection, err error) *MockAdminStorage_InspectBalance_Call {
+	_c.Call.Return(balanceInspection, err)
 	return _c
 }
 
-func (_c *MockStorage_OpenBalance_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID) error) *MockStorage_OpenBalance_Call {
+func (_c *MockAdminStorage_InspectBalance_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, entry domain.AuditEntry) (domain.BalanceInspection, error)) *MockAdminStorage_InspectBalance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PreviousTxs provides a mock function for the type MockStorage
-func (_mock *MockStorage) PreviousTxs(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, before uuid.UUID, limit int) ([]domain.Tx, error) {
-	ret := _mock.Called(ctx, balanceID, includeDeleted, before, limit)
+// SetBalanceStatus provides a mock function for the type MockAdminStorage
+func (_mock *MockAdminStorage) SetBalanceStatus(ctx context.Context, balanceID uuid.UUID, status domain.BalanceStatus, entry domain.AuditEntry) (domain.Balance, error) {
+	ret := _mock.Called(ctx, balanceID, status, entry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PreviousTxs")
+		panic("no return value specified for SetBalanceStatus")
 	}
 
-	var r0 []domain.Tx
+	var r0 domain.Balance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID, int) ([]domain.Tx, error)); ok {
-		return returnFunc(ctx, balanceID, includeDeleted, before, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.BalanceStatus, domain.AuditEntry) (domain.Balance, error)); ok {
+		return returnFunc(ctx, balanceID, status, entry)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, uuid.UUID, int) []domain.Tx); ok {
-		r0 = returnFunc(ctx, balanceID, includeDeleted, before, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.BalanceStatus, domain.AuditEntry) domain.Balance); ok {
+		r0 = returnFunc(ctx, balanceID, status, entry)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Tx)
-		}
+		r0 = ret.Get(0).(domain.Balance)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, uuid.UUID, int) error); ok {
-		r1 = returnFunc(ctx, balanceID, includeDeleted, before, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.BalanceStatus, domain.AuditEntry) error); ok {
+		r1 = returnFunc(ctx, balanceID, status, entry)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_PreviousTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviousTxs'
-type MockStorage_PreviousTxs_Call struct {
+// MockAdminStorage_SetBalanceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBalanceStatus'
+type MockAdminStorage_SetBalanceStatus_Call struct {
 	*mock.Call
 }
 
-// PreviousTxs is a helper method to define mock.On call
+// SetBalanceStatus is a helper method to define mock.On call
 //   - ctx context.Context
 //   - balanceID uuid.UUID
-//   - includeDeleted bool
-//   - before uuid.UUID
-//   - limit int
-func (_e *MockStorage_Expecter) PreviousTxs(ctx interface{}, balanceID interface{}, includeDeleted interface{}, before interface{}, limit interface{}) *MockStorage_PreviousTxs_Call {
-	return &MockStorage_PreviousTxs_Call{Call: _e.mock.On("PreviousTxs", ctx, balanceID, includeDeleted, before, limit)}
+//   - status domain.BalanceStatus
+//   - entry domain.AuditEntry
+func (_e *MockAdminStorage_Expecter) SetBalanceStatus(ctx interface{}, balanceID interface{}, status interface{}, entry interface{}) *MockAdminStorage_SetBalanceStatus_Call {
+	return &MockAdminStorage_SetBalanceStatus_Call{Call: _e.mock.On("SetBalanceStatus", ctx, balanceID, status, entry)}
 }
 
-func (_c *MockStorage_PreviousTxs_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, before uuid.UUID, limit int)) *MockStorage_PreviousTxs_Call {
+func (_c *MockAdminStorage_SetBalanceStatus_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, status domain.BalanceStatus, entry domain.AuditEntry)) *MockAdminStorage_SetBalanceStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -278,82 +300,168 @@ func (_c *MockStorage_PreviousTxs_Call) Run(run func(ctx context.Context, balanc
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 bool
+		var arg2 domain.BalanceStatus
 		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg2 = args[2].(domain.BalanceStatus)
 		}
-		var arg3 uuid.UUID
+		var arg3 domain.AuditEntry
 		if args[3] != nil {
-			arg3 = args[3].(uuid.UUID)
-		}
-		var arg4 int
-		if args[4] != nil {
-			arg4 = args[4].(int)
+			arg3 = args[3].(domain.AuditEntry)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_PreviousTxs_Call) Return(txs []domain.Tx, err error) *MockStorage_PreviousTxs_Call {
-	_c.Call.Return(txs, err)
+func (_c *MockAdminStorage_SetBalanceStatus_Call) Return(balance domain.Balance, err error) *MockAdminStorage_SetBalanceStatus_Call {
+	_c.Call.Return(balance, err)
 	return _c
 }
 
-func (_c *MockStorage_PreviousTxs_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, before uuid.UUID, limit int) ([]domain.Tx, error)) *MockStorage_PreviousTxs_Call {
+func (_c *MockAdminStorage_SetBalanceStatus_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, status domain.BalanceStatus, entry domain.AuditEntry) (domain.Balance, error)) *MockAdminStorage_SetBalanceStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RecentTxs provides a mock function for the type MockStorage
-func (_mock *MockStorage) RecentTxs(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, limit int) ([]domain.Tx, error) {
-	ret := _mock.Called(ctx, balanceID, includeDeleted, limit)
+// NewMockStorage creates a new instance of MockStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStorage {
+	mock := &MockStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStorage is an autogenerated mock type for the Storage type
+type MockStorage struct {
+	mock.Mock
+}
+
+type MockStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
+	return &MockStorage_Expecter{mock: &_m.Mock}
+}
+
+// Aggregate provides a mock function for the type MockStorage
+func (_mock *MockStorage) Aggregate(ctx context.Context, q domain.AggregateQuery) ([]domain.AggregateRow, error) {
+	ret := _mock.Called(ctx, q)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RecentTxs")
+		panic("no return value specified for Aggregate")
 	}
 
-	var r0 []domain.Tx
+	var r0 []domain.AggregateRow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, int) ([]domain.Tx, error)); ok {
-		return returnFunc(ctx, balanceID, includeDeleted, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AggregateQuery) ([]domain.AggregateRow, error)); ok {
+		return returnFunc(ctx, q)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, int) []domain.Tx); ok {
-		r0 = returnFunc(ctx, balanceID, includeDeleted, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AggregateQuery) []domain.AggregateRow); ok {
+		r0 = returnFunc(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Tx)
+			r0 = ret.Get(0).([]domain.AggregateRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.AggregateQuery) error); ok {
+		r1 = returnFunc(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type MockStorage_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q domain.AggregateQuery
+func (_e *MockStorage_Expecter) Aggregate(ctx interface{}, q interface{}) *MockStorage_Aggregate_Call {
+	return &MockStorage_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, q)}
+}
+
+func (_c *MockStorage_Aggregate_Call) Run(run func(ctx context.Context, q domain.AggregateQuery)) *MockStorage_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.AggregateQuery
+		if args[1] != nil {
+			arg1 = args[1].(domain.AggregateQuery)
 		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Aggregate_Call) Return(aggregateRows []domain.AggregateRow, err error) *MockStorage_Aggregate_Call {
+	_c.Call.Return(aggregateRows, err)
+	return _c
+}
+
+func (_c *MockStorage_Aggregate_Call) RunAndReturn(run func(ctx context.Context, q domain.AggregateQuery) ([]domain.AggregateRow, error)) *MockStorage_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Balance provides a mock function for the type MockStorage
+func (_mock *MockStorage) Balance(ctx context.Context, balanceID uuid.UUID) (domain.Balance, error) {
+	ret := _mock.Called(ctx, balanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Balance")
+	}
+
+	var r0 domain.Balance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Balance, error)); ok {
+		return returnFunc(ctx, balanceID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Balance); ok {
+		r0 = returnFunc(ctx, balanceID)
+	} else {
+		r0 = ret.Get(0).(domain.Balance)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, int) error); ok {
-		r1 = returnFunc(ctx, balanceID, includeDeleted, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, balanceID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_RecentTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentTxs'
-type MockStorage_RecentTxs_Call struct {
+// MockStorage_Balance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Balance'
+type MockStorage_Balance_Call struct {
 	*mock.Call
 }
 
-// RecentTxs is a helper method to define mock.On call
+// Balance is a helper method to define mock.On call
 //   - ctx context.Context
 //   - balanceID uuid.UUID
-//   - includeDeleted bool
-//   - limit int
-func (_e *MockStorage_Expecter) RecentTxs(ctx interface{}, balanceID interface{}, includeDeleted interface{}, limit interface{}) *MockStorage_RecentTxs_Call {
-	return &MockStorage_RecentTxs_Call{Call: _e.mock.On("RecentTxs", ctx, balanceID, includeDeleted, limit)}
+func (_e *MockStorage_Expecter) Balance(ctx interface{}, balanceID interface{}) *MockStorage_Balance_Call {
+	return &MockStorage_Balance_Call{Call: _e.mock.On("Balance", ctx, balanceID)}
 }
 
-func (_c *MockStorage_RecentTxs_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, limit int)) *MockStorage_RecentTxs_Call {
+func (_c *MockStorage_Balance_Call) Run(run func(ctx context.Context, balanceID uuid.UUID)) *MockStorage_Balance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -363,87 +471,1383 @@ func (_c *MockStorage_RecentTxs_Call) Run(run func(ctx context.Context, balanceI
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_RecentTxs_Call) Return(txs []domain.Tx, err error) *MockStorage_RecentTxs_Call {
-	_c.Call.Return(txs, err)
+func (_c *MockStorage_Balance_Call) Return(balance domain.Balance, err error) *MockStorage_Balance_Call {
+	_c.Call.Return(balance, err)
 	return _c
 }
 
-func (_c *MockStorage_RecentTxs_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, includeDeleted bool, limit int) ([]domain.Tx, error)) *MockStorage_RecentTxs_Call {
+func (_c *MockStorage_Balance_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID) (domain.Balance, error)) *MockStorage_Balance_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RecordTx provides a mock function for the type MockStorage
-func (_mock *MockStorage) RecordTx(ctx context.Context, tx domain.Tx) error {
-	ret := _mock.Called(ctx, tx)
+// BalanceEvents provides a mock function for the type MockStorage
+func (_mock *MockStorage) BalanceEvents(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.BalanceEvent, error) {
+	ret := _mock.Called(ctx, balanceID, afterSeq, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RecordTx")
+		panic("no return value specified for BalanceEvents")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Tx) error); ok {
-		r0 = returnFunc(ctx, tx)
+	var r0 []domain.BalanceEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, int) ([]domain.BalanceEvent, error)); ok {
+		return returnFunc(ctx, balanceID, afterSeq, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, int) []domain.BalanceEvent); ok {
+		r0 = returnFunc(ctx, balanceID, afterSeq, limit)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BalanceEvent)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64, int) error); ok {
+		r1 = returnFunc(ctx, balanceID, afterSeq, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_RecordTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordTx'
-type MockStorage_RecordTx_Call struct {
+// MockStorage_BalanceEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BalanceEvents'
+type MockStorage_BalanceEvents_Call struct {
 	*mock.Call
 }
 
-// RecordTx is a helper method to define mock.On call
+// BalanceEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tx domain.Tx
-func (_e *MockStorage_Expecter) RecordTx(ctx interface{}, tx interface{}) *MockStorage_RecordTx_Call {
-	return &MockStorage_RecordTx_Call{Call: _e.mock.On("RecordTx", ctx, tx)}
+//   - balanceID uuid.UUID
+//   - afterSeq int64
+//   - limit int
+func (_e *MockStorage_Expecter) BalanceEvents(ctx interface{}, balanceID interface{}, afterSeq interface{}, limit interface{}) *MockStorage_BalanceEvents_Call {
+	return &MockStorage_BalanceEvents_Call{Call: _e.mock.On("BalanceEvents", ctx, balanceID, afterSeq, limit)}
 }
 
-func (_c *MockStorage_RecordTx_Call) Run(run func(ctx context.Context, tx domain.Tx)) *MockStorage_RecordTx_Call {
+func (_c *MockStorage_BalanceEvents_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int)) *MockStorage_BalanceEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.Tx
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(domain.Tx)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_RecordTx_Call) Return(err error) *MockStorage_RecordTx_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_BalanceEvents_Call) Return(balanceEvents []domain.BalanceEvent, err error) *MockStorage_BalanceEvents_Call {
+	_c.Call.Return(balanceEvents, err)
+	return _c
+}
+
+func (_c *MockStorage_BalanceEvents_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.BalanceEvent, error)) *MockStorage_BalanceEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelTxs provides a mock function for the type MockStorage
+func (_mock *MockStorage) CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID, opts domain.WriteOptions) (domain.CancelOutcome, error) {
+	ret := _mock.Called(ctx, balanceID, txIDs, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelTxs")
+	}
+
+	var r0 domain.CancelOutcome
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID, domain.WriteOptions) (domain.CancelOutcome, error)); ok {
+		return returnFunc(ctx, balanceID, txIDs, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID, domain.WriteOptions) domain.CancelOutcome); ok {
+		r0 = returnFunc(ctx, balanceID, txIDs, opts)
+	} else {
+		r0 = ret.Get(0).(domain.CancelOutcome)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, []uuid.UUID, domain.WriteOptions) error); ok {
+		r1 = returnFunc(ctx, balanceID, txIDs, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_CancelTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTxs'
+type MockStorage_CancelTxs_Call struct {
+	*mock.Call
+}
+
+// CancelTxs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+//   - txIDs []uuid.UUID
+//   - opts domain.WriteOptions
+func (_e *MockStorage_Expecter) CancelTxs(ctx interface{}, balanceID interface{}, txIDs interface{}, opts interface{}) *MockStorage_CancelTxs_Call {
+	return &MockStorage_CancelTxs_Call{Call: _e.mock.On("CancelTxs", ctx, balanceID, txIDs, opts)}
+}
+
+func (_c *MockStorage_CancelTxs_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID, opts domain.WriteOptions)) *MockStorage_CancelTxs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].([]uuid.UUID)
+		}
+		var arg3 domain.WriteOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.WriteOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CancelTxs_Call) Return(cancelOutcome domain.CancelOutcome, err error) *MockStorage_CancelTxs_Call {
+	_c.Call.Return(cancelOutcome, err)
+	return _c
+}
+
+func (_c *MockStorage_CancelTxs_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID, opts domain.WriteOptions) (domain.CancelOutcome, error)) *MockStorage_CancelTxs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cancellation provides a mock function for the type MockStorage
+func (_mock *MockStorage) Cancellation(ctx context.Context, cancellationID uuid.UUID) (domain.Cancellation, error) {
+	ret := _mock.Called(ctx, cancellationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancellation")
+	}
+
+	var r0 domain.Cancellation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Cancellation, error)); ok {
+		return returnFunc(ctx, cancellationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Cancellation); ok {
+		r0 = returnFunc(ctx, cancellationID)
+	} else {
+		r0 = ret.Get(0).(domain.Cancellation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, cancellationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Cancellation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancellation'
+type MockStorage_Cancellation_Call struct {
+	*mock.Call
+}
+
+// Cancellation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cancellationID uuid.UUID
+func (_e *MockStorage_Expecter) Cancellation(ctx interface{}, cancellationID interface{}) *MockStorage_Cancellation_Call {
+	return &MockStorage_Cancellation_Call{Call: _e.mock.On("Cancellation", ctx, cancellationID)}
+}
+
+func (_c *MockStorage_Cancellation_Call) Run(run func(ctx context.Context, cancellationID uuid.UUID)) *MockStorage_Cancellation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Cancellation_Call) Return(cancellation domain.Cancellation, err error) *MockStorage_Cancellation_Call {
+	_c.Call.Return(cancellation, err)
+	return _c
+}
+
+func (_c *MockStorage_Cancellation_Call) RunAndReturn(run func(ctx context.Context, cancellationID uuid.UUID) (domain.Cancellation, error)) *MockStorage_Cancellation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancellationBalances provides a mock function for the type MockStorage
+func (_mock *MockStorage) CancellationBalances(ctx context.Context, cancellationID uuid.UUID, afterBalanceID *uuid.UUID, limit int) ([]domain.BalanceCancellation, error) {
+	ret := _mock.Called(ctx, cancellationID, afterBalanceID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancellationBalances")
+	}
+
+	var r0 []domain.BalanceCancellation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, int) ([]domain.BalanceCancellation, error)); ok {
+		return returnFunc(ctx, cancellationID, afterBalanceID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID, int) []domain.BalanceCancellation); ok {
+		r0 = returnFunc(ctx, cancellationID, afterBalanceID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BalanceCancellation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *uuid.UUID, int) error); ok {
+		r1 = returnFunc(ctx, cancellationID, afterBalanceID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_CancellationBalances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancellationBalances'
+type MockStorage_CancellationBalances_Call struct {
+	*mock.Call
+}
+
+// CancellationBalances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cancellationID uuid.UUID
+//   - afterBalanceID *uuid.UUID
+//   - limit int
+func (_e *MockStorage_Expecter) CancellationBalances(ctx interface{}, cancellationID interface{}, afterBalanceID interface{}, limit interface{}) *MockStorage_CancellationBalances_Call {
+	return &MockStorage_CancellationBalances_Call{Call: _e.mock.On("CancellationBalances", ctx, cancellationID, afterBalanceID, limit)}
+}
+
+func (_c *MockStorage_CancellationBalances_Call) Run(run func(ctx context.Context, cancellationID uuid.UUID, afterBalanceID *uuid.UUID, limit int)) *MockStorage_CancellationBalances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(*uuid.UUID)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CancellationBalances_Call) Return(balanceCancellations []domain.BalanceCancellation, err error) *MockStorage_CancellationBalances_Call {
+	_c.Call.Return(balanceCancellations, err)
+	return _c
+}
+
+func (_c *MockStorage_CancellationBalances_Call) RunAndReturn(run func(ctx context.Context, cancellationID uuid.UUID, afterBalanceID *uuid.UUID, limit int) ([]domain.BalanceCancellation, error)) *MockStorage_CancellationBalances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Chargeback provides a mock function for the type MockStorage
+func (_mock *MockStorage) Chargeback(ctx context.Context, balanceID uuid.UUID, txID uuid.UUID, reason *string) (domain.ChargebackOutcome, error) {
+	ret := _mock.Called(ctx, balanceID, txID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Chargeback")
+	}
+
+	var r0 domain.ChargebackOutcome
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *string) (domain.ChargebackOutcome, error)); ok {
+		return returnFunc(ctx, balanceID, txID, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, *string) domain.ChargebackOutcome); ok {
+		r0 = returnFunc(ctx, balanceID, txID, reason)
+	} else {
+		r0 = ret.Get(0).(domain.ChargebackOutcome)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, *string) error); ok {
+		r1 = returnFunc(ctx, balanceID, txID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Chargeback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Chargeback'
+type MockStorage_Chargeback_Call struct {
+	*mock.Call
+}
+
+// Chargeback is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+//   - txID uuid.UUID
+//   - reason *string
+func (_e *MockStorage_Expecter) Chargeback(ctx interface{}, balanceID interface{}, txID interface{}, reason interface{}) *MockStorage_Chargeback_Call {
+	return &MockStorage_Chargeback_Call{Call: _e.mock.On("Chargeback", ctx, balanceID, txID, reason)}
+}
+
+func (_c *MockStorage_Chargeback_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, txID uuid.UUID, reason *string)) *MockStorage_Chargeback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 *string
+		if args[3] != nil {
+			arg3 = args[3].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Chargeback_Call) Return(chargebackOutcome domain.ChargebackOutcome, err error) *MockStorage_Chargeback_Call {
+	_c.Call.Return(chargebackOutcome, err)
+	return _c
+}
+
+func (_c *MockStorage_Chargeback_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, txID uuid.UUID, reason *string) (domain.ChargebackOutcome, error)) *MockStorage_Chargeback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DebtEntries provides a mock function for the type MockStorage
+func (_mock *MockStorage) DebtEntries(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.DebtEntry, error) {
+	ret := _mock.Called(ctx, balanceID, afterSeq, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DebtEntries")
+	}
+
+	var r0 []domain.DebtEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, int) ([]domain.DebtEntry, error)); ok {
+		return returnFunc(ctx, balanceID, afterSeq, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, int) []domain.DebtEntry); ok {
+		r0 = returnFunc(ctx, balanceID, afterSeq, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DebtEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64, int) error); ok {
+		r1 = returnFunc(ctx, balanceID, afterSeq, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_DebtEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DebtEntries'
+type MockStorage_DebtEntries_Call struct {
+	*mock.Call
+}
+
+// DebtEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+//   - afterSeq int64
+//   - limit int
+func (_e *MockStorage_Expecter) DebtEntries(ctx interface{}, balanceID interface{}, afterSeq interface{}, limit interface{}) *MockStorage_DebtEntries_Call {
+	return &MockStorage_DebtEntries_Call{Call: _e.mock.On("DebtEntries", ctx, balanceID, afterSeq, limit)}
+}
+
+func (_c *MockStorage_DebtEntries_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int)) *MockStorage_DebtEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DebtEntries_Call) Return(debtEntrys []domain.DebtEntry, err error) *MockStorage_DebtEntries_Call {
+	_c.Call.Return(debtEntrys, err)
+	return _c
+}
+
+func (_c *MockStorage_DebtEntries_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.DebtEntry, error)) *MockStorage_DebtEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportStatement provides a mock function for the type MockStorage
+func (_mock *MockStorage) ExportStatement(ctx context.Context, balanceID uuid.UUID, from *time.Time, to *time.Time, fn func(domain.StatementEntry) error) error {
+	ret := _mock.Called(ctx, balanceID, from, to, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportStatement")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time, *time.Time, func(domain.StatementEntry) error) error); ok {
+		r0 = returnFunc(ctx, balanceID, from, to, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_ExportStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportStatement'
+type MockStorage_ExportStatement_Call struct {
+	*mock.Call
+}
+
+// ExportStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+//   - from *time.Time
+//   - to *time.Time
+//   - fn func(domain.StatementEntry) error
+func (_e *MockStorage_Expecter) ExportStatement(ctx interface{}, balanceID interface{}, from interface{}, to interface{}, fn interface{}) *MockStorage_ExportStatement_Call {
+	return &MockStorage_ExportStatement_Call{Call: _e.mock.On("ExportStatement", ctx, balanceID, from, to, fn)}
+}
+
+func (_c *MockStorage_ExportStatement_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, from *time.Time, to *time.Time, fn func(domain.StatementEntry) error)) *MockStorage_ExportStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		var arg3 *time.Time
+		if args[3] != nil {
+			arg3 = args[3].(*time.Time)
+		}
+		var arg4 func(domain.StatementEntry) error
+		if args[4] != nil {
+			arg4 = args[4].(func(domain.StatementEntry) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ExportStatement_Call) Return(err error) *MockStorage_ExportStatement_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_ExportStatement_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, from *time.Time, to *time.Time, fn func(domain.StatementEntry) error) error) *MockStorage_ExportStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBalances provides a mock function for the type MockStorage
+func (_mock *MockStorage) ListBalances(ctx context.Context, filter domain.BalanceFilter, sort domain.BalanceSort, after *domain.BalancePosition, limit int) ([]domain.Balance, *domain.BalancePosition, error) {
+	ret := _mock.Called(ctx, filter, sort, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBalances")
+	}
+
+	var r0 []domain.Balance
+	var r1 *domain.BalancePosition
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.BalanceFilter, domain.BalanceSort, *domain.BalancePosition, int) ([]domain.Balance, *domain.BalancePosition, error)); ok {
+		return returnFunc(ctx, filter, sort, after, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.BalanceFilter, domain.BalanceSort, *domain.BalancePosition, int) []domain.Balance); ok {
+		r0 = returnFunc(ctx, filter, sort, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Balance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.BalanceFilter, domain.BalanceSort, *domain.BalancePosition, int) *domain.BalancePosition); ok {
+		r1 = returnFunc(ctx, filter, sort, after, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.BalancePosition)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.BalanceFilter, domain.BalanceSort, *domain.BalancePosition, int) error); ok {
+		r2 = returnFunc(ctx, filter, sort, after, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStorage_ListBalances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBalances'
+type MockStorage_ListBalances_Call struct {
+	*mock.Call
+}
+
+// ListBalances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BalanceFilter
+//   - sort domain.BalanceSort
+//   - after *domain.BalancePosition
+//   - limit int
+func (_e *MockStorage_Expecter) ListBalances(ctx interface{}, filter interface{}, sort interface{}, after interface{}, limit interface{}) *MockStorage_ListBalances_Call {
+	return &MockStorage_ListBalances_Call{Call: _e.mock.On("ListBalances", ctx, filter, sort, after, limit)}
+}
+
+func (_c *MockStorage_ListBalances_Call) Run(run func(ctx context.Context, filter domain.BalanceFilter, sort domain.BalanceSort, after *domain.BalancePosition, limit int)) *MockStorage_ListBalances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.BalanceFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.BalanceFilter)
+		}
+		var arg2 domain.BalanceSort
+		if args[2] != nil {
+			arg2 = args[2].(domain.BalanceSort)
+		}
+		var arg3 *domain.BalancePosition
+		if args[3] != nil {
+			arg3 = args[3].(*domain.BalancePosition)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ListBalances_Call) Return(balances []domain.Balance, balancePosition *domain.BalancePosition, err error) *MockStorage_ListBalances_Call {
+	_c.Call.Return(balances, balancePosition, err)
+	return _c
+}
+
+func (_c *MockStorage_ListBalances_Call) RunAndReturn(run func(ctx context.Context, filter domain.BalanceFilter, sort domain.BalanceSort, after *domain.BalancePosition, limit int) ([]domain.Balance, *domain.BalancePosition, error)) *MockStorage_ListBalances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTxs provides a mock function for the type MockStorage
+func (_mock *MockStorage) ListTxs(ctx context.Context, balanceID uuid.UUID, filter domain.TxFilter, order domain.TxOrder, afterSeq *int64, limit int) ([]domain.Tx, error) {
+	ret := _mock.Called(ctx, balanceID, filter, order, afterSeq, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTxs")
+	}
+
+	var r0 []domain.Tx
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.TxFilter, domain.TxOrder, *int64, int) ([]domain.Tx, error)); ok {
+		return returnFunc(ctx, balanceID, filter, order, afterSeq, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.TxFilter, domain.TxOrder, *int64, int) []domain.Tx); ok {
+		r0 = returnFunc(ctx, balanceID, filter, order, afterSeq, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tx)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain.TxFilter, domain.TxOrder, *int64, int) error); ok {
+		r1 = returnFunc(ctx, balanceID, filter, order, afterSeq, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ListTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTxs'
+type MockStorage_ListTxs_Call struct {
+	*mock.Call
+}
+
+// ListTxs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+//   - filter domain.TxFilter
+//   - order domain.TxOrder
+//   - afterSeq *int64
+//   - limit int
+func (_e *MockStorage_Expecter) ListTxs(ctx interface{}, balanceID interface{}, filter interface{}, order interface{}, afterSeq interface{}, limit interface{}) *MockStorage_ListTxs_Call {
+	return &MockStorage_ListTxs_Call{Call: _e.mock.On("ListTxs", ctx, balanceID, filter, order, afterSeq, limit)}
+}
+
+func (_c *MockStorage_ListTxs_Call) Run(run func(ctx context.Context, balanceID uuid.UUID, filter domain.TxFilter, order domain.TxOrder, afterSeq *int64, limit int)) *MockStorage_ListTxs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.TxFilter
+		if args[2] != nil {
+			arg2 = args[2].(domain.TxFilter)
+		}
+		var arg3 domain.TxOrder
+		if args[3] != nil {
+			arg3 = args[3].(domain.TxOrder)
+		}
+		var arg4 *int64
+		if args[4] != nil {
+			arg4 = args[4].(*int64)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ListTxs_Call) Return(txs []domain.Tx, err error) *MockStorage_ListTxs_Call {
+	_c.Call.Return(txs, err)
+	return _c
+}
+
+func (_c *MockStorage_ListTxs_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID, filter domain.TxFilter, order domain.TxOrder, afterSeq *int64, limit int) ([]domain.Tx, error)) *MockStorage_ListTxs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenBalance provides a mock function for the type MockStorage
+func (_mock *MockStorage) OpenBalance(ctx context.Context, balance domain.Balance) (domain.Balance, error) {
+	ret := _mock.Called(ctx, balance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBalance")
+	}
+
+	var r0 domain.Balance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Balance) (domain.Balance, error)); ok {
+		return returnFunc(ctx, balance)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Balance) domain.Balance); ok {
+		r0 = returnFunc(ctx, balance)
+	} else {
+		r0 = ret.Get(0).(domain.Balance)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Balance) error); ok {
+		r1 = returnFunc(ctx, balance)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_OpenBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBalance'
+type MockStorage_OpenBalance_Call struct {
+	*mock.Call
+}
+
+// OpenBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balance domain.Balance
+func (_e *MockStorage_Expecter) OpenBalance(ctx interface{}, balance interface{}) *MockStorage_OpenBalance_Call {
+	return &MockStorage_OpenBalance_Call{Call: _e.mock.On("OpenBalance", ctx, balance)}
+}
+
+func (_c *MockStorage_OpenBalance_Call) Run(run func(ctx context.Context, balance domain.Balance)) *MockStorage_OpenBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Balance
+		if args[1] != nil {
+			arg1 = args[1].(domain.Balance)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_OpenBalance_Call) Return(balance1 domain.Balance, err error) *MockStorage_OpenBalance_Call {
+	_c.Call.Return(balance1, err)
+	return _c
+}
+
+func (_c *MockStorage_OpenBalance_Call) RunAndReturn(run func(ctx context.Context, balance domain.Balance) (domain.Balance, error)) *MockStorage_OpenBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviousFlaggedEvents provides a mock function for the type MockStorage
+func (_mock *MockStorage) PreviousFlaggedEvents(ctx context.Context, balanceID *uuid.UUID, before uuid.UUID, limit int) ([]domain.FlaggedEvent, error) {
+	ret := _mock.Called(ctx, balanceID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviousFlaggedEvents")
+	}
+
+	var r0 []domain.FlaggedEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uuid.UUID, uuid.UUID, int) ([]domain.FlaggedEvent, error)); ok {
+		return returnFunc(ctx, balanceID, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uuid.UUID, uuid.UUID, int) []domain.FlaggedEvent); ok {
+		r0 = returnFunc(ctx, balanceID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FlaggedEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *uuid.UUID, uuid.UUID, int) error); ok {
+		r1 = returnFunc(ctx, balanceID, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_PreviousFlaggedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviousFlaggedEvents'
+type MockStorage_PreviousFlaggedEvents_Call struct {
+	*mock.Call
+}
+
+// PreviousFlaggedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID *uuid.UUID
+//   - before uuid.UUID
+//   - limit int
+func (_e *MockStorage_Expecter) PreviousFlaggedEvents(ctx interface{}, balanceID interface{}, before interface{}, limit interface{}) *MockStorage_PreviousFlaggedEvents_Call {
+	return &MockStorage_PreviousFlaggedEvents_Call{Call: _e.mock.On("PreviousFlaggedEvents", ctx, balanceID, before, limit)}
+}
+
+func (_c *MockStorage_PreviousFlaggedEvents_Call) Run(run func(ctx context.Context, balanceID *uuid.UUID, before uuid.UUID, limit int)) *MockStorage_PreviousFlaggedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(*uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_PreviousFlaggedEvents_Call) Return(flaggedEvents []domain.FlaggedEvent, err error) *MockStorage_PreviousFlaggedEvents_Call {
+	_c.Call.Return(flaggedEvents, err)
+	return _c
+}
+
+func (_c *MockStorage_PreviousFlaggedEvents_Call) RunAndReturn(run func(ctx context.Context, balanceID *uuid.UUID, before uuid.UUID, limit int) ([]domain.FlaggedEvent, error)) *MockStorage_PreviousFlaggedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecentFlaggedEvents provides a mock function for the type MockStorage
+func (_mock *MockStorage) RecentFlaggedEvents(ctx context.Context, balanceID *uuid.UUID, limit int) ([]domain.FlaggedEvent, error) {
+	ret := _mock.Called(ctx, balanceID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentFlaggedEvents")
+	}
+
+	var r0 []domain.FlaggedEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uuid.UUID, int) ([]domain.FlaggedEvent, error)); ok {
+		return returnFunc(ctx, balanceID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uuid.UUID, int) []domain.FlaggedEvent); ok {
+		r0 = returnFunc(ctx, balanceID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FlaggedEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *uuid.UUID, int) error); ok {
+		r1 = returnFunc(ctx, balanceID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_RecentFlaggedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentFlaggedEvents'
+type MockStorage_RecentFlaggedEvents_Call struct {
+	*mock.Call
+}
+
+// RecentFlaggedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID *uuid.UUID
+//   - limit int
+func (_e *MockStorage_Expecter) RecentFlaggedEvents(ctx interface{}, balanceID interface{}, limit interface{}) *MockStorage_RecentFlaggedEvents_Call {
+	return &MockStorage_RecentFlaggedEvents_Call{Call: _e.mock.On("RecentFlaggedEvents", ctx, balanceID, limit)}
+}
+
+func (_c *MockStorage_RecentFlaggedEvents_Call) Run(run func(ctx context.Context, balanceID *uuid.UUID, limit int)) *MockStorage_RecentFlaggedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(*uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_RecentFlaggedEvents_Call) Return(flaggedEvents []domain.FlaggedEvent, err error) *MockStorage_RecentFlaggedEvents_Call {
+	_c.Call.Return(flaggedEvents, err)
+	return _c
+}
+
+func (_c *MockStorage_RecentFlaggedEvents_Call) RunAndReturn(run func(ctx context.Context, balanceID *uuid.UUID, limit int) ([]domain.FlaggedEvent, error)) *MockStorage_RecentFlaggedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordTx provides a mock function for the type MockStorage
+func (_mock *MockStorage) RecordTx(ctx context.Context, tx domain.Tx, opts domain.WriteOptions) (domain.RecordOutcome, error) {
+	ret := _mock.Called(ctx, tx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordTx")
+	}
+
+	var r0 domain.RecordOutcome
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Tx, domain.WriteOptions) (domain.RecordOutcome, error)); ok {
+		return returnFunc(ctx, tx, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Tx, domain.WriteOptions) domain.RecordOutcome); ok {
+		r0 = returnFunc(ctx, tx, opts)
+	} else {
+		r0 = ret.Get(0).(domain.RecordOutcome)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Tx, domain.WriteOptions) error); ok {
+		r1 = returnFunc(ctx, tx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_RecordTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordTx'
+type MockStorage_RecordTx_Call struct {
+	*mock.Call
+}
+
+// RecordTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx domain.Tx
+//   - opts domain.WriteOptions
+func (_e *MockStorage_Expecter) RecordTx(ctx interface{}, tx interface{}, opts interface{}) *MockStorage_RecordTx_Call {
+	return &MockStorage_RecordTx_Call{Call: _e.mock.On("RecordTx", ctx, tx, opts)}
+}
+
+func (_c *MockStorage_RecordTx_Call) Run(run func(ctx context.Context, tx domain.Tx, opts domain.WriteOptions)) *MockStorage_RecordTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Tx
+		if args[1] != nil {
+			arg1 = args[1].(domain.Tx)
+		}
+		var arg2 domain.WriteOptions
+		if args[2] != nil {
+			arg2 = args[2].(domain.WriteOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_RecordTx_Call) Return(recordOutcome domain.RecordOutcome, err error) *MockStorage_RecordTx_Call {
+	_c.Call.Return(recordOutcome, err)
+	return _c
+}
+
+func (_c *MockStorage_RecordTx_Call) RunAndReturn(run func(ctx context.Context, tx domain.Tx, opts domain.WriteOptions) (domain.RecordOutcome, error)) *MockStorage_RecordTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelatedTxs provides a mock function for the type MockStorage
+func (_mock *MockStorage) RelatedTxs(ctx context.Context, txID uuid.UUID) ([]domain.Tx, error) {
+	ret := _mock.Called(ctx, txID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelatedTxs")
+	}
+
+	var r0 []domain.Tx
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Tx, error)); ok {
+		return returnFunc(ctx, txID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Tx); ok {
+		r0 = returnFunc(ctx, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tx)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_RelatedTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelatedTxs'
+type MockStorage_RelatedTxs_Call struct {
+	*mock.Call
+}
+
+// RelatedTxs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txID uuid.UUID
+func (_e *MockStorage_Expecter) RelatedTxs(ctx interface{}, txID interface{}) *MockStorage_RelatedTxs_Call {
+	return &MockStorage_RelatedTxs_Call{Call: _e.mock.On("RelatedTxs", ctx, txID)}
+}
+
+func (_c *MockStorage_RelatedTxs_Call) Run(run func(ctx context.Context, txID uuid.UUID)) *MockStorage_RelatedTxs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_RelatedTxs_Call) Return(txs []domain.Tx, err error) *MockStorage_RelatedTxs_Call {
+	_c.Call.Return(txs, err)
+	return _c
+}
+
+func (_c *MockStorage_RelatedTxs_Call) RunAndReturn(run func(ctx context.Context, txID uuid.UUID) ([]domain.Tx, error)) *MockStorage_RelatedTxs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartCancellation provides a mock function for the type MockStorage
+func (_mock *MockStorage) StartCancellation(ctx context.Context, criteria domain.CancellationCriteria, policy domain.NegativePolicy) (domain.Cancellation, error) {
+	ret := _mock.Called(ctx, criteria, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartCancellation")
+	}
+
+	var r0 domain.Cancellation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CancellationCriteria, domain.NegativePolicy) (domain.Cancellation, error)); ok {
+		return returnFunc(ctx, criteria, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.CancellationCriteria, domain.NegativePolicy) domain.Cancellation); ok {
+		r0 = returnFunc(ctx, criteria, policy)
+	} else {
+		r0 = ret.Get(0).(domain.Cancellation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.CancellationCriteria, domain.NegativePolicy) error); ok {
+		r1 = returnFunc(ctx, criteria, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_StartCancellation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartCancellation'
+type MockStorage_StartCancellation_Call struct {
+	*mock.Call
+}
+
+// StartCancellation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - criteria domain.CancellationCriteria
+//   - policy domain.NegativePolicy
+func (_e *MockStorage_Expecter) StartCancellation(ctx interface{}, criteria interface{}, policy interface{}) *MockStorage_StartCancellation_Call {
+	return &MockStorage_StartCancellation_Call{Call: _e.mock.On("StartCancellation", ctx, criteria, policy)}
+}
+
+func (_c *MockStorage_StartCancellation_Call) Run(run func(ctx context.Context, criteria domain.CancellationCriteria, policy domain.NegativePolicy)) *MockStorage_StartCancellation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.CancellationCriteria
+		if args[1] != nil {
+			arg1 = args[1].(domain.CancellationCriteria)
+		}
+		var arg2 domain.NegativePolicy
+		if args[2] != nil {
+			arg2 = args[2].(domain.NegativePolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_StartCancellation_Call) Return(cancellation domain.Cancellation, err error) *MockStorage_StartCancellation_Call {
+	_c.Call.Return(cancellation, err)
+	return _c
+}
+
+func (_c *MockStorage_StartCancellation_Call) RunAndReturn(run func(ctx context.Context, criteria domain.CancellationCriteria, policy domain.NegativePolicy) (domain.Cancellation, error)) *MockStorage_StartCancellation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function for the type MockStorage
+func (_mock *MockStorage) Subscribe(ctx context.Context, balanceID uuid.UUID) (<-chan struct{}, func(), error) {
+	ret := _mock.Called(ctx, balanceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan struct{}
+	var r1 func()
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (<-chan struct{}, func(), error)); ok {
+		return returnFunc(ctx, balanceID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) <-chan struct{}); ok {
+		r0 = returnFunc(ctx, balanceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) func()); ok {
+		r1 = returnFunc(ctx, balanceID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uuid.UUID) error); ok {
+		r2 = returnFunc(ctx, balanceID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStorage_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockStorage_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - balanceID uuid.UUID
+func (_e *MockStorage_Expecter) Subscribe(ctx interface{}, balanceID interface{}) *MockStorage_Subscribe_Call {
+	return &MockStorage_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, balanceID)}
+}
+
+func (_c *MockStorage_Subscribe_Call) Run(run func(ctx context.Context, balanceID uuid.UUID)) *MockStorage_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Subscribe_Call) Return(valCh <-chan struct{}, fn func(), err error) *MockStorage_Subscribe_Call {
+	_c.Call.Return(valCh, fn, err)
+	return _c
+}
+
+func (_c *MockStorage_Subscribe_Call) RunAndReturn(run func(ctx context.Context, balanceID uuid.UUID) (<-chan struct{}, func(), error)) *MockStorage_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tx provides a mock function for the type MockStorage
+func (_mock *MockStorage) Tx(ctx context.Context, txID uuid.UUID) (domain.Tx, error) {
+	ret := _mock.Called(ctx, txID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tx")
+	}
+
+	var r0 domain.Tx
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Tx, error)); ok {
+		return returnFunc(ctx, txID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Tx); ok {
+		r0 = returnFunc(ctx, txID)
+	} else {
+		r0 = ret.Get(0).(domain.Tx)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Tx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tx'
+type MockStorage_Tx_Call struct {
+	*mock.Call
+}
+
+// Tx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txID uuid.UUID
+func (_e *MockStorage_Expecter) Tx(ctx interface{}, txID interface{}) *MockStorage_Tx_Call {
+	return &MockStorage_Tx_Call{Call: _e.mock.On("Tx", ctx, txID)}
+}
+
+func (_c *MockStorage_Tx_Call) Run(run func(ctx context.Context, txID uuid.UUID)) *MockStorage_Tx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Tx_Call) Return(tx domain.Tx, err error) *MockStorage_Tx_Call {
+	_c.Call.Return(tx, err)
+	return _c
+}
+
+func (_c *MockStorage_Tx_Call) RunAndReturn(run func(ctx context.Context, txID uuid.UUID) (domain.Tx, error)) *MockStorage_Tx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TxByExternalRef provides a mock function for the type MockStorage
+func (_mock *MockStorage) TxByExternalRef(ctx context.Context, externalRef string) (domain.Tx, error) {
+	ret := _mock.Called(ctx, externalRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TxByExternalRef")
+	}
+
+	var r0 domain.Tx
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Tx, error)); ok {
+		return returnFunc(ctx, externalRef)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Tx); ok {
+		r0 = returnFunc(ctx, externalRef)
+	} else {
+		r0 = ret.Get(0).(domain.Tx)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalRef)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_TxByExternalRef_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TxByExternalRef'
+type MockStorage_TxByExternalRef_Call struct {
+	*mock.Call
+}
+
+// TxByExternalRef is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalRef string
+func (_e *MockStorage_Expecter) TxByExternalRef(ctx interface{}, externalRef interface{}) *MockStorage_TxByExternalRef_Call {
+	return &MockStorage_TxByExternalRef_Call{Call: _e.mock.On("TxByExternalRef", ctx, externalRef)}
+}
+
+func (_c *MockStorage_TxByExternalRef_Call) Run(run func(ctx context.Context, externalRef string)) *MockStorage_TxByExternalRef_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_TxByExternalRef_Call) Return(tx domain.Tx, err error) *MockStorage_TxByExternalRef_Call {
+	_c.Call.Return(tx, err)
 	return _c
 }
 
-func (_c *MockStorage_RecordTx_Call) RunAndReturn(run func(ctx context.Context, tx domain.Tx) error) *MockStorage_RecordTx_Call {
+func (_c *MockStorage_TxByExternalRef_Call) RunAndReturn(run func(ctx context.Context, externalRef string) (domain.Tx, error)) *MockStorage_TxByExternalRef_Call {
 	_c.Call.Return(run)
 	return _c
 }
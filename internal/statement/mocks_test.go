@@ -0,0 +1,132 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package statement
+
+import (
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWriter creates a new instance of MockWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWriter {
+	mock := &MockWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWriter is an autogenerated mock type for the Writer type
+type MockWriter struct {
+	mock.Mock
+}
+
+type MockWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWriter) EXPECT() *MockWriter_Expecter {
+	return &MockWriter_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function for the type MockWriter
+func (_mock *MockWriter) Flush() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWriter_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockWriter_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *MockWriter_Expecter) Flush() *MockWriter_Flush_Call {
+	return &MockWriter_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *MockWriter_Flush_Call) Run(run func()) *MockWriter_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWriter_Flush_Call) Return(err error) *MockWriter_Flush_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWriter_Flush_Call) RunAndReturn(run func() error) *MockWriter_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function for the type MockWriter
+func (_mock *MockWriter) Write(e domain.StatementEntry) error {
+	ret := _mock.Called(e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(domain.StatementEntry) error); ok {
+		r0 = returnFunc(e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWriter_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type MockWriter_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - e domain.StatementEntry
+func (_e *MockWriter_Expecter) Write(e interface{}) *MockWriter_Write_Call {
+	return &MockWriter_Write_Call{Call: _e.mock.On("Write", e)}
+}
+
+func (_c *MockWriter_Write_Call) Run(run func(e domain.StatementEntry)) *MockWriter_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.StatementEntry
+		if args[0] != nil {
+			arg0 = args[0].(domain.StatementEntry)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWriter_Write_Call) Return(err error) *MockWriter_Write_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWriter_Write_Call) RunAndReturn(run func(e domain.StatementEntry) error) *MockWriter_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,132 @@
+// Package statement writes balance statements in export formats.
+package statement
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+var csvHeader = []string{"at", "seq", "kind", "tx_id", "external_ref", "source", "state", "amount", "change", "running_balance"}
+
+type Writer interface {
+	Write(e domain.StatementEntry) error
+	// Flush writes buffered entries, it must be called after the last entry.
+	Flush() error
+}
+
+func NewWriter(w io.Writer, format domain.StatementFormat) (Writer, error) {
+	switch format {
+	case domain.StatementFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return nil, fmt.Errorf("write header: %w", err)
+		}
+
+		return &csvWriter{w: cw}, nil
+	case domain.StatementFormatJSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, format)
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format domain.StatementFormat) string {
+	switch format {
+	case domain.StatementFormatCSV:
+		return "text/csv"
+	case domain.StatementFormatJSONL:
+		return "application/jsonl"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileExtension returns the file extension of the format without a dot.
+func FileExtension(format domain.StatementFormat) string {
+	return strings.ToLower(format.String())
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(e domain.StatementEntry) error {
+	var externalRef string
+	if e.ExternalRef != nil {
+		externalRef = *e.ExternalRef
+	}
+
+	return c.w.Write([]string{
+		e.At.UTC().Format(time.RFC3339Nano),
+		strconv.FormatInt(e.Seq, 10),
+		kind(e),
+		e.TxID.String(),
+		externalRef,
+		strings.ToLower(e.Source.String()),
+		strings.ToLower(e.State.String()),
+		e.Amount.String(),
+		e.Change.String(),
+		e.RunningBalance.String(),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlEntry uses the same names and lowercase enums as CSV columns.
+type jsonlEntry struct {
+	At             time.Time `json:"at"`
+	Seq            int64     `json:"seq"`
+	Kind           string    `json:"kind"`
+	TxID           string    `json:"tx_id"`
+	ExternalRef    *string   `json:"external_ref,omitempty"`
+	Source         string    `json:"source"`
+	State          string    `json:"state"`
+	Amount         string    `json:"amount"`
+	Change         string    `json:"change"`
+	RunningBalance string    `json:"running_balance"`
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlWriter) Write(e domain.StatementEntry) error {
+	return j.enc.Encode(jsonlEntry{
+		At:             e.At.UTC(),
+		Seq:            e.Seq,
+		Kind:           kind(e),
+		TxID:           e.TxID.String(),
+		ExternalRef:    e.ExternalRef,
+		Source:         strings.ToLower(e.Source.String()),
+		State:          strings.ToLower(e.State.String()),
+		Amount:         e.Amount.String(),
+		Change:         e.Change.String(),
+		RunningBalance: e.RunningBalance.String(),
+	})
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
+
+func kind(e domain.StatementEntry) string {
+	if e.Cancellation {
+		return "cancellation"
+	}
+
+	return "tx"
+}
@@ -0,0 +1,129 @@
+package statement_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/statement"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	txID := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	externalRef := "round-1"
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	entries := []domain.StatementEntry{
+		{
+			At:             at,
+			Seq:            1,
+			TxID:           txID,
+			ExternalRef:    &externalRef,
+			Source:         domain.SourceGame,
+			State:          domain.StateDeposit,
+			Amount:         decimal.RequireFromString("10.15"),
+			Change:         decimal.RequireFromString("10.15"),
+			RunningBalance: decimal.RequireFromString("10.15"),
+		},
+		{
+			At:             at.Add(time.Minute),
+			Seq:            1,
+			Cancellation:   true,
+			TxID:           txID,
+			Source:         domain.SourceGame,
+			State:          domain.StateDeposit,
+			Amount:         decimal.RequireFromString("10.15"),
+			Change:         decimal.RequireFromString("-10.15"),
+			RunningBalance: decimal.Zero,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   domain.StatementFormat
+		expected string
+	}{
+		{
+			name:   "csv",
+			format: domain.StatementFormatCSV,
+			expected: "at,seq,kind,tx_id,external_ref,source,state,amount,change,running_balance\n" +
+				"2024-01-02T03:04:05Z,1,tx,01890a5d-ac96-774b-bcce-b302099a8057,round-1,game,deposit,10.15,10.15,10.15\n" +
+				"2024-01-02T03:05:05Z,1,cancellation,01890a5d-ac96-774b-bcce-b302099a8057,,game,deposit,10.15,-10.15,0\n",
+		},
+		{
+			name:   "jsonl",
+			format: domain.StatementFormatJSONL,
+			expected: `{"at":"2024-01-02T03:04:05Z","seq":1,"kind":"tx","tx_id":"01890a5d-ac96-774b-bcce-b302099a8057","external_ref":"round-1","source":"game","state":"deposit","amount":"10.15","change":"10.15","running_balance":"10.15"}` + "\n" +
+				`{"at":"2024-01-02T03:05:05Z","seq":1,"kind":"cancellation","tx_id":"01890a5d-ac96-774b-bcce-b302099a8057","source":"game","state":"deposit","amount":"10.15","change":"-10.15","running_balance":"0"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := statement.NewWriter(&buf, tt.format)
+			require.NoError(t, err)
+
+			for _, e := range entries {
+				require.NoError(t, w.Write(e))
+			}
+			require.NoError(t, w.Flush())
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestWriter_Debt(t *testing.T) {
+	txID := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// The deposit paid off 30 of debt first, so the balance grew by 70 only and its cancellation took 70 back.
+	entries := []domain.StatementEntry{
+		{
+			At:             at,
+			Seq:            1,
+			TxID:           txID,
+			Source:         domain.SourcePayment,
+			State:          domain.StateDeposit,
+			Amount:         decimal.NewFromInt(100),
+			Change:         decimal.NewFromInt(100),
+			RunningBalance: decimal.NewFromInt(70),
+		},
+		{
+			At:             at.Add(time.Minute),
+			Seq:            1,
+			Cancellation:   true,
+			TxID:           txID,
+			Source:         domain.SourcePayment,
+			State:          domain.StateDeposit,
+			Amount:         decimal.NewFromInt(100),
+			Change:         decimal.NewFromInt(-100),
+			RunningBalance: decimal.Zero,
+		},
+	}
+
+	var buf bytes.Buffer
+	w, err := statement.NewWriter(&buf, domain.StatementFormatCSV)
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		require.NoError(t, w.Write(e))
+	}
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, "at,seq,kind,tx_id,external_ref,source,state,amount,change,running_balance\n"+
+		"2024-01-02T03:04:05Z,1,tx,01890a5d-ac96-774b-bcce-b302099a8057,,payment,deposit,100,100,70\n"+
+		"2024-01-02T03:05:05Z,1,cancellation,01890a5d-ac96-774b-bcce-b302099a8057,,payment,deposit,100,-100,0\n",
+		buf.String())
+}
+
+func TestWriter_UnknownFormat(t *testing.T) {
+	_, err := statement.NewWriter(&bytes.Buffer{}, domain.StatementFormatUnknown)
+	assert.ErrorIs(t, err, statement.ErrUnknownFormat)
+}
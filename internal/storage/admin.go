@@ -0,0 +1,356 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// inspectionLimit is the number of latest txs and audit entries of an inspected balance.
+const inspectionLimit = 20
+
+func NewAdmin(c ConnectionPool, q Querier) *Admin {
+	return &Admin{
+		c: c,
+		q: q,
+	}
+}
+
+// Admin makes back-office changes of balances. Every change is audited before and after its pgx tx,
+// so failed and interrupted changes are audited too and no change is made without an audit entry.
+type Admin struct {
+	c ConnectionPool
+	q Querier
+}
+
+// AdjustBalance records the tx as an adjustment. Rules aren't evaluated and frozen balances are adjusted too.
+func (a *Admin) AdjustBalance(ctx context.Context, tx domain.Tx, entry domain.AuditEntry) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	tx.TenantID = tenantID
+	tx.Source = domain.SourceAdjustment
+
+	change := tx.Amount
+	if tx.State == domain.StateWithdraw {
+		change = change.Neg()
+	}
+
+	entry.Action = domain.AuditActionAdjustBalance
+	entry.BalanceID = tx.BalanceID
+	entry.TxID = &tx.TxID
+	entry.Amount = &change
+
+	return a.audited(ctx, tenantID, entry, func() (*decimal.Decimal, error) {
+		if err := a.adjustBalance(ctx, tenantID, tx); err != nil {
+			return nil, err
+		}
+
+		return &change, nil
+	})
+}
+
+func (a *Admin) adjustBalance(ctx context.Context, tenantID string, tx domain.Tx) error {
+	pgxTx, err := a.c.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := a.q.WithTx(pgxTx)
+
+	balance, err := lockBalance(ctx, qtx, tenantID, tx.BalanceID)
+	if err != nil {
+		return err
+	}
+
+	if err := applyTx(ctx, qtx, balance, tx); err != nil {
+		return err
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return nil
+}
+
+// ForceCancelTx cancels the tx of any source, including adjustments.
+// Not cancelled descendants of the tx are cancelled with cascade, otherwise it fails with HasChildrenError.
+func (a *Admin) ForceCancelTx(ctx context.Context, balanceID, txID uuid.UUID, cascade bool, entry domain.AuditEntry) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry.Action = domain.AuditActionForceCancelTx
+	entry.BalanceID = balanceID
+	entry.TxID = &txID
+
+	return a.audited(ctx, tenantID, entry, func() (*decimal.Decimal, error) {
+		return a.forceCancelTx(ctx, tenantID, balanceID, txID, cascade)
+	})
+}
+
+// forceCancelTx returns the correction of the balance.
+func (a *Admin) forceCancelTx(ctx context.Context, tenantID string, balanceID, txID uuid.UUID, cascade bool) (*decimal.Decimal, error) {
+	pgxTx, err := a.c.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := a.q.WithTx(pgxTx)
+
+	balance, err := lockBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := qtx.TxsByID(ctx, db.TxsByIDParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		TxIds:     []uuid.UUID{txID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get txs: %w", err)
+	}
+
+	txs, err = withDescendants(ctx, qtx, tenantID, balanceID, txs, cascade)
+	if err != nil {
+		return nil, err
+	}
+
+	// Support reverts txs only if the balance covers them.
+	result, err := cancelTxs(ctx, qtx, balance, txs, domain.NegativePolicyFail)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return &result.Correction, nil
+}
+
+// InspectBalance returns the balance with its latest txs and audit entries. Inspections are audited too.
+func (a *Admin) InspectBalance(ctx context.Context, balanceID uuid.UUID, entry domain.AuditEntry) (domain.BalanceInspection, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.BalanceInspection{}, err
+	}
+
+	entry.Action = domain.AuditActionInspectBalance
+	entry.BalanceID = balanceID
+
+	var inspection domain.BalanceInspection
+	err = a.audited(ctx, tenantID, entry, func() (*decimal.Decimal, error) {
+		inspection, err = a.inspectBalance(ctx, tenantID, balanceID)
+		return nil, err
+	})
+
+	return inspection, err
+}
+
+func (a *Admin) inspectBalance(ctx context.Context, tenantID string, balanceID uuid.UUID) (domain.BalanceInspection, error) {
+	pgxTx, err := a.c.Begin(ctx)
+	if err != nil {
+		return domain.BalanceInspection{}, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := a.q.WithTx(pgxTx)
+
+	row, err := qtx.Balance(ctx, db.BalanceParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.BalanceInspection{}, fmt.Errorf("%w: %v", ErrNotFound, err)
+		}
+		return domain.BalanceInspection{}, fmt.Errorf("fetch balance: %w", err)
+	}
+
+	balance, err := transform.BalanceFromPgx(row)
+	if err != nil {
+		return domain.BalanceInspection{}, fmt.Errorf("transform balance: %w", err)
+	}
+
+	txRows, err := qtx.TxsNewestFirst(ctx, db.TxsNewestFirstParams{
+		TenantID:       tenantID,
+		BalanceID:      balanceID,
+		IncludeDeleted: true,
+		Limit:          inspectionLimit,
+	})
+	if err != nil {
+		return domain.BalanceInspection{}, fmt.Errorf("fetch txs: %w", err)
+	}
+
+	txs := make([]domain.Tx, 0, len(txRows))
+	for _, r := range txRows {
+		tx, err := transform.TxFromPgx(r)
+		if err != nil {
+			return domain.BalanceInspection{}, fmt.Errorf("transform tx: %w", err)
+		}
+
+		txs = append(txs, tx)
+	}
+
+	entryRows, err := qtx.AuditEntries(ctx, db.AuditEntriesParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		Limit:     inspectionLimit,
+	})
+	if err != nil {
+		return domain.BalanceInspection{}, fmt.Errorf("fetch audit entries: %w", err)
+	}
+
+	entries := make([]domain.AuditEntry, 0, len(entryRows))
+	for _, r := range entryRows {
+		e, err := transform.AuditEntryFromPgx(r)
+		if err != nil {
+			return domain.BalanceInspection{}, fmt.Errorf("transform audit entry: %w", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return domain.BalanceInspection{}, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return domain.BalanceInspection{
+		Balance:      balance,
+		Txs:          txs,
+		AuditEntries: entries,
+	}, nil
+}
+
+// SetBalanceStatus sets the status of the balance and returns the balance after the change.
+// Setting the current status doesn't change the balance.
+func (a *Admin) SetBalanceStatus(ctx context.Context, balanceID uuid.UUID, status domain.BalanceStatus, entry domain.AuditEntry) (domain.Balance, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Balance{}, err
+	}
+
+	entry.Action = domain.AuditActionSetBalanceStatus
+	entry.BalanceID = balanceID
+	entry.Status = &status
+
+	var balance domain.Balance
+	err = a.audited(ctx, tenantID, entry, func() (*decimal.Decimal, error) {
+		balance, err = a.setBalanceStatus(ctx, tenantID, balanceID, status)
+		return nil, err
+	})
+
+	return balance, err
+}
+
+func (a *Admin) setBalanceStatus(ctx context.Context, tenantID string, balanceID uuid.UUID, status domain.BalanceStatus) (domain.Balance, error) {
+	pgxTx, err := a.c.Begin(ctx)
+	if err != nil {
+		return domain.Balance{}, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := a.q.WithTx(pgxTx)
+
+	locked, err := lockBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return domain.Balance{}, err
+	}
+
+	if locked.Status != status {
+		if err := setStatus(ctx, qtx, tenantID, balanceID, status); err != nil {
+			return domain.Balance{}, err
+		}
+	}
+
+	balance, err := currentBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return domain.Balance{}, err
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return domain.Balance{}, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return balance, nil
+}
+
+// audited writes the attempt entry, makes the change and writes the outcome entry with the changed amount.
+// Entries are written outside the pgx tx of the change, so they are kept when the change is rolled back.
+func (a *Admin) audited(ctx context.Context, tenantID string, entry domain.AuditEntry, change func() (*decimal.Decimal, error)) error {
+	entry.Outcome = domain.AuditOutcomeAttempted
+	attemptID, err := insertAuditEntry(ctx, a.q, tenantID, entry)
+	if err != nil {
+		return err
+	}
+
+	amount, changeErr := change()
+
+	entry.Outcome = domain.AuditOutcomeSucceeded
+	entry.AttemptID = &attemptID
+	entry.Amount = amount
+	if changeErr != nil {
+		msg := changeErr.Error()
+		entry.Outcome = domain.AuditOutcomeFailed
+		entry.Error = &msg
+	}
+
+	// The change is already committed or rolled back, so its outcome is written even if the request is cancelled.
+	if _, err := insertAuditEntry(context.WithoutCancel(ctx), a.q, tenantID, entry); err != nil {
+		slog.ErrorContext(ctx, "failed to write audit outcome", "attempt_id", attemptID, "error", err)
+	}
+
+	return changeErr
+}
+
+func insertAuditEntry(ctx context.Context, q Querier, tenantID string, entry domain.AuditEntry) (uuid.UUID, error) {
+	entryID, err := uuid.NewV7()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("generate audit entry id: %w", err)
+	}
+
+	entry.TenantID = tenantID
+	entry.EntryID = entryID
+
+	params, err := transform.AuditEntryToPgx(entry)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("transform audit entry: %w", err)
+	}
+
+	if _, err := q.InsertAuditEntry(ctx, params); err != nil {
+		return uuid.Nil, fmt.Errorf("insert audit entry: %w", err)
+	}
+
+	return entryID, nil
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/aml"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"github.com/jackc/pgx/v5"
+)
+
+const amlMonitor = "aml"
+
+func NewAML(c ConnectionPool, q Querier) *AML {
+	return &AML{
+		c: c,
+		q: q,
+	}
+}
+
+type AML struct {
+	c ConnectionPool
+	q Querier
+}
+
+// ScanTxs passes txs created since the last scan to detect and stores found cases.
+// It returns zero cases without scanning if another replica is already scanning.
+func (a *AML) ScanTxs(ctx context.Context, window time.Duration, until time.Time, detect aml.DetectFunc) (int, error) {
+	pgxTx, err := a.c.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := a.q.WithTx(pgxTx)
+
+	locked, err := qtx.TryLockMonitor(ctx, amlMonitor)
+	if err != nil {
+		return 0, fmt.Errorf("lock monitor: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	since, err := qtx.MonitorCheckpoint(ctx, amlMonitor)
+	if err != nil {
+		return 0, fmt.Errorf("fetch checkpoint: %w", err)
+	}
+	if !until.After(since) {
+		return 0, nil
+	}
+
+	// Txs from the window before the checkpoint are needed to detect aggregated crossings.
+	rows, err := qtx.TxsCreatedBetween(ctx, db.TxsCreatedBetweenParams{
+		CreatedAfter:  since.Add(-window),
+		CreatedBefore: until,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fetch txs: %w", err)
+	}
+
+	txs := make([]domain.Tx, 0, len(rows))
+	for _, r := range rows {
+		t, err := transform.TxFromPgx(r)
+		if err != nil {
+			return 0, fmt.Errorf("transform tx: %w", err)
+		}
+
+		txs = append(txs, t)
+	}
+
+	cases := detect(txs, since)
+	for _, c := range cases {
+		caseID, err := uuid.NewV7()
+		if err != nil {
+			return 0, fmt.Errorf("generate case ID: %w", err)
+		}
+
+		params, err := transform.AMLCaseToPgx(caseID, c)
+		if err != nil {
+			return 0, fmt.Errorf("transform case: %w", err)
+		}
+
+		if _, err := qtx.InsertAMLCase(ctx, params); err != nil {
+			return 0, fmt.Errorf("insert case: %w", err)
+		}
+	}
+
+	if _, err := qtx.SetMonitorCheckpoint(ctx, db.SetMonitorCheckpointParams{
+		Monitor:      amlMonitor,
+		ScannedUntil: until,
+	}); err != nil {
+		return 0, fmt.Errorf("update checkpoint: %w", err)
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return len(cases), nil
+}
+
+// Report passes cases detected within [from, to) to report and marks open ones as reported.
+// Marks are committed only after report succeeds, so cases failed to be written are reported next time.
+// Nil tenant means cases of all tenants.
+func (a *AML) Report(ctx context.Context, tenantID *string, from, to time.Time, report aml.ReportFunc) (int, error) {
+	pgxTx, err := a.c.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := a.q.WithTx(pgxTx)
+
+	rows, err := qtx.AMLCases(ctx, db.AMLCasesParams{
+		TenantID:     tenantID,
+		DetectedFrom: from,
+		DetectedTo:   to,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fetch cases: %w", err)
+	}
+
+	var cases []domain.AMLCase
+	var openIDs []uuid.UUID
+	for _, r := range rows {
+		c, err := transform.AMLCaseFromPgx(r)
+		if err != nil {
+			return 0, fmt.Errorf("transform case: %w", err)
+		}
+
+		if c.Status == domain.AMLCaseStatusOpen {
+			openIDs = append(openIDs, c.CaseID)
+			c.Status = domain.AMLCaseStatusReported
+		}
+
+		cases = append(cases, c)
+	}
+
+	if _, err := qtx.SetAMLCasesStatus(ctx, db.SetAMLCasesStatusParams{
+		Status:        domain.AMLCaseStatusReported,
+		CaseIds:       openIDs,
+		CurrentStatus: domain.AMLCaseStatusOpen,
+	}); err != nil {
+		return 0, fmt.Errorf("mark cases as reported: %w", err)
+	}
+
+	if err := report(cases); err != nil {
+		return 0, fmt.Errorf("write report: %w", err)
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return len(cases), nil
+}
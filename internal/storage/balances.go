@@ -2,13 +2,21 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/iskorotkov/igaming-balance-backend/internal/db"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/finance"
+	"github.com/iskorotkov/igaming-balance-backend/internal/rules"
+	"github.com/iskorotkov/igaming-balance-backend/internal/tenant"
 	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -19,46 +27,123 @@ var (
 	ErrNotFound        = errors.New("not found")
 	ErrAlreadyExists   = errors.New("already exists")
 	ErrNegativeBalance = errors.New("negative balance")
+	ErrRejected        = errors.New("rejected")
+	ErrFrozen          = errors.New("frozen")
+	ErrNoTenant        = errors.New("no tenant")
+	ErrVersionMismatch = errors.New("version mismatch")
+	ErrParentNotFound  = errors.New("parent not found")
+	ErrHasChildren     = errors.New("has children")
+	ErrNotChargeable   = errors.New("not chargeable")
 )
 
+// relatedTxsLimit caps txs linked through parents, real chains are a bet with a few wins or refunds.
+const relatedTxsLimit = 1000
+
+// statementBatchSize is the number of statement entries fetched from the cursor at once.
+const statementBatchSize = 500
+
+// fetchStatement reads the cursor declared by DeclareStatementCursor, sqlc can't infer columns of fetch.
+var fetchStatement = fmt.Sprintf("fetch %d from statement_cursor", statementBatchSize)
+
+// InsufficientFundsError is a negative balance error with amounts known before the balance is changed.
+type InsufficientFundsError struct {
+	Current  decimal.Decimal // Balance before the change.
+	Required decimal.Decimal // Amount the change takes from the balance.
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("%v: balance %s, required %s", ErrNegativeBalance, e.Current, e.Required)
+}
+
+func (e *InsufficientFundsError) Unwrap() error {
+	return ErrNegativeBalance
+}
+
+// VersionMismatchError is returned when the balance changed since the version the client expected.
+type VersionMismatchError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("%v: expected %d, current %d", ErrVersionMismatch, e.Expected, e.Current)
+}
+
+func (e *VersionMismatchError) Unwrap() error {
+	return ErrVersionMismatch
+}
+
+// HasChildrenError is returned when cancelled txs have not cancelled children and cascade isn't requested.
+type HasChildrenError struct {
+	Children []uuid.UUID
+}
+
+func (e *HasChildrenError) Error() string {
+	return fmt.Sprintf("%v: %d not cancelled", ErrHasChildren, len(e.Children))
+}
+
+func (e *HasChildrenError) Unwrap() error {
+	return ErrHasChildren
+}
+
 type ConnectionPool interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type Querier interface {
 	WithTx(tx pgx.Tx) *db.Queries
-	RecentTxs(ctx context.Context, arg db.RecentTxsParams) ([]db.Tx, error)
-	PreviousTxs(ctx context.Context, arg db.PreviousTxsParams) ([]db.Tx, error)
-	OpenBalance(ctx context.Context, balanceID uuid.UUID) (int64, error)
-	Balance(ctx context.Context, balanceID uuid.UUID) (db.Balance, error)
+	TxByID(ctx context.Context, arg db.TxByIDParams) (db.Tx, error)
+	TxByExternalRef(ctx context.Context, arg db.TxByExternalRefParams) (db.Tx, error)
+	TxsNewestFirst(ctx context.Context, arg db.TxsNewestFirstParams) ([]db.Tx, error)
+	TxsOldestFirst(ctx context.Context, arg db.TxsOldestFirstParams) ([]db.Tx, error)
+	OpenBalance(ctx context.Context, arg db.OpenBalanceParams) (db.Balance, error)
+	Balance(ctx context.Context, arg db.BalanceParams) (db.Balance, error)
+	BalanceEvents(ctx context.Context, arg db.BalanceEventsParams) ([]db.BalanceEvent, error)
+	TxsByID(ctx context.Context, arg db.TxsByIDParams) ([]db.Tx, error)
+	RelatedTxs(ctx context.Context, arg db.RelatedTxsParams) ([]db.Tx, error)
+	CancellationBalances(ctx context.Context, arg db.CancellationBalancesParams) ([]db.CancellationBalance, error)
+	SearchBalances(ctx context.Context, arg db.SearchBalancesParams) ([]db.SearchBalancesRow, error)
+	AggregateTxs(ctx context.Context, arg db.AggregateTxsParams) ([]db.AggregateTxsRow, error)
+	AggregateDailyRollups(ctx context.Context, arg db.AggregateDailyRollupsParams) ([]db.AggregateDailyRollupsRow, error)
+	RecentFlaggedEvents(ctx context.Context, arg db.RecentFlaggedEventsParams) ([]db.FlaggedEvent, error)
+	PreviousFlaggedEvents(ctx context.Context, arg db.PreviousFlaggedEventsParams) ([]db.FlaggedEvent, error)
+	DebtEntries(ctx context.Context, arg db.DebtEntriesParams) ([]db.DebtEntry, error)
+	InsertAuditEntry(ctx context.Context, arg db.InsertAuditEntryParams) (int64, error)
+}
+
+type Rules interface {
+	Evaluate(tx domain.Tx, stats rules.StatsFunc) (domain.RuleAction, []domain.RuleHit, error)
 }
 
-func NewBalances(c ConnectionPool, q Querier) *Balances {
+func NewBalances(c ConnectionPool, q Querier, r Rules, l *Listener) *Balances {
 	return &Balances{
 		c: c,
 		q: q,
+		r: r,
+		l: l,
 	}
 }
 
 type Balances struct {
 	c ConnectionPool
 	q Querier
+	r Rules
+	l *Listener
 }
 
-func (b *Balances) RecordTx(ctx context.Context, tx domain.Tx) error {
-	dbTx, err := transform.TxToPgx(tx)
+// RecordTx records the tx and returns the balance after it with rules matched by the tx.
+// Dry runs take the same locks and run the same checks and rules, but always roll back, so the balance is only projected.
+// Txs rejected or freezing the balance aren't errors for dry runs, the outcome action tells what would happen.
+func (b *Balances) RecordTx(ctx context.Context, tx domain.Tx, opts domain.WriteOptions) (domain.RecordOutcome, error) {
+	tenantID, err := tenantFromContext(ctx)
 	if err != nil {
-		return fmt.Errorf("transform tx: %w", err)
-	}
-
-	balanceChange := tx.Amount
-	if tx.State == domain.StateWithdraw {
-		balanceChange = balanceChange.Neg()
+		return domain.RecordOutcome{}, err
 	}
+	tx.TenantID = tenantID
 
 	pgxTx, err := b.c.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("begin pgx tx: %w", err)
+		return domain.RecordOutcome{}, fmt.Errorf("begin pgx tx: %w", err)
 	}
 	defer func() {
 		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
@@ -68,42 +153,99 @@ func (b *Balances) RecordTx(ctx context.Context, tx domain.Tx) error {
 
 	qtx := b.q.WithTx(pgxTx)
 
-	if _, err := qtx.LockBalance(ctx, tx.BalanceID); err != nil {
-		return fmt.Errorf("lock balance: %w", err)
+	balance, err := lockBalance(ctx, qtx, tenantID, tx.BalanceID)
+	if err != nil {
+		return domain.RecordOutcome{}, err
+	}
+	if balance.Status == domain.BalanceStatusFrozen {
+		return domain.RecordOutcome{}, ErrFrozen
+	}
+	if balance.Status == domain.BalanceStatusWithdrawalsFrozen && tx.State == domain.StateWithdraw {
+		return domain.RecordOutcome{}, fmt.Errorf("%w: withdrawals are frozen", ErrFrozen)
+	}
+	if err := checkVersion(balance, opts.ExpectedVersion); err != nil {
+		return domain.RecordOutcome{}, err
+	}
+	if err := checkParent(ctx, qtx, tx); err != nil {
+		return domain.RecordOutcome{}, err
 	}
 
-	updated, err := qtx.UpdateBalance(ctx, db.UpdateBalanceParams{
-		BalanceID: tx.BalanceID,
-		Amount:    balanceChange,
+	// Rules are evaluated before any changes, so rejected txs only leave flagged events behind.
+	action, hits, err := b.r.Evaluate(tx, func(window time.Duration) ([]domain.TxStats, error) {
+		return txStats(ctx, qtx, tenantID, tx.BalanceID, window)
 	})
 	if err != nil {
-		if isPgCode(err, "23514") {
-			return fmt.Errorf("%w: %v", ErrNegativeBalance, err)
+		return domain.RecordOutcome{}, fmt.Errorf("evaluate rules: %w", err)
+	}
+
+	if err := flagTx(ctx, qtx, tx, hits); err != nil {
+		return domain.RecordOutcome{}, fmt.Errorf("flag tx: %w", err)
+	}
+
+	switch action {
+	case domain.RuleActionReject:
+	case domain.RuleActionFreeze:
+		if err := setStatus(ctx, qtx, tenantID, tx.BalanceID, domain.BalanceStatusFrozen); err != nil {
+			return domain.RecordOutcome{}, fmt.Errorf("freeze balance: %w", err)
+		}
+	default:
+		if err := applyTx(ctx, qtx, balance, tx); err != nil {
+			return domain.RecordOutcome{}, err
 		}
-		return fmt.Errorf("update balance: %w", err)
 	}
-	if updated == 0 {
-		return fmt.Errorf("%w: %v", ErrNotFound, err)
+
+	outcome := domain.RecordOutcome{
+		Action: action,
+		Hits:   hits,
 	}
+	if action != domain.RuleActionReject && action != domain.RuleActionFreeze {
+		recorded, err := qtx.TxByID(ctx, db.TxByIDParams{
+			TenantID: tenantID,
+			TxID:     tx.TxID,
+		})
+		if err != nil {
+			return domain.RecordOutcome{}, fmt.Errorf("fetch recorded tx: %w", err)
+		}
 
-	if _, err := qtx.InsertTx(ctx, dbTx); err != nil {
-		if isPgCode(err, "23505") {
-			return fmt.Errorf("%w: %v", ErrAlreadyExists, err)
+		outcome.Tx, err = transform.TxFromPgx(recorded)
+		if err != nil {
+			return domain.RecordOutcome{}, fmt.Errorf("transform tx: %w", err)
 		}
-		return fmt.Errorf("insert tx: %w", err)
+	}
+	outcome.Balance, err = currentBalance(ctx, qtx, tenantID, tx.BalanceID)
+	if err != nil {
+		return domain.RecordOutcome{}, err
+	}
+
+	if opts.DryRun {
+		return outcome, nil
 	}
 
 	if err := pgxTx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit pgx tx: %w", err)
+		return domain.RecordOutcome{}, fmt.Errorf("commit pgx tx: %w", err)
 	}
 
-	return nil
+	switch action {
+	case domain.RuleActionReject:
+		return domain.RecordOutcome{}, fmt.Errorf("%w: %s", ErrRejected, ruleNames(hits))
+	case domain.RuleActionFreeze:
+		return domain.RecordOutcome{}, fmt.Errorf("%w: %s", ErrFrozen, ruleNames(hits))
+	}
+
+	return outcome, nil
 }
 
-func (b *Balances) CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID) error {
+// CancelTxs cancels the txs and returns them with the balance after the cancellation.
+// Dry runs run the same checks, but always roll back, so the balance is only projected.
+func (b *Balances) CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []uuid.UUID, opts domain.WriteOptions) (domain.CancelOutcome, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.CancelOutcome{}, err
+	}
+
 	pgxTx, err := b.c.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("begin pgx tx: %w", err)
+		return domain.CancelOutcome{}, fmt.Errorf("begin pgx tx: %w", err)
 	}
 	defer func() {
 		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
@@ -113,102 +255,273 @@ func (b *Balances) CancelTxs(ctx context.Context, balanceID uuid.UUID, txIDs []u
 
 	qtx := b.q.WithTx(pgxTx)
 
-	if _, err := qtx.LockBalance(ctx, balanceID); err != nil {
-		return fmt.Errorf("lock balance: %w", err)
+	balance, err := lockBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return domain.CancelOutcome{}, err
+	}
+	if err := checkVersion(balance, opts.ExpectedVersion); err != nil {
+		return domain.CancelOutcome{}, err
 	}
 
 	txs, err := qtx.TxsByID(ctx, db.TxsByIDParams{
+		TenantID:  tenantID,
 		BalanceID: balanceID,
 		TxIds:     txIDs,
 	})
 	if err != nil {
-		return fmt.Errorf("get txs: %w", err)
-	}
-	if len(txs) == 0 {
-		return fmt.Errorf("%w: %v", ErrNotFound, err)
+		return domain.CancelOutcome{}, fmt.Errorf("get txs: %w", err)
 	}
 
-	var balanceChange decimal.Decimal
 	for _, tx := range txs {
-		switch tx.State {
-		case domain.StateDeposit:
-			balanceChange = balanceChange.Sub(tx.Amount)
-		case domain.StateWithdraw:
-			balanceChange = balanceChange.Add(tx.Amount)
-		default:
-			return fmt.Errorf("unknown state: %v", tx.State)
+		if tx.Source == domain.SourceAdjustment {
+			return domain.CancelOutcome{}, fmt.Errorf("%w: adjustment %s can be cancelled only by support", ErrRejected, tx.TxID)
 		}
 	}
 
-	updated, err := qtx.UpdateBalance(ctx, db.UpdateBalanceParams{
+	txs, err = withDescendants(ctx, qtx, tenantID, balanceID, txs, opts.Cascade)
+	if err != nil {
+		return domain.CancelOutcome{}, err
+	}
+
+	result, err := cancelTxs(ctx, qtx, balance, txs, opts.NegativePolicy)
+	if err != nil {
+		return domain.CancelOutcome{}, err
+	}
+
+	outcome := domain.CancelOutcome{
+		Correction:   result.Correction,
+		SkippedTxIDs: result.SkippedTxIDs,
+		Debt:         result.Debt,
+	}
+
+	cancelled, err := qtx.TxsByID(ctx, db.TxsByIDParams{
+		TenantID:  tenantID,
 		BalanceID: balanceID,
-		Amount:    balanceChange,
+		TxIds:     result.TxIDs,
 	})
 	if err != nil {
-		if isPgCode(err, "23514") {
-			return fmt.Errorf("%w: %v", ErrNegativeBalance, err)
+		return domain.CancelOutcome{}, fmt.Errorf("fetch cancelled txs: %w", err)
+	}
+
+	for _, c := range cancelled {
+		tx, err := transform.TxFromPgx(c)
+		if err != nil {
+			return domain.CancelOutcome{}, fmt.Errorf("transform tx: %w", err)
 		}
-		return fmt.Errorf("update balance: %w", err)
+
+		outcome.Txs = append(outcome.Txs, tx)
 	}
-	if updated == 0 {
-		return fmt.Errorf("%w: %v", ErrNotFound, err)
+
+	outcome.Balance, err = currentBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return domain.CancelOutcome{}, err
 	}
 
-	if _, err := qtx.DeleteTxs(ctx, db.DeleteTxsParams{
-		BalanceID: balanceID,
-		TxIds:     txIDs,
-	}); err != nil {
-		return fmt.Errorf("insert tx: %w", err)
+	if opts.DryRun {
+		return outcome, nil
 	}
 
 	if err := pgxTx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit pgx tx: %w", err)
+		return domain.CancelOutcome{}, fmt.Errorf("commit pgx tx: %w", err)
 	}
 
-	return nil
+	return outcome, nil
 }
 
-func (b *Balances) RecentTxs(
-	ctx context.Context,
-	balanceID uuid.UUID,
-	includeDeleted bool,
-	limit int,
-) ([]domain.Tx, error) {
-	rows, err := b.q.RecentTxs(ctx, db.RecentTxsParams{
-		BalanceID:      balanceID,
-		IncludeDeleted: includeDeleted,
-		Limit:          int32(limit),
+// Subscribe returns a channel receiving a value when new events of the balance may exist.
+// The channel is closed when the server stops. Cancel must be called to release the subscription.
+func (b *Balances) Subscribe(ctx context.Context, balanceID uuid.UUID) (<-chan struct{}, func(), error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch, cancel := b.l.Subscribe(tenantID, balanceID)
+	return ch, cancel, nil
+}
+
+// BalanceEvents returns events of the balance after afterSeq with txs which caused them.
+func (b *Balances) BalanceEvents(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.BalanceEvent, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.q.BalanceEvents(ctx, db.BalanceEventsParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		AfterSeq:  afterSeq,
+		Limit:     int32(limit),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("fetch txs: %w", err)
+		return nil, fmt.Errorf("fetch balance events: %w", err)
 	}
 
-	var txs []domain.Tx
+	var txIDs []uuid.UUID
 	for _, r := range rows {
-		t, err := transform.TxFromPgx(r)
+		txIDs = append(txIDs, r.TxIds...)
+	}
+
+	txs := make(map[uuid.UUID]domain.Tx, len(txIDs))
+	if len(txIDs) > 0 {
+		txRows, err := b.q.TxsByID(ctx, db.TxsByIDParams{
+			TenantID:  tenantID,
+			BalanceID: balanceID,
+			TxIds:     txIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetch txs: %w", err)
+		}
+
+		for _, r := range txRows {
+			t, err := transform.TxFromPgx(r)
+			if err != nil {
+				return nil, fmt.Errorf("transform tx: %w", err)
+			}
+
+			txs[t.TxID] = t
+		}
+	}
+
+	var events []domain.BalanceEvent
+	for _, r := range rows {
+		e, err := transform.BalanceEventFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform balance event: %w", err)
+		}
+
+		for _, id := range e.TxIDs {
+			if t, ok := txs[id]; ok {
+				e.Txs = append(e.Txs, t)
+			}
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (b *Balances) Tx(ctx context.Context, txID uuid.UUID) (domain.Tx, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Tx{}, err
+	}
+
+	row, err := b.q.TxByID(ctx, db.TxByIDParams{
+		TenantID: tenantID,
+		TxID:     txID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Tx{}, fmt.Errorf("%w: %v", ErrNotFound, err)
+		}
+		return domain.Tx{}, fmt.Errorf("fetch tx: %w", err)
+	}
+
+	tx, err := transform.TxFromPgx(row)
+	if err != nil {
+		return domain.Tx{}, fmt.Errorf("transform tx: %w", err)
+	}
+
+	return tx, nil
+}
+
+// RelatedTxs returns txs sharing the root parent with the tx in balance history order.
+func (b *Balances) RelatedTxs(ctx context.Context, txID uuid.UUID) ([]domain.Tx, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.q.RelatedTxs(ctx, db.RelatedTxsParams{
+		TenantID: tenantID,
+		TxID:     txID,
+		Limit:    relatedTxsLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch related txs: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: tx %s", ErrNotFound, txID)
+	}
+
+	txs := make([]domain.Tx, 0, len(rows))
+	for _, r := range rows {
+		tx, err := transform.TxFromPgx(r)
 		if err != nil {
 			return nil, fmt.Errorf("transform tx: %w", err)
 		}
 
-		txs = append(txs, t)
+		txs = append(txs, tx)
 	}
 
 	return txs, nil
 }
 
-func (b *Balances) PreviousTxs(
+func (b *Balances) TxByExternalRef(ctx context.Context, externalRef string) (domain.Tx, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Tx{}, err
+	}
+
+	row, err := b.q.TxByExternalRef(ctx, db.TxByExternalRefParams{
+		TenantID:    tenantID,
+		ExternalRef: &externalRef,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Tx{}, fmt.Errorf("%w: %v", ErrNotFound, err)
+		}
+		return domain.Tx{}, fmt.Errorf("fetch tx: %w", err)
+	}
+
+	tx, err := transform.TxFromPgx(row)
+	if err != nil {
+		return domain.Tx{}, fmt.Errorf("transform tx: %w", err)
+	}
+
+	return tx, nil
+}
+
+// ListTxs returns txs of the balance in the order, starting after the tx with afterSeq if it's not nil.
+func (b *Balances) ListTxs(
 	ctx context.Context,
 	balanceID uuid.UUID,
-	includeDeleted bool,
-	beforeUUID uuid.UUID,
+	filter domain.TxFilter,
+	order domain.TxOrder,
+	afterSeq *int64,
 	limit int,
 ) ([]domain.Tx, error) {
-	rows, err := b.q.PreviousTxs(ctx, db.PreviousTxsParams{
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.TxsNewestFirstParams{
+		TenantID:       tenantID,
 		BalanceID:      balanceID,
-		IncludeDeleted: includeDeleted,
-		TxID:           beforeUUID,
+		AfterSeq:       afterSeq,
+		IncludeDeleted: filter.IncludeDeleted,
+		CancelledOnly:  filter.CancelledOnly,
+		Source:         optionalEnum(filter.Source, domain.SourceUnknown),
+		State:          optionalEnum(filter.State, domain.StateUnknown),
+		CreatedFrom:    filter.CreatedFrom,
+		CreatedTo:      filter.CreatedTo,
+		MinAmount:      filter.MinAmount,
+		MaxAmount:      filter.MaxAmount,
 		Limit:          int32(limit),
-	})
+	}
+
+	var rows []db.Tx
+	switch order {
+	case domain.TxOrderOldestFirst:
+		rows, err = b.q.TxsOldestFirst(ctx, db.TxsOldestFirstParams(params))
+	case domain.TxOrderNewestFirst:
+		rows, err = b.q.TxsNewestFirst(ctx, params)
+	default:
+		return nil, fmt.Errorf("unknown order: %v", order)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("fetch txs: %w", err)
 	}
@@ -226,19 +539,40 @@ func (b *Balances) PreviousTxs(
 	return txs, nil
 }
 
-func (b *Balances) OpenBalance(ctx context.Context, balanceID uuid.UUID) error {
-	if _, err := b.q.OpenBalance(ctx, balanceID); err != nil {
+// OpenBalance opens the balance and returns it with server fields.
+func (b *Balances) OpenBalance(ctx context.Context, balance domain.Balance) (domain.Balance, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Balance{}, err
+	}
+
+	params, err := transform.OpenBalanceToPgx(balance)
+	if err != nil {
+		return domain.Balance{}, fmt.Errorf("transform balance: %w", err)
+	}
+	params.TenantID = tenantID
+
+	opened, err := b.q.OpenBalance(ctx, params)
+	if err != nil {
 		if isPgCode(err, "23505") {
-			return fmt.Errorf("%w: %v", ErrAlreadyExists, err)
+			return domain.Balance{}, fmt.Errorf("%w: %v", ErrAlreadyExists, err)
 		}
-		return fmt.Errorf("open balance: %w", err)
+		return domain.Balance{}, fmt.Errorf("open balance: %w", err)
 	}
 
-	return nil
+	return transform.BalanceFromPgx(opened)
 }
 
 func (b *Balances) Balance(ctx context.Context, balanceID uuid.UUID) (domain.Balance, error) {
-	row, err := b.q.Balance(ctx, balanceID)
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Balance{}, err
+	}
+
+	row, err := b.q.Balance(ctx, db.BalanceParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.Balance{}, fmt.Errorf("%w: %v", ErrNotFound, err)
@@ -254,7 +588,767 @@ func (b *Balances) Balance(ctx context.Context, balanceID uuid.UUID) (domain.Bal
 	return balance, nil
 }
 
-func isPgCode(err error, code string) bool {
-	var pgerr *pgconn.PgError
-	return errors.As(err, &pgerr) && pgerr.Code == code
+// ExportStatement calls fn for every tx and cancellation of the balance in [from, to) in order.
+// Nil bounds don't limit the range. Entries are read from a server-side cursor in batches, so histories are never loaded at once.
+func (b *Balances) ExportStatement(
+	ctx context.Context,
+	balanceID uuid.UUID,
+	from, to *time.Time,
+	fn func(domain.StatementEntry) error,
+) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	pgxTx, err := b.c.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := b.q.WithTx(pgxTx)
+
+	if _, err := qtx.Balance(ctx, db.BalanceParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %v", ErrNotFound, err)
+		}
+		return fmt.Errorf("fetch balance: %w", err)
+	}
+
+	if err := qtx.DeclareStatementCursor(ctx, db.DeclareStatementCursorParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		FromTime:  from,
+		ToTime:    to,
+	}); err != nil {
+		return fmt.Errorf("declare statement cursor: %w", err)
+	}
+
+	for {
+		rows, err := pgxTx.Query(ctx, fetchStatement)
+		if err != nil {
+			return fmt.Errorf("fetch statement: %w", err)
+		}
+
+		entries, err := pgx.CollectRows(rows, scanStatementEntry)
+		if err != nil {
+			return fmt.Errorf("fetch statement: %w", err)
+		}
+
+		for _, e := range entries {
+			if err := fn(e); err != nil {
+				return fmt.Errorf("write statement entry: %w", err)
+			}
+		}
+
+		if len(entries) < statementBatchSize {
+			break
+		}
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return nil
+}
+
+// Aggregate sums not cancelled txs per bucket, source and state.
+// Daily rollups are used instead of txs when buckets consist of whole UTC days.
+func (b *Balances) Aggregate(ctx context.Context, q domain.AggregateQuery) ([]domain.AggregateRow, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := strings.ToLower(q.Bucket.String())
+
+	var rows []db.AggregateTxsRow
+	if usesDailyRollups(q) {
+		rollups, err := b.q.AggregateDailyRollups(ctx, db.AggregateDailyRollupsParams{
+			Bucket:    bucket,
+			TenantID:  tenantID,
+			BalanceID: q.BalanceID,
+			DayFrom:   q.From,
+			DayTo:     q.To,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetch daily rollups: %w", err)
+		}
+
+		for _, r := range rollups {
+			rows = append(rows, db.AggregateTxsRow(r))
+		}
+	} else {
+		rows, err = b.q.AggregateTxs(ctx, db.AggregateTxsParams{
+			Bucket:      bucket,
+			TimeZone:    q.Location.String(),
+			TenantID:    tenantID,
+			BalanceID:   q.BalanceID,
+			CreatedFrom: q.From,
+			CreatedTo:   q.To,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("aggregate txs: %w", err)
+		}
+	}
+
+	aggregated := make([]domain.AggregateRow, 0, len(rows))
+	for _, r := range rows {
+		row, err := transform.AggregateRowFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform aggregate row: %w", err)
+		}
+
+		aggregated = append(aggregated, row)
+	}
+
+	return aggregated, nil
+}
+
+// ListBalances returns sorted balances, starting after the position if it's not nil.
+// It also returns the position of the last balance to continue from.
+func (b *Balances) ListBalances(
+	ctx context.Context,
+	filter domain.BalanceFilter,
+	sort domain.BalanceSort,
+	after *domain.BalancePosition,
+	limit int,
+) ([]domain.Balance, *domain.BalancePosition, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := db.SearchBalancesParams{
+		SortBy:      sort.Field.String(),
+		Descending:  sort.Descending,
+		TenantID:    tenantID,
+		OwnerID:     filter.OwnerID,
+		Status:      optionalEnum(filter.Status, domain.BalanceStatusUnknown),
+		MinAmount:   filter.MinAmount,
+		MaxAmount:   filter.MaxAmount,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		UpdatedFrom: filter.UpdatedFrom,
+		UpdatedTo:   filter.UpdatedTo,
+		ActiveSince: filter.ActiveSince,
+		MinTxCount:  filter.MinTxCount,
+		MaxTxCount:  filter.MaxTxCount,
+		Limit:       int32(limit),
+	}
+	if after != nil {
+		params.AfterSortKey = &after.SortKey
+		params.AfterBalanceID = &after.BalanceID
+	}
+
+	rows, err := b.q.SearchBalances(ctx, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch balances: %w", err)
+	}
+
+	var balances []domain.Balance
+	var last *domain.BalancePosition
+	for _, r := range rows {
+		balance, err := transform.BalanceFromPgx(r.Balance)
+		if err != nil {
+			return nil, nil, fmt.Errorf("transform balance: %w", err)
+		}
+
+		balances = append(balances, balance)
+		last = &domain.BalancePosition{
+			SortKey:   r.SortKey,
+			BalanceID: balance.BalanceID,
+		}
+	}
+
+	return balances, last, nil
+}
+
+func (b *Balances) RecentFlaggedEvents(
+	ctx context.Context,
+	balanceID *uuid.UUID,
+	limit int,
+) ([]domain.FlaggedEvent, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.q.RecentFlaggedEvents(ctx, db.RecentFlaggedEventsParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch flagged events: %w", err)
+	}
+
+	var events []domain.FlaggedEvent
+	for _, r := range rows {
+		e, err := transform.FlaggedEventFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform flagged event: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (b *Balances) PreviousFlaggedEvents(
+	ctx context.Context,
+	balanceID *uuid.UUID,
+	beforeUUID uuid.UUID,
+	limit int,
+) ([]domain.FlaggedEvent, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.q.PreviousFlaggedEvents(ctx, db.PreviousFlaggedEventsParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		EventID:   beforeUUID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch flagged events: %w", err)
+	}
+
+	var events []domain.FlaggedEvent
+	for _, r := range rows {
+		e, err := transform.FlaggedEventFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform flagged event: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// lockBalance locks the balance until the end of the pgx tx and returns it.
+func lockBalance(ctx context.Context, qtx *db.Queries, tenantID string, balanceID uuid.UUID) (db.Balance, error) {
+	if _, err := qtx.LockBalance(ctx, db.LockBalanceParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	}); err != nil {
+		return db.Balance{}, fmt.Errorf("lock balance: %w", err)
+	}
+
+	balance, err := qtx.Balance(ctx, db.BalanceParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Balance{}, fmt.Errorf("%w: %v", ErrNotFound, err)
+		}
+		return db.Balance{}, fmt.Errorf("fetch balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// checkParent fails if the tx has a parent which doesn't exist on its balance.
+func checkParent(ctx context.Context, qtx *db.Queries, tx domain.Tx) error {
+	if tx.ParentTxID == nil {
+		return nil
+	}
+
+	parents, err := qtx.TxsByID(ctx, db.TxsByIDParams{
+		TenantID:  tx.TenantID,
+		BalanceID: tx.BalanceID,
+		TxIds:     []uuid.UUID{*tx.ParentTxID},
+	})
+	if err != nil {
+		return fmt.Errorf("get parent tx: %w", err)
+	}
+	if len(parents) == 0 {
+		return fmt.Errorf("%w: %s", ErrParentNotFound, tx.ParentTxID)
+	}
+
+	return nil
+}
+
+// withDescendants appends not cancelled descendants of the txs, or fails with HasChildrenError without cascade.
+func withDescendants(ctx context.Context, qtx *db.Queries, tenantID string, balanceID uuid.UUID, txs []db.Tx, cascade bool) ([]db.Tx, error) {
+	txIDs := make([]uuid.UUID, 0, len(txs))
+	for _, tx := range txs {
+		txIDs = append(txIDs, tx.TxID)
+	}
+
+	descendants, err := qtx.DescendantTxs(ctx, db.DescendantTxsParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		TxIds:     txIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get descendant txs: %w", err)
+	}
+
+	// Children cancelled in the same call don't block their parents.
+	requested := make(map[uuid.UUID]struct{}, len(txIDs))
+	for _, id := range txIDs {
+		requested[id] = struct{}{}
+	}
+
+	var children []uuid.UUID
+	for _, d := range descendants {
+		if _, ok := requested[d.TxID]; ok {
+			continue
+		}
+
+		children = append(children, d.TxID)
+		txs = append(txs, d)
+	}
+	if len(children) > 0 && !cascade {
+		return nil, &HasChildrenError{Children: children}
+	}
+
+	return txs, nil
+}
+
+// currentBalance reads the balance with changes made in the pgx tx.
+func currentBalance(ctx context.Context, qtx *db.Queries, tenantID string, balanceID uuid.UUID) (domain.Balance, error) {
+	balance, err := qtx.Balance(ctx, db.BalanceParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	})
+	if err != nil {
+		return domain.Balance{}, fmt.Errorf("fetch balance: %w", err)
+	}
+
+	return transform.BalanceFromPgx(balance)
+}
+
+// checkVersion fails if the locked balance isn't at the expected version. Nil version isn't checked.
+func checkVersion(balance db.Balance, expectedVersion *int64) error {
+	if expectedVersion != nil && balance.Version != *expectedVersion {
+		return &VersionMismatchError{
+			Expected: *expectedVersion,
+			Current:  balance.Version,
+		}
+	}
+
+	return nil
+}
+
+// applyTx inserts the tx and changes the locked balance by it.
+func applyTx(ctx context.Context, qtx *db.Queries, balance db.Balance, tx domain.Tx) error {
+	dbTx, err := transform.TxToPgx(tx)
+	if err != nil {
+		return fmt.Errorf("transform tx: %w", err)
+	}
+
+	balanceChange := tx.Amount
+	if tx.State == domain.StateWithdraw {
+		balanceChange = balanceChange.Neg()
+	}
+
+	if balance.Amount.Add(balanceChange).IsNegative() {
+		return &InsufficientFundsError{
+			Current:  balance.Amount,
+			Required: balanceChange.Neg(),
+		}
+	}
+
+	// Deposits pay the debt off first, only the rest is added to the amount.
+	var settled decimal.Decimal
+	if tx.State == domain.StateDeposit && balance.Debt.IsPositive() {
+		settled = decimal.Min(balance.Debt, tx.Amount)
+	}
+
+	dbTx.SettledDebt = settled
+
+	updated, err := qtx.UpdateBalance(ctx, db.UpdateBalanceParams{
+		TenantID:  tx.TenantID,
+		BalanceID: tx.BalanceID,
+		Amount:    balanceChange.Sub(settled),
+		Debt:      settled.Neg(),
+		TxCount:   1,
+	})
+	if err != nil {
+		if isPgCode(err, "23514") {
+			return fmt.Errorf("%w: %v", ErrNegativeBalance, err)
+		}
+		return fmt.Errorf("update balance: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+
+	dbTx.Seq, err = qtx.NextTxSeq(ctx, db.NextTxSeqParams{
+		TenantID:  tx.TenantID,
+		BalanceID: tx.BalanceID,
+	})
+	if err != nil {
+		return fmt.Errorf("assign tx seq: %w", err)
+	}
+
+	if _, err := qtx.InsertTx(ctx, dbTx); err != nil {
+		if isPgCode(err, "23505") {
+			return fmt.Errorf("%w: %v", ErrAlreadyExists, err)
+		}
+		return fmt.Errorf("insert tx: %w", err)
+	}
+
+	if err := qtx.RollupTxs(ctx, db.RollupTxsParams{
+		Sign:      1,
+		TenantID:  tx.TenantID,
+		BalanceID: tx.BalanceID,
+		TxIds:     []uuid.UUID{tx.TxID},
+	}); err != nil {
+		return fmt.Errorf("rollup tx: %w", err)
+	}
+
+	if err := appendEvent(ctx, qtx, tx.TenantID, tx.BalanceID, domain.BalanceEventKindTxRecorded, []uuid.UUID{tx.TxID}, nil); err != nil {
+		return fmt.Errorf("append balance event: %w", err)
+	}
+
+	if settled.IsPositive() {
+		debt := balance.Debt.Sub(settled)
+		if err := appendDebtEntry(ctx, qtx, tx.TenantID, tx.BalanceID, domain.DebtEntryKindSettled, settled, debt, []uuid.UUID{tx.TxID}); err != nil {
+			return fmt.Errorf("append debt entry: %w", err)
+		}
+
+		// Withdrawals frozen by a chargeback are unfrozen once its debt is paid off.
+		if debt.IsZero() && balance.Status == domain.BalanceStatusWithdrawalsFrozen {
+			if err := setStatus(ctx, qtx, tx.TenantID, tx.BalanceID, domain.BalanceStatusActive); err != nil {
+				return fmt.Errorf("unfreeze withdrawals: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cancelResult is what cancelTxs changed.
+type cancelResult struct {
+	TxIDs        []uuid.UUID     // Cancelled txs.
+	SkippedTxIDs []uuid.UUID     // Deposits not cancelled by the skip policy.
+	Correction   decimal.Decimal // Change of the balance amount.
+	Debt         decimal.Decimal // Debt added: the part the balance couldn't cover and debt paid off by cancelled deposits.
+}
+
+// cancelTxs cancels txs of the locked balance and reverts their changes of it.
+// Already cancelled txs are skipped, so the balance is never reverted twice.
+// The policy decides what happens if the balance would go negative, unknown policy fails.
+func cancelTxs(ctx context.Context, qtx *db.Queries, balance db.Balance, txs []db.Tx, policy domain.NegativePolicy) (cancelResult, error) {
+	if policy == domain.NegativePolicyUnknown {
+		policy = domain.NegativePolicyFail
+	}
+
+	active := make([]domain.Tx, 0, len(txs))
+	for _, tx := range txs {
+		if tx.DeletedAt != nil {
+			continue
+		}
+		if tx.State != domain.StateDeposit && tx.State != domain.StateWithdraw {
+			return cancelResult{}, fmt.Errorf("unknown state: %v", tx.State)
+		}
+
+		t, err := transform.TxFromPgx(tx)
+		if err != nil {
+			return cancelResult{}, fmt.Errorf("transform tx: %w", err)
+		}
+
+		active = append(active, t)
+	}
+	if len(active) == 0 {
+		return cancelResult{}, fmt.Errorf("%w: no txs to cancel", ErrNotFound)
+	}
+
+	var result cancelResult
+	if policy == domain.NegativePolicySkip {
+		result.SkippedTxIDs = finance.SkipOffending(balance.Amount, active)
+		active = slices.DeleteFunc(active, func(tx domain.Tx) bool {
+			return slices.Contains(result.SkippedTxIDs, tx.TxID)
+		})
+	}
+
+	var settlingTxIDs []uuid.UUID
+	result.TxIDs = make([]uuid.UUID, 0, len(active))
+	for _, tx := range active {
+		if tx.SettledDebt.IsPositive() {
+			settlingTxIDs = append(settlingTxIDs, tx.TxID)
+		}
+
+		result.TxIDs = append(result.TxIDs, tx.TxID)
+	}
+
+	reversal := finance.Revert(balance.Amount, active)
+	if reversal.Incurred.IsPositive() && policy != domain.NegativePolicyDebt {
+		return cancelResult{}, &InsufficientFundsError{
+			Current:  balance.Amount,
+			Required: balance.Amount.Add(reversal.Incurred),
+		}
+	}
+
+	// The balance drops to zero at most, the rest and the reinstated debt are recovered from future deposits.
+	result.Correction = reversal.Correction
+	result.Debt = reversal.Reinstated.Add(reversal.Incurred)
+
+	// Everything is skipped, so the balance doesn't change.
+	if len(result.TxIDs) == 0 {
+		return result, nil
+	}
+
+	updated, err := qtx.UpdateBalance(ctx, db.UpdateBalanceParams{
+		TenantID:  balance.TenantID,
+		BalanceID: balance.BalanceID,
+		Amount:    result.Correction,
+		Debt:      result.Debt,
+		TxCount:   -int64(len(result.TxIDs)),
+	})
+	if err != nil {
+		if isPgCode(err, "23514") {
+			return cancelResult{}, fmt.Errorf("%w: %v", ErrNegativeBalance, err)
+		}
+		return cancelResult{}, fmt.Errorf("update balance: %w", err)
+	}
+	if updated == 0 {
+		return cancelResult{}, fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+
+	// Rollups skip cancelled txs, so txs are removed from them before cancellation.
+	if err := qtx.RollupTxs(ctx, db.RollupTxsParams{
+		Sign:      -1,
+		TenantID:  balance.TenantID,
+		BalanceID: balance.BalanceID,
+		TxIds:     result.TxIDs,
+	}); err != nil {
+		return cancelResult{}, fmt.Errorf("rollup txs: %w", err)
+	}
+
+	if _, err := qtx.DeleteTxs(ctx, db.DeleteTxsParams{
+		TenantID:  balance.TenantID,
+		BalanceID: balance.BalanceID,
+		TxIds:     result.TxIDs,
+	}); err != nil {
+		return cancelResult{}, fmt.Errorf("delete txs: %w", err)
+	}
+
+	if err := appendEvent(ctx, qtx, balance.TenantID, balance.BalanceID, domain.BalanceEventKindTxsCancelled, result.TxIDs, &policy); err != nil {
+		return cancelResult{}, fmt.Errorf("append balance event: %w", err)
+	}
+
+	debt := balance.Debt
+	if reversal.Reinstated.IsPositive() {
+		debt = debt.Add(reversal.Reinstated)
+		if err := appendDebtEntry(ctx, qtx, balance.TenantID, balance.BalanceID, domain.DebtEntryKindReinstated, reversal.Reinstated, debt, settlingTxIDs); err != nil {
+			return cancelResult{}, fmt.Errorf("append debt entry: %w", err)
+		}
+	}
+	if reversal.Incurred.IsPositive() {
+		debt = debt.Add(reversal.Incurred)
+		if err := appendDebtEntry(ctx, qtx, balance.TenantID, balance.BalanceID, domain.DebtEntryKindIncurred, reversal.Incurred, debt, result.TxIDs); err != nil {
+			return cancelResult{}, fmt.Errorf("append debt entry: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func txStats(ctx context.Context, qtx *db.Queries, tenantID string, balanceID uuid.UUID, window time.Duration) ([]domain.TxStats, error) {
+	rows, err := qtx.TxStats(ctx, db.TxStatsParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		Since:     time.Now().Add(-window),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch tx stats: %w", err)
+	}
+
+	stats := make([]domain.TxStats, 0, len(rows))
+	for _, r := range rows {
+		s, err := transform.TxStatsFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform tx stats: %w", err)
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, nil
+}
+
+func flagTx(ctx context.Context, qtx *db.Queries, tx domain.Tx, hits []domain.RuleHit) error {
+	for _, h := range hits {
+		eventID, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("generate event ID: %w", err)
+		}
+
+		if _, err := qtx.InsertFlaggedEvent(ctx, db.InsertFlaggedEventParams{
+			TenantID:  tx.TenantID,
+			EventID:   eventID,
+			BalanceID: tx.BalanceID,
+			TxID:      tx.TxID,
+			Rule:      h.Rule,
+			Action:    h.Action,
+		}); err != nil {
+			return fmt.Errorf("insert flagged event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setStatus changes the status of the locked balance and appends the status change event.
+func setStatus(ctx context.Context, qtx *db.Queries, tenantID string, balanceID uuid.UUID, status domain.BalanceStatus) error {
+	updated, err := qtx.SetBalanceStatus(ctx, db.SetBalanceStatusParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		Status:    status,
+	})
+	if err != nil {
+		return fmt.Errorf("set balance status: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+
+	if err := appendEvent(ctx, qtx, tenantID, balanceID, domain.BalanceEventKindStatusChanged, nil, nil); err != nil {
+		return fmt.Errorf("append balance event: %w", err)
+	}
+
+	return nil
+}
+
+// appendEvent records the change of the balance and notifies watchers of all replicas on commit.
+// It must be called after the balance is updated.
+func appendEvent(
+	ctx context.Context,
+	qtx *db.Queries,
+	tenantID string,
+	balanceID uuid.UUID,
+	kind domain.BalanceEventKind,
+	txIDs []uuid.UUID,
+	negativePolicy *domain.NegativePolicy,
+) error {
+	if txIDs == nil {
+		txIDs = []uuid.UUID{}
+	}
+
+	seq, err := qtx.InsertBalanceEvent(ctx, db.InsertBalanceEventParams{
+		Kind:           kind,
+		TxIds:          txIDs,
+		NegativePolicy: negativePolicy,
+		TenantID:       tenantID,
+		BalanceID:      balanceID,
+	})
+	if err != nil {
+		return fmt.Errorf("insert balance event: %w", err)
+	}
+
+	payload, err := json.Marshal(balanceEventNotification{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		Seq:       seq,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+
+	if err := qtx.NotifyBalanceEvent(ctx, string(payload)); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	return nil
+}
+
+// usesDailyRollups reports whether buckets and the range consist of whole UTC days, so rollups sum the same txs.
+func usesDailyRollups(q domain.AggregateQuery) bool {
+	if q.Bucket == domain.AggregateBucketHour || q.Location.String() != time.UTC.String() {
+		return false
+	}
+
+	return q.From.Equal(q.From.UTC().Truncate(24*time.Hour)) && q.To.Equal(q.To.UTC().Truncate(24*time.Hour))
+}
+
+func scanStatementEntry(row pgx.CollectableRow) (domain.StatementEntry, error) {
+	var e domain.StatementEntry
+	err := row.Scan(
+		&e.At,
+		&e.Seq,
+		&e.Cancellation,
+		&e.TxID,
+		&e.ExternalRef,
+		&e.Source,
+		&e.State,
+		&e.Amount,
+		&e.Change,
+		&e.RunningBalance,
+	)
+	return e, err
+}
+
+func ruleNames(hits []domain.RuleHit) string {
+	names := make([]string, 0, len(hits))
+	for _, h := range hits {
+		names = append(names, h.Rule)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// optionalEnum returns nil for unknown values, so they don't filter rows.
+func optionalEnum[T comparable](v, unknown T) *T {
+	if v == unknown {
+		return nil
+	}
+
+	return &v
+}
+
+// tenantFromContext returns the tenant set by the auth interceptor, so queries never run unscoped.
+func tenantFromContext(ctx context.Context) (string, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", ErrNoTenant
+	}
+
+	return tenantID, nil
+}
+
+func isPgCode(err error, code string) bool {
+	var pgerr *pgconn.PgError
+	return errors.As(err, &pgerr) && pgerr.Code == code
+}
+
+// isTransient reports whether the error may go away on retry, e.g. a lost connection or a serialization failure.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Connection exceptions, transaction rollbacks, insufficient resources and operator intervention.
+	var pgerr *pgconn.PgError
+	if errors.As(err, &pgerr) {
+		for _, class := range []string{"08", "40", "53", "57"} {
+			if strings.HasPrefix(pgerr.Code, class) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"github.com/jackc/pgx/v5"
+)
+
+// StartCancellation stores the cancellation with balances having matching txs, a worker cancels them later.
+func (b *Balances) StartCancellation(
+	ctx context.Context,
+	criteria domain.CancellationCriteria,
+	policy domain.NegativePolicy,
+) (domain.Cancellation, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Cancellation{}, err
+	}
+
+	cancellationID, err := uuid.NewV7()
+	if err != nil {
+		return domain.Cancellation{}, fmt.Errorf("generate cancellation ID: %w", err)
+	}
+
+	params, err := transform.CancellationToPgx(tenantID, cancellationID, criteria, policy)
+	if err != nil {
+		return domain.Cancellation{}, fmt.Errorf("transform cancellation: %w", err)
+	}
+
+	pgxTx, err := b.c.Begin(ctx)
+	if err != nil {
+		return domain.Cancellation{}, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := b.q.WithTx(pgxTx)
+
+	if _, err := qtx.InsertCancellation(ctx, params); err != nil {
+		return domain.Cancellation{}, fmt.Errorf("insert cancellation: %w", err)
+	}
+
+	if _, err := qtx.InsertCancellationBalances(ctx, cancellationID); err != nil {
+		return domain.Cancellation{}, fmt.Errorf("insert cancellation balances: %w", err)
+	}
+
+	// Cancellations without matching txs are done at once.
+	if _, err := qtx.SetCancellationStatus(ctx, cancellationID); err != nil {
+		return domain.Cancellation{}, fmt.Errorf("update cancellation status: %w", err)
+	}
+
+	cancellation, err := cancellationWithProgress(ctx, qtx, tenantID, cancellationID)
+	if err != nil {
+		return domain.Cancellation{}, err
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return domain.Cancellation{}, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return cancellation, nil
+}
+
+func (b *Balances) Cancellation(ctx context.Context, cancellationID uuid.UUID) (domain.Cancellation, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.Cancellation{}, err
+	}
+
+	pgxTx, err := b.c.Begin(ctx)
+	if err != nil {
+		return domain.Cancellation{}, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	// Status and progress are read in one pgx tx, so they agree.
+	return cancellationWithProgress(ctx, b.q.WithTx(pgxTx), tenantID, cancellationID)
+}
+
+// CancellationBalances returns outcomes of balances of the cancellation after the balance ordered by balance ID.
+// The cancellation must be checked to belong to the tenant first.
+func (b *Balances) CancellationBalances(
+	ctx context.Context,
+	cancellationID uuid.UUID,
+	afterBalanceID *uuid.UUID,
+	limit int,
+) ([]domain.BalanceCancellation, error) {
+	rows, err := b.q.CancellationBalances(ctx, db.CancellationBalancesParams{
+		CancellationID: cancellationID,
+		AfterBalanceID: afterBalanceID,
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch cancellation balances: %w", err)
+	}
+
+	balances := make([]domain.BalanceCancellation, 0, len(rows))
+	for _, r := range rows {
+		c, err := transform.BalanceCancellationFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform cancellation balance: %w", err)
+		}
+
+		balances = append(balances, c)
+	}
+
+	return balances, nil
+}
+
+// CancelNextBalance cancels matching txs of one pending balance of any tenant.
+// It returns false if no balances are pending or other replicas are processing them.
+func (b *Balances) CancelNextBalance(ctx context.Context) (bool, error) {
+	pgxTx, err := b.c.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := b.q.WithTx(pgxTx)
+
+	next, err := qtx.NextCancellationBalance(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("fetch pending balance: %w", err)
+	}
+
+	cancellationID := next.Cancellation.CancellationID
+
+	params := db.SetCancellationBalanceParams{
+		CancellationID: cancellationID,
+		BalanceID:      next.BalanceID,
+		Status:         domain.BalanceCancellationStatusDone,
+		TxIds:          []uuid.UUID{},
+		SkippedTxIds:   []uuid.UUID{},
+	}
+
+	result, err := cancelByCriteria(ctx, pgxTx, qtx, next.Cancellation, next.BalanceID)
+	switch {
+	case err == nil:
+		if result.TxIDs != nil {
+			params.TxIds = result.TxIDs
+		}
+		if result.SkippedTxIDs != nil {
+			params.SkippedTxIds = result.SkippedTxIDs
+		}
+		params.Correction = result.Correction
+		params.Debt = result.Debt
+	case isTransient(err):
+		// The balance stays pending and is retried on the next tick.
+		return false, err
+	default:
+		// Other balances are still processed, the failure is reported in the outcome of the balance,
+		// so a balance failing every time doesn't block cancellations queued after it.
+		reason := err.Error()
+		params.Status = domain.BalanceCancellationStatusFailed
+		params.Error = &reason
+	}
+
+	if _, err := qtx.SetCancellationBalance(ctx, params); err != nil {
+		return false, fmt.Errorf("update cancellation balance: %w", err)
+	}
+
+	if _, err := qtx.LockCancellation(ctx, cancellationID); err != nil {
+		return false, fmt.Errorf("lock cancellation: %w", err)
+	}
+
+	if _, err := qtx.SetCancellationStatus(ctx, cancellationID); err != nil {
+		return false, fmt.Errorf("update cancellation status: %w", err)
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return true, nil
+}
+
+// cancelByCriteria locks the balance and cancels its matching txs with their descendants in a savepoint,
+// so a failed balance doesn't abort the pgx tx recording its outcome.
+func cancelByCriteria(
+	ctx context.Context,
+	pgxTx pgx.Tx,
+	qtx *db.Queries,
+	cancellation db.Cancellation,
+	balanceID uuid.UUID,
+) (cancelResult, error) {
+	savepoint, err := pgxTx.Begin(ctx)
+	if err != nil {
+		return cancelResult{}, fmt.Errorf("begin savepoint: %w", err)
+	}
+	defer func() {
+		if err := savepoint.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback savepoint", "error", err)
+		}
+	}()
+
+	qtx = qtx.WithTx(savepoint)
+
+	balance, err := lockBalance(ctx, qtx, cancellation.TenantID, balanceID)
+	if err != nil {
+		return cancelResult{}, err
+	}
+
+	txs, err := qtx.CriteriaTxs(ctx, db.CriteriaTxsParams{
+		CancellationID: cancellation.CancellationID,
+		BalanceID:      balance.BalanceID,
+	})
+	if err != nil {
+		return cancelResult{}, fmt.Errorf("get matching txs: %w", err)
+	}
+
+	// Txs may have been cancelled since the cancellation started.
+	if len(txs) == 0 {
+		return cancelResult{}, nil
+	}
+
+	txs, err = withDescendants(ctx, qtx, balance.TenantID, balance.BalanceID, txs, true)
+	if err != nil {
+		return cancelResult{}, err
+	}
+
+	result, err := cancelTxs(ctx, qtx, balance, txs, cancellation.NegativePolicy)
+	if err != nil {
+		return cancelResult{}, err
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return cancelResult{}, fmt.Errorf("release savepoint: %w", err)
+	}
+
+	return result, nil
+}
+
+func cancellationWithProgress(ctx context.Context, qtx *db.Queries, tenantID string, cancellationID uuid.UUID) (domain.Cancellation, error) {
+	row, err := qtx.Cancellation(ctx, db.CancellationParams{
+		TenantID:       tenantID,
+		CancellationID: cancellationID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Cancellation{}, fmt.Errorf("%w: cancellation %s", ErrNotFound, cancellationID)
+		}
+		return domain.Cancellation{}, fmt.Errorf("fetch cancellation: %w", err)
+	}
+
+	cancellation, err := transform.CancellationFromPgx(row)
+	if err != nil {
+		return domain.Cancellation{}, fmt.Errorf("transform cancellation: %w", err)
+	}
+
+	progress, err := qtx.CancellationProgress(ctx, cancellationID)
+	if err != nil {
+		return domain.Cancellation{}, fmt.Errorf("fetch cancellation progress: %w", err)
+	}
+
+	cancellation.Progress = transform.CancellationProgressFromPgx(progress)
+
+	return cancellation, nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalances_CancelNextBalance(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		expectedErr bool
+	}{
+		{
+			name: "failing balance doesn't block the next one",
+			err:  &pgconn.PgError{Code: "22P02", Message: "invalid input syntax"},
+		},
+		{
+			name:        "transient error leaves the balance pending",
+			err:         &pgconn.PgError{Code: "40001", Message: "could not serialize access"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancellationID := uuid.New()
+			failing := uuid.New()
+			next := uuid.New()
+
+			pool := &fakePool{}
+			outcomes := func() map[uuid.UUID]db.SetCancellationBalanceParams {
+				outcomes := make(map[uuid.UUID]db.SetCancellationBalanceParams)
+				for _, c := range pool.calls("SetCancellationBalance") {
+					reason, _ := c.args[5].(*string)
+					outcomes[c.args[7].(uuid.UUID)] = db.SetCancellationBalanceParams{
+						Status: c.args[0].(domain.BalanceCancellationStatus),
+						Error:  reason,
+					}
+				}
+
+				return outcomes
+			}
+			pool.queries = map[string]fakeQuery{
+				"NextCancellationBalance": func(args []any) ([]map[int]any, error) {
+					for _, id := range []uuid.UUID{failing, next} {
+						if _, ok := outcomes()[id]; !ok {
+							return []map[int]any{{3: "casino-1", 4: cancellationID, 14: id}}, nil
+						}
+					}
+
+					return nil, nil
+				},
+				"Balance": func(args []any) ([]map[int]any, error) {
+					return []map[int]any{{0: args[1], 7: args[0]}}, nil
+				},
+				"CriteriaTxs": func(args []any) ([]map[int]any, error) {
+					if args[1] == failing {
+						return nil, tt.err
+					}
+
+					return nil, nil
+				},
+			}
+			s := NewBalances(pool, db.New(nil), nil, nil)
+
+			processed, err := s.CancelNextBalance(context.Background())
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.False(t, processed)
+				assert.Empty(t, outcomes())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, processed)
+			require.Contains(t, outcomes(), failing)
+			assert.Equal(t, domain.BalanceCancellationStatusFailed, outcomes()[failing].Status)
+			require.NotNil(t, outcomes()[failing].Error)
+			assert.Contains(t, *outcomes()[failing].Error, "invalid input syntax")
+
+			processed, err = s.CancelNextBalance(context.Background())
+			require.NoError(t, err)
+			assert.True(t, processed)
+			require.Contains(t, outcomes(), next)
+			assert.Equal(t, domain.BalanceCancellationStatusDone, outcomes()[next].Status)
+
+			processed, err = s.CancelNextBalance(context.Background())
+			require.NoError(t, err)
+			assert.False(t, processed)
+		})
+	}
+}
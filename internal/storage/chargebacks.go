@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"github.com/jackc/pgx/v5"
+)
+
+// Chargeback reverses the payment deposit even if the player already spent it.
+// What the balance can't cover becomes debt, and withdrawals of an active balance are frozen until it's paid off.
+func (b *Balances) Chargeback(ctx context.Context, balanceID, txID uuid.UUID, reason *string) (domain.ChargebackOutcome, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return domain.ChargebackOutcome{}, err
+	}
+
+	chargebackID, err := uuid.NewV7()
+	if err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("generate chargeback ID: %w", err)
+	}
+
+	pgxTx, err := b.c.Begin(ctx)
+	if err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("begin pgx tx: %w", err)
+	}
+	defer func() {
+		if err := pgxTx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "failed to rollback transaction", "error", err)
+		}
+	}()
+
+	qtx := b.q.WithTx(pgxTx)
+
+	balance, err := lockBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return domain.ChargebackOutcome{}, err
+	}
+
+	txs, err := qtx.TxsByID(ctx, db.TxsByIDParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		TxIds:     []uuid.UUID{txID},
+	})
+	if err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("get txs: %w", err)
+	}
+	if len(txs) == 0 {
+		return domain.ChargebackOutcome{}, fmt.Errorf("%w: tx %s", ErrNotFound, txID)
+	}
+
+	tx := txs[0]
+	if tx.Source != domain.SourcePayment || tx.State != domain.StateDeposit {
+		return domain.ChargebackOutcome{}, fmt.Errorf("%w: tx %s is not a payment deposit", ErrNotChargeable, txID)
+	}
+	if tx.DeletedAt != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("%w: tx %s is already cancelled", ErrNotChargeable, txID)
+	}
+
+	// Children, e.g. refunds of the deposit, must be cancelled first.
+	txs, err = withDescendants(ctx, qtx, tenantID, balanceID, txs, false)
+	if err != nil {
+		return domain.ChargebackOutcome{}, err
+	}
+
+	result, err := cancelTxs(ctx, qtx, balance, txs, domain.NegativePolicyDebt)
+	if err != nil {
+		return domain.ChargebackOutcome{}, err
+	}
+
+	row, err := qtx.InsertChargeback(ctx, db.InsertChargebackParams{
+		TenantID:     tenantID,
+		ChargebackID: chargebackID,
+		BalanceID:    balanceID,
+		TxID:         txID,
+		Amount:       tx.Amount,
+		Correction:   result.Correction,
+		Debt:         result.Debt,
+		Reason:       reason,
+	})
+	if err != nil {
+		if isPgCode(err, "23505") {
+			return domain.ChargebackOutcome{}, fmt.Errorf("%w: tx %s is already charged back", ErrNotChargeable, txID)
+		}
+		return domain.ChargebackOutcome{}, fmt.Errorf("insert chargeback: %w", err)
+	}
+
+	// Chargebacks covered by the balance leave nothing to recover, so withdrawals stay open.
+	// Frozen balances stay frozen, they already block withdrawals.
+	if result.Debt.IsPositive() && balance.Status == domain.BalanceStatusActive {
+		if err := setStatus(ctx, qtx, tenantID, balanceID, domain.BalanceStatusWithdrawalsFrozen); err != nil {
+			return domain.ChargebackOutcome{}, fmt.Errorf("freeze withdrawals: %w", err)
+		}
+	}
+
+	var outcome domain.ChargebackOutcome
+	outcome.Chargeback, err = transform.ChargebackFromPgx(row)
+	if err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("transform chargeback: %w", err)
+	}
+
+	cancelled, err := qtx.TxByID(ctx, db.TxByIDParams{
+		TenantID: tenantID,
+		TxID:     txID,
+	})
+	if err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("fetch charged back tx: %w", err)
+	}
+
+	outcome.Tx, err = transform.TxFromPgx(cancelled)
+	if err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("transform tx: %w", err)
+	}
+
+	outcome.Balance, err = currentBalance(ctx, qtx, tenantID, balanceID)
+	if err != nil {
+		return domain.ChargebackOutcome{}, err
+	}
+
+	if err := pgxTx.Commit(ctx); err != nil {
+		return domain.ChargebackOutcome{}, fmt.Errorf("commit pgx tx: %w", err)
+	}
+
+	return outcome, nil
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/tenant"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalances_Chargeback(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+
+	tests := []struct {
+		name           string
+		amount         int64
+		status         domain.BalanceStatus
+		expectedDebt   int64
+		expectedFrozen bool
+	}{
+		{
+			name:         "covered by the balance",
+			amount:       100,
+			status:       domain.BalanceStatusActive,
+			expectedDebt: 0,
+		},
+		{
+			name:           "not covered by the balance",
+			amount:         20,
+			status:         domain.BalanceStatusActive,
+			expectedDebt:   30,
+			expectedFrozen: true,
+		},
+		{
+			name:         "frozen balance stays frozen",
+			amount:       20,
+			status:       domain.BalanceStatusFrozen,
+			expectedDebt: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := map[int]any{
+				2: txID,
+				3: balanceID,
+				4: domain.SourcePayment,
+				5: domain.StateDeposit,
+				6: decimal.NewFromInt(50),
+				7: "casino-1",
+			}
+			pool := &fakePool{
+				queries: map[string]fakeQuery{
+					"Balance": func(args []any) ([]map[int]any, error) {
+						return []map[int]any{{0: balanceID, 1: decimal.NewFromInt(tt.amount), 2: tt.status, 7: "casino-1"}}, nil
+					},
+					"TxsByID": func(args []any) ([]map[int]any, error) {
+						return []map[int]any{tx}, nil
+					},
+					"TxByID": func(args []any) ([]map[int]any, error) {
+						return []map[int]any{tx}, nil
+					},
+				},
+			}
+			s := NewBalances(pool, db.New(nil), nil, nil)
+
+			_, err := s.Chargeback(tenant.WithID(context.Background(), "casino-1"), balanceID, txID, nil)
+			require.NoError(t, err)
+
+			chargebacks := pool.calls("InsertChargeback")
+			require.Len(t, chargebacks, 1)
+			assert.True(t, decimal.NewFromInt(tt.expectedDebt).Equal(chargebacks[0].args[6].(decimal.Decimal)))
+
+			statuses := pool.calls("SetBalanceStatus")
+			if !tt.expectedFrozen {
+				assert.Empty(t, statuses)
+				return
+			}
+
+			require.Len(t, statuses, 1)
+			assert.Equal(t, domain.BalanceStatusWithdrawalsFrozen, statuses[0].args[0])
+		})
+	}
+}
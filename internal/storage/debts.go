@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
+	"github.com/shopspring/decimal"
+)
+
+// DebtEntries returns changes of the debt of the balance after the entry, oldest first.
+func (b *Balances) DebtEntries(ctx context.Context, balanceID uuid.UUID, afterSeq int64, limit int) ([]domain.DebtEntry, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.q.DebtEntries(ctx, db.DebtEntriesParams{
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+		AfterSeq:  afterSeq,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch debt entries: %w", err)
+	}
+
+	entries := make([]domain.DebtEntry, 0, len(rows))
+	for _, r := range rows {
+		e, err := transform.DebtEntryFromPgx(r)
+		if err != nil {
+			return nil, fmt.Errorf("transform debt entry: %w", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// appendDebtEntry records the change of the debt with the debt after it.
+func appendDebtEntry(
+	ctx context.Context,
+	qtx *db.Queries,
+	tenantID string,
+	balanceID uuid.UUID,
+	kind domain.DebtEntryKind,
+	amount decimal.Decimal,
+	debt decimal.Decimal,
+	txIDs []uuid.UUID,
+) error {
+	if txIDs == nil {
+		txIDs = []uuid.UUID{}
+	}
+
+	if _, err := qtx.InsertDebtEntry(ctx, db.InsertDebtEntryParams{
+		Kind:      kind,
+		Amount:    amount,
+		Debt:      debt,
+		TxIds:     txIDs,
+		TenantID:  tenantID,
+		BalanceID: balanceID,
+	}); err != nil {
+		return fmt.Errorf("insert debt entry: %w", err)
+	}
+
+	return nil
+}
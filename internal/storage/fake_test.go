@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeQuery returns rows of a query by column index, other columns are left zero.
+type fakeQuery func(args []any) ([]map[int]any, error)
+
+// fakeCall is a query run in a pgx tx.
+type fakeCall struct {
+	name string
+	args []any
+}
+
+// fakePool serves queries generated by sqlc from memory, so storage runs without a DB.
+// Queries without a handler return no rows, or a zero row for single row queries.
+// Calls are stored on commit only, like changes in a DB.
+type fakePool struct {
+	queries   map[string]fakeQuery
+	committed []fakeCall
+}
+
+func (p *fakePool) Begin(ctx context.Context) (pgx.Tx, error) {
+	return &fakeTx{pool: p}, nil
+}
+
+// calls returns committed calls of the query.
+func (p *fakePool) calls(name string) []fakeCall {
+	var calls []fakeCall
+	for _, c := range p.committed {
+		if c.name == name {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+type fakeTx struct {
+	pgx.Tx // Methods not used by storage panic.
+
+	pool   *fakePool
+	parent *fakeTx // Set for savepoints.
+	calls  []fakeCall
+	closed bool
+}
+
+func (t *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	return &fakeTx{pool: t.pool, parent: t}, nil
+}
+
+func (t *fakeTx) Commit(ctx context.Context) error {
+	if t.parent != nil {
+		t.parent.calls = append(t.parent.calls, t.calls...)
+	} else {
+		t.pool.committed = append(t.pool.committed, t.calls...)
+	}
+	t.closed = true
+
+	return nil
+}
+
+func (t *fakeTx) Rollback(ctx context.Context) error {
+	if t.closed {
+		return pgx.ErrTxClosed
+	}
+	t.closed = true
+
+	return nil
+}
+
+func (t *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if _, err := t.run(sql, args); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (t *fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	rows, err := t.run(sql, args)
+	if err != nil {
+		return fakeRow{err: err}
+	}
+	if rows == nil {
+		return fakeRow{}
+	}
+	if len(rows) == 0 {
+		return fakeRow{err: pgx.ErrNoRows}
+	}
+
+	return fakeRow{values: rows[0]}
+}
+
+func (t *fakeTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := t.run(sql, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fakeRows{rows: rows, next: -1}, nil
+}
+
+// run records the call and returns rows of its handler, nil if there is no handler.
+func (t *fakeTx) run(sql string, args []any) ([]map[int]any, error) {
+	name, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), " ")
+	t.calls = append(t.calls, fakeCall{name: name, args: args})
+
+	query, ok := t.pool.queries[name]
+	if !ok {
+		return nil, nil
+	}
+
+	rows, err := query(args)
+	if rows == nil && err == nil {
+		rows = []map[int]any{}
+	}
+
+	return rows, err
+}
+
+type fakeRow struct {
+	values map[int]any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return scanValues(r.values, dest)
+}
+
+type fakeRows struct {
+	pgx.Rows
+
+	rows []map[int]any
+	next int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return scanValues(r.rows[r.next], dest)
+}
+
+func (r *fakeRows) Close()     {}
+func (r *fakeRows) Err() error { return nil }
+
+func scanValues(values map[int]any, dest []any) error {
+	for i, v := range values {
+		if i >= len(dest) {
+			return errors.New("column out of range")
+		}
+
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	balanceEventsChannel = "balance_events"
+	listenRetryInterval  = time.Second
+)
+
+type AcquirePool interface {
+	Acquire(ctx context.Context) (*pgxpool.Conn, error)
+}
+
+type balanceEventNotification struct {
+	TenantID  string    `json:"tenant_id"`
+	BalanceID uuid.UUID `json:"balance_id"`
+	Seq       int64     `json:"seq"`
+}
+
+type subscriptionKey struct {
+	tenantID  string
+	balanceID uuid.UUID
+}
+
+// Listener receives balance event notifications from all replicas and wakes up subscribers of changed balances.
+// Notifications only signal that new events exist, subscribers read events from the database.
+func NewListener(p AcquirePool) *Listener {
+	return &Listener{
+		p:    p,
+		subs: make(map[subscriptionKey]map[chan struct{}]struct{}),
+	}
+}
+
+type Listener struct {
+	p AcquirePool
+
+	mu     sync.Mutex
+	subs   map[subscriptionKey]map[chan struct{}]struct{}
+	closed bool
+}
+
+// Run listens for notifications until ctx is done and closes all subscriptions after that.
+func (l *Listener) Run(ctx context.Context) {
+	defer l.close()
+
+	for {
+		if err := l.listen(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to listen for balance events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// Subscribe returns a channel receiving a value when new events of the balance may exist.
+// The channel is closed when the listener stops. Cancel must be called to release the subscription.
+func (l *Listener) Subscribe(tenantID string, balanceID uuid.UUID) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	key := subscriptionKey{tenantID: tenantID, balanceID: balanceID}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if l.subs[key] == nil {
+		l.subs[key] = make(map[chan struct{}]struct{})
+	}
+	l.subs[key][ch] = struct{}{}
+
+	return ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, ok := l.subs[key][ch]; !ok {
+			return
+		}
+
+		delete(l.subs[key], ch)
+		if len(l.subs[key]) == 0 {
+			delete(l.subs, key)
+		}
+		close(ch)
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	pooled, err := l.p.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire conn: %w", err)
+	}
+
+	// The conn is listening, so it's taken out of the pool and never reused by other queries.
+	conn := pooled.Hijack()
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			slog.ErrorContext(ctx, "failed to close listener conn", "error", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "listen "+balanceEventsChannel); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	// Notifications sent while the listener was disconnected are lost, so subscribers must check for new events.
+	l.wakeAll()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		var payload balanceEventNotification
+		if err := json.Unmarshal([]byte(n.Payload), &payload); err != nil {
+			slog.ErrorContext(ctx, "failed to parse balance event notification", "error", err, "payload", n.Payload)
+			continue
+		}
+
+		l.wake(subscriptionKey{tenantID: payload.TenantID, balanceID: payload.BalanceID})
+	}
+}
+
+func (l *Listener) wake(key subscriptionKey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.subs[key] {
+		notify(ch)
+	}
+}
+
+func (l *Listener) wakeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, subs := range l.subs {
+		for ch := range subs {
+			notify(ch)
+		}
+	}
+}
+
+func (l *Listener) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, subs := range l.subs {
+		for ch := range subs {
+			close(ch)
+		}
+	}
+	l.subs = make(map[subscriptionKey]map[chan struct{}]struct{})
+	l.closed = true
+}
+
+// notify doesn't block, a pending value already tells the subscriber to check for new events.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,15 @@
+// Package tenant passes the operator owning the request data through context.
+package tenant
+
+import "context"
+
+type contextKey struct{}
+
+func WithID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+func FromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(contextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
@@ -0,0 +1,183 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	adminv1 "github.com/iskorotkov/igaming-balance-backend/gen/admin/v1"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func AdjustmentFromProto(req *adminv1.AdjustBalanceRequest) (domain.Tx, error) {
+	state := domain.State(req.GetState())
+	if state == domain.StateUnknown || !state.IsAState() {
+		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidState, req.GetState())
+	}
+
+	balanceID, err := uuid.Parse(req.GetBalanceId())
+	if err != nil {
+		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidBalanceID, err)
+	}
+
+	txID, err := uuid.Parse(req.GetTxId())
+	if err != nil {
+		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidTxID, err)
+	}
+
+	amount, err := decimal.NewFromString(req.GetAmount().GetValue())
+	if err != nil {
+		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+
+	return domain.Tx{
+		TxID:      txID,
+		BalanceID: balanceID,
+		Source:    domain.SourceAdjustment,
+		State:     state,
+		Amount:    amount,
+	}, nil
+}
+
+// BalanceStatusFromProto returns the status set by support, unspecified statuses are invalid.
+func BalanceStatusFromProto(s balancev1.BalanceStatus) (domain.BalanceStatus, error) {
+	status := domain.BalanceStatus(s)
+	if status == domain.BalanceStatusUnknown || !status.IsABalanceStatus() {
+		return domain.BalanceStatusUnknown, fmt.Errorf("%w: %v", ErrInvalidStatus, s)
+	}
+
+	return status, nil
+}
+
+var ErrInvalidAudit = errors.New("invalid audit")
+
+// AuditEntryFromProto returns an entry with who requested the action and why, storage fills in the action itself.
+// The operator comes from the authenticated admin key, not from the request.
+func AuditEntryFromProto(operator string, a *adminv1.Audit) (domain.AuditEntry, error) {
+	if operator == "" || a.GetReason() == "" || a.GetTicketRef() == "" {
+		return domain.AuditEntry{}, fmt.Errorf("%w: %v", ErrInvalidAudit, "operator, reason and ticket_ref are required")
+	}
+
+	return domain.AuditEntry{
+		Operator:  operator,
+		Reason:    a.GetReason(),
+		TicketRef: a.GetTicketRef(),
+	}, nil
+}
+
+func AuditEntryToProto(e domain.AuditEntry) (*adminv1.AuditEntry, error) {
+	var txID string
+	if e.TxID != nil {
+		txID = e.TxID.String()
+	}
+
+	var amount *balancev1.Decimal
+	if e.Amount != nil {
+		amount = &balancev1.Decimal{Value: e.Amount.String()}
+	}
+
+	var attemptID string
+	if e.AttemptID != nil {
+		attemptID = e.AttemptID.String()
+	}
+
+	var errMsg string
+	if e.Error != nil {
+		errMsg = *e.Error
+	}
+
+	var status balancev1.BalanceStatus
+	if e.Status != nil {
+		status = balancev1.BalanceStatus(*e.Status)
+	}
+
+	return &adminv1.AuditEntry{
+		CreatedAt:      timestamppb.New(e.CreatedAt),
+		EntryId:        e.EntryID.String(),
+		Operator:       e.Operator,
+		Action:         adminv1.AuditAction(e.Action),
+		BalanceId:      e.BalanceID.String(),
+		TxId:           txID,
+		Amount:         amount,
+		Reason:         e.Reason,
+		TicketRef:      e.TicketRef,
+		Outcome:        adminv1.AuditOutcome(e.Outcome),
+		AttemptEntryId: attemptID,
+		Error:          errMsg,
+		Status:         status,
+	}, nil
+}
+
+func AuditEntryFromPgx(e db.AuditLog) (domain.AuditEntry, error) {
+	return domain.AuditEntry{
+		CreatedAt: e.CreatedAt,
+		TenantID:  e.TenantID,
+		EntryID:   e.EntryID,
+		Operator:  e.Operator,
+		Action:    e.Action,
+		BalanceID: e.BalanceID,
+		TxID:      e.TxID,
+		Amount:    e.Amount,
+		Reason:    e.Reason,
+		TicketRef: e.TicketRef,
+		Outcome:   e.Outcome,
+		AttemptID: e.AttemptID,
+		Error:     e.Error,
+		Status:    e.Status,
+	}, nil
+}
+
+func AuditEntryToPgx(e domain.AuditEntry) (db.InsertAuditEntryParams, error) {
+	return db.InsertAuditEntryParams{
+		TenantID:  e.TenantID,
+		EntryID:   e.EntryID,
+		Operator:  e.Operator,
+		Action:    e.Action,
+		BalanceID: e.BalanceID,
+		TxID:      e.TxID,
+		Amount:    e.Amount,
+		Reason:    e.Reason,
+		TicketRef: e.TicketRef,
+		Outcome:   e.Outcome,
+		AttemptID: e.AttemptID,
+		Error:     e.Error,
+		Status:    e.Status,
+	}, nil
+}
+
+func BalanceInspectionToProto(i domain.BalanceInspection) (*adminv1.InspectBalanceResponse, error) {
+	balance, err := BalanceToProto(i.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*balancev1.Tx, 0, len(i.Txs))
+	for _, tx := range i.Txs {
+		t, err := TxToProto(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, t)
+	}
+
+	entries := make([]*adminv1.AuditEntry, 0, len(i.AuditEntries))
+	for _, e := range i.AuditEntries {
+		pe, err := AuditEntryToProto(e)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, pe)
+	}
+
+	return &adminv1.InspectBalanceResponse{
+		Balance:      balance,
+		Txs:          txs,
+		AuditEntries: entries,
+	}, nil
+}
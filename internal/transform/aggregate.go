@@ -0,0 +1,103 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrInvalidBucket   = errors.New("invalid bucket")
+	ErrInvalidTimeZone = errors.New("invalid time zone")
+)
+
+func AggregateQueryFromProto(req *balancev1.AggregateRequest) (domain.AggregateQuery, error) {
+	var balanceID *uuid.UUID
+	if req.GetBalanceId() != "" {
+		id, err := uuid.Parse(req.GetBalanceId())
+		if err != nil {
+			return domain.AggregateQuery{}, fmt.Errorf("%w: %v", ErrInvalidBalanceID, err)
+		}
+
+		balanceID = &id
+	}
+
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return domain.AggregateQuery{}, fmt.Errorf("%w: %v", ErrInvalidRange, "from and to are required")
+	}
+
+	from := req.GetFrom().AsTime()
+	to := req.GetTo().AsTime()
+	if !from.Before(to) {
+		return domain.AggregateQuery{}, fmt.Errorf("%w: %v", ErrInvalidRange, "from must be before to")
+	}
+
+	bucket := domain.AggregateBucket(req.GetBucket())
+	if bucket == domain.AggregateBucketUnknown {
+		bucket = domain.AggregateBucketDay
+	}
+	if !bucket.IsAAggregateBucket() {
+		return domain.AggregateQuery{}, fmt.Errorf("%w: %v", ErrInvalidBucket, req.GetBucket())
+	}
+
+	location := time.UTC
+	if req.GetTimeZone() != "" {
+		// Local depends on the server settings, so only explicit names are accepted.
+		if req.GetTimeZone() == "Local" {
+			return domain.AggregateQuery{}, fmt.Errorf("%w: %q", ErrInvalidTimeZone, req.GetTimeZone())
+		}
+
+		loc, err := time.LoadLocation(req.GetTimeZone())
+		if err != nil {
+			return domain.AggregateQuery{}, fmt.Errorf("%w: %v", ErrInvalidTimeZone, err)
+		}
+
+		location = loc
+	}
+
+	return domain.AggregateQuery{
+		BalanceID: balanceID,
+		From:      from,
+		To:        to,
+		Bucket:    bucket,
+		Location:  location,
+	}, nil
+}
+
+func AggregateRowToProto(r domain.AggregateRow) (*balancev1.AggregateRow, error) {
+	return &balancev1.AggregateRow{
+		BucketStart: timestamppb.New(r.BucketStart),
+		Source:      balancev1.Source(r.Source),
+		State:       balancev1.State(r.State),
+		Count:       r.Count,
+		Amount: &balancev1.Decimal{
+			Value: r.Amount.String(),
+		},
+	}, nil
+}
+
+func AggregateRowFromPgx(r db.AggregateTxsRow) (domain.AggregateRow, error) {
+	return domain.AggregateRow{
+		BucketStart: r.BucketStart,
+		Source:      r.Source,
+		State:       r.State,
+		Count:       r.TxCount,
+		Amount:      r.Amount,
+	}, nil
+}
+
+func RevenueToProto(r domain.Revenue) (*balancev1.Revenue, error) {
+	return &balancev1.Revenue{
+		BucketStart: timestamppb.New(r.BucketStart),
+		Ggr:         &balancev1.Decimal{Value: r.GGR.String()},
+		Turnover:    &balancev1.Decimal{Value: r.Turnover.String()},
+		Deposits:    &balancev1.Decimal{Value: r.Deposits.String()},
+		Payouts:     &balancev1.Decimal{Value: r.Payouts.String()},
+	}, nil
+}
@@ -0,0 +1,35 @@
+package transform
+
+import (
+	"github.com/google/uuid"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+)
+
+func AMLCaseFromPgx(c db.AmlCase) (domain.AMLCase, error) {
+	return domain.AMLCase{
+		CreatedAt:  c.CreatedAt,
+		DetectedAt: c.DetectedAt,
+		TenantID:   c.TenantID,
+		CaseID:     c.CaseID,
+		BalanceID:  c.BalanceID,
+		Kind:       c.Kind,
+		Status:     c.Status,
+		State:      c.State,
+		Amount:     c.Amount,
+		TxIDs:      c.TxIds,
+	}, nil
+}
+
+func AMLCaseToPgx(caseID uuid.UUID, c domain.AMLCase) (db.InsertAMLCaseParams, error) {
+	return db.InsertAMLCaseParams{
+		TenantID:   c.TenantID,
+		CaseID:     caseID,
+		DetectedAt: c.DetectedAt,
+		BalanceID:  c.BalanceID,
+		Kind:       c.Kind,
+		State:      c.State,
+		Amount:     c.Amount,
+		TxIds:      c.TxIDs,
+	}, nil
+}
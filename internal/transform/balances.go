@@ -3,22 +3,100 @@ package transform
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/google/uuid"
 	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
 	"github.com/iskorotkov/igaming-balance-backend/internal/db"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
 	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-var ErrInvalidBalanceID = errors.New("invalid balance id")
+var (
+	ErrInvalidBalanceID  = errors.New("invalid balance id")
+	ErrInvalidOwnerID    = errors.New("invalid owner id")
+	ErrInvalidCurrency   = errors.New("invalid currency")
+	ErrInvalidWalletType = errors.New("invalid wallet type")
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidSort       = errors.New("invalid sort")
+)
+
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func OpenBalanceFromProto(req *balancev1.OpenBalanceRequest) (domain.Balance, error) {
+	balanceID, err := uuid.Parse(req.GetBalanceId())
+	if err != nil {
+		return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidBalanceID, err)
+	}
+
+	var ownerID *uuid.UUID
+	if req.GetOwnerId() != "" {
+		id, err := uuid.Parse(req.GetOwnerId())
+		if err != nil {
+			return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidOwnerID, err)
+		}
+
+		ownerID = &id
+	}
+
+	if req.GetCurrency() != "" && !currencyRegexp.MatchString(req.GetCurrency()) {
+		return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidCurrency, "currency must be ISO 4217 code")
+	}
+
+	walletType := domain.WalletType(req.GetWalletType())
+	if walletType == domain.WalletTypeUnknown {
+		walletType = domain.WalletTypeMain
+	}
+	if !walletType.IsAWalletType() {
+		return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidWalletType, req.GetWalletType())
+	}
+
+	return domain.Balance{
+		BalanceID:         balanceID,
+		Status:            domain.BalanceStatusActive,
+		OwnerID:           ownerID,
+		ExternalPlayerRef: optionalString(req.GetExternalPlayerRef()),
+		Currency:          optionalString(req.GetCurrency()),
+		WalletType:        walletType,
+	}, nil
+}
+
+func OpenBalanceToPgx(b domain.Balance) (db.OpenBalanceParams, error) {
+	return db.OpenBalanceParams{
+		BalanceID:         b.BalanceID,
+		OwnerID:           b.OwnerID,
+		ExternalPlayerRef: b.ExternalPlayerRef,
+		Currency:          b.Currency,
+		WalletType:        b.WalletType,
+	}, nil
+}
 
 func BalanceToProto(b domain.Balance) (*balancev1.BalanceResponse, error) {
+	var ownerID string
+	if b.OwnerID != nil {
+		ownerID = b.OwnerID.String()
+	}
+
 	return &balancev1.BalanceResponse{
 		BalanceId: b.BalanceID.String(),
 		Amount: &balancev1.Decimal{
 			Value: b.Amount.String(),
 		},
+		Status:            balancev1.BalanceStatus(b.Status),
+		OwnerId:           ownerID,
+		ExternalPlayerRef: stringValue(b.ExternalPlayerRef),
+		Currency:          stringValue(b.Currency),
+		WalletType:        balancev1.WalletType(b.WalletType),
+		CreatedAt:         timestamppb.New(b.CreatedAt),
+		UpdatedAt:         timestamppb.New(b.UpdatedAt),
+		LastActivityAt:    timestampValue(b.LastActivityAt),
+		TxCount:           b.TxCount,
+		Version:           b.Version,
+		Debt: &balancev1.Decimal{
+			Value: b.Debt.String(),
+		},
 	}, nil
 }
 
@@ -33,15 +111,209 @@ func BalanceFromProto(proto *balancev1.BalanceResponse) (domain.Balance, error)
 		return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
 	}
 
+	var ownerID *uuid.UUID
+	if proto.GetOwnerId() != "" {
+		id, err := uuid.Parse(proto.GetOwnerId())
+		if err != nil {
+			return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidOwnerID, err)
+		}
+
+		ownerID = &id
+	}
+
+	var debt decimal.Decimal
+	if proto.GetDebt() != nil {
+		debt, err = decimal.NewFromString(proto.GetDebt().GetValue())
+		if err != nil {
+			return domain.Balance{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+		}
+	}
+
 	return domain.Balance{
-		BalanceID: balanceID,
-		Amount:    amount,
+		CreatedAt:         proto.GetCreatedAt().AsTime(),
+		BalanceID:         balanceID,
+		Amount:            amount,
+		Status:            domain.BalanceStatus(proto.GetStatus()),
+		OwnerID:           ownerID,
+		ExternalPlayerRef: optionalString(proto.GetExternalPlayerRef()),
+		Currency:          optionalString(proto.GetCurrency()),
+		WalletType:        domain.WalletType(proto.GetWalletType()),
+		UpdatedAt:         proto.GetUpdatedAt().AsTime(),
+		LastActivityAt:    optionalTime(proto.GetLastActivityAt()),
+		TxCount:           proto.GetTxCount(),
+		Version:           proto.GetVersion(),
+		Debt:              debt,
 	}, nil
 }
 
 func BalanceFromPgx(b db.Balance) (domain.Balance, error) {
 	return domain.Balance{
-		BalanceID: b.BalanceID,
-		Amount:    b.Amount,
+		CreatedAt:         b.CreatedAt,
+		BalanceID:         b.BalanceID,
+		Amount:            b.Amount,
+		Status:            b.Status,
+		OwnerID:           b.OwnerID,
+		ExternalPlayerRef: b.ExternalPlayerRef,
+		Currency:          b.Currency,
+		WalletType:        b.WalletType,
+		EventSeq:          b.LastEventSeq,
+		UpdatedAt:         b.UpdatedAt,
+		LastActivityAt:    b.LastActivityAt,
+		TxCount:           b.TxCount,
+		Version:           b.Version,
+		Debt:              b.Debt,
 	}, nil
 }
+
+func BalanceFilterFromProto(req *balancev1.ListBalancesRequest) (domain.BalanceFilter, error) {
+	var ownerID *uuid.UUID
+	if req.GetOwnerId() != "" {
+		id, err := uuid.Parse(req.GetOwnerId())
+		if err != nil {
+			return domain.BalanceFilter{}, fmt.Errorf("%w: %v", ErrInvalidOwnerID, err)
+		}
+
+		ownerID = &id
+	}
+
+	status := domain.BalanceStatus(req.GetStatus())
+	if !status.IsABalanceStatus() {
+		return domain.BalanceFilter{}, fmt.Errorf("%w: %v", ErrInvalidStatus, req.GetStatus())
+	}
+
+	minAmount, err := optionalDecimal(req.GetMinAmount())
+	if err != nil {
+		return domain.BalanceFilter{}, err
+	}
+
+	maxAmount, err := optionalDecimal(req.GetMaxAmount())
+	if err != nil {
+		return domain.BalanceFilter{}, err
+	}
+
+	if minAmount != nil && maxAmount != nil && minAmount.GreaterThan(*maxAmount) {
+		return domain.BalanceFilter{}, fmt.Errorf("%w: %v", ErrInvalidRange, "min_amount must not exceed max_amount")
+	}
+
+	createdFrom := optionalTime(req.GetCreatedFrom())
+	createdTo := optionalTime(req.GetCreatedTo())
+	if createdFrom != nil && createdTo != nil && !createdFrom.Before(*createdTo) {
+		return domain.BalanceFilter{}, fmt.Errorf("%w: %v", ErrInvalidRange, "created_from must be before created_to")
+	}
+
+	updatedFrom := optionalTime(req.GetUpdatedFrom())
+	updatedTo := optionalTime(req.GetUpdatedTo())
+	if updatedFrom != nil && updatedTo != nil && !updatedFrom.Before(*updatedTo) {
+		return domain.BalanceFilter{}, fmt.Errorf("%w: %v", ErrInvalidRange, "updated_from must be before updated_to")
+	}
+
+	if req.MinTxCount != nil && req.MaxTxCount != nil && req.GetMinTxCount() > req.GetMaxTxCount() {
+		return domain.BalanceFilter{}, fmt.Errorf("%w: %v", ErrInvalidRange, "min_tx_count must not exceed max_tx_count")
+	}
+
+	return domain.BalanceFilter{
+		OwnerID:     ownerID,
+		Status:      status,
+		MinAmount:   minAmount,
+		MaxAmount:   maxAmount,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		UpdatedFrom: updatedFrom,
+		UpdatedTo:   updatedTo,
+		ActiveSince: optionalTime(req.GetActiveSince()),
+		MinTxCount:  req.MinTxCount,
+		MaxTxCount:  req.MaxTxCount,
+	}, nil
+}
+
+func BalanceSortFromProto(req *balancev1.ListBalancesRequest) (domain.BalanceSort, error) {
+	field := domain.BalanceSortField(req.GetSortBy())
+	if field == domain.BalanceSortFieldUnknown {
+		field = domain.BalanceSortFieldCreatedAt
+	}
+	if !field.IsABalanceSortField() {
+		return domain.BalanceSort{}, fmt.Errorf("%w: %v", ErrInvalidSort, req.GetSortBy())
+	}
+
+	return domain.BalanceSort{
+		Field:      field,
+		Descending: req.GetDescending(),
+	}, nil
+}
+
+func BalanceEventToProto(e domain.BalanceEvent) (*balancev1.BalanceEvent, error) {
+	txs := make([]*balancev1.Tx, 0, len(e.Txs))
+	for _, tx := range e.Txs {
+		t, err := TxToProto(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, t)
+	}
+
+	return &balancev1.BalanceEvent{
+		Seq:       e.Seq,
+		Kind:      balancev1.BalanceEventKind(e.Kind),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		Amount: &balancev1.Decimal{
+			Value: e.Amount.String(),
+		},
+		Status:         balancev1.BalanceStatus(e.Status),
+		Txs:            txs,
+		NegativePolicy: balancev1.NegativePolicy(e.NegativePolicy),
+	}, nil
+}
+
+func BalanceEventFromPgx(e db.BalanceEvent) (domain.BalanceEvent, error) {
+	var negativePolicy domain.NegativePolicy
+	if e.NegativePolicy != nil {
+		negativePolicy = *e.NegativePolicy
+	}
+
+	return domain.BalanceEvent{
+		CreatedAt: e.CreatedAt,
+		BalanceID: e.BalanceID,
+		Seq:       e.Seq,
+		Kind:      e.Kind,
+		Amount:    e.Amount,
+		Status:    e.Status,
+		TxIDs:     e.TxIds,
+
+		NegativePolicy: negativePolicy,
+	}, nil
+}
+
+// optionalString maps empty proto strings to missing values.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, id.String())
+	}
+
+	return s
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func timestampValue(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
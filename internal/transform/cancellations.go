@@ -0,0 +1,188 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func CancellationCriteriaFromProto(c *balancev1.CancellationCriteria) (domain.CancellationCriteria, error) {
+	source := domain.Source(c.GetSource())
+	if !source.IsASource() || source == domain.SourceUnknown {
+		return domain.CancellationCriteria{}, fmt.Errorf("%w: %v", ErrInvalidSource, c.GetSource())
+	}
+	if source == domain.SourceAdjustment {
+		return domain.CancellationCriteria{}, fmt.Errorf("%w: %v", ErrInvalidSource, "adjustments can be cancelled only by support")
+	}
+
+	state := domain.State(c.GetState())
+	if !state.IsAState() {
+		return domain.CancellationCriteria{}, fmt.Errorf("%w: %v", ErrInvalidState, c.GetState())
+	}
+
+	if c.GetCreatedFrom() == nil || c.GetCreatedTo() == nil {
+		return domain.CancellationCriteria{}, fmt.Errorf("%w: %v", ErrInvalidRange, "created_from and created_to are required")
+	}
+
+	createdFrom := c.GetCreatedFrom().AsTime()
+	createdTo := c.GetCreatedTo().AsTime()
+	if !createdFrom.Before(createdTo) {
+		return domain.CancellationCriteria{}, fmt.Errorf("%w: %v", ErrInvalidRange, "created_from must be before created_to")
+	}
+
+	balanceIDs := make([]uuid.UUID, 0, len(c.GetBalanceIds()))
+	for _, id := range c.GetBalanceIds() {
+		balanceID, err := uuid.Parse(id)
+		if err != nil {
+			return domain.CancellationCriteria{}, fmt.Errorf("%w: %v", ErrInvalidBalanceID, err)
+		}
+
+		balanceIDs = append(balanceIDs, balanceID)
+	}
+
+	return domain.CancellationCriteria{
+		Source:            source,
+		State:             state,
+		CreatedFrom:       createdFrom,
+		CreatedTo:         createdTo,
+		ExternalRefPrefix: optionalString(c.GetExternalRefPrefix()),
+		BalanceIDs:        balanceIDs,
+		Metadata:          c.GetMetadata(),
+	}, nil
+}
+
+func CancellationCriteriaToProto(c domain.CancellationCriteria) *balancev1.CancellationCriteria {
+	balanceIDs := make([]string, 0, len(c.BalanceIDs))
+	for _, id := range c.BalanceIDs {
+		balanceIDs = append(balanceIDs, id.String())
+	}
+
+	return &balancev1.CancellationCriteria{
+		Source:            balancev1.Source(c.Source),
+		State:             balancev1.State(c.State),
+		CreatedFrom:       timestamppb.New(c.CreatedFrom),
+		CreatedTo:         timestamppb.New(c.CreatedTo),
+		ExternalRefPrefix: stringValue(c.ExternalRefPrefix),
+		BalanceIds:        balanceIDs,
+		Metadata:          c.Metadata,
+	}
+}
+
+func CancellationToProto(c domain.Cancellation) (*balancev1.Cancellation, error) {
+	return &balancev1.Cancellation{
+		CancellationId: c.CancellationID.String(),
+		Status:         balancev1.CancellationStatus(c.Status),
+		Criteria:       CancellationCriteriaToProto(c.Criteria),
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		UpdatedAt:      timestamppb.New(c.UpdatedAt),
+		FinishedAt:     timestampValue(c.FinishedAt),
+		BalancesTotal:  c.Progress.BalancesTotal,
+		BalancesDone:   c.Progress.BalancesDone,
+		BalancesFailed: c.Progress.BalancesFailed,
+		TxsCancelled:   c.Progress.TxsCancelled,
+		NegativePolicy: balancev1.NegativePolicy(c.NegativePolicy),
+	}, nil
+}
+
+func BalanceCancellationToProto(c domain.BalanceCancellation) (*balancev1.BalanceCancellation, error) {
+	return &balancev1.BalanceCancellation{
+		BalanceId: c.BalanceID.String(),
+		Status:    balancev1.BalanceCancellationStatus(c.Status),
+		TxIds:     uuidStrings(c.TxIDs),
+		Correction: &balancev1.Decimal{
+			Value: c.Correction.String(),
+		},
+		Error:        stringValue(c.Error),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+		SkippedTxIds: uuidStrings(c.SkippedTxIDs),
+		Debt: &balancev1.Decimal{
+			Value: c.Debt.String(),
+		},
+	}, nil
+}
+
+func CancellationFromPgx(c db.Cancellation) (domain.Cancellation, error) {
+	var state domain.State
+	if c.State != nil {
+		state = *c.State
+	}
+
+	return domain.Cancellation{
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+		FinishedAt:     c.FinishedAt,
+		TenantID:       c.TenantID,
+		CancellationID: c.CancellationID,
+		Status:         c.Status,
+		Criteria: domain.CancellationCriteria{
+			Source:            c.Source,
+			State:             state,
+			CreatedFrom:       c.CreatedFrom,
+			CreatedTo:         c.CreatedTo,
+			ExternalRefPrefix: c.ExternalRefPrefix,
+			BalanceIDs:        c.BalanceIds,
+			Metadata:          c.Metadata,
+		},
+		NegativePolicy: c.NegativePolicy,
+	}, nil
+}
+
+func CancellationToPgx(
+	tenantID string,
+	cancellationID uuid.UUID,
+	c domain.CancellationCriteria,
+	policy domain.NegativePolicy,
+) (db.InsertCancellationParams, error) {
+	if policy == domain.NegativePolicyUnknown {
+		policy = domain.NegativePolicyFail
+	}
+
+	var state *domain.State
+	if c.State != domain.StateUnknown {
+		state = &c.State
+	}
+
+	balanceIDs := c.BalanceIDs
+	if balanceIDs == nil {
+		balanceIDs = []uuid.UUID{}
+	}
+
+	return db.InsertCancellationParams{
+		TenantID:          tenantID,
+		CancellationID:    cancellationID,
+		Source:            c.Source,
+		State:             state,
+		CreatedFrom:       c.CreatedFrom,
+		CreatedTo:         c.CreatedTo,
+		ExternalRefPrefix: c.ExternalRefPrefix,
+		BalanceIds:        balanceIDs,
+		NegativePolicy:    policy,
+		Metadata:          metadataValue(c.Metadata),
+	}, nil
+}
+
+func BalanceCancellationFromPgx(c db.CancellationBalance) (domain.BalanceCancellation, error) {
+	return domain.BalanceCancellation{
+		UpdatedAt:    c.UpdatedAt,
+		BalanceID:    c.BalanceID,
+		Status:       c.Status,
+		TxIDs:        c.TxIds,
+		SkippedTxIDs: c.SkippedTxIds,
+		Correction:   c.Correction,
+		Debt:         c.Debt,
+		Error:        c.Error,
+	}, nil
+}
+
+func CancellationProgressFromPgx(p db.CancellationProgressRow) domain.CancellationProgress {
+	return domain.CancellationProgress{
+		BalancesTotal:  p.BalancesTotal,
+		BalancesDone:   p.BalancesDone,
+		BalancesFailed: p.BalancesFailed,
+		TxsCancelled:   p.TxsCancelled,
+	}
+}
@@ -0,0 +1,59 @@
+package transform
+
+import (
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ChargebackOutcomeToProto(o domain.ChargebackOutcome) (*balancev1.ChargebackResponse, error) {
+	tx, err := TxToProto(o.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := BalanceToProto(o.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &balancev1.ChargebackResponse{
+		Chargeback: ChargebackToProto(o.Chargeback),
+		Tx:         tx,
+		Balance:    balance,
+	}, nil
+}
+
+func ChargebackToProto(c domain.Chargeback) *balancev1.Chargeback {
+	return &balancev1.Chargeback{
+		ChargebackId: c.ChargebackID.String(),
+		BalanceId:    c.BalanceID.String(),
+		TxId:         c.TxID.String(),
+		Amount: &balancev1.Decimal{
+			Value: c.Amount.String(),
+		},
+		Correction: &balancev1.Decimal{
+			Value: c.Correction.String(),
+		},
+		Debt: &balancev1.Decimal{
+			Value: c.Debt.String(),
+		},
+		Reason:    stringValue(c.Reason),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+func ChargebackFromPgx(c db.Chargeback) (domain.Chargeback, error) {
+	return domain.Chargeback{
+		CreatedAt:    c.CreatedAt,
+		TenantID:     c.TenantID,
+		ChargebackID: c.ChargebackID,
+		BalanceID:    c.BalanceID,
+		TxID:         c.TxID,
+		Amount:       c.Amount,
+		Correction:   c.Correction,
+		Debt:         c.Debt,
+		Reason:       c.Reason,
+	}, nil
+}
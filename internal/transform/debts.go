@@ -0,0 +1,35 @@
+package transform
+
+import (
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func DebtEntryToProto(e domain.DebtEntry) (*balancev1.DebtEntry, error) {
+	return &balancev1.DebtEntry{
+		Seq:       e.Seq,
+		Kind:      balancev1.DebtEntryKind(e.Kind),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		Amount: &balancev1.Decimal{
+			Value: e.Amount.String(),
+		},
+		Debt: &balancev1.Decimal{
+			Value: e.Debt.String(),
+		},
+		TxIds: uuidStrings(e.TxIDs),
+	}, nil
+}
+
+func DebtEntryFromPgx(e db.DebtEntry) (domain.DebtEntry, error) {
+	return domain.DebtEntry{
+		CreatedAt: e.CreatedAt,
+		BalanceID: e.BalanceID,
+		Seq:       e.Seq,
+		Kind:      e.Kind,
+		Amount:    e.Amount,
+		Debt:      e.Debt,
+		TxIDs:     e.TxIds,
+	}, nil
+}
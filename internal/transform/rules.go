@@ -0,0 +1,46 @@
+package transform
+
+import (
+	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
+	"github.com/iskorotkov/igaming-balance-backend/internal/db"
+	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func FlaggedEventToProto(e domain.FlaggedEvent) (*balancev1.FlaggedEvent, error) {
+	return &balancev1.FlaggedEvent{
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		EventId:   e.EventID.String(),
+		BalanceId: e.BalanceID.String(),
+		TxId:      e.TxID.String(),
+		Rule:      e.Rule,
+		Action:    balancev1.RuleAction(e.Action),
+	}, nil
+}
+
+func RuleHitToProto(h domain.RuleHit) (*balancev1.RuleHit, error) {
+	return &balancev1.RuleHit{
+		Rule:   h.Rule,
+		Action: balancev1.RuleAction(h.Action),
+	}, nil
+}
+
+func FlaggedEventFromPgx(e db.FlaggedEvent) (domain.FlaggedEvent, error) {
+	return domain.FlaggedEvent{
+		CreatedAt: e.CreatedAt,
+		EventID:   e.EventID,
+		BalanceID: e.BalanceID,
+		TxID:      e.TxID,
+		Rule:      e.Rule,
+		Action:    e.Action,
+	}, nil
+}
+
+func TxStatsFromPgx(s db.TxStatsRow) (domain.TxStats, error) {
+	return domain.TxStats{
+		Source: s.Source,
+		State:  s.State,
+		Count:  s.Count,
+		Sum:    s.Sum,
+	}, nil
+}
@@ -3,6 +3,7 @@ package transform
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	balancev1 "github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
@@ -13,10 +14,14 @@ import (
 )
 
 var (
-	ErrInvalidTxID   = errors.New("invalid tx id")
-	ErrInvalidSource = errors.New("invalid source")
-	ErrInvalidState  = errors.New("invalid state")
-	ErrInvalidAmount = errors.New("invalid amount")
+	ErrInvalidTxID       = errors.New("invalid tx id")
+	ErrInvalidParentTxID = errors.New("invalid parent tx id")
+	ErrInvalidSource     = errors.New("invalid source")
+	ErrInvalidState      = errors.New("invalid state")
+	ErrInvalidAmount     = errors.New("invalid amount")
+	ErrInvalidRange      = errors.New("invalid range")
+	ErrInvalidOrder      = errors.New("invalid order")
+	ErrInvalidFormat     = errors.New("invalid format")
 )
 
 func TxFromProto(tx *balancev1.RecordTxRequest) (domain.Tx, error) {
@@ -24,6 +29,10 @@ func TxFromProto(tx *balancev1.RecordTxRequest) (domain.Tx, error) {
 		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidSource, "source is unspecified")
 	}
 
+	if tx.GetSource() == balancev1.Source_SOURCE_ADJUSTMENT {
+		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidSource, "adjustments are recorded only by support")
+	}
+
 	if tx.GetState() == balancev1.State_STATE_UNSPECIFIED {
 		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidState, "state is unspecified")
 	}
@@ -43,15 +52,98 @@ func TxFromProto(tx *balancev1.RecordTxRequest) (domain.Tx, error) {
 		return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
 	}
 
+	var parentTxID *uuid.UUID
+	if tx.GetParentTxId() != "" {
+		id, err := uuid.Parse(tx.GetParentTxId())
+		if err != nil {
+			return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidParentTxID, err)
+		}
+		if id == txID {
+			return domain.Tx{}, fmt.Errorf("%w: %v", ErrInvalidParentTxID, "tx can't be its own parent")
+		}
+
+		parentTxID = &id
+	}
+
 	return domain.Tx{
-		TxID:      txID,
-		BalanceID: balanceID,
-		Source:    domain.Source(tx.GetSource()),
-		State:     domain.State(tx.GetState()),
-		Amount:    amount,
+		TxID:        txID,
+		BalanceID:   balanceID,
+		ParentTxID:  parentTxID,
+		ExternalRef: optionalString(tx.GetExternalRef()),
+		Source:      domain.Source(tx.GetSource()),
+		State:       domain.State(tx.GetState()),
+		Amount:      amount,
+		Metadata:    tx.GetMetadata(),
 	}, nil
 }
 
+func TxFilterFromProto(req *balancev1.ListTxRequest) (domain.TxFilter, error) {
+	source := domain.Source(req.GetSource())
+	if !source.IsASource() {
+		return domain.TxFilter{}, fmt.Errorf("%w: %v", ErrInvalidSource, req.GetSource())
+	}
+
+	state := domain.State(req.GetState())
+	if !state.IsAState() {
+		return domain.TxFilter{}, fmt.Errorf("%w: %v", ErrInvalidState, req.GetState())
+	}
+
+	createdFrom := optionalTime(req.GetCreatedFrom())
+	createdTo := optionalTime(req.GetCreatedTo())
+	if createdFrom != nil && createdTo != nil && !createdFrom.Before(*createdTo) {
+		return domain.TxFilter{}, fmt.Errorf("%w: %v", ErrInvalidRange, "created_from must be before created_to")
+	}
+
+	minAmount, err := optionalDecimal(req.GetMinAmount())
+	if err != nil {
+		return domain.TxFilter{}, err
+	}
+
+	maxAmount, err := optionalDecimal(req.GetMaxAmount())
+	if err != nil {
+		return domain.TxFilter{}, err
+	}
+
+	if minAmount != nil && maxAmount != nil && minAmount.GreaterThan(*maxAmount) {
+		return domain.TxFilter{}, fmt.Errorf("%w: %v", ErrInvalidRange, "min_amount must not exceed max_amount")
+	}
+
+	return domain.TxFilter{
+		Source:         source,
+		State:          state,
+		CreatedFrom:    createdFrom,
+		CreatedTo:      createdTo,
+		MinAmount:      minAmount,
+		MaxAmount:      maxAmount,
+		IncludeDeleted: req.GetIncludeDeleted() || req.GetCancelledOnly(),
+		CancelledOnly:  req.GetCancelledOnly(),
+	}, nil
+}
+
+func StatementFormatFromProto(f balancev1.StatementFormat) (domain.StatementFormat, error) {
+	format := domain.StatementFormat(f)
+	if format == domain.StatementFormatUnknown {
+		return domain.StatementFormatCSV, nil
+	}
+	if !format.IsAStatementFormat() {
+		return domain.StatementFormatUnknown, fmt.Errorf("%w: %v", ErrInvalidFormat, f)
+	}
+
+	return format, nil
+}
+
+func TxOrderFromProto(o balancev1.TxOrder) (domain.TxOrder, error) {
+	order := domain.TxOrder(o)
+	if order == domain.TxOrderUnknown {
+		return domain.TxOrderNewestFirst, nil
+	}
+	if !order.IsATxOrder() {
+		return domain.TxOrderUnknown, fmt.Errorf("%w: %v", ErrInvalidOrder, o)
+	}
+
+	return order, nil
+}
+
 func TxToProto(tx domain.Tx) (*balancev1.Tx, error) {
 	var deletedAt *timestamppb.Timestamp
 	if tx.DeletedAt != nil {
@@ -68,27 +160,150 @@ func TxToProto(tx domain.Tx) (*balancev1.Tx, error) {
 		Amount: &balancev1.Decimal{
 			Value: tx.Amount.String(),
 		},
+		Seq:         tx.Seq,
+		ExternalRef: stringValue(tx.ExternalRef),
+		ParentTxId:  uuidValue(tx.ParentTxID),
+		SettledDebt: &balancev1.Decimal{
+			Value: tx.SettledDebt.String(),
+		},
+		Metadata: tx.Metadata,
+	}, nil
+}
+
+// RecordOutcomeToProto returns the response to a recorded tx, or to a dry run of it.
+func RecordOutcomeToProto(o domain.RecordOutcome) (*balancev1.RecordTxResponse, error) {
+	balance, err := BalanceToProto(o.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*balancev1.RuleHit, 0, len(o.Hits))
+	for _, h := range o.Hits {
+		hit, err := RuleHitToProto(h)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, hit)
+	}
+
+	var tx *balancev1.Tx
+	if o.Tx.TxID != uuid.Nil {
+		tx, err = TxToProto(o.Tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &balancev1.RecordTxResponse{
+		Version:    o.Balance.Version,
+		Balance:    balance,
+		RuleAction: balancev1.RuleAction(o.Action),
+		RuleHits:   hits,
+		Tx:         tx,
+	}, nil
+}
+
+// CancelOutcomeToProto returns the response to cancelled txs, or to a dry run of it.
+func CancelOutcomeToProto(o domain.CancelOutcome) (*balancev1.CancelTxsResponse, error) {
+	balance, err := BalanceToProto(o.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*balancev1.Tx, 0, len(o.Txs))
+	for _, t := range o.Txs {
+		tx, err := TxToProto(t)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return &balancev1.CancelTxsResponse{
+		Version: o.Balance.Version,
+		Balance: balance,
+		Txs:     txs,
+		Correction: &balancev1.Decimal{
+			Value: o.Correction.String(),
+		},
+		SkippedTxIds: uuidStrings(o.SkippedTxIDs),
+		Debt: &balancev1.Decimal{
+			Value: o.Debt.String(),
+		},
 	}, nil
 }
 
 func TxFromPgx(tx db.Tx) (domain.Tx, error) {
 	return domain.Tx{
-		CreatedAt: tx.CreatedAt,
-		DeletedAt: tx.DeletedAt,
-		TxID:      tx.TxID,
-		BalanceID: tx.BalanceID,
-		Source:    tx.Source,
-		State:     tx.State,
-		Amount:    tx.Amount,
+		CreatedAt:   tx.CreatedAt,
+		DeletedAt:   tx.DeletedAt,
+		TenantID:    tx.TenantID,
+		TxID:        tx.TxID,
+		BalanceID:   tx.BalanceID,
+		Seq:         tx.Seq,
+		ExternalRef: tx.ExternalRef,
+		ParentTxID:  tx.ParentTxID,
+		Source:      tx.Source,
+		State:       tx.State,
+		Amount:      tx.Amount,
+		SettledDebt: tx.SettledDebt,
+		Metadata:    tx.Metadata,
 	}, nil
 }
 
 func TxToPgx(tx domain.Tx) (db.InsertTxParams, error) {
 	return db.InsertTxParams{
-		TxID:      tx.TxID,
-		BalanceID: tx.BalanceID,
-		Source:    tx.Source,
-		State:     tx.State,
-		Amount:    tx.Amount,
+		TenantID:    tx.TenantID,
+		TxID:        tx.TxID,
+		BalanceID:   tx.BalanceID,
+		Source:      tx.Source,
+		State:       tx.State,
+		Amount:      tx.Amount,
+		Seq:         tx.Seq,
+		ExternalRef: tx.ExternalRef,
+		ParentTxID:  tx.ParentTxID,
+		SettledDebt: tx.SettledDebt,
+		Metadata:    metadataValue(tx.Metadata),
 	}, nil
 }
+
+// metadataValue returns empty metadata instead of nil, which is stored as null.
+func metadataValue(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+
+	return m
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+	return &t
+}
+
+func optionalDecimal(d *balancev1.Decimal) (*decimal.Decimal, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	v, err := decimal.NewFromString(d.GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+
+	return &v, nil
+}
+
+func uuidValue(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	adminv1 "github.com/iskorotkov/igaming-balance-backend/gen/admin/v1"
 	"github.com/iskorotkov/igaming-balance-backend/gen/balance/v1"
 	"github.com/iskorotkov/igaming-balance-backend/internal/domain"
 	"github.com/iskorotkov/igaming-balance-backend/internal/transform"
@@ -18,7 +19,9 @@ import (
 func TestTxFromProto(t *testing.T) {
 	balanceID := uuid.New()
 	txID := uuid.New()
+	parentTxID := uuid.New()
 	amount := decimal.NewFromInt(100)
+	externalRef := "operator-tx-1"
 
 	tests := []struct {
 		name    string
@@ -60,6 +63,87 @@ func TestTxFromProto(t *testing.T) {
 				State:     domain.StateWithdraw,
 			},
 		},
+		{
+			name: "transaction with external reference",
+			proto: &balancev1.RecordTxRequest{
+				BalanceId:   balanceID.String(),
+				TxId:        txID.String(),
+				Amount:      &balancev1.Decimal{Value: amount.String()},
+				Source:      balancev1.Source_SOURCE_PAYMENT,
+				State:       balancev1.State_STATE_DEPOSIT,
+				ExternalRef: externalRef,
+			},
+			want: domain.Tx{
+				BalanceID:   balanceID,
+				TxID:        txID,
+				Amount:      amount,
+				Source:      domain.SourcePayment,
+				State:       domain.StateDeposit,
+				ExternalRef: &externalRef,
+			},
+		},
+		{
+			name: "transaction with parent",
+			proto: &balancev1.RecordTxRequest{
+				BalanceId:  balanceID.String(),
+				TxId:       txID.String(),
+				Amount:     &balancev1.Decimal{Value: amount.String()},
+				Source:     balancev1.Source_SOURCE_GAME,
+				State:      balancev1.State_STATE_DEPOSIT,
+				ParentTxId: parentTxID.String(),
+			},
+			want: domain.Tx{
+				BalanceID:  balanceID,
+				TxID:       txID,
+				ParentTxID: &parentTxID,
+				Amount:     amount,
+				Source:     domain.SourceGame,
+				State:      domain.StateDeposit,
+			},
+		},
+		{
+			name: "transaction with metadata",
+			proto: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_GAME,
+				State:     balancev1.State_STATE_WITHDRAW,
+				Metadata:  map[string]string{"provider": "provider-x", "round": "round-1"},
+			},
+			want: domain.Tx{
+				BalanceID: balanceID,
+				TxID:      txID,
+				Amount:    amount,
+				Source:    domain.SourceGame,
+				State:     domain.StateWithdraw,
+				Metadata:  map[string]string{"provider": "provider-x", "round": "round-1"},
+			},
+		},
+		{
+			name: "invalid parent transaction ID",
+			proto: &balancev1.RecordTxRequest{
+				BalanceId:  balanceID.String(),
+				TxId:       txID.String(),
+				Amount:     &balancev1.Decimal{Value: amount.String()},
+				Source:     balancev1.Source_SOURCE_GAME,
+				State:      balancev1.State_STATE_DEPOSIT,
+				ParentTxId: "invalid-uuid",
+			},
+			wantErr: transform.ErrInvalidParentTxID,
+		},
+		{
+			name: "transaction is its own parent",
+			proto: &balancev1.RecordTxRequest{
+				BalanceId:  balanceID.String(),
+				TxId:       txID.String(),
+				Amount:     &balancev1.Decimal{Value: amount.String()},
+				Source:     balancev1.Source_SOURCE_GAME,
+				State:      balancev1.State_STATE_DEPOSIT,
+				ParentTxId: txID.String(),
+			},
+			wantErr: transform.ErrInvalidParentTxID,
+		},
 		{
 			name: "invalid balance ID",
 			proto: &balancev1.RecordTxRequest{
@@ -104,6 +188,17 @@ func TestTxFromProto(t *testing.T) {
 			},
 			wantErr: transform.ErrInvalidSource,
 		},
+		{
+			name: "adjustment source",
+			proto: &balancev1.RecordTxRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Source:    balancev1.Source_SOURCE_ADJUSTMENT,
+				State:     balancev1.State_STATE_DEPOSIT,
+			},
+			wantErr: transform.ErrInvalidSource,
+		},
 		{
 			name: "unspecified state",
 			proto: &balancev1.RecordTxRequest{
@@ -133,6 +228,134 @@ func TestTxFromProto(t *testing.T) {
 			assert.True(t, tt.want.Amount.Equal(got.Amount))
 			assert.Equal(t, tt.want.Source, got.Source)
 			assert.Equal(t, tt.want.State, got.State)
+			assert.Equal(t, tt.want.ExternalRef, got.ExternalRef)
+			assert.Equal(t, tt.want.Metadata, got.Metadata)
+		})
+	}
+}
+
+func TestTxFilterFromProto(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	minAmount := decimal.NewFromInt(10)
+	maxAmount := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name    string
+		proto   *balancev1.ListTxRequest
+		want    domain.TxFilter
+		wantErr error
+	}{
+		{
+			name:  "no filter",
+			proto: &balancev1.ListTxRequest{},
+			want:  domain.TxFilter{},
+		},
+		{
+			name: "all filters",
+			proto: &balancev1.ListTxRequest{
+				Source:      balancev1.Source_SOURCE_GAME,
+				State:       balancev1.State_STATE_WITHDRAW,
+				CreatedFrom: timestamppb.New(from),
+				CreatedTo:   timestamppb.New(to),
+				MinAmount:   &balancev1.Decimal{Value: minAmount.String()},
+				MaxAmount:   &balancev1.Decimal{Value: maxAmount.String()},
+			},
+			want: domain.TxFilter{
+				Source:      domain.SourceGame,
+				State:       domain.StateWithdraw,
+				CreatedFrom: &from,
+				CreatedTo:   &to,
+				MinAmount:   &minAmount,
+				MaxAmount:   &maxAmount,
+			},
+		},
+		{
+			name: "cancelled only includes deleted",
+			proto: &balancev1.ListTxRequest{
+				CancelledOnly: true,
+			},
+			want: domain.TxFilter{
+				IncludeDeleted: true,
+				CancelledOnly:  true,
+			},
+		},
+		{
+			name: "invalid source",
+			proto: &balancev1.ListTxRequest{
+				Source: balancev1.Source(42),
+			},
+			wantErr: transform.ErrInvalidSource,
+		},
+		{
+			name: "invalid amount",
+			proto: &balancev1.ListTxRequest{
+				MinAmount: &balancev1.Decimal{Value: "invalid-amount"},
+			},
+			wantErr: transform.ErrInvalidAmount,
+		},
+		{
+			name: "empty created range",
+			proto: &balancev1.ListTxRequest{
+				CreatedFrom: timestamppb.New(to),
+				CreatedTo:   timestamppb.New(from),
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.TxFilterFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTxOrderFromProto(t *testing.T) {
+	tests := []struct {
+		name    string
+		proto   balancev1.TxOrder
+		want    domain.TxOrder
+		wantErr error
+	}{
+		{
+			name:  "unspecified is newest first",
+			proto: balancev1.TxOrder_TX_ORDER_UNSPECIFIED,
+			want:  domain.TxOrderNewestFirst,
+		},
+		{
+			name:  "oldest first",
+			proto: balancev1.TxOrder_TX_ORDER_OLDEST_FIRST,
+			want:  domain.TxOrderOldestFirst,
+		},
+		{
+			name:    "invalid order",
+			proto:   balancev1.TxOrder(42),
+			wantErr: transform.ErrInvalidOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.TxOrderFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -151,20 +374,22 @@ func TestTxToProto(t *testing.T) {
 		{
 			name: "valid deposit transaction",
 			tx: domain.Tx{
-				BalanceID: balanceID,
-				TxID:      txID,
-				Amount:    amount,
-				Source:    domain.SourceGame,
-				State:     domain.StateDeposit,
-				CreatedAt: createdAt,
+				BalanceID:   balanceID,
+				TxID:        txID,
+				Amount:      amount,
+				Source:      domain.SourceGame,
+				State:       domain.StateDeposit,
+				CreatedAt:   createdAt,
+				SettledDebt: decimal.NewFromInt(30),
 			},
 			want: &balancev1.Tx{
-				BalanceId: balanceID.String(),
-				TxId:      txID.String(),
-				Amount:    &balancev1.Decimal{Value: amount.String()},
-				Source:    balancev1.Source_SOURCE_GAME,
-				State:     balancev1.State_STATE_DEPOSIT,
-				CreatedAt: timestamppb.New(createdAt),
+				BalanceId:   balanceID.String(),
+				TxId:        txID.String(),
+				Amount:      &balancev1.Decimal{Value: amount.String()},
+				Source:      balancev1.Source_SOURCE_GAME,
+				State:       balancev1.State_STATE_DEPOSIT,
+				CreatedAt:   timestamppb.New(createdAt),
+				SettledDebt: &balancev1.Decimal{Value: "30"},
 			},
 		},
 		{
@@ -178,12 +403,13 @@ func TestTxToProto(t *testing.T) {
 				CreatedAt: createdAt,
 			},
 			want: &balancev1.Tx{
-				BalanceId: balanceID.String(),
-				TxId:      txID.String(),
-				Amount:    &balancev1.Decimal{Value: amount.String()},
-				Source:    balancev1.Source_SOURCE_PAYMENT,
-				State:     balancev1.State_STATE_WITHDRAW,
-				CreatedAt: timestamppb.New(createdAt),
+				BalanceId:   balanceID.String(),
+				TxId:        txID.String(),
+				Amount:      &balancev1.Decimal{Value: amount.String()},
+				Source:      balancev1.Source_SOURCE_PAYMENT,
+				State:       balancev1.State_STATE_WITHDRAW,
+				CreatedAt:   timestamppb.New(createdAt),
+				SettledDebt: &balancev1.Decimal{Value: "0"},
 			},
 		},
 	}
@@ -199,10 +425,301 @@ func TestTxToProto(t *testing.T) {
 			assert.Equal(t, tt.want.Source, got.Source)
 			assert.Equal(t, tt.want.State, got.State)
 			assert.Equal(t, tt.want.CreatedAt.AsTime(), got.CreatedAt.AsTime())
+			assert.Equal(t, tt.want.SettledDebt.GetValue(), got.GetSettledDebt().GetValue())
 		})
 	}
 }
 
+func TestRecordOutcomeToProto(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := transform.RecordOutcomeToProto(domain.RecordOutcome{
+		Tx: domain.Tx{
+			CreatedAt: createdAt,
+			TxID:      txID,
+			BalanceID: balanceID,
+			Seq:       8,
+			Source:    domain.SourceGame,
+			State:     domain.StateDeposit,
+			Amount:    decimal.NewFromInt(25),
+		},
+		Balance: domain.Balance{
+			BalanceID: balanceID,
+			Amount:    decimal.NewFromInt(125),
+			Version:   8,
+		},
+		Action: domain.RuleActionFlag,
+		Hits:   []domain.RuleHit{{Rule: "big-win", Action: domain.RuleActionFlag}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(8), got.GetVersion())
+	assert.Equal(t, "125", got.GetBalance().GetAmount().GetValue())
+	assert.Equal(t, txID.String(), got.GetTx().GetTxId())
+	assert.Equal(t, int64(8), got.GetTx().GetSeq())
+	assert.Equal(t, createdAt, got.GetTx().GetCreatedAt().AsTime())
+	assert.Equal(t, balancev1.RuleAction_RULE_ACTION_FLAG, got.GetRuleAction())
+	require.Len(t, got.GetRuleHits(), 1)
+	assert.Equal(t, "big-win", got.GetRuleHits()[0].GetRule())
+
+	got, err = transform.RecordOutcomeToProto(domain.RecordOutcome{
+		Balance: domain.Balance{BalanceID: balanceID},
+		Action:  domain.RuleActionReject,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, got.GetTx())
+}
+
+func TestCancelOutcomeToProto(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	skippedTxID := uuid.New()
+	deletedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := transform.CancelOutcomeToProto(domain.CancelOutcome{
+		Balance: domain.Balance{
+			BalanceID: balanceID,
+			Amount:    decimal.NewFromInt(75),
+			Version:   3,
+		},
+		Txs: []domain.Tx{
+			{
+				DeletedAt: &deletedAt,
+				TxID:      txID,
+				BalanceID: balanceID,
+				Source:    domain.SourcePayment,
+				State:     domain.StateDeposit,
+				Amount:    decimal.NewFromInt(25),
+			},
+		},
+		Correction:   decimal.NewFromInt(-25),
+		SkippedTxIDs: []uuid.UUID{skippedTxID},
+		Debt:         decimal.NewFromInt(5),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), got.GetVersion())
+	assert.Equal(t, "75", got.GetBalance().GetAmount().GetValue())
+	assert.Equal(t, "-25", got.GetCorrection().GetValue())
+	assert.Equal(t, []string{skippedTxID.String()}, got.GetSkippedTxIds())
+	assert.Equal(t, "5", got.GetDebt().GetValue())
+	require.Len(t, got.GetTxs(), 1)
+	assert.Equal(t, txID.String(), got.GetTxs()[0].GetTxId())
+}
+
+func TestChargebackOutcomeToProto(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	deletedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := transform.ChargebackOutcomeToProto(domain.ChargebackOutcome{
+		Chargeback: domain.Chargeback{
+			ChargebackID: uuid.New(),
+			BalanceID:    balanceID,
+			TxID:         txID,
+			Amount:       decimal.NewFromInt(100),
+			Correction:   decimal.NewFromInt(-40),
+			Debt:         decimal.NewFromInt(60),
+		},
+		Tx: domain.Tx{
+			DeletedAt: &deletedAt,
+			TxID:      txID,
+			BalanceID: balanceID,
+			Source:    domain.SourcePayment,
+			State:     domain.StateDeposit,
+			Amount:    decimal.NewFromInt(100),
+		},
+		Balance: domain.Balance{
+			BalanceID: balanceID,
+			Status:    domain.BalanceStatusWithdrawalsFrozen,
+			Debt:      decimal.NewFromInt(60),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, txID.String(), got.GetChargeback().GetTxId())
+	assert.Equal(t, "100", got.GetChargeback().GetAmount().GetValue())
+	assert.Equal(t, "-40", got.GetChargeback().GetCorrection().GetValue())
+	assert.Equal(t, "60", got.GetChargeback().GetDebt().GetValue())
+	assert.Empty(t, got.GetChargeback().GetReason())
+	assert.Equal(t, deletedAt, got.GetTx().GetDeletedAt().AsTime())
+	assert.Equal(t, balancev1.BalanceStatus_BALANCE_STATUS_WITHDRAWALS_FROZEN, got.GetBalance().GetStatus())
+}
+
+func TestCancellationCriteriaFromProto(t *testing.T) {
+	from := time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC)
+	to := from.Add(20 * time.Minute)
+	balanceID := uuid.New()
+	prefix := "provider-x:"
+
+	tests := []struct {
+		name    string
+		proto   *balancev1.CancellationCriteria
+		want    domain.CancellationCriteria
+		wantErr error
+	}{
+		{
+			name: "source and range",
+			proto: &balancev1.CancellationCriteria{
+				Source:      balancev1.Source_SOURCE_GAME,
+				CreatedFrom: timestamppb.New(from),
+				CreatedTo:   timestamppb.New(to),
+			},
+			want: domain.CancellationCriteria{
+				Source:      domain.SourceGame,
+				CreatedFrom: from,
+				CreatedTo:   to,
+				BalanceIDs:  []uuid.UUID{},
+			},
+		},
+		{
+			name: "all criteria",
+			proto: &balancev1.CancellationCriteria{
+				Source:            balancev1.Source_SOURCE_GAME,
+				State:             balancev1.State_STATE_DEPOSIT,
+				CreatedFrom:       timestamppb.New(from),
+				CreatedTo:         timestamppb.New(to),
+				ExternalRefPrefix: prefix,
+				BalanceIds:        []string{balanceID.String()},
+				Metadata:          map[string]string{"provider": "provider-x"},
+			},
+			want: domain.CancellationCriteria{
+				Source:            domain.SourceGame,
+				State:             domain.StateDeposit,
+				CreatedFrom:       from,
+				CreatedTo:         to,
+				ExternalRefPrefix: &prefix,
+				BalanceIDs:        []uuid.UUID{balanceID},
+				Metadata:          map[string]string{"provider": "provider-x"},
+			},
+		},
+		{
+			name: "unspecified source",
+			proto: &balancev1.CancellationCriteria{
+				CreatedFrom: timestamppb.New(from),
+				CreatedTo:   timestamppb.New(to),
+			},
+			wantErr: transform.ErrInvalidSource,
+		},
+		{
+			name: "adjustment source",
+			proto: &balancev1.CancellationCriteria{
+				Source:      balancev1.Source_SOURCE_ADJUSTMENT,
+				CreatedFrom: timestamppb.New(from),
+				CreatedTo:   timestamppb.New(to),
+			},
+			wantErr: transform.ErrInvalidSource,
+		},
+		{
+			name: "missing range",
+			proto: &balancev1.CancellationCriteria{
+				Source:      balancev1.Source_SOURCE_GAME,
+				CreatedFrom: timestamppb.New(from),
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+		{
+			name: "empty range",
+			proto: &balancev1.CancellationCriteria{
+				Source:      balancev1.Source_SOURCE_GAME,
+				CreatedFrom: timestamppb.New(to),
+				CreatedTo:   timestamppb.New(from),
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+		{
+			name: "invalid balance ID",
+			proto: &balancev1.CancellationCriteria{
+				Source:      balancev1.Source_SOURCE_GAME,
+				CreatedFrom: timestamppb.New(from),
+				CreatedTo:   timestamppb.New(to),
+				BalanceIds:  []string{"invalid-uuid"},
+			},
+			wantErr: transform.ErrInvalidBalanceID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.CancellationCriteriaFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCancellationToPgx(t *testing.T) {
+	tests := []struct {
+		name             string
+		metadata         map[string]string
+		expectedMetadata map[string]string
+	}{
+		{
+			name:             "metadata",
+			metadata:         map[string]string{"provider": "provider-x"},
+			expectedMetadata: map[string]string{"provider": "provider-x"},
+		},
+		{
+			name:             "no metadata matches all txs",
+			expectedMetadata: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.CancellationToPgx("casino-1", uuid.New(), domain.CancellationCriteria{
+				Source:   domain.SourceGame,
+				Metadata: tt.metadata,
+			}, domain.NegativePolicyFail)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedMetadata, got.Metadata)
+		})
+	}
+}
+
+func TestBalanceCancellationToProto(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+	reason := "negative balance"
+
+	got, err := transform.BalanceCancellationToProto(domain.BalanceCancellation{
+		BalanceID:  balanceID,
+		Status:     domain.BalanceCancellationStatusDone,
+		TxIDs:      []uuid.UUID{txID},
+		Correction: decimal.NewFromInt(-25),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, balanceID.String(), got.GetBalanceId())
+	assert.Equal(t, balancev1.BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_DONE, got.GetStatus())
+	assert.Equal(t, []string{txID.String()}, got.GetTxIds())
+	assert.Equal(t, "-25", got.GetCorrection().GetValue())
+	assert.Empty(t, got.GetSkippedTxIds())
+	assert.Equal(t, "0", got.GetDebt().GetValue())
+	assert.Empty(t, got.GetError())
+
+	got, err = transform.BalanceCancellationToProto(domain.BalanceCancellation{
+		BalanceID: balanceID,
+		Status:    domain.BalanceCancellationStatusFailed,
+		Error:     &reason,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, balancev1.BalanceCancellationStatus_BALANCE_CANCELLATION_STATUS_FAILED, got.GetStatus())
+	assert.Empty(t, got.GetTxIds())
+	assert.Equal(t, reason, got.GetError())
+}
+
 func TestBalanceFromProto(t *testing.T) {
 	balanceID := uuid.New()
 	amount := decimal.NewFromInt(1000)
@@ -259,9 +776,228 @@ func TestBalanceFromProto(t *testing.T) {
 	}
 }
 
+func TestOpenBalanceFromProto(t *testing.T) {
+	balanceID := uuid.New()
+	ownerID := uuid.New()
+	currency := "EUR"
+	playerRef := "player-42"
+
+	tests := []struct {
+		name    string
+		proto   *balancev1.OpenBalanceRequest
+		want    domain.Balance
+		wantErr error
+	}{
+		{
+			name: "anonymous balance",
+			proto: &balancev1.OpenBalanceRequest{
+				BalanceId: balanceID.String(),
+			},
+			want: domain.Balance{
+				BalanceID:  balanceID,
+				Status:     domain.BalanceStatusActive,
+				WalletType: domain.WalletTypeMain,
+			},
+		},
+		{
+			name: "owned balance",
+			proto: &balancev1.OpenBalanceRequest{
+				BalanceId:         balanceID.String(),
+				OwnerId:           ownerID.String(),
+				ExternalPlayerRef: playerRef,
+				Currency:          currency,
+				WalletType:        balancev1.WalletType_WALLET_TYPE_BONUS,
+			},
+			want: domain.Balance{
+				BalanceID:         balanceID,
+				Status:            domain.BalanceStatusActive,
+				OwnerID:           &ownerID,
+				ExternalPlayerRef: &playerRef,
+				Currency:          &currency,
+				WalletType:        domain.WalletTypeBonus,
+			},
+		},
+		{
+			name: "invalid balance ID",
+			proto: &balancev1.OpenBalanceRequest{
+				BalanceId: "invalid-uuid",
+			},
+			wantErr: transform.ErrInvalidBalanceID,
+		},
+		{
+			name: "invalid owner ID",
+			proto: &balancev1.OpenBalanceRequest{
+				BalanceId: balanceID.String(),
+				OwnerId:   "invalid-uuid",
+			},
+			wantErr: transform.ErrInvalidOwnerID,
+		},
+		{
+			name: "invalid currency",
+			proto: &balancev1.OpenBalanceRequest{
+				BalanceId: balanceID.String(),
+				Currency:  "eur",
+			},
+			wantErr: transform.ErrInvalidCurrency,
+		},
+		{
+			name: "invalid wallet type",
+			proto: &balancev1.OpenBalanceRequest{
+				BalanceId:  balanceID.String(),
+				WalletType: balancev1.WalletType(42),
+			},
+			wantErr: transform.ErrInvalidWalletType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.OpenBalanceFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBalanceFilterFromProto(t *testing.T) {
+	ownerID := uuid.New()
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	minAmount := decimal.NewFromInt(10)
+	minTxCount := int64(5)
+	maxTxCount := int64(50)
+
+	tests := []struct {
+		name    string
+		proto   *balancev1.ListBalancesRequest
+		want    domain.BalanceFilter
+		wantErr error
+	}{
+		{
+			name:  "no filter",
+			proto: &balancev1.ListBalancesRequest{},
+			want:  domain.BalanceFilter{},
+		},
+		{
+			name: "owner, status, amount and activity",
+			proto: &balancev1.ListBalancesRequest{
+				OwnerId:     ownerID.String(),
+				Status:      balancev1.BalanceStatus_BALANCE_STATUS_ACTIVE,
+				MinAmount:   &balancev1.Decimal{Value: minAmount.String()},
+				ActiveSince: timestamppb.New(since),
+			},
+			want: domain.BalanceFilter{
+				OwnerID:     &ownerID,
+				Status:      domain.BalanceStatusActive,
+				MinAmount:   &minAmount,
+				ActiveSince: &since,
+			},
+		},
+		{
+			name: "invalid owner ID",
+			proto: &balancev1.ListBalancesRequest{
+				OwnerId: "invalid-uuid",
+			},
+			wantErr: transform.ErrInvalidOwnerID,
+		},
+		{
+			name: "invalid status",
+			proto: &balancev1.ListBalancesRequest{
+				Status: balancev1.BalanceStatus(42),
+			},
+			wantErr: transform.ErrInvalidStatus,
+		},
+		{
+			name: "update time and tx count",
+			proto: &balancev1.ListBalancesRequest{
+				UpdatedFrom: timestamppb.New(since),
+				MinTxCount:  &minTxCount,
+				MaxTxCount:  &maxTxCount,
+			},
+			want: domain.BalanceFilter{
+				UpdatedFrom: &since,
+				MinTxCount:  &minTxCount,
+				MaxTxCount:  &maxTxCount,
+			},
+		},
+		{
+			name: "empty amount range",
+			proto: &balancev1.ListBalancesRequest{
+				MinAmount: &balancev1.Decimal{Value: "100"},
+				MaxAmount: &balancev1.Decimal{Value: "10"},
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+		{
+			name: "empty update time range",
+			proto: &balancev1.ListBalancesRequest{
+				UpdatedFrom: timestamppb.New(since),
+				UpdatedTo:   timestamppb.New(since),
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+		{
+			name: "empty tx count range",
+			proto: &balancev1.ListBalancesRequest{
+				MinTxCount: &maxTxCount,
+				MaxTxCount: &minTxCount,
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.BalanceFilterFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBalanceSortFromProto(t *testing.T) {
+	got, err := transform.BalanceSortFromProto(&balancev1.ListBalancesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BalanceSort{Field: domain.BalanceSortFieldCreatedAt}, got)
+
+	got, err = transform.BalanceSortFromProto(&balancev1.ListBalancesRequest{
+		SortBy:     balancev1.BalanceSortField_BALANCE_SORT_FIELD_AMOUNT,
+		Descending: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BalanceSort{Field: domain.BalanceSortFieldAmount, Descending: true}, got)
+
+	got, err = transform.BalanceSortFromProto(&balancev1.ListBalancesRequest{
+		SortBy: balancev1.BalanceSortField_BALANCE_SORT_FIELD_LAST_ACTIVITY_AT,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BalanceSort{Field: domain.BalanceSortFieldLastActivityAt}, got)
+
+	_, err = transform.BalanceSortFromProto(&balancev1.ListBalancesRequest{
+		SortBy: balancev1.BalanceSortField(42),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, transform.ErrInvalidSort))
+}
+
 func TestBalanceToProto(t *testing.T) {
 	balanceID := uuid.New()
 	amount := decimal.NewFromInt(1000)
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	activeAt := createdAt.Add(time.Hour)
 
 	tests := []struct {
 		name string
@@ -277,6 +1013,37 @@ func TestBalanceToProto(t *testing.T) {
 			want: &balancev1.BalanceResponse{
 				BalanceId: balanceID.String(),
 				Amount:    &balancev1.Decimal{Value: amount.String()},
+				Debt:      &balancev1.Decimal{Value: "0"},
+			},
+		},
+		{
+			name: "balance with activity",
+			bal: domain.Balance{
+				CreatedAt:      createdAt,
+				BalanceID:      balanceID,
+				Amount:         amount,
+				UpdatedAt:      activeAt,
+				LastActivityAt: &activeAt,
+				TxCount:        3,
+			},
+			want: &balancev1.BalanceResponse{
+				BalanceId:      balanceID.String(),
+				Amount:         &balancev1.Decimal{Value: amount.String()},
+				LastActivityAt: timestamppb.New(activeAt),
+				TxCount:        3,
+				Debt:           &balancev1.Decimal{Value: "0"},
+			},
+		},
+		{
+			name: "balance with debt",
+			bal: domain.Balance{
+				BalanceID: balanceID,
+				Debt:      decimal.RequireFromString("12.5"),
+			},
+			want: &balancev1.BalanceResponse{
+				BalanceId: balanceID.String(),
+				Amount:    &balancev1.Decimal{Value: "0"},
+				Debt:      &balancev1.Decimal{Value: "12.5"},
 			},
 		},
 	}
@@ -288,6 +1055,231 @@ func TestBalanceToProto(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, tt.want.BalanceId, got.BalanceId)
 			assert.Equal(t, tt.want.Amount, got.Amount)
+			assert.Equal(t, tt.want.GetLastActivityAt().AsTime(), got.GetLastActivityAt().AsTime())
+			assert.Equal(t, tt.want.TxCount, got.TxCount)
+			assert.Equal(t, tt.want.GetDebt().GetValue(), got.GetDebt().GetValue())
+		})
+	}
+}
+
+func TestDebtEntryToProto(t *testing.T) {
+	txID := uuid.New()
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := transform.DebtEntryToProto(domain.DebtEntry{
+		CreatedAt: createdAt,
+		Seq:       2,
+		Kind:      domain.DebtEntryKindSettled,
+		Amount:    decimal.NewFromInt(30),
+		Debt:      decimal.NewFromInt(20),
+		TxIDs:     []uuid.UUID{txID},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), got.GetSeq())
+	assert.Equal(t, balancev1.DebtEntryKind_DEBT_ENTRY_KIND_SETTLED, got.GetKind())
+	assert.Equal(t, createdAt, got.GetCreatedAt().AsTime())
+	assert.Equal(t, "30", got.GetAmount().GetValue())
+	assert.Equal(t, "20", got.GetDebt().GetValue())
+	assert.Equal(t, []string{txID.String()}, got.GetTxIds())
+}
+
+func TestAggregateQueryFromProto(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	malta, err := time.LoadLocation("Europe/Malta")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		proto   *balancev1.AggregateRequest
+		want    domain.AggregateQuery
+		wantErr error
+	}{
+		{
+			name: "days in utc by default",
+			proto: &balancev1.AggregateRequest{
+				From: timestamppb.New(from),
+				To:   timestamppb.New(to),
+			},
+			want: domain.AggregateQuery{
+				From:     from,
+				To:       to,
+				Bucket:   domain.AggregateBucketDay,
+				Location: time.UTC,
+			},
+		},
+		{
+			name: "hours in time zone",
+			proto: &balancev1.AggregateRequest{
+				From:     timestamppb.New(from),
+				To:       timestamppb.New(to),
+				Bucket:   balancev1.AggregateBucket_AGGREGATE_BUCKET_HOUR,
+				TimeZone: "Europe/Malta",
+			},
+			want: domain.AggregateQuery{
+				From:     from,
+				To:       to,
+				Bucket:   domain.AggregateBucketHour,
+				Location: malta,
+			},
+		},
+		{
+			name: "missing range",
+			proto: &balancev1.AggregateRequest{
+				From: timestamppb.New(from),
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+		{
+			name: "reversed range",
+			proto: &balancev1.AggregateRequest{
+				From: timestamppb.New(to),
+				To:   timestamppb.New(from),
+			},
+			wantErr: transform.ErrInvalidRange,
+		},
+		{
+			name: "invalid bucket",
+			proto: &balancev1.AggregateRequest{
+				From:   timestamppb.New(from),
+				To:     timestamppb.New(to),
+				Bucket: balancev1.AggregateBucket(42),
+			},
+			wantErr: transform.ErrInvalidBucket,
+		},
+		{
+			name: "local time zone",
+			proto: &balancev1.AggregateRequest{
+				From:     timestamppb.New(from),
+				To:       timestamppb.New(to),
+				TimeZone: "Local",
+			},
+			wantErr: transform.ErrInvalidTimeZone,
+		},
+		{
+			name: "unknown time zone",
+			proto: &balancev1.AggregateRequest{
+				From:     timestamppb.New(from),
+				To:       timestamppb.New(to),
+				TimeZone: "Mars/Olympus",
+			},
+			wantErr: transform.ErrInvalidTimeZone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.AggregateQueryFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestAdjustmentFromProto(t *testing.T) {
+	balanceID := uuid.New()
+	txID := uuid.New()
+
+	tests := []struct {
+		name    string
+		proto   *adminv1.AdjustBalanceRequest
+		want    domain.Tx
+		wantErr error
+	}{
+		{
+			name: "withdrawal adjustment",
+			proto: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_WITHDRAW,
+				Amount:    &balancev1.Decimal{Value: "12.5"},
+			},
+			want: domain.Tx{
+				BalanceID: balanceID,
+				TxID:      txID,
+				Source:    domain.SourceAdjustment,
+				State:     domain.StateWithdraw,
+				Amount:    decimal.RequireFromString("12.5"),
+			},
+		},
+		{
+			name: "unspecified state",
+			proto: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				Amount:    &balancev1.Decimal{Value: "12.5"},
+			},
+			wantErr: transform.ErrInvalidState,
+		},
+		{
+			name: "invalid amount",
+			proto: &adminv1.AdjustBalanceRequest{
+				BalanceId: balanceID.String(),
+				TxId:      txID.String(),
+				State:     balancev1.State_STATE_DEPOSIT,
+				Amount:    &balancev1.Decimal{Value: "invalid-amount"},
+			},
+			wantErr: transform.ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transform.AdjustmentFromProto(tt.proto)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBalanceStatusFromProto(t *testing.T) {
+	got, err := transform.BalanceStatusFromProto(balancev1.BalanceStatus_BALANCE_STATUS_ACTIVE)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BalanceStatusActive, got)
+
+	_, err = transform.BalanceStatusFromProto(balancev1.BalanceStatus_BALANCE_STATUS_UNSPECIFIED)
+	assert.True(t, errors.Is(err, transform.ErrInvalidStatus))
+
+	_, err = transform.BalanceStatusFromProto(balancev1.BalanceStatus(42))
+	assert.True(t, errors.Is(err, transform.ErrInvalidStatus))
+}
+
+func TestAuditEntryFromProto(t *testing.T) {
+	got, err := transform.AuditEntryFromProto("support@casino.example", &adminv1.Audit{
+		Reason:    "duplicate deposit",
+		TicketRef: "SUP-123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AuditEntry{
+		Operator:  "support@casino.example",
+		Reason:    "duplicate deposit",
+		TicketRef: "SUP-123",
+	}, got)
+
+	_, err = transform.AuditEntryFromProto("support@casino.example", &adminv1.Audit{
+		TicketRef: "SUP-123",
+	})
+	assert.True(t, errors.Is(err, transform.ErrInvalidAudit))
+
+	_, err = transform.AuditEntryFromProto("", &adminv1.Audit{
+		Reason:    "duplicate deposit",
+		TicketRef: "SUP-123",
+	})
+	assert.True(t, errors.Is(err, transform.ErrInvalidAudit))
+}
@@ -0,0 +1,22 @@
+package transform
+
+import (
+	"buf.build/go/protovalidate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// BadRequestFromValidation lists violations of schema rules as field violations, fields are paths like "tx_ids[1]".
+func BadRequestFromValidation(err *protovalidate.ValidationError) *errdetails.BadRequest {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+			Description: v.Proto.GetMessage(),
+			Reason:      v.Proto.GetRuleId(),
+		})
+	}
+
+	return &errdetails.BadRequest{
+		FieldViolations: violations,
+	}
+}